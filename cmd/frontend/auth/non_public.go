@@ -65,6 +65,9 @@ var (
 		router.SignOut:           {},
 		router.ResetPasswordInit: {},
 		router.ResetPasswordCode: {},
+
+		// Discussion unsubscribe links are authorized by the token in their URL.
+		router.DiscussionsUnsubscribe: {},
 	}
 	anonymousAccessibleUIRoutes = map[string]struct{}{
 		uirouter.RouteSignIn:        {},
@@ -96,6 +99,12 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		return true
 	}
 
+	// Webhooks are sent by code hosts, which can't authenticate as a user. Their handlers verify
+	// the webhook secret instead.
+	if strings.HasPrefix(req.URL.Path, "/.api/webhooks/") {
+		return true
+	}
+
 	apiRouteName := matchedRouteName(req, router.Router())
 	if apiRouteName == router.UI {
 		// Test against UI router. (Some of its handlers inject private data into the title or meta tags.)
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// codeMonitorResults provides access to the `code_monitor_results` table, which records the search
+// results that the actions of saved queries handled (and the issue or discussion thread they
+// created for them), so that repeated runs don't handle a result twice.
+//
+// For a detailed overview of the schema, see schema.md.
+type codeMonitorResults struct{}
+
+// Handled returns the subset of the result keys that the action of the saved query already
+// handled.
+func (*codeMonitorResults) Handled(ctx context.Context, spec api.SavedQueryIDSpec, actionKey string, resultKeys []string) (map[string]bool, error) {
+	rows, err := dbconn.Global.QueryContext(ctx,
+		"SELECT result_key FROM code_monitor_results WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 AND action_key=$4 AND result_key = ANY($5)",
+		spec.Key, spec.Subject.User, spec.Subject.Org, actionKey, pq.Array(resultKeys),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	handled := map[string]bool{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		handled[key] = true
+	}
+	return handled, rows.Err()
+}
+
+// LatestTarget returns the ID and URL of the target (such as an issue) that the action of the
+// saved query most recently created or updated. If the action never handled a result, the ID is
+// empty.
+func (*codeMonitorResults) LatestTarget(ctx context.Context, spec api.SavedQueryIDSpec, actionKey string) (targetID, targetURL string, err error) {
+	var url sql.NullString
+	err = dbconn.Global.QueryRowContext(ctx,
+		"SELECT target_id, target_url FROM code_monitor_results WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 AND action_key=$4 ORDER BY id DESC LIMIT 1",
+		spec.Key, spec.Subject.User, spec.Subject.Org, actionKey,
+	).Scan(&targetID, &url)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return targetID, url.String, err
+}
+
+// Create records that the action of the saved query handled the results by creating or updating
+// the target. Results that were already recorded are ignored.
+func (*codeMonitorResults) Create(ctx context.Context, spec api.SavedQueryIDSpec, actionKey string, resultKeys []string, targetID, targetURL string) error {
+	if len(resultKeys) == 0 {
+		return nil
+	}
+	var url *string
+	if targetURL != "" {
+		url = &targetURL
+	}
+	values := make([]*sqlf.Query, len(resultKeys))
+	for i, key := range resultKeys {
+		values[i] = sqlf.Sprintf("(%s, %s, %s, %s, %s, %s, %s)", spec.Subject.User, spec.Subject.Org, spec.Key, actionKey, key, targetID, url)
+	}
+	q := sqlf.Sprintf("INSERT INTO code_monitor_results(user_id, org_id, saved_query_key, action_key, result_key, target_id, target_url) VALUES %s ON CONFLICT DO NOTHING", sqlf.Join(values, ","))
+	_, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
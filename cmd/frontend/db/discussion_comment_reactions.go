@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// discussionCommentReactions provides access to the `discussion_comment_reactions` table, which
+// records the (emoji) reactions of users to discussion comments.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentReactions struct{}
+
+// Add records the user's reaction to the comment. Adding the same reaction twice is a no-op.
+func (*discussionCommentReactions) Add(ctx context.Context, commentID int64, userID int32, reaction string) error {
+	if Mocks.DiscussionCommentReactions.Add != nil {
+		return Mocks.DiscussionCommentReactions.Add(ctx, commentID, userID, reaction)
+	}
+
+	if reaction == "" {
+		return errors.New("reaction must be present")
+	}
+	if len([]rune(reaction)) > 16 {
+		return errors.New("reaction too long (must be less than 16 UTF-8 characters)")
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_comment_reactions(comment_id, user_id, reaction)
+		SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM discussion_comments WHERE id=$1 AND deleted_at IS NULL)
+		ON CONFLICT DO NOTHING`,
+		commentID, userID, reaction,
+	)
+	return err
+}
+
+// Remove removes the user's reaction to the comment, if any.
+func (*discussionCommentReactions) Remove(ctx context.Context, commentID int64, userID int32, reaction string) error {
+	if Mocks.DiscussionCommentReactions.Remove != nil {
+		return Mocks.DiscussionCommentReactions.Remove(ctx, commentID, userID, reaction)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_comment_reactions WHERE comment_id=$1 AND user_id=$2 AND reaction=$3", commentID, userID, reaction)
+	return err
+}
+
+// List returns the reactions to the comment, oldest first.
+func (*discussionCommentReactions) List(ctx context.Context, commentID int64) ([]*types.DiscussionCommentReaction, error) {
+	if Mocks.DiscussionCommentReactions.List != nil {
+		return Mocks.DiscussionCommentReactions.List(ctx, commentID)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT comment_id, user_id, reaction, created_at
+		FROM discussion_comment_reactions
+		WHERE comment_id=$1
+		ORDER BY created_at ASC, user_id ASC`,
+		commentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []*types.DiscussionCommentReaction
+	for rows.Next() {
+		var r types.DiscussionCommentReaction
+		if err := rows.Scan(&r.CommentID, &r.UserID, &r.Reaction, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, &r)
+	}
+	return reactions, rows.Err()
+}
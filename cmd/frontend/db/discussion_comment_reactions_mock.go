@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentReactions struct {
+	Add    func(ctx context.Context, commentID int64, userID int32, reaction string) error
+	Remove func(ctx context.Context, commentID int64, userID int32, reaction string) error
+	List   func(ctx context.Context, commentID int64) ([]*types.DiscussionCommentReaction, error)
+}
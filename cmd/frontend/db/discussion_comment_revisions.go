@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// discussionCommentRevisions provides access to the `discussion_comment_revisions` table, which
+// holds the edit history of discussion comments. Revisions are created by DiscussionComments.Create
+// and DiscussionComments.Update; the last revision of a comment has the comment's current contents.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentRevisions struct{}
+
+// List returns the revisions of the comment, oldest first.
+func (*discussionCommentRevisions) List(ctx context.Context, commentID int64) ([]*types.DiscussionCommentRevision, error) {
+	if Mocks.DiscussionCommentRevisions.List != nil {
+		return Mocks.DiscussionCommentRevisions.List(ctx, commentID)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT id, comment_id, author_user_id, contents, reports, created_at
+		FROM discussion_comment_revisions
+		WHERE comment_id=$1
+		ORDER BY id ASC`,
+		commentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*types.DiscussionCommentRevision
+	for rows.Next() {
+		var r types.DiscussionCommentRevision
+		if err := rows.Scan(&r.ID, &r.CommentID, &r.AuthorUserID, &r.Contents, pq.Array(&r.Reports), &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &r)
+	}
+	return revisions, rows.Err()
+}
+
+// create records a new revision of the comment with the given contents. It is called in the
+// transaction that writes the contents to the comment.
+func (*discussionCommentRevisions) create(ctx context.Context, tx *sql.Tx, commentID int64, authorUserID int32, contents string) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO discussion_comment_revisions(comment_id, author_user_id, contents) VALUES ($1, $2, $3)", commentID, authorUserID, contents)
+	return err
+}
+
+// addReport adds the report to the given revision of the comment, or to its latest revision if
+// revisionID is nil. It is called in the transaction that adds the report to the comment.
+func (*discussionCommentRevisions) addReport(ctx context.Context, tx *sql.Tx, commentID int64, revisionID *int64, report string) error {
+	if revisionID != nil {
+		res, err := tx.ExecContext(ctx, "UPDATE discussion_comment_revisions SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND comment_id=$3", report, *revisionID, commentID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return &commentRevisionNotFoundError{commentID: commentID, revisionID: *revisionID}
+		}
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `UPDATE discussion_comment_revisions SET reports=ARRAY_APPEND(reports,$1)
+		WHERE id=(SELECT max(id) FROM discussion_comment_revisions WHERE comment_id=$2)`, report, commentID)
+	return err
+}
+
+// clearReports clears the reports on all revisions of the comment. It is called in the transaction
+// that clears the reports on the comment.
+func (*discussionCommentRevisions) clearReports(ctx context.Context, tx *sql.Tx, commentID int64) error {
+	_, err := tx.ExecContext(ctx, "UPDATE discussion_comment_revisions SET reports='{}' WHERE comment_id=$1", commentID)
+	return err
+}
+
+type commentRevisionNotFoundError struct {
+	commentID, revisionID int64
+}
+
+func (e *commentRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("revision %d of comment %d not found", e.revisionID, e.commentID)
+}
+
+func (e *commentRevisionNotFoundError) NotFound() bool { return true }
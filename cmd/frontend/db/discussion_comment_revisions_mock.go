@@ -0,0 +1,11 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentRevisions struct {
+	List func(ctx context.Context, commentID int64) ([]*types.DiscussionCommentRevision, error)
+}
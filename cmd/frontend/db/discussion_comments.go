@@ -11,6 +11,7 @@ import (
 	"github.com/lib/pq"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbutil"
 )
 
 // TODO(slimsag:discussions): future: tests for DiscussionComments.List
@@ -56,34 +57,59 @@ func (c *discussionComments) Create(ctx context.Context, newComment *types.Discu
 	if newComment.DeletedAt != nil {
 		return nil, errors.New("newComment.DeletedAt must not be specified")
 	}
+	if newComment.ParentCommentID != nil {
+		parent, err := c.Get(ctx, *newComment.ParentCommentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ThreadID != newComment.ThreadID {
+			return nil, errors.New("newComment.ParentCommentID must be a comment in the same thread")
+		}
+	}
 
 	// Create the comment.
 	newComment.CreatedAt = time.Now()
 	newComment.UpdatedAt = newComment.CreatedAt
 
-	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_comments(
-		thread_id,
-		author_user_id,
-		contents,
-		created_at,
-		updated_at
-	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
-		newComment.ThreadID,
-		newComment.AuthorUserID,
-		newComment.Contents,
-		newComment.CreatedAt,
-		newComment.UpdatedAt,
-	).Scan(&newComment.ID)
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `INSERT INTO discussion_comments(
+			thread_id,
+			author_user_id,
+			contents,
+			created_at,
+			updated_at,
+			parent_comment_id
+		) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+			newComment.ThreadID,
+			newComment.AuthorUserID,
+			newComment.Contents,
+			newComment.CreatedAt,
+			newComment.UpdatedAt,
+			newComment.ParentCommentID,
+		).Scan(&newComment.ID)
+		if err != nil {
+			return err
+		}
+
+		// The original contents are the first revision of the comment.
+		return DiscussionCommentRevisions.create(ctx, tx, newComment.ID, newComment.AuthorUserID, newComment.Contents)
+	})
 	if err != nil {
+		newComment.ID = 0
 		return nil, err
 	}
 	return newComment, nil
 }
 
 type DiscussionCommentsUpdateOptions struct {
-	// Contents, when non-nil, specifies the new contents of the comment.
+	// Contents, when non-nil, specifies the new contents of the comment. The
+	// new contents are recorded as a new revision of the comment.
 	Contents *string
 
+	// EditorUserID is the user who changed the contents, recorded as the
+	// author of the new revision. When zero, the comment's author is used.
+	EditorUserID int32
+
 	// Delete, when true, specifies that the comment should be deleted. This
 	// operation cannot be undone.
 	Delete bool
@@ -96,8 +122,10 @@ type DiscussionCommentsUpdateOptions struct {
 	hardDelete bool
 
 	// Report, when non-nil, specifies that the report message string should be
-	// added to the list of reports on this comment.
-	Report *string
+	// added to the list of reports on this comment, and on the revision
+	// ReportRevisionID (or the latest revision, when nil).
+	Report           *string
+	ReportRevisionID *int64
 
 	// ClearReports, when true, specifies that the comments reports should be
 	// cleared (e.g. after review by an admin)
@@ -123,7 +151,21 @@ func (c *discussionComments) Update(ctx context.Context, commentID int64, opts *
 	anyUpdate := false
 	if opts.Contents != nil {
 		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET contents=$1 WHERE id=$2 AND deleted_at IS NULL", *opts.Contents, commentID); err != nil {
+		comment, err := c.Get(ctx, commentID)
+		if err != nil {
+			return nil, err
+		}
+		editorUserID := opts.EditorUserID
+		if editorUserID == 0 {
+			editorUserID = comment.AuthorUserID
+		}
+		err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET contents=$1 WHERE id=$2 AND deleted_at IS NULL", *opts.Contents, commentID); err != nil {
+				return err
+			}
+			return DiscussionCommentRevisions.create(ctx, tx, commentID, editorUserID, *opts.Contents)
+		})
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -170,13 +212,27 @@ func (c *discussionComments) Update(ctx context.Context, commentID int64, opts *
 	}
 	if opts.Report != nil {
 		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND deleted_at IS NULL", *opts.Report, commentID); err != nil {
+		err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+			if err := DiscussionCommentRevisions.addReport(ctx, tx, commentID, opts.ReportRevisionID, *opts.Report); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND deleted_at IS NULL", *opts.Report, commentID)
+			return err
+		})
+		if err != nil {
 			return nil, err
 		}
 	}
 	if opts.ClearReports {
 		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET reports='{}' WHERE id=$1 AND deleted_at IS NULL", commentID); err != nil {
+		err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+			if err := DiscussionCommentRevisions.clearReports(ctx, tx, commentID); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET reports='{}' WHERE id=$1 AND deleted_at IS NULL", commentID)
+			return err
+		})
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -207,6 +263,10 @@ type DiscussionCommentsListOptions struct {
 	// be returned.
 	CommentID *int64
 
+	// ParentCommentID, when non-nil, specifies that only replies to this
+	// comment should be returned.
+	ParentCommentID *int64
+
 	// Reported, when true, returns only threads that have at least one report.
 	Reported bool
 
@@ -265,6 +325,9 @@ func (*discussionComments) getListSQL(opts *DiscussionCommentsListOptions) (cond
 	if opts.CommentID != nil {
 		conds = append(conds, sqlf.Sprintf("id=%v", *opts.CommentID))
 	}
+	if opts.ParentCommentID != nil {
+		conds = append(conds, sqlf.Sprintf("parent_comment_id=%v", *opts.ParentCommentID))
+	}
 	if opts.Reported {
 		conds = append(conds, sqlf.Sprintf("array_length(reports,1) > 0"))
 	}
@@ -297,7 +360,8 @@ func (*discussionComments) getBySQL(ctx context.Context, query string, args ...i
 			c.contents,
 			c.created_at,
 			c.updated_at,
-			c.reports
+			c.reports,
+			c.parent_comment_id
 		FROM discussion_comments c `+query, args...)
 	if err != nil {
 		return nil, err
@@ -315,6 +379,7 @@ func (*discussionComments) getBySQL(ctx context.Context, query string, args ...i
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
 			pq.Array(&comment.Reports),
+			&comment.ParentCommentID,
 		)
 		if err != nil {
 			return nil, err
@@ -65,3 +65,165 @@ func TestDiscussionComments_Create(t *testing.T) {
 		t.Fatal("expected CreatedAt to be set, got zero value time")
 	}
 }
+
+func TestDiscussionComments_RepliesReactionsRevisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moderator, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create two threads with a comment each.
+	newThread := func() (*types.DiscussionThread, *types.DiscussionComment) {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        "Hello world!",
+			TargetRepo: &types.DiscussionThreadTargetRepo{
+				RepoID:   repo.ID,
+				Path:     strPtr("foo/bar/mux.go"),
+				Revision: strPtr("0c1a96370c1a96370c1a96370c1a96370c1a9637"),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{
+			ThreadID:     thread.ID,
+			AuthorUserID: user.ID,
+			Contents:     "What do you think of Hello World as a Service?",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread, comment
+	}
+	thread, comment := newThread()
+	otherThread, _ := newThread()
+
+	// Reply to the comment.
+	reply, err := DiscussionComments.Create(ctx, &types.DiscussionComment{
+		ThreadID:        thread.ID,
+		AuthorUserID:    moderator.ID,
+		Contents:        "Sounds great.",
+		ParentCommentID: &comment.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replies, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ParentCommentID: &comment.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 1 || replies[0].ID != reply.ID || *replies[0].ParentCommentID != comment.ID {
+		t.Errorf("got replies %+v, want only %d", replies, reply.ID)
+	}
+	if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{
+		ThreadID:        otherThread.ID,
+		AuthorUserID:    user.ID,
+		Contents:        "Wrong thread",
+		ParentCommentID: &comment.ID,
+	}); err == nil {
+		t.Error("got nil error for a reply to a comment in another thread, want an error")
+	}
+
+	// React to the comment, twice with the same reaction.
+	for _, reaction := range []string{"👍", "👍", "🎉"} {
+		if err := DiscussionCommentReactions.Add(ctx, comment.ID, moderator.ID, reaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := DiscussionCommentReactions.Remove(ctx, comment.ID, moderator.ID, "🎉"); err != nil {
+		t.Fatal(err)
+	}
+	reactions, err := DiscussionCommentReactions.List(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reactions) != 1 || reactions[0].UserID != moderator.ID || reactions[0].Reaction != "👍" {
+		t.Errorf("got reactions %+v, want only 👍 by the moderator", reactions)
+	}
+
+	// Edit the comment, then report its first revision.
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{
+		Contents:     strPtr("Edited"),
+		EditorUserID: moderator.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	revisions, err := DiscussionCommentRevisions.List(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("got %d revisions, want 2", len(revisions))
+	}
+	if revisions[0].Contents != "What do you think of Hello World as a Service?" || revisions[0].AuthorUserID != user.ID {
+		t.Errorf("got first revision %+v, want the original contents by the author", revisions[0])
+	}
+	if revisions[1].Contents != "Edited" || revisions[1].AuthorUserID != moderator.ID {
+		t.Errorf("got second revision %+v, want the edited contents by the moderator", revisions[1])
+	}
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{
+		Report:           strPtr("spam"),
+		ReportRevisionID: &revisions[0].ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	revisions, err = DiscussionCommentRevisions.List(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions[0].Reports) != 1 || len(revisions[1].Reports) != 0 {
+		t.Errorf("got reports %q and %q, want only the first revision reported", revisions[0].Reports, revisions[1].Reports)
+	}
+	reported, err := DiscussionComments.Count(ctx, &DiscussionCommentsListOptions{Reported: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reported != 1 {
+		t.Errorf("got %d reported comments, want 1", reported)
+	}
+
+	// Reporting a revision that doesn't exist doesn't report the comment either.
+	missingRevisionID := revisions[1].ID + 1000
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{
+		Report:           strPtr("abuse"),
+		ReportRevisionID: &missingRevisionID,
+	}); err == nil {
+		t.Fatal("got nil error reporting a missing revision")
+	}
+	comment, err = DiscussionComments.Get(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comment.Reports) != 1 {
+		t.Errorf("got reports %q, want only the first report", comment.Reports)
+	}
+}
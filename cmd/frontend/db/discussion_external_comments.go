@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// discussionExternalComments provides access to the `discussion_external_comments` table, which
+// records the discussion comments that were imported from (or posted to) review comments on pull
+// requests of code hosts, so that they are synced only once.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionExternalComments struct{}
+
+// Create records that the discussion comment was imported from, or posted to, the review comment.
+func (*discussionExternalComments) Create(ctx context.Context, c *types.DiscussionExternalComment) error {
+	if Mocks.DiscussionExternalComments.Create != nil {
+		return Mocks.DiscussionExternalComments.Create(ctx, c)
+	}
+
+	if c == nil {
+		return errors.New("external comment is nil")
+	}
+	if c.CommentID == 0 || c.ThreadID == 0 || c.RepoID == 0 {
+		return errors.New("external comment must have a comment, thread and repository")
+	}
+	if c.ServiceType == "" || c.ServiceID == "" || c.PullRequestID == "" || c.ExternalThreadID == "" || c.ExternalID == "" {
+		return errors.New("external comment must have a service, pull request, thread and ID")
+	}
+	return dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_external_comments(
+		comment_id,
+		thread_id,
+		repo_id,
+		service_type,
+		service_id,
+		pull_request_id,
+		external_thread_id,
+		external_id
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING created_at`,
+		c.CommentID,
+		c.ThreadID,
+		c.RepoID,
+		c.ServiceType,
+		c.ServiceID,
+		c.PullRequestID,
+		c.ExternalThreadID,
+		c.ExternalID,
+	).Scan(&c.CreatedAt)
+}
+
+// GetByExternalID returns the record of the review comment with the given ID on the external
+// service. If the review comment was never synced, it returns nil and no error.
+func (*discussionExternalComments) GetByExternalID(ctx context.Context, serviceType, serviceID, externalID string) (*types.DiscussionExternalComment, error) {
+	if Mocks.DiscussionExternalComments.GetByExternalID != nil {
+		return Mocks.DiscussionExternalComments.GetByExternalID(ctx, serviceType, serviceID, externalID)
+	}
+
+	var c types.DiscussionExternalComment
+	err := dbconn.Global.QueryRowContext(ctx, `
+		SELECT comment_id, thread_id, repo_id, service_type, service_id, pull_request_id, external_thread_id, external_id, created_at
+		FROM discussion_external_comments
+		WHERE service_type=$1 AND service_id=$2 AND external_id=$3`,
+		serviceType, serviceID, externalID,
+	).Scan(&c.CommentID, &c.ThreadID, &c.RepoID, &c.ServiceType, &c.ServiceID, &c.PullRequestID, &c.ExternalThreadID, &c.ExternalID, &c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// DiscussionPendingReply is a comment made on Sourcegraph in a thread that was imported from a code
+// host, which is yet to be posted to the code host.
+type DiscussionPendingReply struct {
+	Comment *types.DiscussionComment
+
+	// Thread is the record of the first synced comment of the comment's thread, which identifies
+	// the thread on the code host.
+	Thread *types.DiscussionExternalComment
+}
+
+// ListPendingReplies lists the comments in the (non-deleted) threads that were imported from review
+// comments on pull requests of the repository, which were not imported or posted themselves.
+func (*discussionExternalComments) ListPendingReplies(ctx context.Context, repoID api.RepoID) ([]*DiscussionPendingReply, error) {
+	if Mocks.DiscussionExternalComments.ListPendingReplies != nil {
+		return Mocks.DiscussionExternalComments.ListPendingReplies(ctx, repoID)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			c.id, c.thread_id, c.author_user_id, c.contents, c.created_at, c.updated_at, c.reports,
+			e.comment_id, e.thread_id, e.repo_id, e.service_type, e.service_id, e.pull_request_id, e.external_thread_id, e.external_id, e.created_at
+		FROM discussion_comments c
+		JOIN (
+			SELECT DISTINCT ON (thread_id) * FROM discussion_external_comments WHERE repo_id=$1 ORDER BY thread_id, comment_id
+		) e ON e.thread_id=c.thread_id
+		WHERE c.deleted_at IS NULL
+		AND c.thread_id IN (SELECT id FROM discussion_threads WHERE deleted_at IS NULL)
+		AND NOT EXISTS (SELECT 1 FROM discussion_external_comments x WHERE x.comment_id=c.id)
+		ORDER BY c.id ASC`,
+		repoID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var replies []*DiscussionPendingReply
+	for rows.Next() {
+		r := &DiscussionPendingReply{Comment: &types.DiscussionComment{}, Thread: &types.DiscussionExternalComment{}}
+		if err := rows.Scan(
+			&r.Comment.ID,
+			&r.Comment.ThreadID,
+			&r.Comment.AuthorUserID,
+			&r.Comment.Contents,
+			&r.Comment.CreatedAt,
+			&r.Comment.UpdatedAt,
+			pq.Array(&r.Comment.Reports),
+			&r.Thread.CommentID,
+			&r.Thread.ThreadID,
+			&r.Thread.RepoID,
+			&r.Thread.ServiceType,
+			&r.Thread.ServiceID,
+			&r.Thread.PullRequestID,
+			&r.Thread.ExternalThreadID,
+			&r.Thread.ExternalID,
+			&r.Thread.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		replies = append(replies, r)
+	}
+	return replies, rows.Err()
+}
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockDiscussionExternalComments struct {
+	Create             func(ctx context.Context, c *types.DiscussionExternalComment) error
+	GetByExternalID    func(ctx context.Context, serviceType, serviceID, externalID string) (*types.DiscussionExternalComment, error)
+	ListPendingReplies func(ctx context.Context, repoID api.RepoID) ([]*DiscussionPendingReply, error)
+}
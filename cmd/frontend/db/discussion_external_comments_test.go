@@ -0,0 +1,107 @@
+package db
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestDiscussionExternalComments(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Imported",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Path: strPtr("a.go")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addComment := func(contents string) *types.DiscussionComment {
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: contents})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return comment
+	}
+
+	// Record the imported comment.
+	imported := addComment("imported")
+	root := &types.DiscussionExternalComment{
+		CommentID:        imported.ID,
+		ThreadID:         thread.ID,
+		RepoID:           repo.ID,
+		ServiceType:      "github",
+		ServiceID:        "https://github.com/",
+		PullRequestID:    "12",
+		ExternalThreadID: "100",
+		ExternalID:       "100",
+	}
+	if err := DiscussionExternalComments.Create(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiscussionExternalComments.GetByExternalID(ctx, "github", "https://github.com/", "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.CommentID != imported.ID || got.ThreadID != thread.ID || got.PullRequestID != "12" {
+		t.Fatalf("got %+v, want the record of comment %d", got, imported.ID)
+	}
+	if got, err := DiscussionExternalComments.GetByExternalID(ctx, "github", "https://github.com/", "101"); err != nil || got != nil {
+		t.Fatalf("got %+v (error %v) for an unknown comment, want nil", got, err)
+	}
+
+	// A second record of the same external comment is rejected.
+	dup := *root
+	dup.CommentID = addComment("duplicate").ID
+	if err := DiscussionExternalComments.Create(ctx, &dup); err == nil {
+		t.Fatal("got no error recording an external comment twice, want an error")
+	}
+
+	// Comments made on Sourcegraph are pending until they are recorded as posted.
+	reply := addComment("reply")
+	replies, err := DiscussionExternalComments.ListPendingReplies(ctx, repo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 2 || replies[0].Comment.ID != dup.CommentID || replies[1].Comment.ID != reply.ID {
+		t.Fatalf("got %d pending replies, want the 2 unrecorded comments", len(replies))
+	}
+	if replies[1].Thread.ExternalThreadID != "100" || replies[1].Thread.PullRequestID != "12" {
+		t.Errorf("got thread %+v, want the imported comment", replies[1].Thread)
+	}
+	for _, c := range []int64{dup.CommentID, reply.ID} {
+		posted := *root
+		posted.CommentID = c
+		posted.ExternalID = "posted-" + strconv.FormatInt(c, 10)
+		if err := DiscussionExternalComments.Create(ctx, &posted); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if replies, err := DiscussionExternalComments.ListPendingReplies(ctx, repo.ID); err != nil || len(replies) != 0 {
+		t.Fatalf("got %d pending replies (error %v), want none", len(replies), err)
+	}
+}
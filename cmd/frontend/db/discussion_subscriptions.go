@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// discussionSubscriptions provides access to the `discussion_subscriptions` table, which records
+// the threads, repositories and paths that users watch (to be notified about new threads and
+// comments) and the threads that users muted.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionSubscriptions struct{}
+
+// WatchThread subscribes the user to the thread, unmuting it if the user muted it.
+func (s *discussionSubscriptions) WatchThread(ctx context.Context, userID int32, threadID int64) error {
+	if Mocks.DiscussionSubscriptions.WatchThread != nil {
+		return Mocks.DiscussionSubscriptions.WatchThread(ctx, userID, threadID)
+	}
+	return s.setThread(ctx, userID, threadID, false)
+}
+
+// MuteThread unsubscribes the user from the thread, so that they are not notified about it even
+// if they are subscribed to its repository or were involved in it.
+func (s *discussionSubscriptions) MuteThread(ctx context.Context, userID int32, threadID int64) error {
+	if Mocks.DiscussionSubscriptions.MuteThread != nil {
+		return Mocks.DiscussionSubscriptions.MuteThread(ctx, userID, threadID)
+	}
+	return s.setThread(ctx, userID, threadID, true)
+}
+
+func (*discussionSubscriptions) setThread(ctx context.Context, userID int32, threadID int64, muted bool) error {
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_subscriptions(user_id, thread_id, muted) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, thread_id) WHERE thread_id IS NOT NULL DO UPDATE SET muted=excluded.muted`,
+		userID, threadID, muted,
+	)
+	return err
+}
+
+// WatchRepo subscribes the user to the threads on the repository whose path starts with
+// pathPrefix (or to all of the repository's threads, if pathPrefix is empty).
+func (*discussionSubscriptions) WatchRepo(ctx context.Context, userID int32, repoID api.RepoID, pathPrefix string) error {
+	if Mocks.DiscussionSubscriptions.WatchRepo != nil {
+		return Mocks.DiscussionSubscriptions.WatchRepo(ctx, userID, repoID, pathPrefix)
+	}
+
+	pathPrefix = strings.TrimPrefix(pathPrefix, "/")
+	if len(pathPrefix) > 1000 {
+		return errors.New("path prefix too long (must be less than 1,000 characters)")
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_subscriptions(user_id, repo_id, path_prefix) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, repo_id, path_prefix) WHERE repo_id IS NOT NULL DO NOTHING`,
+		userID, repoID, pathPrefix,
+	)
+	return err
+}
+
+// UnwatchRepo removes the user's subscription to the threads on the repository under pathPrefix,
+// as created by WatchRepo.
+func (*discussionSubscriptions) UnwatchRepo(ctx context.Context, userID int32, repoID api.RepoID, pathPrefix string) error {
+	if Mocks.DiscussionSubscriptions.UnwatchRepo != nil {
+		return Mocks.DiscussionSubscriptions.UnwatchRepo(ctx, userID, repoID, pathPrefix)
+	}
+
+	pathPrefix = strings.TrimPrefix(pathPrefix, "/")
+	_, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_subscriptions WHERE user_id=$1 AND repo_id=$2 AND path_prefix=$3", userID, repoID, pathPrefix)
+	return err
+}
+
+type DiscussionSubscriptionsListOptions struct {
+	// UserID, when non-nil, specifies that only subscriptions of this user
+	// should be returned.
+	UserID *int32
+
+	// ThreadID, when non-nil, specifies that only subscriptions to this thread
+	// should be returned.
+	ThreadID *int64
+}
+
+// List returns the subscriptions matching the options, oldest first.
+func (*discussionSubscriptions) List(ctx context.Context, opts *DiscussionSubscriptionsListOptions) ([]*types.DiscussionSubscription, error) {
+	if Mocks.DiscussionSubscriptions.List != nil {
+		return Mocks.DiscussionSubscriptions.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserID != nil {
+		conds = append(conds, sqlf.Sprintf("user_id=%v", *opts.UserID))
+	}
+	if opts.ThreadID != nil {
+		conds = append(conds, sqlf.Sprintf("thread_id=%v", *opts.ThreadID))
+	}
+	q := sqlf.Sprintf(`
+		SELECT id, user_id, thread_id, repo_id, path_prefix, muted, created_at
+		FROM discussion_subscriptions
+		WHERE %s
+		ORDER BY id ASC`, sqlf.Join(conds, "AND"))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*types.DiscussionSubscription
+	for rows.Next() {
+		var s types.DiscussionSubscription
+		if err := rows.Scan(&s.ID, &s.UserID, &s.ThreadID, &s.RepoID, &s.PathPrefix, &s.Muted, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &s)
+	}
+	return subscriptions, rows.Err()
+}
+
+// Subscribers returns the users who watch the thread (directly, or through its repository and
+// path), and the users who muted the thread. A user who muted the thread is never also returned
+// as watching it.
+func (*discussionSubscriptions) Subscribers(ctx context.Context, thread *types.DiscussionThread) (watching, muted []int32, err error) {
+	if Mocks.DiscussionSubscriptions.Subscribers != nil {
+		return Mocks.DiscussionSubscriptions.Subscribers(ctx, thread)
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("thread_id=%v", thread.ID)}
+	if tr := thread.TargetRepo; tr != nil {
+		var path string
+		if tr.Path != nil {
+			path = *tr.Path
+		}
+		conds = append(conds, sqlf.Sprintf("(repo_id=%v AND left(%v::text, length(path_prefix))=path_prefix)", tr.RepoID, path))
+	}
+	q := sqlf.Sprintf(`
+		SELECT user_id, bool_or(muted)
+		FROM discussion_subscriptions
+		WHERE %s
+		GROUP BY user_id
+		ORDER BY user_id ASC`, sqlf.Join(conds, "OR"))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID  int32
+			isMuted bool
+		)
+		if err := rows.Scan(&userID, &isMuted); err != nil {
+			return nil, nil, err
+		}
+		if isMuted {
+			muted = append(muted, userID)
+		} else {
+			watching = append(watching, userID)
+		}
+	}
+	return watching, muted, rows.Err()
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockDiscussionSubscriptions struct {
+	WatchThread func(ctx context.Context, userID int32, threadID int64) error
+	MuteThread  func(ctx context.Context, userID int32, threadID int64) error
+	WatchRepo   func(ctx context.Context, userID int32, repoID api.RepoID, pathPrefix string) error
+	UnwatchRepo func(ctx context.Context, userID int32, repoID api.RepoID, pathPrefix string) error
+	List        func(ctx context.Context, opts *DiscussionSubscriptionsListOptions) ([]*types.DiscussionSubscription, error)
+	Subscribers func(ctx context.Context, thread *types.DiscussionThread) (watching, muted []int32, err error)
+}
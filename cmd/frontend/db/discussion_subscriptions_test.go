@@ -0,0 +1,168 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestDiscussionSubscriptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	var users []*types.User
+	for _, username := range []string{"u1", "u2", "u3", "u4"} {
+		user, err := Users.Create(ctx, NewUser{
+			Email:                 username + "@a.com",
+			Username:              username,
+			Password:              "p",
+			EmailVerificationCode: "c",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		users = append(users, user)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: users[0].ID,
+		Title:        "Hello world!",
+		TargetRepo: &types.DiscussionThreadTargetRepo{
+			RepoID:   repo.ID,
+			Path:     strPtr("foo/bar/mux.go"),
+			Branch:   strPtr("master"),
+			Revision: strPtr("0c1a96370c1a96370c1a96370c1a96370c1a9637"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSubscribers := func(wantWatching, wantMuted []int32) {
+		t.Helper()
+		watching, muted, err := DiscussionSubscriptions.Subscribers(ctx, thread)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(watching, wantWatching) {
+			t.Errorf("got watching %v, want %v", watching, wantWatching)
+		}
+		if !reflect.DeepEqual(muted, wantMuted) {
+			t.Errorf("got muted %v, want %v", muted, wantMuted)
+		}
+	}
+	checkSubscribers(nil, nil)
+
+	// u1 watches the thread, u2 the repository, u3 a matching path prefix and
+	// u4 another path prefix.
+	if err := DiscussionSubscriptions.WatchThread(ctx, users[0].ID, thread.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionSubscriptions.WatchRepo(ctx, users[1].ID, repo.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionSubscriptions.WatchRepo(ctx, users[2].ID, repo.ID, "/foo/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionSubscriptions.WatchRepo(ctx, users[3].ID, repo.ID, "qux/"); err != nil {
+		t.Fatal(err)
+	}
+	checkSubscribers([]int32{users[0].ID, users[1].ID, users[2].ID}, nil)
+
+	// Watching twice is a no-op.
+	if err := DiscussionSubscriptions.WatchRepo(ctx, users[2].ID, repo.ID, "foo/"); err != nil {
+		t.Fatal(err)
+	}
+	subscriptions, err := DiscussionSubscriptions.List(ctx, &DiscussionSubscriptionsListOptions{UserID: &users[2].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].PathPrefix != "foo/" || *subscriptions[0].RepoID != repo.ID {
+		t.Fatalf("unexpected subscriptions %+v", subscriptions)
+	}
+
+	// Muting the thread overrides the repository subscription, and watching it
+	// again unmutes it.
+	if err := DiscussionSubscriptions.MuteThread(ctx, users[1].ID, thread.ID); err != nil {
+		t.Fatal(err)
+	}
+	checkSubscribers([]int32{users[0].ID, users[2].ID}, []int32{users[1].ID})
+	if err := DiscussionSubscriptions.WatchThread(ctx, users[1].ID, thread.ID); err != nil {
+		t.Fatal(err)
+	}
+	checkSubscribers([]int32{users[0].ID, users[1].ID, users[2].ID}, nil)
+
+	if err := DiscussionSubscriptions.UnwatchRepo(ctx, users[2].ID, repo.ID, "foo/"); err != nil {
+		t.Fatal(err)
+	}
+	checkSubscribers([]int32{users[0].ID, users[1].ID}, nil)
+}
+
+func TestDiscussionUnsubscribeTokens(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Hello world!",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := DiscussionUnsubscribeTokens.Generate(ctx, user.ID, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token2, err := DiscussionUnsubscribeTokens.Generate(ctx, user.ID, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != token2 {
+		t.Errorf("got a new token %q, want the existing token %q", token2, token)
+	}
+
+	userID, threadID, err := DiscussionUnsubscribeTokens.Get(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID != user.ID || threadID != thread.ID {
+		t.Errorf("got user %d and thread %d, want user %d and thread %d", userID, threadID, user.ID, thread.ID)
+	}
+	if _, _, err := DiscussionUnsubscribeTokens.Get(ctx, "invalid"); err != ErrInvalidToken {
+		t.Errorf("got error %v, want ErrInvalidToken", err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/searchquery"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/actor"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
@@ -70,12 +71,30 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 	if newThread.DeletedAt != nil {
 		return nil, errors.New("newThread.DeletedAt must not be specified")
 	}
+	if newThread.ResolvedAt != nil {
+		return nil, errors.New("newThread.ResolvedAt must not be specified")
+	}
+	labels, err := normalizeLabels(newThread.Labels)
+	if err != nil {
+		return nil, errors.Wrap(err, "newThread.Labels")
+	}
+	newThread.Labels = labels
 	if newThread.TargetRepo != nil {
 		if rev := newThread.TargetRepo.Revision; rev != nil {
 			if !git.IsAbsoluteRevision(*rev) {
 				return nil, errors.New("newThread.TargetRepo.Revision must be an absolute Git revision (40 character SHA-1 hash)")
 			}
 		}
+		if rev := newThread.TargetRepo.BaseRevision; rev != nil {
+			if !git.IsAbsoluteRevision(*rev) {
+				return nil, errors.New("newThread.TargetRepo.BaseRevision must be an absolute Git revision (40 character SHA-1 hash)")
+			}
+			if newThread.TargetRepo.Revision == nil {
+				return nil, errors.New("newThread.TargetRepo.Revision (the head of the diff) must be specified when BaseRevision is")
+			}
+		} else if newThread.TargetRepo.BaseBranch != nil {
+			return nil, errors.New("newThread.TargetRepo.BaseRevision must be specified when BaseBranch is")
+		}
 	} else {
 		return nil, errors.New("newThread must have a target")
 	}
@@ -85,20 +104,28 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 	// First, create the thread itself. Initially it will have no target.
 	newThread.CreatedAt = time.Now()
 	newThread.UpdatedAt = newThread.CreatedAt
-	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_threads(
+	err = dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_threads(
 		author_user_id,
 		title,
+		labels,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4) RETURNING id`,
+	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
 		newThread.AuthorUserID,
 		newThread.Title,
+		pq.Array(newThread.Labels),
 		newThread.CreatedAt,
 		newThread.UpdatedAt,
 	).Scan(&newThread.ID)
 	if err != nil {
 		return nil, errors.Wrap(err, "create thread")
 	}
+	if len(newThread.AssigneeUserIDs) > 0 {
+		newThread.AssigneeUserIDs, err = t.setAssignees(ctx, newThread.ID, newThread.AssigneeUserIDs)
+		if err != nil {
+			return nil, errors.Wrap(err, "setAssignees")
+		}
+	}
 
 	// Create the thread target and have it reference the thread we just created.
 	var (
@@ -143,6 +170,17 @@ type DiscussionThreadsUpdateOptions struct {
 	// Archive, when non-nil, specifies whether the thread is archived or not.
 	Archive *bool
 
+	// Resolve, when non-nil, specifies whether the thread is resolved (true)
+	// or open (false).
+	Resolve *bool
+
+	// Labels, when non-nil, replaces the labels of the thread.
+	Labels *[]string
+
+	// AssigneeUserIDs, when non-nil, replaces the users the thread is
+	// assigned to.
+	AssigneeUserIDs *[]int32
+
 	// Delete, when true, specifies that the thread should be deleted. This
 	// operation cannot be undone.
 	Delete bool
@@ -178,6 +216,34 @@ func (t *discussionThreads) Update(ctx context.Context, threadID int64, opts *Di
 			return nil, err
 		}
 	}
+	if opts.Resolve != nil {
+		anyUpdate = true
+		var resolvedAt *time.Time
+		if *opts.Resolve {
+			resolvedAt = &now
+		}
+		// Keep the original resolution time when resolving an already
+		// resolved thread.
+		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET resolved_at=(CASE WHEN $1::timestamptz IS NULL THEN NULL ELSE COALESCE(resolved_at, $1) END) WHERE id=$2 AND deleted_at IS NULL", resolvedAt, threadID); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Labels != nil {
+		anyUpdate = true
+		labels, err := normalizeLabels(*opts.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET labels=$1 WHERE id=$2 AND deleted_at IS NULL", pq.Array(labels), threadID); err != nil {
+			return nil, err
+		}
+	}
+	if opts.AssigneeUserIDs != nil {
+		anyUpdate = true
+		if _, err := t.setAssignees(ctx, threadID, *opts.AssigneeUserIDs); err != nil {
+			return nil, err
+		}
+	}
 	if opts.Delete {
 		anyUpdate = true
 		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, threadID); err != nil {
@@ -202,10 +268,13 @@ func (t *discussionThreads) Update(ctx context.Context, threadID int64, opts *Di
 		// Intentionally not setting anyUpdate=true here, it would cause us to
 		// try to update updated_at below which would fail.
 
-		// Hard delete the mail reply tokens.
+		// Hard delete the mail reply tokens and assignees.
 		if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_mail_reply_tokens WHERE thread_id=$1", threadID); err != nil {
 			return nil, err
 		}
+		if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_threads_assignees WHERE thread_id=$1", threadID); err != nil {
+			return nil, err
+		}
 
 		// Unlink and hard delete discussion thread targets.
 		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET target_repo_id=null WHERE id=$1", threadID); err != nil {
@@ -245,6 +314,51 @@ func (t *discussionThreads) Update(ctx context.Context, threadID int64, opts *Di
 	return t.Get(ctx, threadID)
 }
 
+// setAssignees replaces the users that the thread is assigned to, and returns
+// the resulting (deduplicated) list of assignees.
+func (t *discussionThreads) setAssignees(ctx context.Context, threadID int64, userIDs []int32) ([]int32, error) {
+	var (
+		assignees = []int32{} // non-nil, so that pq.Array is an empty array and not NULL
+		set       = make(map[int32]struct{}, len(userIDs))
+	)
+	for _, userID := range userIDs {
+		if _, ok := set[userID]; !ok {
+			set[userID] = struct{}{}
+			assignees = append(assignees, userID)
+		}
+	}
+	if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_threads_assignees WHERE thread_id=$1 AND NOT (user_id = ANY($2))", threadID, pq.Array(assignees)); err != nil {
+		return nil, err
+	}
+	for _, userID := range assignees {
+		if _, err := dbconn.Global.ExecContext(ctx, "INSERT INTO discussion_threads_assignees(thread_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", threadID, userID); err != nil {
+			return nil, err
+		}
+	}
+	return assignees, nil
+}
+
+// normalizeLabels trims whitespace from and removes duplicates of the labels,
+// returning an error if a label is empty or too long.
+func normalizeLabels(labels []string) ([]string, error) {
+	normalized := []string{}
+	set := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, errors.New("label must be present (and not whitespace)")
+		}
+		if len([]rune(label)) > 100 {
+			return nil, fmt.Errorf("label %q too long (must be less than 100 UTF-8 characters)", label)
+		}
+		if _, ok := set[label]; !ok {
+			set[label] = struct{}{}
+			normalized = append(normalized, label)
+		}
+	}
+	return normalized, nil
+}
+
 type DiscussionThreadsListOptions struct {
 	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
 	*LimitOffset
@@ -274,6 +388,16 @@ type DiscussionThreadsListOptions struct {
 	TargetRepoPath    *string
 	NotTargetRepoPath *string
 
+	// TargetRepoDiff, when non-nil, specifies that only threads that have a repo target
+	// on a diff (true) or on a single revision (false) should be returned.
+	TargetRepoDiff *bool
+
+	// TargetRepoBaseRevision and TargetRepoHeadRevision, when non-nil, specify that only
+	// threads that have a repo target on a diff with this base or head revision should be
+	// returned.
+	TargetRepoBaseRevision *string
+	TargetRepoHeadRevision *string
+
 	// CreatedBefore, when non-nil, specifies that only threads that were
 	// created before this time should be returned.
 	CreatedBefore *time.Time
@@ -286,6 +410,20 @@ type DiscussionThreadsListOptions struct {
 	// Reported, when true, specifies that only threads with at least one
 	// reported comment should be returned.
 	Reported bool
+
+	// Resolved, when non-nil, specifies that only threads that are resolved
+	// (true) or open (false) should be returned.
+	Resolved *bool
+
+	// AssigneeUserIDs, when len() > 0, specifies that only threads assigned to
+	// at least one of these users should be returned.
+	AssigneeUserIDs    []int32
+	NotAssigneeUserIDs []int32
+
+	// Labels, when len() > 0, specifies that only threads that have all of
+	// these labels should be returned.
+	Labels    []string
+	NotLabels []string
 }
 
 // SetFromQuery sets the options based on the search query string.
@@ -293,6 +431,15 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 	userList := func(value string) (users []*types.User) {
 		for _, username := range strings.Fields(value) {
 			username = strings.TrimSpace(strings.TrimPrefix(username, "@"))
+			if username == "me" {
+				// "@me" refers to the current user, if any.
+				if a := actor.FromContext(ctx); a.IsAuthenticated() {
+					if user, err := Users.GetByID(ctx, a.UID); err == nil {
+						users = append(users, user)
+						continue
+					}
+				}
+			}
 			user, err := Users.GetByUsername(ctx, username)
 			if err != nil {
 				continue
@@ -404,6 +551,13 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 			opts.NotTargetRepoPath = &value
 		},
 
+		// syntax: "diff:true" or "diff:false"
+		"diff": func(value string) {
+			if diff, err := strconv.ParseBool(value); err == nil {
+				opts.TargetRepoDiff = &diff
+			}
+		},
+
 		// syntax: "file:dir/file.go" or "file:something.go"
 		"before": func(value string) {
 			opts.CreatedBefore = parseTimeOrDuration(value)
@@ -421,6 +575,37 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 		"reported": func(value string) {
 			reported, _ = strconv.ParseBool(value)
 		},
+
+		// syntax: "is:open" or "is:resolved"
+		"is": func(value string) {
+			switch strings.ToLower(value) {
+			case "open":
+				resolved := false
+				opts.Resolved = &resolved
+			case "resolved", "closed":
+				resolved := true
+				opts.Resolved = &resolved
+			}
+		},
+
+		// syntax: "assignee:slimsag" or "assignee:@me" or `assignee:"slimsag @jack"`
+		"assignee": func(value string) {
+			opts.AssigneeUserIDs = userIDsList(value)
+			if len(opts.AssigneeUserIDs) == 0 {
+				opts.AssigneeUserIDs = []int32{-1}
+			}
+		},
+		"-assignee": func(value string) {
+			opts.NotAssigneeUserIDs = userIDsList(value)
+		},
+
+		// syntax: "label:security" or "-label:wontfix"
+		"label": func(value string) {
+			opts.Labels = append(opts.Labels, value)
+		},
+		"-label": func(value string) {
+			opts.NotLabels = append(opts.NotLabels, value)
+		},
 	}
 	remaining, operations := searchquery.Parse(query)
 	for _, operation := range operations {
@@ -580,8 +765,27 @@ func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds
 	if opts.CreatedAfter != nil {
 		conds = append(conds, sqlf.Sprintf("created_at > %v", *opts.CreatedAfter))
 	}
+	if opts.Resolved != nil {
+		if *opts.Resolved {
+			conds = append(conds, sqlf.Sprintf("resolved_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("resolved_at IS NULL"))
+		}
+	}
+	if len(opts.AssigneeUserIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT thread_id FROM discussion_threads_assignees WHERE user_id = ANY(%v))", pq.Array(opts.AssigneeUserIDs)))
+	}
+	if len(opts.NotAssigneeUserIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id NOT IN (SELECT thread_id FROM discussion_threads_assignees WHERE user_id = ANY(%v))", pq.Array(opts.NotAssigneeUserIDs)))
+	}
+	if len(opts.Labels) > 0 {
+		conds = append(conds, sqlf.Sprintf("labels @> %v", pq.Array(opts.Labels)))
+	}
+	if len(opts.NotLabels) > 0 {
+		conds = append(conds, sqlf.Sprintf("NOT (labels && %v)", pq.Array(opts.NotLabels)))
+	}
 
-	if opts.TargetRepoID != nil || opts.TargetRepoPath != nil || opts.NotTargetRepoID != nil || opts.NotTargetRepoPath != nil {
+	if opts.TargetRepoID != nil || opts.TargetRepoPath != nil || opts.NotTargetRepoID != nil || opts.NotTargetRepoPath != nil || opts.TargetRepoDiff != nil || opts.TargetRepoBaseRevision != nil || opts.TargetRepoHeadRevision != nil {
 		targetRepoConds := []*sqlf.Query{}
 		if opts.TargetRepoID != nil {
 			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("repo_id = %v", *opts.TargetRepoID))
@@ -605,7 +809,20 @@ func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds
 				targetRepoConds = append(targetRepoConds, sqlf.Sprintf("path!=%v", *opts.NotTargetRepoPath))
 			}
 		}
-		conds = append(conds, sqlf.Sprintf("id IN (SELECT id FROM discussion_threads_target_repo WHERE %v)", sqlf.Join(targetRepoConds, "AND")))
+		if opts.TargetRepoDiff != nil {
+			if *opts.TargetRepoDiff {
+				targetRepoConds = append(targetRepoConds, sqlf.Sprintf("base_revision IS NOT NULL"))
+			} else {
+				targetRepoConds = append(targetRepoConds, sqlf.Sprintf("base_revision IS NULL"))
+			}
+		}
+		if opts.TargetRepoBaseRevision != nil {
+			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("base_revision=%v", *opts.TargetRepoBaseRevision))
+		}
+		if opts.TargetRepoHeadRevision != nil {
+			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("base_revision IS NOT NULL AND revision=%v", *opts.TargetRepoHeadRevision))
+		}
+		conds = append(conds, sqlf.Sprintf("target_repo_id IN (SELECT id FROM discussion_threads_target_repo WHERE %v)", sqlf.Join(targetRepoConds, "AND")))
 	}
 	return conds
 }
@@ -639,6 +856,12 @@ func (t *discussionThreads) createTargetRepo(ctx context.Context, tr *types.Disc
 	if tr.Revision != nil {
 		field("revision", *tr.Revision)
 	}
+	if tr.BaseBranch != nil {
+		field("base_branch", *tr.BaseBranch)
+	}
+	if tr.BaseRevision != nil {
+		field("base_revision", *tr.BaseRevision)
+	}
 	if tr.HasSelection() {
 		field("start_line", *tr.StartLine)
 		field("end_line", *tr.EndLine)
@@ -671,7 +894,10 @@ func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...
 			t.target_repo_id,
 			t.created_at,
 			t.archived_at,
-			t.updated_at
+			t.updated_at,
+			t.resolved_at,
+			t.labels,
+			ARRAY(SELECT a.user_id FROM discussion_threads_assignees a WHERE a.thread_id=t.id ORDER BY a.created_at, a.user_id)
 		FROM discussion_threads t `+query, args...)
 	if err != nil {
 		return nil, err
@@ -681,8 +907,9 @@ func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...
 	defer rows.Close()
 	for rows.Next() {
 		var (
-			thread       types.DiscussionThread
-			targetRepoID *int64
+			thread          types.DiscussionThread
+			targetRepoID    *int64
+			assigneeUserIDs pq.Int64Array
 		)
 		err := rows.Scan(
 			&thread.ID,
@@ -692,10 +919,16 @@ func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...
 			&thread.CreatedAt,
 			&thread.ArchivedAt,
 			&thread.UpdatedAt,
+			&thread.ResolvedAt,
+			pq.Array(&thread.Labels),
+			&assigneeUserIDs,
 		)
 		if err != nil {
 			return nil, err
 		}
+		for _, userID := range assigneeUserIDs {
+			thread.AssigneeUserIDs = append(thread.AssigneeUserIDs, int32(userID))
+		}
 		if targetRepoID != nil {
 			thread.TargetRepo, err = t.getTargetRepo(ctx, *targetRepoID)
 			if err != nil {
@@ -721,6 +954,8 @@ func (t *discussionThreads) getTargetRepo(ctx context.Context, targetRepoID int6
 			t.path,
 			t.branch,
 			t.revision,
+			t.base_branch,
+			t.base_revision,
 			t.start_line,
 			t.end_line,
 			t.start_character,
@@ -736,6 +971,8 @@ func (t *discussionThreads) getTargetRepo(ctx context.Context, targetRepoID int6
 		&tr.Path,
 		&tr.Branch,
 		&tr.Revision,
+		&tr.BaseBranch,
+		&tr.BaseRevision,
 		&tr.StartLine,
 		&tr.EndLine,
 		&tr.StartCharacter,
@@ -7,6 +7,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
 )
 
@@ -252,6 +253,283 @@ func TestDiscussionThreads_List(t *testing.T) {
 	}
 }
 
+func TestDiscussionThreads_ListTargetRepoPath(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make the IDs of the threads differ from the IDs of their targets.
+	if _, err := dbconn.Global.ExecContext(ctx, "SELECT nextval('discussion_threads_id_seq')"); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"a.go", "b.go"} {
+		if _, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        path,
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Path: strPtr(path)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{TargetRepoPath: strPtr("b.go")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].Title != "b.go" {
+		t.Errorf("got threads %v, want only the thread on b.go", spew.Sdump(threads))
+	}
+}
+
+func TestDiscussionThreads_ListDiff(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a thread on a file and a thread on a diff.
+	base, head := "0c1a96370c1a96370c1a96370c1a96370c1a9637", "1d2b07481d2b07481d2b07481d2b07481d2b0748"
+	if _, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "On a file",
+		TargetRepo: &types.DiscussionThreadTargetRepo{
+			RepoID:   repo.ID,
+			Path:     strPtr("foo/bar/mux.go"),
+			Revision: strPtr(head),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	diffThread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "On a diff",
+		TargetRepo: &types.DiscussionThreadTargetRepo{
+			RepoID:       repo.ID,
+			Path:         strPtr("foo/bar/mux.go"),
+			Branch:       strPtr("feature"),
+			Revision:     strPtr(head),
+			BaseBranch:   strPtr("master"),
+			BaseRevision: strPtr(base),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opts DiscussionThreadsListOptions
+		want []string
+	}{
+		"diff":          {opts: DiscussionThreadsListOptions{TargetRepoDiff: boolPtr(true)}, want: []string{"On a diff"}},
+		"not diff":      {opts: DiscussionThreadsListOptions{TargetRepoDiff: boolPtr(false)}, want: []string{"On a file"}},
+		"base revision": {opts: DiscussionThreadsListOptions{TargetRepoBaseRevision: strPtr(base)}, want: []string{"On a diff"}},
+		"head revision": {opts: DiscussionThreadsListOptions{TargetRepoHeadRevision: strPtr(head)}, want: []string{"On a diff"}},
+		"other base":    {opts: DiscussionThreadsListOptions{TargetRepoBaseRevision: strPtr(head)}, want: nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			threads, err := DiscussionThreads.List(ctx, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var titles []string
+			for _, thread := range threads {
+				titles = append(titles, thread.Title)
+			}
+			if !reflect.DeepEqual(titles, test.want) {
+				t.Errorf("got threads %q, want %q", titles, test.want)
+			}
+		})
+	}
+
+	// The diff target is read back.
+	gotThread, err := DiscussionThreads.Get(ctx, diffThread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr := gotThread.TargetRepo; !tr.IsDiff() || *tr.BaseBranch != "master" || *tr.BaseRevision != base {
+		t.Errorf("got thread TargetRepo %v, want a diff from master", spew.Sdump(tr))
+	}
+
+	// A base branch requires a base revision.
+	if _, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Invalid",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Revision: strPtr(head), BaseBranch: strPtr("master")},
+	}); err == nil {
+		t.Error("got nil error for a base branch without base revision, want an error")
+	}
+}
+
+func TestDiscussionThreads_StatusAssigneesLabels(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignee, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create two threads, one of them labeled.
+	create := func(title string, labels ...string) *types.DiscussionThread {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        title,
+			Labels:       labels,
+			TargetRepo: &types.DiscussionThreadTargetRepo{
+				RepoID:   repo.ID,
+				Path:     strPtr("foo/bar/mux.go"),
+				Revision: strPtr("0c1a96370c1a96370c1a96370c1a96370c1a9637"),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread
+	}
+	leak := create("Leak", " security", "security", "bug")
+	create("Typo")
+
+	// Resolve, label and assign the thread.
+	labels := []string{"security"}
+	assignees := []int32{assignee.ID, assignee.ID}
+	gotThread, err := DiscussionThreads.Update(ctx, leak.ID, &DiscussionThreadsUpdateOptions{
+		Resolve:         boolPtr(true),
+		Labels:          &labels,
+		AssigneeUserIDs: &assignees,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.ResolvedAt == nil {
+		t.Error("expected thread to be resolved")
+	}
+	if want := []string{"security"}; !reflect.DeepEqual(gotThread.Labels, want) {
+		t.Errorf("got labels %q, want %q", gotThread.Labels, want)
+	}
+	if want := []int32{assignee.ID}; !reflect.DeepEqual(gotThread.AssigneeUserIDs, want) {
+		t.Errorf("got assignees %v, want %v", gotThread.AssigneeUserIDs, want)
+	}
+
+	tests := map[string]struct {
+		opts DiscussionThreadsListOptions
+		want []string
+	}{
+		"resolved":     {opts: DiscussionThreadsListOptions{Resolved: boolPtr(true)}, want: []string{"Leak"}},
+		"open":         {opts: DiscussionThreadsListOptions{Resolved: boolPtr(false)}, want: []string{"Typo"}},
+		"assignee":     {opts: DiscussionThreadsListOptions{AssigneeUserIDs: []int32{assignee.ID}}, want: []string{"Leak"}},
+		"not assignee": {opts: DiscussionThreadsListOptions{NotAssigneeUserIDs: []int32{assignee.ID}}, want: []string{"Typo"}},
+		"label":        {opts: DiscussionThreadsListOptions{Labels: []string{"security"}}, want: []string{"Leak"}},
+		"not label":    {opts: DiscussionThreadsListOptions{NotLabels: []string{"security"}}, want: []string{"Typo"}},
+		"all labels":   {opts: DiscussionThreadsListOptions{Labels: []string{"security", "bug"}}, want: nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			threads, err := DiscussionThreads.List(ctx, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var titles []string
+			for _, thread := range threads {
+				titles = append(titles, thread.Title)
+			}
+			if !reflect.DeepEqual(titles, test.want) {
+				t.Errorf("got threads %q, want %q", titles, test.want)
+			}
+		})
+	}
+
+	// Reopen the thread and remove all assignees.
+	assignees = nil
+	gotThread, err = DiscussionThreads.Update(ctx, leak.ID, &DiscussionThreadsUpdateOptions{
+		Resolve:         boolPtr(false),
+		AssigneeUserIDs: &assignees,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.ResolvedAt != nil || len(gotThread.AssigneeUserIDs) != 0 {
+		t.Errorf("got resolved at %v and assignees %v, want an open thread without assignees", gotThread.ResolvedAt, gotThread.AssigneeUserIDs)
+	}
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	got, err := normalizeLabels([]string{"security", " bug ", "security"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"security", "bug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := normalizeLabels([]string{" "}); err == nil {
+		t.Error("got nil error for an empty label, want an error")
+	}
+}
+
 func TestDiscussionThreads_Delete(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
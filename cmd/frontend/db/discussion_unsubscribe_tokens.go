@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// discussionUnsubscribeTokens provides access to the `discussion_unsubscribe_tokens` table, which
+// holds the tokens of the one-click unsubscribe links in discussion notification emails.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionUnsubscribeTokens struct{}
+
+// Generate gets the existing token, or generates a new one, for muting the
+// specified thread for the specified user through only the token.
+//
+// 🚨 SECURITY: The caller must ensure the token is ONLY given to the user that
+// is passed to this method. Anyone with the token can mute the specified thread
+// for the specified user. Unlike mail reply tokens, it grants no other access.
+func (*discussionUnsubscribeTokens) Generate(ctx context.Context, userID int32, threadID int64) (string, error) {
+	if Mocks.DiscussionUnsubscribeTokens.Generate != nil {
+		return Mocks.DiscussionUnsubscribeTokens.Generate(ctx, userID, threadID)
+	}
+
+	// Check if there already exists a token for this userID + threadID pair.
+	// If there is, we do not need to store a new one.
+	var token string
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT token FROM discussion_unsubscribe_tokens WHERE user_id=$1 AND thread_id=$2", userID, threadID).Scan(&token)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+	if err == nil {
+		return token, nil // use the existing token
+	}
+
+	token, err = generateSecureToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = dbconn.Global.ExecContext(ctx, "INSERT INTO discussion_unsubscribe_tokens(token, user_id, thread_id) VALUES($1, $2, $3)", token, userID, threadID)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Get returns the user and thread ID found for the given token. If there is
+// none, the token is invalid and ErrInvalidToken is returned.
+func (*discussionUnsubscribeTokens) Get(ctx context.Context, token string) (userID int32, threadID int64, err error) {
+	if Mocks.DiscussionUnsubscribeTokens.Get != nil {
+		return Mocks.DiscussionUnsubscribeTokens.Get(ctx, token)
+	}
+	err = dbconn.Global.QueryRowContext(ctx, "SELECT user_id, thread_id FROM discussion_unsubscribe_tokens WHERE token=$1", token).Scan(
+		&userID,
+		&threadID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, 0, ErrInvalidToken
+		}
+		return 0, 0, err
+	}
+	return userID, threadID, nil
+}
@@ -0,0 +1,8 @@
+package db
+
+import "context"
+
+type MockDiscussionUnsubscribeTokens struct {
+	Generate func(ctx context.Context, userID int32, threadID int64) (string, error)
+	Get      func(ctx context.Context, token string) (userID int32, threadID int64, err error)
+}
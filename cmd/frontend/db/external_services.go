@@ -31,7 +31,11 @@ type externalServices struct{}
 // external services.
 var ExternalServiceKinds = map[string]ExternalServiceKind{
 	"AWSCODECOMMIT":   {CodeHost: true, Definition: "AWSCodeCommitConnection"},
+	"AZUREDEVOPS":     {CodeHost: true, Definition: "AzureDevOpsConnection"},
+	"BITBUCKETCLOUD":  {CodeHost: true, Definition: "BitbucketCloudConnection"},
 	"BITBUCKETSERVER": {CodeHost: true, Definition: "BitbucketServerConnection"},
+	"GERRIT":          {CodeHost: true, Definition: "GerritConnection"},
+	"GITEA":           {CodeHost: true, Definition: "GiteaConnection"},
 	"GITHUB":          {CodeHost: true, Definition: "GitHubConnection"},
 	"GITLAB":          {CodeHost: true, Definition: "GitLabConnection"},
 	"GITOLITE":        {CodeHost: true, Definition: "GitoliteConnection"},
@@ -355,6 +359,50 @@ func (c *externalServices) ListAWSCodeCommitConnections(ctx context.Context) ([]
 	return connections, nil
 }
 
+// ListBitbucketCloudConnections returns a list of BitbucketCloud configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (c *externalServices) ListBitbucketCloudConnections(ctx context.Context) ([]*schema.BitbucketCloudConnection, error) {
+	var connections []*schema.BitbucketCloudConnection
+	if err := c.listConfigs(ctx, "BITBUCKETCLOUD", &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// ListGerritConnections returns a list of Gerrit configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (c *externalServices) ListGerritConnections(ctx context.Context) ([]*schema.GerritConnection, error) {
+	var connections []*schema.GerritConnection
+	if err := c.listConfigs(ctx, "GERRIT", &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// ListGiteaConnections returns a list of Gitea configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (c *externalServices) ListGiteaConnections(ctx context.Context) ([]*schema.GiteaConnection, error) {
+	var connections []*schema.GiteaConnection
+	if err := c.listConfigs(ctx, "GITEA", &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// ListAzureDevOpsConnections returns a list of Azure DevOps configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (c *externalServices) ListAzureDevOpsConnections(ctx context.Context) ([]*schema.AzureDevOpsConnection, error) {
+	var connections []*schema.AzureDevOpsConnection
+	if err := c.listConfigs(ctx, "AZUREDEVOPS", &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
 // ListBitbucketServerConnections returns a list of BitbucketServer configs.
 //
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin.
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/lsif"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbutil"
+)
+
+// lsifDumps provides access to the `lsif_dumps` and `lsif_documents` tables.
+//
+// For a detailed overview of the schema, see schema.md.
+type lsifDumps struct{}
+
+// Create stores the documents of an LSIF dump for the given repository and commit, replacing any
+// dump that was previously uploaded for the same commit.
+func (*lsifDumps) Create(ctx context.Context, repoID api.RepoID, commitID api.CommitID, documents map[string]*lsif.Document) (*types.LSIFDump, error) {
+	if Mocks.LSIFDumps.Create != nil {
+		return Mocks.LSIFDumps.Create(ctx, repoID, commitID, documents)
+	}
+
+	dump := &types.LSIFDump{RepoID: repoID, CommitID: commitID}
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM lsif_dumps WHERE repo_id=$1 AND commit_id=$2", repoID, commitID); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, "INSERT INTO lsif_dumps(repo_id, commit_id) VALUES($1, $2) RETURNING id, created_at", repoID, commitID).Scan(&dump.ID, &dump.CreatedAt); err != nil {
+			return err
+		}
+		for path, document := range documents {
+			data, err := json.Marshal(document)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "INSERT INTO lsif_documents(dump_id, path, data) VALUES($1, $2, $3)", dump.ID, path, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+// ListByCommits returns the dumps of the repository for any of the given commits.
+func (*lsifDumps) ListByCommits(ctx context.Context, repoID api.RepoID, commitIDs []api.CommitID) ([]*types.LSIFDump, error) {
+	if Mocks.LSIFDumps.ListByCommits != nil {
+		return Mocks.LSIFDumps.ListByCommits(ctx, repoID, commitIDs)
+	}
+	if len(commitIDs) == 0 {
+		return nil, nil
+	}
+
+	items := make([]*sqlf.Query, len(commitIDs))
+	for i, commitID := range commitIDs {
+		items[i] = sqlf.Sprintf("%s", commitID)
+	}
+	q := sqlf.Sprintf("SELECT id, repo_id, commit_id, created_at FROM lsif_dumps WHERE repo_id=%d AND commit_id IN (%s)", repoID, sqlf.Join(items, ","))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dumps []*types.LSIFDump
+	for rows.Next() {
+		var d types.LSIFDump
+		if err := rows.Scan(&d.ID, &d.RepoID, &d.CommitID, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		dumps = append(dumps, &d)
+	}
+	return dumps, rows.Err()
+}
+
+// GetDocument returns the code intelligence data of the file at path in the dump. If the dump has
+// no data for the file, it returns nil.
+func (*lsifDumps) GetDocument(ctx context.Context, dumpID int64, path string) (*lsif.Document, error) {
+	if Mocks.LSIFDumps.GetDocument != nil {
+		return Mocks.LSIFDumps.GetDocument(ctx, dumpID, path)
+	}
+
+	var data []byte
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT data FROM lsif_documents WHERE dump_id=$1 AND path=$2", dumpID, path).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var document lsif.Document
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
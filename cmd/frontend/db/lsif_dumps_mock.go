@@ -0,0 +1,15 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/lsif"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockLSIFDumps struct {
+	Create        func(ctx context.Context, repoID api.RepoID, commitID api.CommitID, documents map[string]*lsif.Document) (*types.LSIFDump, error)
+	ListByCommits func(ctx context.Context, repoID api.RepoID, commitIDs []api.CommitID) ([]*types.LSIFDump, error)
+	GetDocument   func(ctx context.Context, dumpID int64, path string) (*lsif.Document, error)
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	lsp "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/lsif"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestLSIFDumps(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		commit1 = api.CommitID("0c1a96370c1a96370c1a96370c1a96370c1a9637")
+		commit2 = api.CommitID("1c1a96370c1a96370c1a96370c1a96370c1a9637")
+	)
+	doc := &lsif.Document{
+		Ranges: []lsif.Range{{Range: lsp.Range{End: lsp.Position{Character: 3}}, Hover: 0, Definitions: -1, References: -1}},
+		Hovers: []string{"hello"},
+	}
+	if _, err := LSIFDumps.Create(ctx, repo.ID, commit1, map[string]*lsif.Document{"a.go": {}}); err != nil {
+		t.Fatal(err)
+	}
+	// Uploading a dump for the same commit again replaces the previous one.
+	dump, err := LSIFDumps.Create(ctx, repo.ID, commit1, map[string]*lsif.Document{"a.go": doc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dumps, err := LSIFDumps.ListByCommits(ctx, repo.ID, []api.CommitID{commit1, commit2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dumps) != 1 || dumps[0].ID != dump.ID || dumps[0].CommitID != commit1 {
+		t.Fatalf("got dumps %+v, want only %+v", dumps, dump)
+	}
+
+	gotDoc, err := LSIFDumps.GetDocument(ctx, dump.ID, "a.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotDoc, doc) {
+		t.Errorf("got document %+v, want %+v", gotDoc, doc)
+	}
+
+	gotDoc, err = LSIFDumps.GetDocument(ctx, dump.ID, "b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotDoc != nil {
+		t.Errorf("got document %+v for path without data, want nil", gotDoc)
+	}
+}
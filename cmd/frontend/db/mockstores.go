@@ -6,9 +6,14 @@ var Mocks MockStores
 type MockStores struct {
 	AccessTokens MockAccessTokens
 
-	DiscussionThreads         MockDiscussionThreads
-	DiscussionComments        MockDiscussionComments
-	DiscussionMailReplyTokens MockDiscussionMailReplyTokens
+	DiscussionThreads           MockDiscussionThreads
+	DiscussionComments          MockDiscussionComments
+	DiscussionCommentReactions  MockDiscussionCommentReactions
+	DiscussionCommentRevisions  MockDiscussionCommentRevisions
+	DiscussionExternalComments  MockDiscussionExternalComments
+	DiscussionMailReplyTokens   MockDiscussionMailReplyTokens
+	DiscussionSubscriptions     MockDiscussionSubscriptions
+	DiscussionUnsubscribeTokens MockDiscussionUnsubscribeTokens
 
 	Repos      MockRepos
 	Orgs       MockOrgs
@@ -17,6 +22,8 @@ type MockStores struct {
 	Users      MockUsers
 	UserEmails MockUserEmails
 
+	WebhookSecrets MockWebhookSecrets
+
 	Phabricator MockPhabricator
 
 	ExternalAccounts MockExternalAccounts
@@ -24,4 +31,12 @@ type MockStores struct {
 	OrgInvitations MockOrgInvitations
 
 	ExternalServices MockExternalServices
+
+	LSIFDumps MockLSIFDumps
+
+	SavedQueryExecutions    MockSavedQueryExecutions
+	SavedQueryNotifications MockSavedQueryNotifications
+
+	RepoPermissions     MockRepoPermissions
+	UserRepoPermissions MockUserRepoPermissions
 }
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbutil"
+)
+
+// repoPermissions provides access to the `repo_permissions` table, which holds repository
+// permissions that site admins set explicitly (for code hosts that Sourcegraph can't read
+// permissions from).
+//
+// For a detailed overview of the schema, see schema.md.
+type repoPermissions struct{}
+
+// Set replaces the explicit permission p of the given repositories with a grant to the given users
+// and organizations. If both userIDs and orgIDs are empty, the repositories no longer have explicit
+// permissions for p.
+func (*repoPermissions) Set(ctx context.Context, repoIDs []api.RepoID, p authz.Perm, userIDs, orgIDs []int32) error {
+	if Mocks.RepoPermissions.Set != nil {
+		return Mocks.RepoPermissions.Set(ctx, repoIDs, p, userIDs, orgIDs)
+	}
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]*sqlf.Query, len(repoIDs))
+	for i, id := range repoIDs {
+		ids[i] = sqlf.Sprintf("%d", id)
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		q := sqlf.Sprintf("DELETE FROM repo_permissions WHERE permission=%s AND repo_id IN (%s)", p, sqlf.Join(ids, ","))
+		if _, err := tx.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+			return err
+		}
+
+		var values []*sqlf.Query
+		for _, repoID := range repoIDs {
+			for _, userID := range userIDs {
+				values = append(values, sqlf.Sprintf("(%d, %d, NULL, %s)", repoID, userID, p))
+			}
+			for _, orgID := range orgIDs {
+				values = append(values, sqlf.Sprintf("(%d, NULL, %d, %s)", repoID, orgID, p))
+			}
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		q = sqlf.Sprintf("INSERT INTO repo_permissions(repo_id, user_id, org_id, permission) VALUES %s ON CONFLICT DO NOTHING", sqlf.Join(values, ","))
+		_, err := tx.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		return err
+	})
+}
+
+// ListExplicit returns the names of the given repositories that have explicit permission p
+// (restricted) and the subset of those that the user is granted p on, directly or as a member of an
+// organization (granted). A userID of 0 denotes an anonymous user, who is granted nothing.
+func (*repoPermissions) ListExplicit(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (restricted, granted map[api.RepoName]struct{}, err error) {
+	if Mocks.RepoPermissions.ListExplicit != nil {
+		return Mocks.RepoPermissions.ListExplicit(ctx, userID, repos, p)
+	}
+
+	restricted = map[api.RepoName]struct{}{}
+	granted = map[api.RepoName]struct{}{}
+	if len(repos) == 0 {
+		return restricted, granted, nil
+	}
+
+	names := make([]string, len(repos))
+	for i, name := range repos {
+		names[i] = string(name)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `
+SELECT repo.name, bool_or(rp.user_id=$1 OR rp.org_id IN (
+	SELECT org_members.org_id FROM org_members
+	INNER JOIN orgs ON orgs.id=org_members.org_id
+	WHERE org_members.user_id=$1 AND orgs.deleted_at IS NULL
+))
+FROM repo
+INNER JOIN repo_permissions rp ON rp.repo_id=repo.id AND rp.permission=$2
+WHERE repo.name = ANY($3::citext[])
+GROUP BY repo.name`, userID, p, pq.Array(names))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			name api.RepoName
+			ok   bool
+		)
+		if err := rows.Scan(&name, &ok); err != nil {
+			return nil, nil, err
+		}
+		restricted[name] = struct{}{}
+		if ok {
+			granted[name] = struct{}{}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return restricted, granted, nil
+}
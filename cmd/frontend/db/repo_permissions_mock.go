@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockRepoPermissions struct {
+	Set          func(ctx context.Context, repoIDs []api.RepoID, p authz.Perm, userIDs, orgIDs []int32) error
+	ListExplicit func(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (restricted, granted map[api.RepoName]struct{}, err error)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestRepoPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	// Create fixtures.
+	var repoIDs []api.RepoID
+	for _, name := range []api.RepoName{"r1", "r2", "r3"} {
+		if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: name, Enabled: true}); err != nil {
+			t.Fatal(err)
+		}
+		repo, err := Repos.GetByName(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	var userIDs []int32
+	for _, username := range []string{"u1", "u2", "u3"} {
+		user, err := Users.Create(ctx, NewUser{Username: username})
+		if err != nil {
+			t.Fatal(err)
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+	org, err := Orgs.Create(ctx, "org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OrgMembers.Create(ctx, org.ID, userIDs[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(userID int32, wantRestricted, wantGranted []api.RepoName) {
+		t.Helper()
+		restricted, granted, err := RepoPermissions.ListExplicit(ctx, userID, []api.RepoName{"r1", "r2", "r3"}, authz.Read)
+		if err != nil {
+			t.Fatal(err)
+		}
+		toSet := func(names []api.RepoName) map[api.RepoName]struct{} {
+			set := map[api.RepoName]struct{}{}
+			for _, name := range names {
+				set[name] = struct{}{}
+			}
+			return set
+		}
+		if want := toSet(wantRestricted); !reflect.DeepEqual(restricted, want) {
+			t.Errorf("user %d: got restricted %v, want %v", userID, restricted, want)
+		}
+		if want := toSet(wantGranted); !reflect.DeepEqual(granted, want) {
+			t.Errorf("user %d: got granted %v, want %v", userID, granted, want)
+		}
+	}
+
+	// r1 and r2 are readable by u1 and the members of org (u2).
+	if err := RepoPermissions.Set(ctx, repoIDs[:2], authz.Read, userIDs[:1], []int32{org.ID}); err != nil {
+		t.Fatal(err)
+	}
+	check(userIDs[0], []api.RepoName{"r1", "r2"}, []api.RepoName{"r1", "r2"})
+	check(userIDs[1], []api.RepoName{"r1", "r2"}, []api.RepoName{"r1", "r2"})
+	check(userIDs[2], []api.RepoName{"r1", "r2"}, nil)
+	check(0, []api.RepoName{"r1", "r2"}, nil)
+
+	// Setting permissions again replaces the previous ones.
+	if err := RepoPermissions.Set(ctx, repoIDs[1:], authz.Read, userIDs[2:], nil); err != nil {
+		t.Fatal(err)
+	}
+	check(userIDs[0], []api.RepoName{"r1", "r2", "r3"}, []api.RepoName{"r1"})
+	check(userIDs[1], []api.RepoName{"r1", "r2", "r3"}, []api.RepoName{"r1"})
+	check(userIDs[2], []api.RepoName{"r1", "r2", "r3"}, []api.RepoName{"r2", "r3"})
+
+	// Granting nobody removes the explicit permissions.
+	if err := RepoPermissions.Set(ctx, repoIDs, authz.Read, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	check(userIDs[0], nil, nil)
+
+	// Only the given repositories are listed.
+	if err := RepoPermissions.Set(ctx, repoIDs, authz.Read, userIDs[:1], nil); err != nil {
+		t.Fatal(err)
+	}
+	restricted, granted, err := RepoPermissions.ListExplicit(ctx, userIDs[0], []api.RepoName{"r2", "nonexistent"}, authz.Read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[api.RepoName]struct{}{"r2": {}}; !reflect.DeepEqual(restricted, want) || !reflect.DeepEqual(granted, want) {
+		t.Errorf("got restricted %v and granted %v, want both %v", restricted, granted, want)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	regexpsyntax "regexp/syntax"
 	"strings"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db/query"
@@ -44,6 +46,10 @@ type repos struct{}
 // caller is concerned the copy of the data in the database might be
 // stale, the caller is responsible for fetching data from any
 // external services.
+//
+// Unlike GetByName and List, Get also returns repositories that were deleted
+// on their code host (see MarkDeleted), so that data attached to them (such as
+// discussion threads) can still be resolved.
 func (s *repos) Get(ctx context.Context, id api.RepoID) (*types.Repo, error) {
 	if Mocks.Repos.Get != nil {
 		return Mocks.Repos.Get(ctx, id)
@@ -61,15 +67,16 @@ func (s *repos) Get(ctx context.Context, id api.RepoID) (*types.Repo, error) {
 }
 
 // GetByName returns the repository with the given name from the database, or an
-// error. If the repo doesn't exist in the DB, then errcode.IsNotFound will
-// return true on the error returned. It does not attempt to look up or update
-// the repository on any external service (such as its code host).
+// error. If the repo doesn't exist in the DB (or was deleted on its code
+// host), then errcode.IsNotFound will return true on the error returned. It
+// does not attempt to look up or update the repository on any external
+// service (such as its code host).
 func (s *repos) GetByName(ctx context.Context, name api.RepoName) (*types.Repo, error) {
 	if Mocks.Repos.GetByName != nil {
 		return Mocks.Repos.GetByName(ctx, name)
 	}
 
-	repos, err := s.getBySQL(ctx, sqlf.Sprintf("WHERE name=%s LIMIT 1", name))
+	repos, err := s.getBySQL(ctx, sqlf.Sprintf("WHERE name=%s AND deleted_at IS NULL LIMIT 1", name))
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +107,7 @@ func (s *repos) Count(ctx context.Context, opt ReposListOptions) (int, error) {
 }
 
 func (s *repos) getBySQL(ctx context.Context, querySuffix *sqlf.Query) ([]*types.Repo, error) {
-	q := sqlf.Sprintf("SELECT id, name, description, language, enabled, created_at, updated_at, external_id, external_service_type, external_service_id FROM repo %s", querySuffix)
+	q := sqlf.Sprintf("SELECT id, name, description, language, enabled, COALESCE(fork, false), archived, topics, stars, COALESCE(default_branch, ''), COALESCE(visibility, ''), created_at, updated_at, external_id, external_service_type, external_service_id FROM repo %s", querySuffix)
 	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
 		return nil, err
@@ -118,6 +125,12 @@ func (s *repos) getBySQL(ctx context.Context, querySuffix *sqlf.Query) ([]*types
 			&repo.Description,
 			&repo.Language,
 			&repo.Enabled,
+			&repo.Fork,
+			&repo.Archived,
+			pq.Array(&repo.Topics),
+			&repo.Stars,
+			&repo.DefaultBranch,
+			&repo.Visibility,
 			&repo.CreatedAt,
 			&repo.UpdatedAt,
 			&spec.id, &spec.serviceType, &spec.serviceID,
@@ -175,6 +188,16 @@ type ReposListOptions struct {
 	// OnlyArchived excludes non-archived repositories from the list.
 	OnlyArchived bool
 
+	// Topics excludes repositories that don't have all of the topics from the list.
+	Topics []string
+
+	// NotTopics excludes repositories that have any of the topics from the list.
+	NotTopics []string
+
+	// Visibility excludes repositories with a different visibility (one of the
+	// api.RepoVisibility constants) from the list, if set.
+	Visibility string
+
 	// Index when set will only include repositories which should be indexed
 	// if true. If false it will exclude repositories which should be
 	// indexed. An example use case of this is for indexed search only
@@ -257,8 +280,12 @@ func (s *repos) List(ctx context.Context, opt ReposListOptions) (results []*type
 // requested information by other services (repo-updater and
 // indexed-search). We special case just returning enabled names so that we
 // read much less data into memory.
+//
+// Repositories that were deleted on their code host are listed until
+// RepoDeletionGracePeriod has passed, so that their clones are kept for a
+// while in case the deletion is reverted.
 func (s *repos) ListEnabledNames(ctx context.Context) ([]string, error) {
-	q := sqlf.Sprintf("SELECT name FROM repo WHERE enabled = true")
+	q := sqlf.Sprintf("SELECT name FROM repo WHERE enabled = true AND (deleted_at IS NULL OR deleted_at > %s)", time.Now().Add(-RepoDeletionGracePeriod))
 	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
 		return nil, err
@@ -311,7 +338,7 @@ func parsePattern(p string) ([]*sqlf.Query, error) {
 }
 
 func (*repos) listSQL(opt ReposListOptions) (conds []*sqlf.Query, err error) {
-	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	conds = []*sqlf.Query{sqlf.Sprintf("deleted_at IS NULL")}
 	if opt.Query != "" && (len(opt.IncludePatterns) > 0 || opt.ExcludePattern != "") {
 		return nil, errors.New("Repos.List: Query and IncludePatterns/ExcludePattern options are mutually exclusive")
 	}
@@ -370,6 +397,15 @@ func (*repos) listSQL(opt ReposListOptions) (conds []*sqlf.Query, err error) {
 	if opt.OnlyArchived {
 		conds = append(conds, sqlf.Sprintf("archived"))
 	}
+	if len(opt.Topics) > 0 {
+		conds = append(conds, sqlf.Sprintf("topics @> %s", pq.Array(opt.Topics)))
+	}
+	if len(opt.NotTopics) > 0 {
+		conds = append(conds, sqlf.Sprintf("NOT topics && %s", pq.Array(opt.NotTopics)))
+	}
+	if opt.Visibility != "" {
+		conds = append(conds, sqlf.Sprintf("visibility = %s", opt.Visibility))
+	}
 
 	if opt.Index != nil {
 		// We don't currently have an index column, but when we want the
@@ -560,6 +596,28 @@ func (s *repos) Delete(ctx context.Context, repo api.RepoID) error {
 	return err
 }
 
+// RepoDeletionGracePeriod is how long the clone of a repository that was
+// deleted on its code host is kept before it is removed.
+var RepoDeletionGracePeriod = 24 * time.Hour
+
+// MarkDeleted marks the repository with the given name as deleted on its code
+// host. The repository row is kept (so that data attached to it, such as
+// discussion threads, remains intact), but it is no longer returned by
+// GetByName, List, or Count. Its clone is removed after
+// RepoDeletionGracePeriod.
+//
+// If the repository is later upserted with the same name or external
+// repository spec, it is no longer marked as deleted.
+func (s *repos) MarkDeleted(ctx context.Context, name api.RepoName) error {
+	if Mocks.Repos.MarkDeleted != nil {
+		return Mocks.Repos.MarkDeleted(ctx, name)
+	}
+
+	q := sqlf.Sprintf("UPDATE repo SET deleted_at=now() WHERE name=%s AND deleted_at IS NULL", name)
+	_, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
+
 func (s *repos) SetEnabled(ctx context.Context, id api.RepoID, enabled bool) error {
 	q := sqlf.Sprintf("UPDATE repo SET enabled=%t WHERE id=%d", enabled, id)
 	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
@@ -581,13 +639,26 @@ func (s *repos) UpdateLanguage(ctx context.Context, repo api.RepoID, language st
 	return err
 }
 
-func (s *repos) UpdateRepositoryMetadata(ctx context.Context, name api.RepoName, description string, fork bool, archived bool) error {
-	_, err := dbconn.Global.ExecContext(ctx, "UPDATE repo SET description=$1, fork=$2, archived=$3 WHERE name=$4 	AND (description <> $1 OR fork <> $2 OR archived <> $3)", description, fork, archived, name)
+// UpdateRepositoryMetadata updates the metadata of the repository from its code host. It only
+// writes to the database if the metadata changed.
+func (s *repos) UpdateRepositoryMetadata(ctx context.Context, op api.ReposUpdateMetadataRequest) error {
+	topics := op.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	q := sqlf.Sprintf(`
+UPDATE repo SET description=%s, fork=%s, archived=%s, topics=%s, stars=%s, default_branch=NULLIF(%s, ''), visibility=NULLIF(%s, '')
+WHERE name=%s AND (description IS DISTINCT FROM %s OR fork IS DISTINCT FROM %s OR archived <> %s OR topics <> %s OR stars <> %s
+	OR default_branch IS DISTINCT FROM NULLIF(%s, '') OR visibility IS DISTINCT FROM NULLIF(%s, ''))`,
+		op.Description, op.Fork, op.Archived, pq.Array(topics), op.Stars, op.DefaultBranch, op.Visibility,
+		op.RepoName, op.Description, op.Fork, op.Archived, pq.Array(topics), op.Stars, op.DefaultBranch, op.Visibility,
+	)
+	_, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	return err
 }
 
 const upsertSQL = `WITH UPSERT AS (
-	UPDATE repo SET name=$1, description=$2, fork=$3, enabled=$4, external_id=$5, external_service_type=$6, external_service_id=$7, archived=$9 WHERE name=$1 RETURNING name
+	UPDATE repo SET name=$1, description=$2, fork=$3, enabled=$4, external_id=$5, external_service_type=$6, external_service_id=$7, archived=$9, deleted_at=NULL WHERE name=$1 RETURNING name
 )
 INSERT INTO repo(name, description, fork, language, enabled, external_id, external_service_type, external_service_id, archived) (
 	SELECT $1 AS name, $2 AS description, $3 AS fork, $8 as language, $4 AS enabled,
@@ -598,7 +669,12 @@ INSERT INTO repo(name, description, fork, language, enabled, external_id, extern
 // Upsert updates the repository if it already exists (keyed on name) and
 // inserts it if it does not.
 //
-// If repo exists, op.Enabled is ignored.
+// If no repository with the name exists but one with the same external
+// repository spec does, the repository was renamed on its code host, and it
+// is renamed in place (keeping its ID and the data attached to it).
+//
+// If repo exists (even if it was deleted on its code host), op.Enabled is
+// ignored.
 func (s *repos) Upsert(ctx context.Context, op api.InsertRepoOp) error {
 	if Mocks.Repos.Upsert != nil {
 		return Mocks.Repos.Upsert(op)
@@ -619,6 +695,31 @@ func (s *repos) Upsert(ctx context.Context, op api.InsertRepoOp) error {
 			return err
 		}
 		insert = true // missing
+
+		// A repository that was deleted on its code host keeps its settings when it is restored
+		// (the upsert clears deleted_at).
+		deleted, err := s.getBySQL(ctx, sqlf.Sprintf("WHERE name=%s AND deleted_at IS NOT NULL LIMIT 1", op.Name))
+		if err != nil {
+			return err
+		}
+		if len(deleted) > 0 {
+			enabled = deleted[0].Enabled
+			language = deleted[0].Language
+		} else {
+			renamed, err := s.renameByExternalRepo(ctx, op.Name, op.ExternalRepo)
+			if err != nil {
+				return err
+			}
+			if renamed {
+				// Fall through to the upsert, which updates the renamed repository's metadata.
+				r, err = s.GetByName(ctx, op.Name)
+				if err != nil {
+					return err
+				}
+				enabled = r.Enabled
+				language = r.Language
+			}
+		}
 	} else {
 		enabled = r.Enabled
 		language = r.Language
@@ -637,6 +738,30 @@ func (s *repos) Upsert(ctx context.Context, op api.InsertRepoOp) error {
 	return err
 }
 
+// renameByExternalRepo renames the repository with the given external
+// repository spec to name, unless a repository with the name already exists
+// (including one that was deleted on its code host). It reports whether a
+// repository was renamed.
+func (s *repos) renameByExternalRepo(ctx context.Context, name api.RepoName, spec *api.ExternalRepoSpec) (bool, error) {
+	if spec == nil {
+		return false, nil
+	}
+	q := sqlf.Sprintf(`
+UPDATE repo SET name=%s, deleted_at=NULL
+WHERE external_service_type=%s AND external_service_id=%s AND external_id=%s
+AND NOT EXISTS (SELECT 1 FROM repo WHERE name=%s)`,
+		name, spec.ServiceType, spec.ServiceID, spec.ID, name)
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
 // dbExternalRepoSpec is convenience type for inserting or selecting *api.ExternalRepoSpec database data.
 type dbExternalRepoSpec struct{ id, serviceType, serviceID *string }
 
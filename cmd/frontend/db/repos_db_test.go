@@ -142,6 +142,54 @@ func TestRepos_List_fork(t *testing.T) {
 	}
 }
 
+func TestRepos_List_metadata(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := dbtesting.TestContext(t)
+
+	ctx = actor.WithActor(ctx, &actor.Actor{})
+
+	mustCreate(ctx, t, &types.Repo{Name: "a/r"}, &types.Repo{Name: "b/r"}, &types.Repo{Name: "c/r"})
+	for _, op := range []api.ReposUpdateMetadataRequest{
+		{RepoName: "a/r", Topics: []string{"go", "cli"}, Stars: 3, DefaultBranch: "master", Visibility: api.RepoVisibilityPublic},
+		{RepoName: "b/r", Topics: []string{"go"}, Visibility: api.RepoVisibilityPrivate},
+	} {
+		if err := Repos.UpdateRepositoryMetadata(ctx, op); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, err := Repos.GetByName(ctx, "a/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a.Topics, []string{"go", "cli"}) || a.Stars != 3 || a.DefaultBranch != "master" || a.Visibility != api.RepoVisibilityPublic {
+		t.Errorf("got repo %+v, want metadata to be stored", a)
+	}
+
+	tests := []struct {
+		opt  ReposListOptions
+		want []api.RepoName
+	}{
+		{ReposListOptions{Topics: []string{"go"}}, []api.RepoName{"a/r", "b/r"}},
+		{ReposListOptions{Topics: []string{"go", "cli"}}, []api.RepoName{"a/r"}},
+		{ReposListOptions{NotTopics: []string{"cli"}}, []api.RepoName{"b/r", "c/r"}},
+		{ReposListOptions{Visibility: api.RepoVisibilityPrivate}, []api.RepoName{"b/r"}},
+	}
+	for _, test := range tests {
+		test.opt.Enabled = true
+		repos, err := Repos.List(ctx, test.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sortedRepoNames(repos); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%+v: got %v, want %v", test.opt, got, test.want)
+		}
+	}
+}
+
 func TestRepos_List_pagination(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -7,8 +7,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/pkg/actor"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
-	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
-	log15 "gopkg.in/inconshreveable/log15.v2"
 )
 
 var mockAuthzFilter func(ctx context.Context, repos []*types.Repo, p authz.Perm) ([]*types.Repo, error)
@@ -63,66 +61,60 @@ func isInternalActor(ctx context.Context) bool {
 	return actor.FromContext(ctx).Internal
 }
 
+// getFilteredRepoNames returns the names of the repositories that the user (nil for anonymous
+// users) has permission p on. It never calls a code host: the permissions from authz providers are
+// synced in the background (see SyncUserPermissions), and a user whose permissions have not been
+// synced yet has none on the repositories that authz providers claim.
 func getFilteredRepoNames(ctx context.Context, currentUser *types.User, repos map[authz.Repo]struct{}, p authz.Perm) (accepted map[api.RepoName]struct{}, err error) {
-	var accts []*extsvc.ExternalAccount
-	authzAllowByDefault, authzProviders := authz.GetProviders()
-	if len(authzProviders) > 0 && currentUser != nil {
-		accts, err = ExternalAccounts.List(ctx, ExternalAccountsListOptions{UserID: currentUser.ID})
-		if err != nil {
-			return nil, err
-		}
+	var userID int32
+	if currentUser != nil {
+		userID = currentUser.ID
+	}
+	repoNames := make([]api.RepoName, 0, len(repos))
+	for repo := range repos {
+		repoNames = append(repoNames, repo.RepoName)
+	}
+	restricted, granted, err := RepoPermissions.ListExplicit(ctx, userID, repoNames, p)
+	if err != nil {
+		return nil, err
 	}
 
 	accepted = make(map[api.RepoName]struct{})  // repositories that have been claimed and have read permissions
 	unverified := make(map[authz.Repo]struct{}) // repositories that have not been claimed by any authz provider
 	for repo := range repos {
+		// Permissions that a site admin set explicitly take precedence over authz providers.
+		if _, ok := restricted[repo.RepoName]; ok {
+			if _, ok := granted[repo.RepoName]; ok {
+				accepted[repo.RepoName] = struct{}{}
+			}
+			continue
+		}
 		unverified[repo] = struct{}{}
 	}
 
-	// Walk through all authz providers, checking repo permissions against each. If any own a given
-	// repo, we use its permissions for that repo.
+	authzAllowByDefault, authzProviders := authz.GetProviders()
+
+	// Determine which repos "belong" to an authz provider. If any own a given repo, we use the
+	// permissions synced from it for that repo.
+	var claimed []api.RepoName
 	for _, authzProvider := range authzProviders {
 		if len(unverified) == 0 {
 			break
 		}
-
-		// determine external account to use
-		var providerAcct *extsvc.ExternalAccount
-		for _, acct := range accts {
-			if acct.ServiceID == authzProvider.ServiceID() && acct.ServiceType == authzProvider.ServiceType() {
-				providerAcct = acct
-				break
-			}
-		}
-		if providerAcct == nil && currentUser != nil { // no existing external account for authz provider
-			if pr, err := authzProvider.FetchAccount(ctx, currentUser, accts); err == nil {
-				providerAcct = pr
-				if providerAcct != nil {
-					err := ExternalAccounts.AssociateUserAndSave(ctx, currentUser.ID, providerAcct.ExternalAccountSpec, providerAcct.ExternalAccountData)
-					if err != nil {
-						return nil, err
-					}
-				}
-			} else {
-				log15.Warn("Could not fetch authz provider account for user", "username", currentUser.Username, "authzProvider", authzProvider.ServiceID(), "error", err)
-			}
+		var mine map[authz.Repo]struct{}
+		mine, unverified = authzProvider.Repos(ctx, unverified)
+		for repo := range mine {
+			claimed = append(claimed, repo.RepoName)
 		}
-
-		// determine which repos "belong" to this authz provider
-		myUnverified, nextUnverified := authzProvider.Repos(ctx, unverified)
-
-		// check the perms on those repos
-		perms, err := authzProvider.RepoPerms(ctx, providerAcct, myUnverified)
+	}
+	if len(claimed) > 0 {
+		synced, err := UserRepoPermissions.ListRepoNames(ctx, userID, claimed, p)
 		if err != nil {
 			return nil, err
 		}
-		for unverifiedRepo := range myUnverified {
-			if repoPerms, ok := perms[unverifiedRepo.RepoName]; ok && repoPerms[p] {
-				accepted[unverifiedRepo.RepoName] = struct{}{}
-			}
+		for repoName := range synced {
+			accepted[repoName] = struct{}{}
 		}
-		// continue checking repos that didn't belong to this authz provider
-		unverified = nextUnverified
 	}
 
 	if authzAllowByDefault {
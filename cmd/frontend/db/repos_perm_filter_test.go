@@ -18,6 +18,10 @@ type authzFilter_Test struct {
 	authzAllowByDefault bool
 	authzProviders      []authz.Provider
 
+	// explicitPerms maps repositories with explicit read permissions to the IDs of the users that
+	// are granted read access.
+	explicitPerms map[api.RepoName][]int32
+
 	calls []authzFilter_call
 }
 
@@ -36,6 +40,26 @@ type authzFilter_call struct {
 func (r authzFilter_Test) run(t *testing.T) {
 	t.Logf("Test case %q", r.description)
 	authz.SetProviders(r.authzAllowByDefault, r.authzProviders)
+	Mocks.RepoPermissions.ListExplicit = func(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (restricted, granted map[api.RepoName]struct{}, err error) {
+		restricted, granted = map[api.RepoName]struct{}{}, map[api.RepoName]struct{}{}
+		if p != authz.Read {
+			return restricted, granted, nil
+		}
+		for _, repo := range repos {
+			userIDs, ok := r.explicitPerms[repo]
+			if !ok {
+				continue
+			}
+			restricted[repo] = struct{}{}
+			for _, id := range userIDs {
+				if id == userID {
+					granted[repo] = struct{}{}
+				}
+			}
+		}
+		return restricted, granted, nil
+	}
+	defer func() { Mocks.RepoPermissions.ListExplicit = nil }()
 
 	for _, c := range r.calls {
 		t.Logf("Call %q", c.description)
@@ -59,7 +83,19 @@ func (r authzFilter_Test) run(t *testing.T) {
 		Mocks.ExternalAccounts.AssociateUserAndSave = func(userID int32, spec extsvc.ExternalAccountSpec, data extsvc.ExternalAccountData) error { return nil }
 		Mocks.ExternalAccounts.List = func(ExternalAccountsListOptions) ([]*extsvc.ExternalAccount, error) { return c.userAccounts, nil }
 
+		// Sync the permissions of anonymous users and of the user, as the background worker does.
+		resetSyncedPerms := mockSyncedPermissions(c.repos)
+		if err := SyncUserPermissions(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+		if c.user != nil {
+			if err := SyncUserPermissions(context.Background(), c.user); err != nil {
+				t.Fatal(err)
+			}
+		}
+
 		filteredRepos, err := authzFilter(ctx, c.repos, c.perm)
+		resetSyncedPerms()
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -519,13 +555,113 @@ func Test_authzFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			description:         "explicit permissions take precedence over authz providers",
+			authzAllowByDefault: true,
+			authzProviders: []authz.Provider{
+				&MockAuthzProvider{
+					serviceID:   "https://gitlab.mine/",
+					serviceType: "gitlab",
+					repos: map[api.RepoName]struct{}{
+						"gitlab.mine/u1/r0": {},
+					},
+					perms: map[extsvc.ExternalAccount]map[api.RepoName]map[authz.Perm]bool{
+						*acct(1, "gitlab", "https://gitlab.mine/", "u1"): {
+							"gitlab.mine/u1/r0": {authz.Read: true},
+						},
+					},
+				},
+			},
+			explicitPerms: map[api.RepoName][]int32{
+				"gitlab.mine/u1/r0":    {2},
+				"gitolite.mine/shared": {1, 2},
+				"gitolite.mine/secret": {2},
+			},
+			calls: []authzFilter_call{
+				{
+					description:  "u1 can only read repos it is explicitly granted",
+					user:         &types.User{ID: 1},
+					userAccounts: []*extsvc.ExternalAccount{acct(1, "gitlab", "https://gitlab.mine/", "u1")},
+					repos: []*types.Repo{
+						{Name: "gitlab.mine/u1/r0"},
+						{Name: "gitolite.mine/shared"},
+						{Name: "gitolite.mine/secret"},
+						{Name: "gitolite.mine/public"},
+					},
+					perm: authz.Read,
+					expFilteredRepos: []*types.Repo{
+						{Name: "gitolite.mine/shared"},
+						{Name: "gitolite.mine/public"},
+					},
+				},
+				{
+					description: "unauthenticated user can't read repos with explicit permissions",
+					user:        nil,
+					repos: []*types.Repo{
+						{Name: "gitolite.mine/shared"},
+						{Name: "gitolite.mine/public"},
+					},
+					perm: authz.Read,
+					expFilteredRepos: []*types.Repo{
+						{Name: "gitolite.mine/public"},
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		test.run(t)
 	}
 }
 
-func Test_authzFilter_createsNewUsers(t *testing.T) {
+func Test_authzFilter_doesNotSyncPermissions(t *testing.T) {
+	// The user's permissions have never been synced, so they have none on the repositories that
+	// the authz provider claims, and authzFilter must not sync them from the code host.
+	resetSyncedPerms := mockSyncedPermissions([]*types.Repo{{Name: "gitlab.mine/r0"}, {Name: "other.mine/r0"}})
+	defer resetSyncedPerms()
+	Mocks.UserRepoPermissions.SetForUser = func(context.Context, int32, map[api.RepoID][]authz.Perm) error {
+		t.Fatal("unexpected permissions sync")
+		return nil
+	}
+	Mocks.RepoPermissions.ListExplicit = func(context.Context, int32, []api.RepoName, authz.Perm) (restricted, granted map[api.RepoName]struct{}, err error) {
+		return nil, nil, nil
+	}
+	defer func() { Mocks.RepoPermissions.ListExplicit = nil }()
+	Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	defer func() { Mocks.Users.GetByCurrentAuthUser = nil }()
+	authz.SetProviders(true, []authz.Provider{
+		&MockAuthzProvider{
+			serviceID:   "https://gitlab.mine/",
+			serviceType: "gitlab",
+			repos:       map[api.RepoName]struct{}{"gitlab.mine/r0": {}},
+			perms: map[extsvc.ExternalAccount]map[api.RepoName]map[authz.Perm]bool{
+				{}: {"gitlab.mine/r0": {authz.Read: true}},
+			},
+		},
+	})
+	defer authz.SetProviders(true, nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	repos, err := authzFilter(ctx, []*types.Repo{{Name: "gitlab.mine/r0"}, {Name: "other.mine/r0"}}, authz.Read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*types.Repo{{Name: "other.mine/r0"}}; !reflect.DeepEqual(repos, want) {
+		t.Errorf("got repos %+v, want %+v", repos, want)
+	}
+}
+
+func TestSyncUserPermissions_createsNewUsers(t *testing.T) {
+	Mocks.Repos.List = func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+		return []*types.Repo{{ID: 77}}, nil
+	}
+	Mocks.UserRepoPermissions.SetForUser = func(context.Context, int32, map[api.RepoID][]authz.Perm) error { return nil }
+	defer func() {
+		Mocks.Repos.List = nil
+		Mocks.UserRepoPermissions = MockUserRepoPermissions{}
+	}()
 	associateUserAndSaveCount := make(map[int32]map[extsvc.ExternalAccountSpec]int)
 	Mocks.ExternalAccounts.AssociateUserAndSave = func(userID int32, spec extsvc.ExternalAccountSpec, data extsvc.ExternalAccountData) error {
 		if _, ok := associateUserAndSaveCount[userID]; !ok {
@@ -555,12 +691,6 @@ func Test_authzFilter_createsNewUsers(t *testing.T) {
 		}
 		return nil, nil
 	}
-	Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
-		if actr := actor.FromContext(ctx); actr != nil {
-			return &types.User{ID: actr.UID}, nil
-		}
-		return nil, nil
-	}
 	authz.SetProviders(true, []authz.Provider{
 		&MockAuthzProvider{
 			serviceID:    "https://gitlab.mine/",
@@ -575,9 +705,9 @@ func Test_authzFilter_createsNewUsers(t *testing.T) {
 
 	var (
 		expNewAcct           = extsvc.ExternalAccountSpec{ServiceID: "https://gitlab.mine/", ServiceType: "gitlab", AccountID: "101"}
-		unAuthdCtx           = context.Background()
-		authd23Ctx           = actor.WithActor(unAuthdCtx, &actor.Actor{UID: 23})
-		authd99Ctx           = actor.WithActor(unAuthdCtx, &actor.Actor{UID: 99})
+		ctx                  = context.Background()
+		user23               = &types.User{ID: 23}
+		user99               = &types.User{ID: 99}
 		account23CreatedOnce = map[int32]map[extsvc.ExternalAccountSpec]int{
 			23: {
 				expNewAcct: 1,
@@ -594,47 +724,47 @@ func Test_authzFilter_createsNewUsers(t *testing.T) {
 		t.Errorf("expected counts to be %s, but was %s", asJSON(t, exp), asJSON(t, associateUserAndSaveCount))
 	}
 
-	// Unauthed filter does not trigger new account creation
-	if _, err := authzFilter(unAuthdCtx, []*types.Repo{{ID: 77}}, authz.Read); err != nil {
+	// Syncing anonymous users does not trigger new account creation
+	if err := SyncUserPermissions(ctx, nil); err != nil {
 		t.Fatal(err)
 	}
 	if exp := map[int32]map[extsvc.ExternalAccountSpec]int{}; !reflect.DeepEqual(associateUserAndSaveCount, exp) {
 		t.Errorf("expected counts to be %s, but was %s", asJSON(t, exp), asJSON(t, associateUserAndSaveCount))
 	}
 
-	// Authed filter triggers new account creation
-	if _, err := authzFilter(authd23Ctx, []*types.Repo{{ID: 77}}, authz.Read); err != nil {
+	// Syncing a user triggers new account creation
+	if err := SyncUserPermissions(ctx, user23); err != nil {
 		t.Fatal(err)
 	}
 	if !reflect.DeepEqual(associateUserAndSaveCount, account23CreatedOnce) {
 		t.Errorf("expected counts to be %+v, but was %+v", account23CreatedOnce, associateUserAndSaveCount)
 	}
 
-	// Unauthed filter does not trigger new account creation
-	if _, err := authzFilter(unAuthdCtx, []*types.Repo{{ID: 77}}, authz.Read); err != nil {
+	// Syncing anonymous users does not trigger new account creation
+	if err := SyncUserPermissions(ctx, nil); err != nil {
 		t.Fatal(err)
 	}
 	if !reflect.DeepEqual(associateUserAndSaveCount, account23CreatedOnce) {
 		t.Errorf("expected counts to be %+v, but was %+v", account23CreatedOnce, associateUserAndSaveCount)
 	}
 
-	// Authed filter triggers new account creation
-	if _, err := authzFilter(authd23Ctx, []*types.Repo{{ID: 77}}, authz.Read); err != nil {
+	// Syncing a user triggers new account creation
+	if err := SyncUserPermissions(ctx, user23); err != nil {
 		t.Fatal(err)
 	}
 	if !reflect.DeepEqual(associateUserAndSaveCount, account23CreatedTwice) {
 		t.Errorf("expected counts to be %+v, but was %+v", account23CreatedTwice, associateUserAndSaveCount)
 	}
 
-	// Authed filter under another user for whom FetchAccount returns empty doesn't trigger new account creation
-	if _, err := authzFilter(authd99Ctx, []*types.Repo{{ID: 77}}, authz.Read); err != nil {
+	// Syncing another user for whom FetchAccount returns empty doesn't trigger new account creation
+	if err := SyncUserPermissions(ctx, user99); err != nil {
 		t.Fatal(err)
 	}
 	if !reflect.DeepEqual(associateUserAndSaveCount, account23CreatedTwice) {
 		t.Errorf("expected counts to be %+v, but was %+v", account23CreatedTwice, associateUserAndSaveCount)
 	}
 
-	// Authed filter does NOT trigger new account creation if new account is already provided
+	// Syncing a user does NOT trigger new account creation if new account is already provided
 	mockUser23Accounts = append(mockUser23Accounts, &extsvc.ExternalAccount{
 		UserID: 23,
 		ExternalAccountSpec: extsvc.ExternalAccountSpec{
@@ -643,7 +773,7 @@ func Test_authzFilter_createsNewUsers(t *testing.T) {
 			AccountID:   "101",
 		},
 	})
-	if _, err := authzFilter(authd23Ctx, []*types.Repo{{ID: 77, ExternalRepo: &api.ExternalRepoSpec{}}}, authz.Read); err != nil {
+	if err := SyncUserPermissions(ctx, user23); err != nil {
 		t.Fatal(err)
 	}
 	if !reflect.DeepEqual(associateUserAndSaveCount, account23CreatedTwice) {
@@ -651,6 +781,47 @@ func Test_authzFilter_createsNewUsers(t *testing.T) {
 	}
 }
 
+// mockSyncedPermissions mocks the repositories that SyncUserPermissions lists and an in-memory
+// store of synced permissions. It returns a func that resets the mocks.
+func mockSyncedPermissions(repos []*types.Repo) func() {
+	repoIDs := map[api.RepoName]api.RepoID{}
+	repoNames := map[api.RepoID]api.RepoName{}
+	var listed []*types.Repo
+	for _, repo := range repos {
+		if _, ok := repoIDs[repo.Name]; ok {
+			continue
+		}
+		r := *repo
+		r.ID = api.RepoID(len(listed) + 1)
+		repoIDs[r.Name], repoNames[r.ID] = r.ID, r.Name
+		listed = append(listed, &r)
+	}
+
+	synced := map[int32]map[api.RepoID][]authz.Perm{}
+	Mocks.Repos.List = func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+		return listed, nil
+	}
+	Mocks.UserRepoPermissions.SetForUser = func(ctx context.Context, userID int32, perms map[api.RepoID][]authz.Perm) error {
+		synced[userID] = perms
+		return nil
+	}
+	Mocks.UserRepoPermissions.ListRepoNames = func(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (map[api.RepoName]struct{}, error) {
+		names := map[api.RepoName]struct{}{}
+		for _, repo := range repos {
+			for _, perm := range synced[userID][repoIDs[repo]] {
+				if perm == p {
+					names[repo] = struct{}{}
+				}
+			}
+		}
+		return names, nil
+	}
+	return func() {
+		Mocks.Repos.List = nil
+		Mocks.UserRepoPermissions = MockUserRepoPermissions{}
+	}
+}
+
 func acct(userID int32, serviceType, serviceID, accountID string) *extsvc.ExternalAccount {
 	return &extsvc.ExternalAccount{
 		UserID: userID,
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/actor"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// SyncUserPermissions fetches the permissions of the user (or of anonymous users, if user is nil)
+// on all repositories from the authz providers and stores them, replacing the previously synced
+// permissions. authzFilter only reads the stored permissions, so that filtering repositories never
+// needs to call a code host.
+func SyncUserPermissions(ctx context.Context, user *types.User) error {
+	_, authzProviders := authz.GetProviders()
+	if len(authzProviders) == 0 {
+		return nil
+	}
+
+	// 🚨 SECURITY: List all repositories regardless of the permissions of the current actor. This
+	// is safe because the repositories are only passed to the authz providers and never returned.
+	repos, err := Repos.List(actor.WithActor(ctx, &actor.Actor{Internal: true}), ReposListOptions{Enabled: true, Disabled: true})
+	if err != nil {
+		return err
+	}
+	repoIDs := make(map[api.RepoName]api.RepoID, len(repos))
+	for _, repo := range repos {
+		repoIDs[repo.Name] = repo.ID
+	}
+
+	var accts []*extsvc.ExternalAccount
+	var userID int32
+	if user != nil {
+		userID = user.ID
+		accts, err = ExternalAccounts.List(ctx, ExternalAccountsListOptions{UserID: user.ID})
+		if err != nil {
+			return err
+		}
+	}
+
+	perms := map[api.RepoID][]authz.Perm{}
+	unverified := authz.ToRepos(repos)
+	for _, authzProvider := range authzProviders {
+		if len(unverified) == 0 {
+			break
+		}
+
+		var providerAcct *extsvc.ExternalAccount
+		if user != nil {
+			providerAcct, err = providerAccount(ctx, authzProvider, user, accts)
+			if err != nil {
+				return err
+			}
+		}
+
+		// determine which repos "belong" to this authz provider and check the perms on those repos
+		var mine map[authz.Repo]struct{}
+		mine, unverified = authzProvider.Repos(ctx, unverified)
+		repoPerms, err := authzProvider.RepoPerms(ctx, providerAcct, mine)
+		if err != nil {
+			return err
+		}
+		for repoName, ps := range repoPerms {
+			repoID, ok := repoIDs[repoName]
+			if !ok {
+				continue
+			}
+			for p, ok := range ps {
+				if ok {
+					perms[repoID] = append(perms[repoID], p)
+				}
+			}
+		}
+	}
+
+	return UserRepoPermissions.SetForUser(ctx, userID, perms)
+}
+
+// SyncRepoPermissions fetches the permissions of anonymous users and of all users whose
+// permissions have been synced before on the repository from the authz provider of the repository,
+// and stores them, replacing the previously synced permissions. It is used to sync the permissions
+// of repositories that were added after users were last synced.
+func SyncRepoPermissions(ctx context.Context, repo *types.Repo) error {
+	_, authzProviders := authz.GetProviders()
+
+	var authzProvider authz.Provider
+	repos := authz.ToRepos([]*types.Repo{repo})
+	for _, p := range authzProviders {
+		if mine, _ := p.Repos(ctx, repos); len(mine) > 0 {
+			authzProvider = p
+			break
+		}
+	}
+	if authzProvider == nil {
+		// No authz provider claims the repository, so there are no permissions to sync.
+		return UserRepoPermissions.SetForRepo(ctx, repo.ID, nil)
+	}
+
+	granted := func(acct *extsvc.ExternalAccount) ([]authz.Perm, error) {
+		repoPerms, err := authzProvider.RepoPerms(ctx, acct, repos)
+		if err != nil {
+			return nil, err
+		}
+		var ps []authz.Perm
+		for p, ok := range repoPerms[repo.Name] {
+			if ok {
+				ps = append(ps, p)
+			}
+		}
+		return ps, nil
+	}
+
+	// Users without an account on the code host have the same permissions as anonymous users.
+	anonymousPerms, err := granted(nil)
+	if err != nil {
+		return err
+	}
+	perms := map[int32][]authz.Perm{0: anonymousPerms}
+
+	userIDs, err := UserRepoPermissions.ListSyncedUsers(ctx)
+	if err != nil {
+		return err
+	}
+	accts, err := ExternalAccounts.List(ctx, ExternalAccountsListOptions{ServiceType: authzProvider.ServiceType(), ServiceID: authzProvider.ServiceID()})
+	if err != nil {
+		return err
+	}
+	userAccts := make(map[int32]*extsvc.ExternalAccount, len(accts))
+	for _, acct := range accts {
+		userAccts[acct.UserID] = acct
+	}
+	for _, userID := range userIDs {
+		acct, ok := userAccts[userID]
+		if !ok {
+			perms[userID] = anonymousPerms
+			continue
+		}
+		if perms[userID], err = granted(acct); err != nil {
+			return err
+		}
+	}
+
+	return UserRepoPermissions.SetForRepo(ctx, repo.ID, perms)
+}
+
+// providerAccount returns the user's external account for the authz provider, fetching and saving
+// it if the user has none yet. It returns nil if the user has no account on the code host.
+func providerAccount(ctx context.Context, authzProvider authz.Provider, user *types.User, accts []*extsvc.ExternalAccount) (*extsvc.ExternalAccount, error) {
+	for _, acct := range accts {
+		if acct.ServiceID == authzProvider.ServiceID() && acct.ServiceType == authzProvider.ServiceType() {
+			return acct, nil
+		}
+	}
+
+	// no existing external account for authz provider
+	acct, err := authzProvider.FetchAccount(ctx, user, accts)
+	if err != nil {
+		log15.Warn("Could not fetch authz provider account for user", "username", user.Username, "authzProvider", authzProvider.ServiceID(), "error", err)
+		return nil, nil
+	}
+	if acct != nil {
+		if err := ExternalAccounts.AssociateUserAndSave(ctx, user.ID, acct.ExternalAccountSpec, acct.ExternalAccountData); err != nil {
+			return nil, err
+		}
+	}
+	return acct, nil
+}
@@ -170,3 +170,85 @@ func TestRepos_Upsert(t *testing.T) {
 		t.Fatalf("rp.Name: %q != %q", rp.Description, "asdfasdf")
 	}
 }
+
+func TestRepos_Upsert_renamed(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	spec := &api.ExternalRepoSpec{ID: "r1", ServiceType: "github", ServiceID: "https://github.com/"}
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "github.com/a/myrepo", Enabled: true, ExternalRepo: spec}); err != nil {
+		t.Fatal(err)
+	}
+	rp, err := Repos.GetByName(ctx, "github.com/a/myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Upserting a repository with a new name and the same external repository spec renames it in
+	// place.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "github.com/b/myrepo", Description: "d", ExternalRepo: spec}); err != nil {
+		t.Fatal(err)
+	}
+	renamed, err := Repos.GetByName(ctx, "github.com/b/myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamed.ID != rp.ID || !renamed.Enabled || renamed.Description != "d" {
+		t.Errorf("got renamed repo %+v, want ID %d, enabled, with description", renamed, rp.ID)
+	}
+	if _, err := Repos.GetByName(ctx, "github.com/a/myrepo"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v for old name, want not found", err)
+	}
+}
+
+func TestRepos_MarkDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	rp, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Repos.MarkDeleted(ctx, "myrepo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted repositories are only accessible by ID, and their clones are kept during the grace
+	// period.
+	if _, err := Repos.GetByName(ctx, "myrepo"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+	if count, err := Repos.Count(ctx, ReposListOptions{Enabled: true}); err != nil {
+		t.Fatal(err)
+	} else if count != 0 {
+		t.Errorf("got count %d, want 0", count)
+	}
+	if _, err := Repos.Get(ctx, rp.ID); err != nil {
+		t.Errorf("got error %v getting deleted repo by ID", err)
+	}
+	if names, err := Repos.ListEnabledNames(ctx); err != nil {
+		t.Fatal(err)
+	} else if len(names) != 1 {
+		t.Errorf("got enabled names %q during grace period, want [myrepo]", names)
+	}
+
+	// Upserting the repository again restores it.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo"}); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := Repos.GetByName(ctx, "myrepo"); err != nil {
+		t.Fatal(err)
+	} else if restored.ID != rp.ID {
+		t.Errorf("got restored repo ID %d, want %d", restored.ID, rp.ID)
+	} else if !restored.Enabled {
+		t.Error("restored repo is disabled, want it to keep its enabled state")
+	}
+}
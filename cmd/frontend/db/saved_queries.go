@@ -6,29 +6,35 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
 )
 
 type savedQueries struct{}
 
+// SavedQueryInfo is the info of a saved query. It is keyed by saved query and query, so that saved
+// queries with the same query don't share it, and it is reset when the saved query's query changes.
+// Saved queries in global settings have neither a user_id nor an org_id.
 type SavedQueryInfo struct {
+	Spec         api.SavedQueryIDSpec
 	Query        string
 	LastExecuted time.Time
 	LatestResult time.Time
 	ExecDuration time.Duration
 }
 
-// Get gets the saved query information for the given query. nil
+// Get gets the saved query information for the given saved query and query. nil
 // is returned if there is no existing saved query info.
-func (s *savedQueries) Get(ctx context.Context, query string) (*SavedQueryInfo, error) {
+func (s *savedQueries) Get(ctx context.Context, spec api.SavedQueryIDSpec, query string) (*SavedQueryInfo, error) {
 	info := &SavedQueryInfo{
+		Spec:  spec,
 		Query: query,
 	}
 	var execDurationNs int64
 	err := dbconn.Global.QueryRowContext(
 		ctx,
-		"SELECT last_executed, latest_result, exec_duration_ns FROM saved_queries WHERE query=$1",
-		query,
+		"SELECT last_executed, latest_result, exec_duration_ns FROM saved_queries WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 AND query=$4",
+		spec.Key, spec.Subject.User, spec.Subject.Org, query,
 	).Scan(&info.LastExecuted, &info.LatestResult, &execDurationNs)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -40,17 +46,20 @@ func (s *savedQueries) Get(ctx context.Context, query string) (*SavedQueryInfo,
 	return info, nil
 }
 
-// Set sets the saved query information for the given info.Query.
+// Set sets the saved query information for the given info.Spec and info.Query.
 //
-// It is not safe to call concurrently for the same info.Query, as it uses a
-// poor man's upsert implementation.
+// It is not safe to call concurrently for the same info.Spec and info.Query, as
+// it uses a poor man's upsert implementation.
 func (s *savedQueries) Set(ctx context.Context, info *SavedQueryInfo) error {
 	res, err := dbconn.Global.ExecContext(
 		ctx,
-		"UPDATE saved_queries SET last_executed=$1, latest_result=$2, exec_duration_ns=$3 WHERE query=$4",
+		"UPDATE saved_queries SET last_executed=$1, latest_result=$2, exec_duration_ns=$3 WHERE saved_query_key=$4 AND user_id IS NOT DISTINCT FROM $5 AND org_id IS NOT DISTINCT FROM $6 AND query=$7",
 		info.LastExecuted,
 		info.LatestResult,
 		int64(info.ExecDuration),
+		info.Spec.Key,
+		info.Spec.Subject.User,
+		info.Spec.Subject.Org,
 		info.Query,
 	)
 	if err != nil {
@@ -64,7 +73,10 @@ func (s *savedQueries) Set(ctx context.Context, info *SavedQueryInfo) error {
 		// Didn't update any row, so insert a new one.
 		_, err := dbconn.Global.ExecContext(
 			ctx,
-			"INSERT INTO saved_queries(query, last_executed, latest_result, exec_duration_ns) VALUES($1, $2, $3, $4)",
+			"INSERT INTO saved_queries(saved_query_key, user_id, org_id, query, last_executed, latest_result, exec_duration_ns) VALUES($1, $2, $3, $4, $5, $6, $7)",
+			info.Spec.Key,
+			info.Spec.Subject.User,
+			info.Spec.Subject.Org,
 			info.Query,
 			info.LastExecuted,
 			info.LatestResult,
@@ -77,11 +89,12 @@ func (s *savedQueries) Set(ctx context.Context, info *SavedQueryInfo) error {
 	return nil
 }
 
-func (s *savedQueries) Delete(ctx context.Context, query string) error {
+// Delete deletes the saved query information for the given saved query and query.
+func (s *savedQueries) Delete(ctx context.Context, spec api.SavedQueryIDSpec, query string) error {
 	_, err := dbconn.Global.ExecContext(
 		ctx,
-		"DELETE FROM saved_queries WHERE query=$1",
-		query,
+		"DELETE FROM saved_queries WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 AND query=$4",
+		spec.Key, spec.Subject.User, spec.Subject.Org, query,
 	)
 	return err
 }
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestSavedQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userSpec := api.SavedQueryIDSpec{Subject: api.SettingsSubject{User: &user.ID}, Key: "k"}
+	siteSpec := api.SavedQueryIDSpec{Subject: api.SettingsSubject{Site: true}, Key: "k"}
+
+	checkLastExecuted := func(spec api.SavedQueryIDSpec, query string, want time.Time) {
+		t.Helper()
+		info, err := SavedQueries.Get(ctx, spec, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want.IsZero() {
+			if info != nil {
+				t.Errorf("%+v %q: got info %+v, want none", spec, query, info)
+			}
+			return
+		}
+		if info == nil || !info.LastExecuted.Equal(want) {
+			t.Errorf("%+v %q: got info %+v, want last executed %s", spec, query, info, want)
+		}
+	}
+
+	t1 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	for _, info := range []*SavedQueryInfo{
+		{Spec: userSpec, Query: "q", LastExecuted: t1},
+		{Spec: siteSpec, Query: "q", LastExecuted: t1},
+		{Spec: siteSpec, Query: "q", LastExecuted: t2}, // update
+	} {
+		if err := SavedQueries.Set(ctx, info); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Saved queries with the same query don't share info.
+	checkLastExecuted(userSpec, "q", t1)
+	checkLastExecuted(siteSpec, "q", t2)
+	checkLastExecuted(userSpec, "q2", time.Time{})
+
+	if err := SavedQueries.Delete(ctx, siteSpec, "q"); err != nil {
+		t.Fatal(err)
+	}
+	checkLastExecuted(siteSpec, "q", time.Time{})
+	checkLastExecuted(userSpec, "q", t1)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// savedQueryExecutions provides access to the `saved_query_executions` table, which records the
+// runs of saved queries by query-runner.
+//
+// For a detailed overview of the schema, see schema.md.
+type savedQueryExecutions struct{}
+
+// savedQueryHistoryRetention is how long executions and delivered notifications of saved queries
+// are kept.
+const savedQueryHistoryRetention = "30 days"
+
+// Create records an execution of a saved query, and removes the saved query's executions that are
+// older than the retention period.
+func (*savedQueryExecutions) Create(ctx context.Context, e *api.SavedQueryExecution) error {
+	var errStr *string
+	if e.Error != "" {
+		errStr = &e.Error
+	}
+	_, err := dbconn.Global.ExecContext(ctx,
+		"INSERT INTO saved_query_executions(user_id, org_id, saved_query_key, query, executed_at, duration_ms, result_count, error) VALUES($1, $2, $3, $4, $5, $6, $7, $8)",
+		e.Spec.Subject.User, e.Spec.Subject.Org, e.Spec.Key, e.Query, e.ExecutedAt, int64(e.Duration/time.Millisecond), e.ResultCount, errStr,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = dbconn.Global.ExecContext(ctx,
+		"DELETE FROM saved_query_executions WHERE saved_query_key=$1 AND executed_at < now() - $2::interval",
+		e.Spec.Key, savedQueryHistoryRetention,
+	)
+	return err
+}
+
+// List returns the most recent executions of the saved query, newest first.
+func (*savedQueryExecutions) List(ctx context.Context, spec api.SavedQueryIDSpec, limit int) ([]*api.SavedQueryExecution, error) {
+	if Mocks.SavedQueryExecutions.List != nil {
+		return Mocks.SavedQueryExecutions.List(ctx, spec, limit)
+	}
+
+	// Saved queries in global settings have neither a user_id nor an org_id.
+	rows, err := dbconn.Global.QueryContext(ctx,
+		"SELECT query, executed_at, duration_ms, result_count, error FROM saved_query_executions WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 ORDER BY executed_at DESC LIMIT $4",
+		spec.Key, spec.Subject.User, spec.Subject.Org, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var executions []*api.SavedQueryExecution
+	for rows.Next() {
+		e := api.SavedQueryExecution{Spec: spec}
+		var durationMS int64
+		var errStr sql.NullString
+		if err := rows.Scan(&e.Query, &e.ExecutedAt, &durationMS, &e.ResultCount, &errStr); err != nil {
+			return nil, err
+		}
+		e.Duration = time.Duration(durationMS) * time.Millisecond
+		e.Error = errStr.String
+		executions = append(executions, &e)
+	}
+	return executions, rows.Err()
+}
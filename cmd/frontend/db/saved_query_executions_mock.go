@@ -0,0 +1,11 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockSavedQueryExecutions struct {
+	List func(ctx context.Context, spec api.SavedQueryIDSpec, limit int) ([]*api.SavedQueryExecution, error)
+}
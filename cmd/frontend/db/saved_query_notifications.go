@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbutil"
+)
+
+// savedQueryNotifications provides access to the `saved_query_notifications` table, which is the
+// outbox of notifications about the results of saved queries. Notifications stay in the outbox
+// after they are delivered (or dead-lettered), as a log of what was sent.
+//
+// For a detailed overview of the schema, see schema.md.
+type savedQueryNotifications struct{}
+
+const (
+	// savedQueryNotificationClaimDuration is how long a notification returned by Dequeue is not
+	// returned again, unless its delivery attempt is reported with Update. It bounds the delay of
+	// retrying notifications whose delivery was interrupted (for example, by a restart).
+	savedQueryNotificationClaimDuration = "5 minutes"
+
+	// savedQueryNotificationMaxAttempts is the number of failed delivery attempts after which a
+	// notification is dead-lettered.
+	savedQueryNotificationMaxAttempts = 8
+)
+
+// Enqueue adds notifications to the outbox, and removes delivered and dead notifications that are
+// older than the retention period.
+func (*savedQueryNotifications) Enqueue(ctx context.Context, notifications []*api.SavedQueryNotification) error {
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		for _, n := range notifications {
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO saved_query_notifications(user_id, org_id, saved_query_key, channel, recipient_user_id, recipient_org_id, payload) VALUES($1, $2, $3, $4, $5, $6, $7)",
+				n.Spec.Subject.User, n.Spec.Subject.Org, n.Spec.Key, n.Channel, n.Recipient.User, n.Recipient.Org, []byte(n.Payload),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM saved_query_notifications WHERE state <> 'queued' AND created_at < now() - $1::interval", savedQueryHistoryRetention)
+		return err
+	})
+}
+
+// Dequeue claims up to limit queued notifications that are due for a delivery attempt, and counts
+// the attempt.
+func (*savedQueryNotifications) Dequeue(ctx context.Context, limit int) ([]*api.SavedQueryNotification, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+UPDATE saved_query_notifications SET attempts=attempts+1, next_attempt_at=now() + $2::interval
+WHERE id IN (
+	SELECT id FROM saved_query_notifications
+	WHERE state='queued' AND next_attempt_at <= now()
+	ORDER BY next_attempt_at
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING `+savedQueryNotificationColumns,
+		limit, savedQueryNotificationClaimDuration,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSavedQueryNotifications(rows)
+}
+
+// Update records the outcome of a delivery attempt of a notification. If the delivery failed, the
+// notification is retried with exponential backoff, unless the error is permanent or the
+// notification failed too many times (then it is dead-lettered).
+func (*savedQueryNotifications) Update(ctx context.Context, id int64, deliveryErr string, permanent bool) error {
+	var res sql.Result
+	var err error
+	if deliveryErr == "" {
+		res, err = dbconn.Global.ExecContext(ctx, "UPDATE saved_query_notifications SET state='sent', sent_at=now(), last_error=NULL WHERE id=$1", id)
+	} else {
+		res, err = dbconn.Global.ExecContext(ctx, `
+UPDATE saved_query_notifications SET
+	last_error=$2,
+	state=(CASE WHEN $3 OR attempts >= $4 THEN 'dead' ELSE 'queued' END),
+	next_attempt_at=now() + LEAST(interval '30 seconds' * power(2, attempts - 1), interval '1 hour')
+WHERE id=$1`,
+			id, deliveryErr, permanent, savedQueryNotificationMaxAttempts,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return fmt.Errorf("saved query notification not found: %d", id)
+	}
+	return nil
+}
+
+// List returns the most recent notifications about the results of the saved query, newest first.
+func (*savedQueryNotifications) List(ctx context.Context, spec api.SavedQueryIDSpec, limit int) ([]*api.SavedQueryNotification, error) {
+	if Mocks.SavedQueryNotifications.List != nil {
+		return Mocks.SavedQueryNotifications.List(ctx, spec, limit)
+	}
+
+	// Saved queries in global settings have neither a user_id nor an org_id.
+	rows, err := dbconn.Global.QueryContext(ctx,
+		"SELECT "+savedQueryNotificationColumns+" FROM saved_query_notifications WHERE saved_query_key=$1 AND user_id IS NOT DISTINCT FROM $2 AND org_id IS NOT DISTINCT FROM $3 ORDER BY created_at DESC, id DESC LIMIT $4",
+		spec.Key, spec.Subject.User, spec.Subject.Org, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSavedQueryNotifications(rows)
+}
+
+const savedQueryNotificationColumns = "id, user_id, org_id, saved_query_key, channel, recipient_user_id, recipient_org_id, payload, state, attempts, last_error, created_at, sent_at"
+
+func scanSavedQueryNotifications(rows *sql.Rows) ([]*api.SavedQueryNotification, error) {
+	defer rows.Close()
+
+	var notifications []*api.SavedQueryNotification
+	for rows.Next() {
+		var n api.SavedQueryNotification
+		var payload []byte
+		var lastError sql.NullString
+		if err := rows.Scan(&n.ID, &n.Spec.Subject.User, &n.Spec.Subject.Org, &n.Spec.Key, &n.Channel, &n.Recipient.User, &n.Recipient.Org, &payload, &n.State, &n.Attempts, &lastError, &n.CreatedAt, &n.SentAt); err != nil {
+			return nil, err
+		}
+		if n.Spec.Subject.User == nil && n.Spec.Subject.Org == nil {
+			n.Spec.Subject.Site = true
+		}
+		n.Payload = payload
+		n.LastError = lastError.String
+		notifications = append(notifications, &n)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestSavedQueryNotifications(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := api.SavedQueryIDSpec{Subject: api.SettingsSubject{User: &user.ID}, Key: "k"}
+
+	if err := SavedQueryNotifications.Enqueue(ctx, []*api.SavedQueryNotification{
+		{Spec: spec, Channel: api.SavedQueryNotificationChannelEmail, Recipient: api.SettingsSubject{User: &user.ID}, Payload: json.RawMessage(`{"a":1}`)},
+		{Spec: spec, Channel: api.SavedQueryNotificationChannelSlack, Recipient: api.SettingsSubject{User: &user.ID}, Payload: json.RawMessage(`{"b":2}`)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Makes all queued notifications due for a delivery attempt. (now() doesn't advance in the
+	// test's transaction.)
+	makeDue := func() {
+		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE saved_query_notifications SET next_attempt_at=now() - interval '1 second'"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both notifications are claimed, and not returned again while claimed.
+	makeDue()
+	claimed, err := SavedQueryNotifications.Dequeue(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 {
+		t.Fatalf("got %d notifications, want 2", len(claimed))
+	}
+	for _, n := range claimed {
+		if n.Attempts != 1 || n.State != api.SavedQueryNotificationStateQueued {
+			t.Errorf("got attempts %d and state %q, want 1 and queued", n.Attempts, n.State)
+		}
+	}
+	if again, err := SavedQueryNotifications.Dequeue(ctx, 10); err != nil {
+		t.Fatal(err)
+	} else if len(again) != 0 {
+		t.Errorf("got %d claimed notifications again, want 0", len(again))
+	}
+
+	email, slack := claimed[0], claimed[1]
+	if email.Channel != api.SavedQueryNotificationChannelEmail {
+		email, slack = slack, email
+	}
+
+	// The email is delivered. The Slack message fails and is retried until it is dead.
+	if err := SavedQueryNotifications.Update(ctx, email.ID, "", false); err != nil {
+		t.Fatal(err)
+	}
+	for attempt := 1; attempt <= savedQueryNotificationMaxAttempts; attempt++ {
+		if attempt > 1 {
+			makeDue()
+			retried, err := SavedQueryNotifications.Dequeue(ctx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(retried) != 1 || retried[0].ID != slack.ID || retried[0].Attempts != attempt {
+				t.Fatalf("attempt %d: got %+v, want the Slack notification", attempt, retried)
+			}
+		}
+		if err := SavedQueryNotifications.Update(ctx, slack.ID, "slack is down", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	makeDue()
+	if dead, err := SavedQueryNotifications.Dequeue(ctx, 10); err != nil {
+		t.Fatal(err)
+	} else if len(dead) != 0 {
+		t.Errorf("got %d notifications after too many attempts, want 0", len(dead))
+	}
+
+	list, err := SavedQueryNotifications.List(ctx, spec, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states := map[string]*api.SavedQueryNotification{}
+	for _, n := range list {
+		states[n.Channel] = n
+	}
+	if n := states[api.SavedQueryNotificationChannelEmail]; n == nil || n.State != api.SavedQueryNotificationStateSent || n.SentAt == nil {
+		t.Errorf("got email notification %+v, want sent", n)
+	}
+	if n := states[api.SavedQueryNotificationChannelSlack]; n == nil || n.State != api.SavedQueryNotificationStateDead || n.LastError != "slack is down" {
+		t.Errorf("got Slack notification %+v, want dead with the last error", n)
+	}
+
+	if err := SavedQueryNotifications.Update(ctx, 0, "", false); err == nil {
+		t.Error("got nil error for an unknown notification, want an error")
+	}
+}
+
+func TestSavedQueryNotifications_permanentError(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	spec := api.SavedQueryIDSpec{Subject: api.SettingsSubject{Site: true}, Key: "k"}
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SavedQueryNotifications.Enqueue(ctx, []*api.SavedQueryNotification{
+		{Spec: spec, Channel: api.SavedQueryNotificationChannelWebhook, Recipient: api.SettingsSubject{Org: &org.ID}, Payload: json.RawMessage(`{}`)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := SavedQueryNotifications.Dequeue(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 {
+		t.Fatalf("got %d notifications, want 1", len(claimed))
+	}
+	if err := SavedQueryNotifications.Update(ctx, claimed[0].ID, "HTTP 400", true); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := SavedQueryNotifications.List(ctx, spec, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].State != api.SavedQueryNotificationStateDead || !list[0].Spec.Subject.Site {
+		t.Errorf("got %+v, want 1 dead notification of a global saved query", list)
+	}
+}
@@ -1,19 +1,31 @@
 package db
 
 var (
-	AccessTokens              = &accessTokens{}
-	ExternalServices          = &externalServices{}
-	DiscussionThreads         = &discussionThreads{}
-	DiscussionComments        = &discussionComments{}
-	DiscussionMailReplyTokens = &discussionMailReplyTokens{}
-	Repos                     = &repos{}
-	Phabricator               = &phabricator{}
-	SavedQueries              = &savedQueries{}
-	Orgs                      = &orgs{}
-	OrgMembers                = &orgMembers{}
-	Settings                  = &settings{}
-	Users                     = &users{}
-	UserEmails                = &userEmails{}
+	AccessTokens                = &accessTokens{}
+	CodeMonitorResults          = &codeMonitorResults{}
+	ExternalServices            = &externalServices{}
+	DiscussionThreads           = &discussionThreads{}
+	DiscussionComments          = &discussionComments{}
+	DiscussionCommentReactions  = &discussionCommentReactions{}
+	DiscussionCommentRevisions  = &discussionCommentRevisions{}
+	DiscussionExternalComments  = &discussionExternalComments{}
+	DiscussionMailReplyTokens   = &discussionMailReplyTokens{}
+	DiscussionSubscriptions     = &discussionSubscriptions{}
+	DiscussionUnsubscribeTokens = &discussionUnsubscribeTokens{}
+	LSIFDumps                   = &lsifDumps{}
+	Repos                       = &repos{}
+	RepoPermissions             = &repoPermissions{}
+	Phabricator                 = &phabricator{}
+	SavedQueries                = &savedQueries{}
+	SavedQueryExecutions        = &savedQueryExecutions{}
+	SavedQueryNotifications     = &savedQueryNotifications{}
+	Orgs                        = &orgs{}
+	OrgMembers                  = &orgMembers{}
+	Settings                    = &settings{}
+	Users                       = &users{}
+	UserEmails                  = &userEmails{}
+	UserRepoPermissions         = &userRepoPermissions{}
+	WebhookSecrets              = &webhookSecrets{}
 
 	SurveyResponses = &surveyResponses{}
 
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbutil"
+)
+
+// userRepoPermissions provides access to the `user_repo_permissions` table, which holds the
+// permissions of users on repositories as synced in the background from authz providers. Rows with
+// a NULL user_id hold the permissions of anonymous users.
+//
+// In this file, a user ID of 0 denotes anonymous users.
+//
+// For a detailed overview of the schema, see schema.md.
+type userRepoPermissions struct{}
+
+// SetForUser replaces all synced permissions of the user with perms and records the time of the
+// sync.
+func (*userRepoPermissions) SetForUser(ctx context.Context, userID int32, perms map[api.RepoID][]authz.Perm) error {
+	if Mocks.UserRepoPermissions.SetForUser != nil {
+		return Mocks.UserRepoPermissions.SetForUser(ctx, userID, perms)
+	}
+
+	var values []*sqlf.Query
+	for repoID, ps := range perms {
+		for _, p := range ps {
+			values = append(values, sqlf.Sprintf("(%s, %d, %s)", nullUserID(userID), repoID, p))
+		}
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_repo_permissions WHERE user_id IS NOT DISTINCT FROM $1", nullUserID(userID)); err != nil {
+			return err
+		}
+		if err := insertUserRepoPermissions(ctx, tx, values); err != nil {
+			return err
+		}
+		if userID == 0 {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE users SET permissions_synced_at=now() WHERE id=$1", userID)
+		return err
+	})
+}
+
+// SetForRepo replaces the synced permissions of the given users (keyed by user ID) on the
+// repository with perms and records the time of the sync. The permissions of users that are not in
+// perms are removed.
+func (*userRepoPermissions) SetForRepo(ctx context.Context, repoID api.RepoID, perms map[int32][]authz.Perm) error {
+	if Mocks.UserRepoPermissions.SetForRepo != nil {
+		return Mocks.UserRepoPermissions.SetForRepo(ctx, repoID, perms)
+	}
+
+	var values []*sqlf.Query
+	for userID, ps := range perms {
+		for _, p := range ps {
+			values = append(values, sqlf.Sprintf("(%s, %d, %s)", nullUserID(userID), repoID, p))
+		}
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_repo_permissions WHERE repo_id=$1", repoID); err != nil {
+			return err
+		}
+		if err := insertUserRepoPermissions(ctx, tx, values); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE repo SET permissions_synced_at=now() WHERE id=$1", repoID)
+		return err
+	})
+}
+
+func insertUserRepoPermissions(ctx context.Context, tx *sql.Tx, values []*sqlf.Query) error {
+	if len(values) == 0 {
+		return nil
+	}
+	q := sqlf.Sprintf("INSERT INTO user_repo_permissions(user_id, repo_id, permission) VALUES %s ON CONFLICT DO NOTHING", sqlf.Join(values, ","))
+	_, err := tx.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
+
+// ListRepoNames returns the names of the repositories among repos on which the user has the
+// synced permission p.
+func (*userRepoPermissions) ListRepoNames(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (map[api.RepoName]struct{}, error) {
+	if Mocks.UserRepoPermissions.ListRepoNames != nil {
+		return Mocks.UserRepoPermissions.ListRepoNames(ctx, userID, repos, p)
+	}
+
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = string(repo)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `
+SELECT repo.name FROM user_repo_permissions urp
+INNER JOIN repo ON repo.id=urp.repo_id
+WHERE urp.user_id IS NOT DISTINCT FROM $1 AND urp.permission=$2 AND repo.name = ANY($3::citext[])`, nullUserID(userID), p, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permitted := map[api.RepoName]struct{}{}
+	for rows.Next() {
+		var name api.RepoName
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		permitted[name] = struct{}{}
+	}
+	return permitted, rows.Err()
+}
+
+// SyncedAt returns the time at which the permissions of the user were last synced, or nil if they
+// have never been synced.
+func (*userRepoPermissions) SyncedAt(ctx context.Context, userID int32) (*time.Time, error) {
+	if Mocks.UserRepoPermissions.SyncedAt != nil {
+		return Mocks.UserRepoPermissions.SyncedAt(ctx, userID)
+	}
+
+	var syncedAt *time.Time
+	if err := dbconn.Global.QueryRowContext(ctx, "SELECT permissions_synced_at FROM users WHERE id=$1", userID).Scan(&syncedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, userNotFoundErr{args: []interface{}{userID}}
+		}
+		return nil, err
+	}
+	return syncedAt, nil
+}
+
+// ListUsersToSync returns the IDs of at most limit users whose permissions have never been synced
+// or were last synced before the given time, least recently synced first.
+func (*userRepoPermissions) ListUsersToSync(ctx context.Context, syncedBefore time.Time, limit int) ([]int32, error) {
+	return listIDs(ctx, sqlf.Sprintf(`
+SELECT id FROM users
+WHERE deleted_at IS NULL AND (permissions_synced_at IS NULL OR permissions_synced_at < %s)
+ORDER BY permissions_synced_at ASC NULLS FIRST, id ASC
+LIMIT %d`, syncedBefore, limit))
+}
+
+// ListSyncedUsers returns the IDs of all users whose permissions have been synced.
+func (*userRepoPermissions) ListSyncedUsers(ctx context.Context) ([]int32, error) {
+	return listIDs(ctx, sqlf.Sprintf("SELECT id FROM users WHERE deleted_at IS NULL AND permissions_synced_at IS NOT NULL ORDER BY id ASC"))
+}
+
+// ListReposToSync returns the IDs of at most limit repositories whose permissions have never been
+// synced (typically because they were added after the last sync of all users) or were last synced
+// before the given time, least recently synced first.
+func (*userRepoPermissions) ListReposToSync(ctx context.Context, syncedBefore time.Time, limit int) ([]api.RepoID, error) {
+	ids, err := listIDs(ctx, sqlf.Sprintf(`
+SELECT id FROM repo
+WHERE deleted_at IS NULL AND (permissions_synced_at IS NULL OR permissions_synced_at < %s)
+ORDER BY permissions_synced_at ASC NULLS FIRST, id ASC
+LIMIT %d`, syncedBefore, limit))
+	if err != nil {
+		return nil, err
+	}
+	repoIDs := make([]api.RepoID, len(ids))
+	for i, id := range ids {
+		repoIDs[i] = api.RepoID(id)
+	}
+	return repoIDs, nil
+}
+
+func listIDs(ctx context.Context, q *sqlf.Query) ([]int32, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// nullUserID returns the database value of the user ID, which is NULL for anonymous users.
+func nullUserID(userID int32) interface{} {
+	if userID == 0 {
+		return nil
+	}
+	return userID
+}
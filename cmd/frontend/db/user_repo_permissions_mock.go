@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockUserRepoPermissions struct {
+	SetForUser    func(ctx context.Context, userID int32, perms map[api.RepoID][]authz.Perm) error
+	SetForRepo    func(ctx context.Context, repoID api.RepoID, perms map[int32][]authz.Perm) error
+	ListRepoNames func(ctx context.Context, userID int32, repos []api.RepoName, p authz.Perm) (map[api.RepoName]struct{}, error)
+	SyncedAt      func(ctx context.Context, userID int32) (*time.Time, error)
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestUserRepoPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	// Create fixtures.
+	var repoIDs []api.RepoID
+	for _, name := range []api.RepoName{"r1", "r2"} {
+		if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: name, Enabled: true}); err != nil {
+			t.Fatal(err)
+		}
+		repo, err := Repos.GetByName(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	var userIDs []int32
+	for _, username := range []string{"u1", "u2"} {
+		user, err := Users.Create(ctx, NewUser{Username: username})
+		if err != nil {
+			t.Fatal(err)
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+
+	check := func(userID int32, want ...api.RepoName) {
+		t.Helper()
+		names, err := UserRepoPermissions.ListRepoNames(ctx, userID, []api.RepoName{"r1", "r2"}, authz.Read)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantNames := map[api.RepoName]struct{}{}
+		for _, name := range want {
+			wantNames[name] = struct{}{}
+		}
+		if !reflect.DeepEqual(names, wantNames) {
+			t.Errorf("user %d: got repos %v, want %v", userID, names, wantNames)
+		}
+	}
+
+	// Nothing has been synced yet.
+	if syncedAt, err := UserRepoPermissions.SyncedAt(ctx, userIDs[0]); err != nil || syncedAt != nil {
+		t.Fatalf("got synced at %v (error %v), want nil", syncedAt, err)
+	}
+	if ids, err := UserRepoPermissions.ListUsersToSync(ctx, time.Now(), 10); err != nil || !reflect.DeepEqual(ids, userIDs) {
+		t.Fatalf("got users to sync %v (error %v), want %v", ids, err, userIDs)
+	}
+	if ids, err := UserRepoPermissions.ListReposToSync(ctx, time.Now(), 10); err != nil || !reflect.DeepEqual(ids, repoIDs) {
+		t.Fatalf("got repos to sync %v (error %v), want %v", ids, err, repoIDs)
+	}
+
+	// Sync users.
+	if err := UserRepoPermissions.SetForUser(ctx, userIDs[0], map[api.RepoID][]authz.Perm{repoIDs[0]: {authz.Read}, repoIDs[1]: {authz.Read}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UserRepoPermissions.SetForUser(ctx, 0, map[api.RepoID][]authz.Perm{repoIDs[1]: {authz.Read}}); err != nil {
+		t.Fatal(err)
+	}
+	check(userIDs[0], "r1", "r2")
+	check(userIDs[1])
+	check(0, "r2")
+	if names, err := UserRepoPermissions.ListRepoNames(ctx, userIDs[0], []api.RepoName{"R2", "r3"}, authz.Read); err != nil || !reflect.DeepEqual(names, map[api.RepoName]struct{}{"r2": {}}) {
+		t.Fatalf("got repos %v (error %v), want only r2", names, err)
+	}
+	if syncedAt, err := UserRepoPermissions.SyncedAt(ctx, userIDs[0]); err != nil || syncedAt == nil {
+		t.Fatalf("got synced at %v (error %v), want non-nil", syncedAt, err)
+	}
+	if ids, err := UserRepoPermissions.ListUsersToSync(ctx, time.Now().Add(-time.Hour), 10); err != nil || !reflect.DeepEqual(ids, userIDs[1:]) {
+		t.Fatalf("got users to sync %v (error %v), want %v", ids, err, userIDs[1:])
+	}
+	if ids, err := UserRepoPermissions.ListSyncedUsers(ctx); err != nil || !reflect.DeepEqual(ids, userIDs[:1]) {
+		t.Fatalf("got synced users %v (error %v), want %v", ids, err, userIDs[:1])
+	}
+
+	// Syncing a repository replaces the permissions of all users on it.
+	if err := UserRepoPermissions.SetForRepo(ctx, repoIDs[1], map[int32][]authz.Perm{userIDs[1]: {authz.Read}}); err != nil {
+		t.Fatal(err)
+	}
+	check(userIDs[0], "r1")
+	check(userIDs[1], "r2")
+	check(0)
+	if ids, err := UserRepoPermissions.ListReposToSync(ctx, time.Now().Add(-time.Hour), 10); err != nil || !reflect.DeepEqual(ids, repoIDs[:1]) {
+		t.Fatalf("got repos to sync %v (error %v), want %v", ids, err, repoIDs[:1])
+	}
+
+	// Repositories whose permissions are stale are synced again, least recently synced first.
+	if ids, err := UserRepoPermissions.ListReposToSync(ctx, time.Now().Add(time.Hour), 10); err != nil || !reflect.DeepEqual(ids, repoIDs) {
+		t.Fatalf("got repos to sync %v (error %v), want %v", ids, err, repoIDs)
+	}
+}
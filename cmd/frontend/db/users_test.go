@@ -441,6 +441,16 @@ func TestUsers_Delete(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			// Watch the repository and create an unsubscribe token, to confirm that
+			// deletion removes them.
+			if err := DiscussionSubscriptions.WatchRepo(ctx, user.ID, repo.ID, ""); err != nil {
+				t.Fatal(err)
+			}
+			unsubscribeToken, err := DiscussionUnsubscribeTokens.Generate(ctx, user.ID, newThread.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			if hard {
 				// Hard delete user.
 				if err := Users.HardDelete(ctx, user.ID); err != nil {
@@ -495,6 +505,16 @@ func TestUsers_Delete(t *testing.T) {
 			if _, ok := err.(*ErrCommentNotFound); !ok {
 				t.Fatal("expected ErrCommentNotFound")
 			}
+
+			// Confirm discussion subscriptions and unsubscribe tokens no longer exist.
+			if subscriptions, err := DiscussionSubscriptions.List(ctx, &DiscussionSubscriptionsListOptions{UserID: &user.ID}); err != nil {
+				t.Fatal(err)
+			} else if len(subscriptions) != 0 {
+				t.Errorf("got %d subscriptions, want 0", len(subscriptions))
+			}
+			if _, _, err := DiscussionUnsubscribeTokens.Get(ctx, unsubscribeToken); err != ErrInvalidToken {
+				t.Errorf("got error %v, want ErrInvalidToken", err)
+			}
 		})
 	}
 }
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbconn"
+)
+
+// webhookSecrets provides access to the `webhook_secrets` table, which holds the secrets that the
+// saved search webhook notifications of users and organizations are signed with.
+//
+// The secrets are not stored in settings, because all members of an organization can read its
+// settings. There is intentionally no way to read a secret through the GraphQL API.
+//
+// For a detailed overview of the schema, see schema.md.
+type webhookSecrets struct{}
+
+// Set sets the webhook secret of the user or organization. An empty secret removes it.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to administer the subject.
+func (*webhookSecrets) Set(ctx context.Context, subject api.SettingsSubject, secret string) error {
+	if Mocks.WebhookSecrets.Set != nil {
+		return Mocks.WebhookSecrets.Set(ctx, subject, secret)
+	}
+
+	cond, err := webhookSecretsSubjectCond(subject)
+	if err != nil {
+		return err
+	}
+	if secret == "" {
+		q := sqlf.Sprintf("DELETE FROM webhook_secrets WHERE %s", cond)
+		_, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		return err
+	}
+
+	var conflict *sqlf.Query
+	if subject.User != nil {
+		conflict = sqlf.Sprintf("(user_id) WHERE user_id IS NOT NULL")
+	} else {
+		conflict = sqlf.Sprintf("(org_id) WHERE org_id IS NOT NULL")
+	}
+	q := sqlf.Sprintf(`
+INSERT INTO webhook_secrets(user_id, org_id, secret) VALUES(%s, %s, %s)
+ON CONFLICT %s DO UPDATE SET secret=EXCLUDED.secret, updated_at=now()`,
+		subject.User, subject.Org, secret, conflict)
+	_, err = dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
+
+// Get returns the webhook secret of the user or organization, or "" if it has none.
+//
+// 🚨 SECURITY: The secret must only be used to sign webhook notifications. It must never be shown
+// to users.
+func (*webhookSecrets) Get(ctx context.Context, subject api.SettingsSubject) (string, error) {
+	if Mocks.WebhookSecrets.Get != nil {
+		return Mocks.WebhookSecrets.Get(ctx, subject)
+	}
+
+	cond, err := webhookSecretsSubjectCond(subject)
+	if err != nil {
+		return "", err
+	}
+	q := sqlf.Sprintf("SELECT secret FROM webhook_secrets WHERE %s", cond)
+	var secret string
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return secret, nil
+}
+
+func webhookSecretsSubjectCond(subject api.SettingsSubject) (*sqlf.Query, error) {
+	switch {
+	case subject.User != nil:
+		return sqlf.Sprintf("user_id=%s", *subject.User), nil
+	case subject.Org != nil:
+		return sqlf.Sprintf("org_id=%s", *subject.Org), nil
+	default:
+		return nil, errors.New("webhook secrets can only be set for users and organizations")
+	}
+}
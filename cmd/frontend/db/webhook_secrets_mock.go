@@ -0,0 +1,12 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+type MockWebhookSecrets struct {
+	Set func(ctx context.Context, subject api.SettingsSubject, secret string) error
+	Get func(ctx context.Context, subject api.SettingsSubject) (string, error)
+}
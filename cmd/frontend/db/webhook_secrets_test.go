@@ -0,0 +1,66 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/db/dbtesting"
+)
+
+func TestWebhookSecrets(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := dbtesting.TestContext(t)
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userSubject := api.SettingsSubject{User: &user.ID}
+	orgSubject := api.SettingsSubject{Org: &org.ID}
+
+	checkSecret := func(subject api.SettingsSubject, want string) {
+		t.Helper()
+		secret, err := WebhookSecrets.Get(ctx, subject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret != want {
+			t.Errorf("%+v: got secret %q, want %q", subject, secret, want)
+		}
+	}
+
+	checkSecret(userSubject, "")
+	for _, secret := range []string{"s1", "s2"} {
+		if err := WebhookSecrets.Set(ctx, userSubject, secret); err != nil {
+			t.Fatal(err)
+		}
+		checkSecret(userSubject, secret)
+	}
+	if err := WebhookSecrets.Set(ctx, orgSubject, "s3"); err != nil {
+		t.Fatal(err)
+	}
+	checkSecret(orgSubject, "s3")
+	checkSecret(userSubject, "s2")
+
+	// An empty secret removes it.
+	if err := WebhookSecrets.Set(ctx, userSubject, ""); err != nil {
+		t.Fatal(err)
+	}
+	checkSecret(userSubject, "")
+	checkSecret(orgSubject, "s3")
+
+	if err := WebhookSecrets.Set(ctx, api.SettingsSubject{Site: true}, "s"); err == nil {
+		t.Error("got no error setting the site's webhook secret")
+	}
+}
@@ -0,0 +1,241 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	lsp "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/search"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/search/query"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/gituri"
+	"github.com/sourcegraph/sourcegraph/pkg/symbols/protocol"
+)
+
+// This file implements best-effort ("fuzzy") code intelligence on top of the symbols index and
+// searcher. It does not run language servers, so results are textual approximations: definitions
+// are symbols whose name equals the token at the position, and references are word-boundary
+// matches of the token.
+
+type codeIntelPositionArgs struct {
+	graphqlutil.ConnectionArgs
+	Line      int32
+	Character int32
+}
+
+func (r *gitTreeEntryResolver) Definition(ctx context.Context, args *codeIntelPositionArgs) (*locationConnectionResolver, error) {
+	token, err := r.tokenAt(ctx, args)
+	if err != nil || token == "" {
+		return &locationConnectionResolver{}, err
+	}
+	limit := limitOrDefault(args.First)
+
+	locations, err := r.definitionsInCommit(ctx, token, limit+1) // add 1 so we can determine PageInfo.hasNextPage
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		// Fall back to other repositories.
+		fileMatches, err := searchFileMatchesOutsideRepo(ctx, r.commit.repo.repo.Name, fmt.Sprintf("type:symbol case:yes count:%d ^%s$", limit+1, regexp.QuoteMeta(token)), "symbol")
+		if err != nil {
+			return nil, err
+		}
+		for _, fm := range fileMatches {
+			for _, symbol := range fm.symbols {
+				locations = append(locations, symbol.location)
+			}
+		}
+	}
+	return &locationConnectionResolver{locations: locations, first: args.First}, nil
+}
+
+func (r *gitTreeEntryResolver) References(ctx context.Context, args *codeIntelPositionArgs) (*locationConnectionResolver, error) {
+	token, err := r.tokenAt(ctx, args)
+	if err != nil || token == "" {
+		return &locationConnectionResolver{}, err
+	}
+	limit := limitOrDefault(args.First)
+
+	locations, err := r.referencesInCommit(ctx, token, limit+1) // add 1 so we can determine PageInfo.hasNextPage
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) <= limit {
+		// Fill up the remaining space with references from other repositories.
+		fileMatches, err := searchFileMatchesOutsideRepo(ctx, r.commit.repo.repo.Name, fmt.Sprintf(`type:file case:yes count:%d \b%s\b`, limit+1-len(locations), regexp.QuoteMeta(token)), "file")
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, lineMatchLocations(fileMatches)...)
+	}
+	return &locationConnectionResolver{locations: locations, first: args.First}, nil
+}
+
+// tokenAt returns the token at the position given in args in this blob.
+func (r *gitTreeEntryResolver) tokenAt(ctx context.Context, args *codeIntelPositionArgs) (string, error) {
+	if r.IsDirectory() {
+		return "", errors.New("code intelligence is only available for files")
+	}
+	content, err := r.Content(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tokenAtPosition(content, int(args.Line), int(args.Character)), nil
+}
+
+// definitionsInCommit returns the locations of symbols named token in this blob's commit. Symbols
+// defined in this blob are listed first.
+func (r *gitTreeEntryResolver) definitionsInCommit(ctx context.Context, token string, limit int) (locations []*locationResolver, err error) {
+	ctx, done := context.WithTimeout(ctx, 5*time.Second)
+	defer done()
+	defer func() {
+		if ctx.Err() != nil && len(locations) == 0 {
+			err = errors.New("processing symbols is taking longer than expected. Try again in a while")
+		}
+	}()
+
+	commit := r.commit
+	symbols, err := backend.Symbols.ListTags(ctx, protocol.SearchArgs{
+		Repo:            commit.repo.repo.Name,
+		CommitID:        api.CommitID(commit.oid),
+		Query:           "^" + regexp.QuoteMeta(token) + "$",
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		First:           limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].Path == r.path && symbols[j].Path != r.path
+	})
+
+	baseURI, err := gituri.Parse("git://" + string(commit.repo.repo.Name) + "?" + string(commit.oid))
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		resolver := toSymbolResolver(symbolToLSPSymbolInformation(symbol, baseURI), strings.ToLower(symbol.Language), commit)
+		if resolver == nil {
+			continue
+		}
+		locations = append(locations, resolver.location)
+	}
+	return locations, nil
+}
+
+// referencesInCommit returns the locations of word-boundary matches of token in this blob's
+// commit.
+func (r *gitTreeEntryResolver) referencesInCommit(ctx context.Context, token string, limit int) ([]*locationResolver, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	commit := r.commit
+	gitserverRepo, err := backend.CachedGitRepo(ctx, commit.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	fileMatches, _, err := searchFilesInRepo(ctx, commit.repo.repo, *gitserverRepo, string(commit.oid), &search.PatternInfo{
+		Pattern:               token,
+		IsWordMatch:           true,
+		IsCaseSensitive:       true,
+		FileMatchLimit:        int32(limit),
+		PatternMatchesContent: true,
+	}, defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return lineMatchLocations(fileMatches), nil
+}
+
+// searchFileMatchesOutsideRepo runs the search query (restricted to the given result type) over
+// all repositories except repo and returns the file matches.
+func searchFileMatchesOutsideRepo(ctx context.Context, repo api.RepoName, queryString, resultType string) ([]*fileMatchResolver, error) {
+	q, err := query.ParseAndCheck(fmt.Sprintf("-repo:^%s$ %s", regexp.QuoteMeta(string(repo)), queryString))
+	if err != nil {
+		return nil, err
+	}
+	results, err := (&searchResolver{query: q}).doResults(ctx, resultType)
+	if err != nil {
+		return nil, err
+	}
+	var fileMatches []*fileMatchResolver
+	for _, result := range results.results {
+		if result.fileMatch != nil {
+			fileMatches = append(fileMatches, result.fileMatch)
+		}
+	}
+	return fileMatches, nil
+}
+
+// lineMatchLocations returns a location for each matched range in fileMatches.
+func lineMatchLocations(fileMatches []*fileMatchResolver) []*locationResolver {
+	var locations []*locationResolver
+	for _, fm := range fileMatches {
+		file := fm.File()
+		for _, lm := range fm.JLineMatches {
+			for _, offsetAndLength := range lm.JOffsetAndLengths {
+				line := int(lm.JLineNumber)
+				offset, length := int(offsetAndLength[0]), int(offsetAndLength[1])
+				locations = append(locations, &locationResolver{
+					resource: file,
+					lspRange: &lsp.Range{
+						Start: lsp.Position{Line: line, Character: offset},
+						End:   lsp.Position{Line: line, Character: offset + length},
+					},
+				})
+			}
+		}
+	}
+	return locations
+}
+
+// tokenAtPosition returns the identifier that contains the zero-based line and character position
+// in content, or the empty string if there is no identifier at the position.
+func tokenAtPosition(content string, line, character int) string {
+	lines := strings.Split(content, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	runes := []rune(lines[line])
+	if character < 0 || character > len(runes) {
+		return ""
+	}
+	start, end := character, character
+	for start > 0 && isIdentifierRune(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isIdentifierRune(runes[end]) {
+		end++
+	}
+	return string(runes[start:end])
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+type locationConnectionResolver struct {
+	first     *int32
+	locations []*locationResolver
+}
+
+func (r *locationConnectionResolver) Nodes(ctx context.Context) ([]*locationResolver, error) {
+	locations := r.locations
+	if len(locations) > limitOrDefault(r.first) {
+		locations = locations[:limitOrDefault(r.first)]
+	}
+	return locations, nil
+}
+
+func (r *locationConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return graphqlutil.HasNextPage(len(r.locations) > limitOrDefault(r.first)), nil
+}
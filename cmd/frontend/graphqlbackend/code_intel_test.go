@@ -0,0 +1,29 @@
+package graphqlbackend
+
+import "testing"
+
+func TestTokenAtPosition(t *testing.T) {
+	content := "package main\n\nfunc fooBar(x int) {\n\treturn x_1 + ölçü\n}\n"
+	tests := []struct {
+		line, character int
+		want            string
+	}{
+		{line: 0, character: 0, want: "package"},
+		{line: 0, character: 7, want: "package"},
+		{line: 0, character: 8, want: "main"},
+		{line: 1, character: 0, want: ""},
+		{line: 2, character: 7, want: "fooBar"},
+		{line: 2, character: 11, want: "fooBar"},
+		{line: 3, character: 9, want: "x_1"},
+		{line: 3, character: 14, want: "ölçü"},
+		{line: 3, character: 12, want: ""},
+		{line: 3, character: 100, want: ""},
+		{line: -1, character: 0, want: ""},
+		{line: 10, character: 0, want: ""},
+	}
+	for _, test := range tests {
+		if got := tokenAtPosition(content, test.line, test.character); got != test.want {
+			t.Errorf("line %d character %d: got %q, want %q", test.line, test.character, got, test.want)
+		}
+	}
+}
@@ -83,6 +83,74 @@ func (r *discussionCommentResolver) Reports(ctx context.Context) []string {
 	return r.c.Reports
 }
 
+func (r *discussionCommentResolver) ParentComment(ctx context.Context) (*discussionCommentResolver, error) {
+	if r.c.ParentCommentID == nil {
+		return nil, nil
+	}
+	parent, err := db.DiscussionComments.Get(ctx, *r.c.ParentCommentID)
+	if err != nil {
+		if _, ok := err.(*db.ErrCommentNotFound); ok {
+			// The parent comment was deleted.
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "DiscussionComments.Get")
+	}
+	return &discussionCommentResolver{c: parent}, nil
+}
+
+func (r *discussionCommentResolver) Replies(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionCommentsConnectionResolver {
+	// 🚨 SECURITY: Replies are comments in the same thread, so anyone with
+	// access to this comment also has access to them.
+	opt := &db.DiscussionCommentsListOptions{ThreadID: &r.c.ThreadID, ParentCommentID: &r.c.ID}
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	return &discussionCommentsConnectionResolver{opt: opt}
+}
+
+func (r *discussionCommentResolver) Reactions(ctx context.Context) ([]*discussionCommentReactionGroupResolver, error) {
+	reactions, err := db.DiscussionCommentReactions.List(ctx, r.c.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentReactions.List")
+	}
+	var viewerUserID int32
+	if currentUser, err := CurrentUser(ctx); err == nil && currentUser != nil {
+		viewerUserID = currentUser.user.ID
+	}
+	groups := make([]*discussionCommentReactionGroupResolver, 0, len(discussions.Reactions))
+	for _, reaction := range discussions.Reactions {
+		group := &discussionCommentReactionGroupResolver{reaction: reaction}
+		for _, re := range reactions {
+			if re.Reaction != reaction {
+				continue
+			}
+			group.userIDs = append(group.userIDs, re.UserID)
+			if re.UserID == viewerUserID {
+				group.viewerHasReacted = true
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (r *discussionCommentResolver) Revisions(ctx context.Context) ([]*discussionCommentRevisionResolver, error) {
+	// 🚨 SECURITY: Only site admins and the comment author can read the
+	// revisions of a comment.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.c.AuthorUserID); err != nil {
+		return []*discussionCommentRevisionResolver{}, nil
+	}
+	revisions, err := db.DiscussionCommentRevisions.List(ctx, r.c.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentRevisions.List")
+	}
+	l := make([]*discussionCommentRevisionResolver, 0, len(revisions))
+	for _, revision := range revisions {
+		l = append(l, &discussionCommentRevisionResolver{r: revision})
+	}
+	return l, nil
+}
+
 func (r *discussionCommentResolver) CanReport(ctx context.Context) bool {
 	if dc := conf.Get().Discussions; dc != nil && !dc.AbuseProtection {
 		return false
@@ -117,6 +185,65 @@ func (r *discussionCommentResolver) CanDelete(ctx context.Context) bool {
 	return true
 }
 
+type discussionCommentReactionGroupResolver struct {
+	reaction         string
+	userIDs          []int32
+	viewerHasReacted bool
+}
+
+func (r *discussionCommentReactionGroupResolver) Reaction() string { return r.reaction }
+
+func (r *discussionCommentReactionGroupResolver) Users(ctx context.Context) ([]*UserResolver, error) {
+	users := make([]*UserResolver, 0, len(r.userIDs))
+	for _, userID := range r.userIDs {
+		user, err := UserByIDInt32(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (r *discussionCommentReactionGroupResolver) TotalCount() int32 { return int32(len(r.userIDs)) }
+
+func (r *discussionCommentReactionGroupResolver) ViewerHasReacted() bool { return r.viewerHasReacted }
+
+// 🚨 SECURITY: When instantiating a discussionCommentRevisionResolver value,
+// the caller MUST check permissions.
+type discussionCommentRevisionResolver struct {
+	r *types.DiscussionCommentRevision
+}
+
+func (r *discussionCommentRevisionResolver) ID() graphql.ID {
+	return marshalDiscussionID(r.r.ID)
+}
+
+func (r *discussionCommentRevisionResolver) Author(ctx context.Context) (*UserResolver, error) {
+	return UserByIDInt32(ctx, r.r.AuthorUserID)
+}
+
+func (r *discussionCommentRevisionResolver) Contents() string { return r.r.Contents }
+
+func (r *discussionCommentRevisionResolver) HTML(ctx context.Context, args *struct{ Options *markdownOptions }) (string, error) {
+	return markdown.Render(r.r.Contents, nil)
+}
+
+func (r *discussionCommentRevisionResolver) CreatedAt() string {
+	return r.r.CreatedAt.Format(time.RFC3339)
+}
+
+func (r *discussionCommentRevisionResolver) Reports(ctx context.Context) []string {
+	// 🚨 SECURITY: Only site admins can read reports.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return []string{}
+	}
+	if dc := conf.Get().Discussions; dc != nil && !dc.AbuseProtection {
+		return []string{}
+	}
+	return r.r.Reports
+}
+
 func (*schemaResolver) DiscussionComments(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
 	AuthorUserID *graphql.ID
@@ -164,8 +291,9 @@ func checkSignedInAndEmailVerified(ctx context.Context) (*UserResolver, error) {
 }
 
 func (r *discussionsMutationResolver) AddCommentToThread(ctx context.Context, args *struct {
-	ThreadID graphql.ID
-	Contents string
+	ThreadID        graphql.ID
+	Contents        string
+	ParentCommentID *graphql.ID
 }) (*discussionThreadResolver, error) {
 	// 🚨 SECURITY: Only signed in users with a verified email may add comments
 	// to a discussion thread.
@@ -188,11 +316,20 @@ func (r *discussionsMutationResolver) AddCommentToThread(ctx context.Context, ar
 	if err != nil {
 		return nil, err
 	}
+	var parentCommentID *int64
+	if args.ParentCommentID != nil {
+		id, err := unmarshalDiscussionID(*args.ParentCommentID)
+		if err != nil {
+			return nil, err
+		}
+		parentCommentID = &id
+	}
 
 	updatedThread, err := discussions.InsecureAddCommentToThread(ctx, &types.DiscussionComment{
-		ThreadID:     threadID,
-		AuthorUserID: currentUser.user.ID,
-		Contents:     args.Contents,
+		ThreadID:        threadID,
+		AuthorUserID:    currentUser.user.ID,
+		Contents:        args.Contents,
+		ParentCommentID: parentCommentID,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "AddCommentToThread")
@@ -202,11 +339,12 @@ func (r *discussionsMutationResolver) AddCommentToThread(ctx context.Context, ar
 
 func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *struct {
 	Input *struct {
-		CommentID    graphql.ID
-		Contents     *string
-		Delete       *bool
-		Report       *string
-		ClearReports *bool
+		CommentID        graphql.ID
+		Contents         *string
+		Delete           *bool
+		Report           *string
+		ReportRevisionID *graphql.ID
+		ClearReports     *bool
 	}
 }) (*discussionThreadResolver, error) {
 	commentID, err := unmarshalDiscussionID(args.Input.CommentID)
@@ -251,6 +389,14 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 		newReport := fmt.Sprintf(`"%s"\n\nreported by @%s`, *args.Input.Report, currentUser.user.Username)
 		args.Input.Report = &newReport
 	}
+	var reportRevisionID *int64
+	if args.Input.ReportRevisionID != nil {
+		id, err := unmarshalDiscussionID(*args.Input.ReportRevisionID)
+		if err != nil {
+			return nil, err
+		}
+		reportRevisionID = &id
+	}
 
 	if args.Input.Contents != nil {
 		// 🚨 SECURITY: Only site admins and the comment author can update the contents.
@@ -274,10 +420,12 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 	threadID := comment.ThreadID
 
 	updatedComment, err := db.DiscussionComments.Update(ctx, commentID, &db.DiscussionCommentsUpdateOptions{
-		Contents:     args.Input.Contents,
-		Delete:       delete,
-		Report:       args.Input.Report,
-		ClearReports: clearReports,
+		Contents:         args.Input.Contents,
+		EditorUserID:     currentUser.user.ID,
+		Delete:           delete,
+		Report:           args.Input.Report,
+		ReportRevisionID: reportRevisionID,
+		ClearReports:     clearReports,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "DiscussionComments.Update")
@@ -296,6 +444,52 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 	return &discussionThreadResolver{t: thread}, nil
 }
 
+func (r *discussionsMutationResolver) AddReactionToComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+	Reaction  string
+}) (*discussionCommentResolver, error) {
+	return r.updateReaction(ctx, args.CommentID, args.Reaction, true)
+}
+
+func (r *discussionsMutationResolver) RemoveReactionFromComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+	Reaction  string
+}) (*discussionCommentResolver, error) {
+	return r.updateReaction(ctx, args.CommentID, args.Reaction, false)
+}
+
+func (r *discussionsMutationResolver) updateReaction(ctx context.Context, id graphql.ID, reaction string, add bool) (*discussionCommentResolver, error) {
+	// 🚨 SECURITY: Only signed in users may react to a discussion comment.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no current user")
+	}
+	if !discussions.IsValidReaction(reaction) {
+		return nil, fmt.Errorf("invalid reaction %q (must be one of %s)", reaction, strings.Join(discussions.Reactions, " "))
+	}
+
+	commentID, err := unmarshalDiscussionID(id)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := db.DiscussionComments.Get(ctx, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.Get")
+	}
+	if add {
+		err = db.DiscussionCommentReactions.Add(ctx, comment.ID, currentUser.user.ID, reaction)
+	} else {
+		err = db.DiscussionCommentReactions.Remove(ctx, comment.ID, currentUser.user.ID, reaction)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentReactions")
+	}
+	return &discussionCommentResolver{c: comment}, nil
+}
+
 // discussionCommentsConnectionResolver resolves a list of discussion comments.
 //
 // 🚨 SECURITY: When instantiating an discussionCommentsConnectionResolver
@@ -0,0 +1,180 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func (r *discussionsMutationResolver) WatchThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadSubscription(ctx, args.ThreadID, false)
+}
+
+func (r *discussionsMutationResolver) UnwatchThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadSubscription(ctx, args.ThreadID, true)
+}
+
+func (r *discussionsMutationResolver) setThreadSubscription(ctx context.Context, id graphql.ID, mute bool) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only signed in users may watch or unwatch a discussion thread.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no current user")
+	}
+
+	threadID, err := unmarshalDiscussionID(id)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	if mute {
+		err = db.DiscussionSubscriptions.MuteThread(ctx, currentUser.user.ID, thread.ID)
+	} else {
+		err = db.DiscussionSubscriptions.WatchThread(ctx, currentUser.user.ID, thread.ID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionSubscriptions")
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) WatchRepository(ctx context.Context, args *struct {
+	Repository graphql.ID
+	PathPrefix *string
+}) (*EmptyResponse, error) {
+	return r.setRepositorySubscription(ctx, args.Repository, args.PathPrefix, true)
+}
+
+func (r *discussionsMutationResolver) UnwatchRepository(ctx context.Context, args *struct {
+	Repository graphql.ID
+	PathPrefix *string
+}) (*EmptyResponse, error) {
+	return r.setRepositorySubscription(ctx, args.Repository, args.PathPrefix, false)
+}
+
+func (r *discussionsMutationResolver) setRepositorySubscription(ctx context.Context, id graphql.ID, pathPrefix *string, watch bool) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only signed in users may watch or unwatch a repository.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no current user")
+	}
+
+	repoID, err := unmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := repositoryByIDInt32(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	if pathPrefix != nil {
+		path = *pathPrefix
+	}
+	if watch {
+		err = db.DiscussionSubscriptions.WatchRepo(ctx, currentUser.user.ID, repo.repo.ID, path)
+	} else {
+		err = db.DiscussionSubscriptions.UnwatchRepo(ctx, currentUser.user.ID, repo.repo.ID, path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionSubscriptions")
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (d *discussionThreadResolver) ViewerSubscription(ctx context.Context) (string, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if currentUser == nil {
+		return "NOT_WATCHING", nil
+	}
+
+	watching, muted, err := db.DiscussionSubscriptions.Subscribers(ctx, d.t)
+	if err != nil {
+		return "", err
+	}
+	for _, userID := range muted {
+		if userID == currentUser.user.ID {
+			return "MUTED", nil
+		}
+	}
+	for _, userID := range watching {
+		if userID == currentUser.user.ID {
+			return "WATCHING", nil
+		}
+	}
+	return "NOT_WATCHING", nil
+}
+
+func (r *UserResolver) DiscussionSubscriptions(ctx context.Context) ([]*discussionSubscriptionResolver, error) {
+	// 🚨 SECURITY: Only the user and site admins can list the user's discussion subscriptions.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := db.DiscussionSubscriptions.List(ctx, &db.DiscussionSubscriptionsListOptions{UserID: &r.user.ID})
+	if err != nil {
+		return nil, err
+	}
+	rs := make([]*discussionSubscriptionResolver, len(subscriptions))
+	for i, s := range subscriptions {
+		rs[i] = &discussionSubscriptionResolver{s: s}
+	}
+	return rs, nil
+}
+
+// 🚨 SECURITY: When instantiating a discussionSubscriptionResolver value, the
+// caller MUST check permissions.
+type discussionSubscriptionResolver struct {
+	s *types.DiscussionSubscription
+}
+
+func (r *discussionSubscriptionResolver) Thread(ctx context.Context) (*discussionThreadResolver, error) {
+	if r.s.ThreadID == nil {
+		return nil, nil
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, *r.s.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionSubscriptionResolver) Repository(ctx context.Context) (*repositoryResolver, error) {
+	if r.s.RepoID == nil {
+		return nil, nil
+	}
+	return repositoryByIDInt32(ctx, *r.s.RepoID)
+}
+
+func (r *discussionSubscriptionResolver) PathPrefix() *string {
+	if r.s.RepoID == nil {
+		return nil
+	}
+	return &r.s.PathPrefix
+}
+
+func (r *discussionSubscriptionResolver) Muted() bool { return r.s.Muted }
+
+func (r *discussionSubscriptionResolver) CreatedAt() string {
+	return r.s.CreatedAt.Format(time.RFC3339)
+}
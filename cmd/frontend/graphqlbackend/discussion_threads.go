@@ -85,7 +85,9 @@ type discussionThreadTargetRepoInput struct {
 	Selection             *discussionThreadTargetRepoSelectionInput
 }
 
-func (d *discussionThreadTargetRepoInput) convert(ctx context.Context) (*types.DiscussionThreadTargetRepo, error) {
+// resolveRepository resolves the repository given by the input's repository ID, name, or git
+// clone URL (exactly one of which must be specified).
+func (d *discussionThreadTargetRepoInput) resolveRepository(ctx context.Context) (*repositoryResolver, error) {
 	count := 0
 	if d.RepositoryID != nil {
 		count++
@@ -99,7 +101,11 @@ func (d *discussionThreadTargetRepoInput) convert(ctx context.Context) (*types.D
 	if count != 1 {
 		return nil, errors.New("exactly one of repositoryID, repositoryName, or repositoryGitCloneURL must be specified")
 	}
-	repo, err := discussionsResolveRepository(ctx, d.RepositoryID, d.RepositoryName, d.RepositoryGitCloneURL)
+	return discussionsResolveRepository(ctx, d.RepositoryID, d.RepositoryName, d.RepositoryGitCloneURL)
+}
+
+func (d *discussionThreadTargetRepoInput) convert(ctx context.Context) (*types.DiscussionThreadTargetRepo, error) {
+	repo, err := d.resolveRepository(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -203,9 +209,10 @@ func (d *discussionThreadTargetRepoInput) populateLinesFromRepository(ctx contex
 
 func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *struct {
 	Input *struct {
-		Title      *string
-		Contents   string
-		TargetRepo *discussionThreadTargetRepoInput
+		Title          *string
+		Contents       string
+		TargetRepo     *discussionThreadTargetRepoInput
+		TargetRepoDiff *discussionThreadTargetRepoDiffInput
 	}
 }) (*discussionThreadResolver, error) {
 	if args.Input.Title == nil {
@@ -239,7 +246,10 @@ func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *st
 		AuthorUserID: currentUser.user.ID,
 		Title:        *args.Input.Title,
 	}
-	if args.Input.TargetRepo != nil {
+	switch {
+	case args.Input.TargetRepo != nil && args.Input.TargetRepoDiff != nil:
+		return nil, errors.New("only one of targetRepo or targetRepoDiff can be specified")
+	case args.Input.TargetRepo != nil:
 		if err := args.Input.TargetRepo.validate(); err != nil {
 			return nil, err
 		}
@@ -247,6 +257,11 @@ func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *st
 		if err != nil {
 			return nil, err
 		}
+	case args.Input.TargetRepoDiff != nil:
+		newThread.TargetRepo, err = args.Input.TargetRepoDiff.convert(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 	thread, err := db.DiscussionThreads.Create(ctx, newThread)
 	if err != nil {
@@ -269,9 +284,12 @@ func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *st
 
 func (r *discussionsMutationResolver) UpdateThread(ctx context.Context, args *struct {
 	Input *struct {
-		ThreadID graphql.ID
-		Archive  *bool
-		Delete   *bool
+		ThreadID  graphql.ID
+		Archive   *bool
+		Delete    *bool
+		Resolve   *bool
+		Labels    *[]string
+		Assignees *[]graphql.ID
 	}
 }) (*discussionThreadResolver, error) {
 	// 🚨 SECURITY: Only signed in users may update a discussion thread.
@@ -296,9 +314,31 @@ func (r *discussionsMutationResolver) UpdateThread(ctx context.Context, args *st
 	if err != nil {
 		return nil, err
 	}
+	var assigneeUserIDs *[]int32
+	if args.Input.Assignees != nil {
+		userIDs := []int32{}
+		for _, id := range *args.Input.Assignees {
+			userID, err := UnmarshalUserID(id)
+			if err != nil {
+				return nil, err
+			}
+			userIDs = append(userIDs, userID)
+		}
+		assigneeUserIDs = &userIDs
+	}
+
+	// Get the thread as it was before the update, so that we know whom to
+	// notify about status and assignment changes.
+	oldThread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
 	thread, err := db.DiscussionThreads.Update(ctx, threadID, &db.DiscussionThreadsUpdateOptions{
-		Archive: args.Input.Archive,
-		Delete:  delete,
+		Archive:         args.Input.Archive,
+		Delete:          delete,
+		Resolve:         args.Input.Resolve,
+		Labels:          args.Input.Labels,
+		AssigneeUserIDs: assigneeUserIDs,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "DiscussionThreads.Update")
@@ -307,9 +347,30 @@ func (r *discussionsMutationResolver) UpdateThread(ctx context.Context, args *st
 		// deleted
 		return nil, nil
 	}
+	if (oldThread.ResolvedAt == nil) != (thread.ResolvedAt == nil) {
+		discussions.NotifyThreadStatusChanged(thread, currentUser.user.ID)
+	}
+	if newAssignees := newAssigneeUserIDs(oldThread, thread); len(newAssignees) > 0 {
+		discussions.NotifyThreadAssigned(thread, currentUser.user.ID, newAssignees)
+	}
 	return &discussionThreadResolver{t: thread}, nil
 }
 
+// newAssigneeUserIDs returns the users that updatedThread is assigned to but
+// oldThread was not.
+func newAssigneeUserIDs(oldThread, updatedThread *types.DiscussionThread) (userIDs []int32) {
+	old := make(map[int32]struct{}, len(oldThread.AssigneeUserIDs))
+	for _, userID := range oldThread.AssigneeUserIDs {
+		old[userID] = struct{}{}
+	}
+	for _, userID := range updatedThread.AssigneeUserIDs {
+		if _, ok := old[userID]; !ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs
+}
+
 func (*schemaResolver) Discussions(ctx context.Context) (*discussionsMutationResolver, error) {
 	if err := viewerCanUseDiscussions(ctx); err != nil {
 		return nil, err
@@ -319,13 +380,16 @@ func (*schemaResolver) Discussions(ctx context.Context) (*discussionsMutationRes
 
 func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
-	Query                       *string
-	ThreadID                    *graphql.ID
-	AuthorUserID                *graphql.ID
-	TargetRepositoryID          *graphql.ID
-	TargetRepositoryName        *string
-	TargetRepositoryGitCloneURL *string
-	TargetRepositoryPath        *string
+	Query                        *string
+	ThreadID                     *graphql.ID
+	AuthorUserID                 *graphql.ID
+	TargetRepositoryID           *graphql.ID
+	TargetRepositoryName         *string
+	TargetRepositoryGitCloneURL  *string
+	TargetRepositoryPath         *string
+	TargetRepositoryDiff         *bool
+	TargetRepositoryBaseRevision *string
+	TargetRepositoryHeadRevision *string
 }) (*discussionThreadsConnectionResolver, error) {
 	if err := viewerCanUseDiscussions(ctx); err != nil {
 		return nil, err
@@ -336,7 +400,10 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 	// GraphQL API) is private.
 
 	opt := &db.DiscussionThreadsListOptions{
-		TargetRepoPath: args.TargetRepositoryPath,
+		TargetRepoPath:         args.TargetRepositoryPath,
+		TargetRepoDiff:         args.TargetRepositoryDiff,
+		TargetRepoBaseRevision: args.TargetRepositoryBaseRevision,
+		TargetRepoHeadRevision: args.TargetRepositoryHeadRevision,
 	}
 	if args.Query != nil {
 		opt.SetFromQuery(ctx, *args.Query)
@@ -593,7 +660,12 @@ func (r *discussionThreadTargetRepoResolver) RelativeSelection(ctx context.Conte
 	if r.t.Revision != nil && *r.t.Revision == string(commit.OID()) {
 		return oldSel, nil // nothing to do (requested relative revision is identical to the stored revision)
 	}
-	if r.t.Branch != nil {
+	// The selection of a thread on a diff is tracked from its stored (head)
+	// revision, because the head branch may have moved since. For other
+	// threads, the selection is assumed to be at the branch's current
+	// revision.
+	onDiff := (r.t.BaseBranch != nil || r.t.BaseRevision != nil) && r.t.Revision != nil
+	if r.t.Branch != nil && !onDiff {
 		branchCommit, err := repo.Commit(ctx, &repositoryCommitArgs{Rev: *r.t.Branch})
 		if err != nil {
 			return nil, err
@@ -618,12 +690,19 @@ type discussionThreadTargetResolver struct {
 }
 
 func (r *discussionThreadTargetResolver) ToDiscussionThreadTargetRepo() (*discussionThreadTargetRepoResolver, bool) {
-	if r.t.TargetRepo == nil {
+	if r.t.TargetRepo == nil || r.t.TargetRepo.IsDiff() {
 		return nil, false
 	}
 	return &discussionThreadTargetRepoResolver{t: r.t.TargetRepo}, true
 }
 
+func (r *discussionThreadTargetResolver) ToDiscussionThreadTargetRepoDiff() (*discussionThreadTargetRepoDiffResolver, bool) {
+	if r.t.TargetRepo == nil || !r.t.TargetRepo.IsDiff() {
+		return nil, false
+	}
+	return &discussionThreadTargetRepoDiffResolver{&discussionThreadTargetRepoResolver{t: r.t.TargetRepo}}, true
+}
+
 // 🚨 SECURITY: When instantiating an discussionThreadResolver value, the
 // caller MUST check permissions.
 type discussionThreadResolver struct {
@@ -667,6 +746,39 @@ func (d *discussionThreadResolver) ArchivedAt(ctx context.Context) *string {
 	return strptr(d.t.ArchivedAt.Format(time.RFC3339))
 }
 
+func (d *discussionThreadResolver) Status() string {
+	if d.t.ResolvedAt != nil {
+		return "RESOLVED"
+	}
+	return "OPEN"
+}
+
+func (d *discussionThreadResolver) ResolvedAt(ctx context.Context) *string {
+	if d.t.ResolvedAt == nil {
+		return nil
+	}
+	return strptr(d.t.ResolvedAt.Format(time.RFC3339))
+}
+
+func (d *discussionThreadResolver) Assignees(ctx context.Context) ([]*UserResolver, error) {
+	assignees := make([]*UserResolver, 0, len(d.t.AssigneeUserIDs))
+	for _, userID := range d.t.AssigneeUserIDs {
+		user, err := UserByIDInt32(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		assignees = append(assignees, user)
+	}
+	return assignees, nil
+}
+
+func (d *discussionThreadResolver) Labels() []string {
+	if d.t.Labels == nil {
+		return []string{}
+	}
+	return d.t.Labels
+}
+
 func (d *discussionThreadResolver) Comments(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
 }) *discussionCommentsConnectionResolver {
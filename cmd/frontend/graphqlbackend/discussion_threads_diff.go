@@ -0,0 +1,125 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
+)
+
+type discussionThreadTargetRepoDiffInput struct {
+	RepositoryID          *graphql.ID
+	RepositoryName        *string
+	RepositoryGitCloneURL *string
+	Base                  string
+	Head                  string
+	Path                  *string
+	Selection             *discussionThreadTargetRepoSelectionInput
+}
+
+func (d *discussionThreadTargetRepoDiffInput) convert(ctx context.Context) (*types.DiscussionThreadTargetRepo, error) {
+	// The diff target is stored like a repo target on the head, plus the base.
+	head := &discussionThreadTargetRepoInput{
+		RepositoryID:          d.RepositoryID,
+		RepositoryName:        d.RepositoryName,
+		RepositoryGitCloneURL: d.RepositoryGitCloneURL,
+		Path:                  d.Path,
+		Selection:             d.Selection,
+	}
+	repo, err := head.resolveRepository(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseBranch, baseRevision, err := discussionsResolveRevision(ctx, repo, d.Base)
+	if err != nil {
+		return nil, err
+	}
+	head.Branch, head.Revision, err = discussionsResolveRevision(ctx, repo, d.Head)
+	if err != nil {
+		return nil, err
+	}
+	if err := head.validate(); err != nil {
+		return nil, err
+	}
+	tr, err := head.convert(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tr.BaseBranch = baseBranch
+	tr.BaseRevision = baseRevision
+	return tr, nil
+}
+
+// discussionsResolveRevision resolves the Git revision specifier to an exact revision. If the
+// specifier is not an exact revision itself (e.g. a branch), it is returned as the branch.
+func discussionsResolveRevision(ctx context.Context, repo *repositoryResolver, rev string) (branch, revision *string, err error) {
+	commit, err := repo.Commit(ctx, &repositoryCommitArgs{Rev: rev})
+	if err != nil {
+		return nil, nil, err
+	}
+	if commit == nil {
+		return nil, nil, fmt.Errorf("revision not found: %s", rev)
+	}
+	oid := string(commit.OID())
+	if !git.IsAbsoluteRevision(rev) {
+		branch = &rev
+	}
+	return branch, &oid, nil
+}
+
+// discussionThreadTargetRepoDiffResolver resolves a repo target on a diff. The head of the diff
+// is stored as the branch and revision of the repo target.
+type discussionThreadTargetRepoDiffResolver struct {
+	*discussionThreadTargetRepoResolver
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) BaseBranch(ctx context.Context) (*gitRefResolver, error) {
+	return r.branchOrRevision(ctx, r.t.BaseBranch)
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) BaseRevision(ctx context.Context) (*gitRefResolver, error) {
+	return r.branchOrRevision(ctx, r.t.BaseRevision)
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) HeadBranch(ctx context.Context) (*gitRefResolver, error) {
+	return r.branchOrRevision(ctx, r.t.Branch)
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) HeadRevision(ctx context.Context) (*gitRefResolver, error) {
+	return r.branchOrRevision(ctx, r.t.Revision)
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) Comparison(ctx context.Context) (*repositoryComparisonResolver, error) {
+	repo, err := repositoryByIDInt32(ctx, r.t.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Comparison(ctx, &repositoryComparisonInput{Base: r.t.BaseRevision, Head: r.t.Revision})
+}
+
+// currentHead returns the given revision specifier, or else the current head of the diff (the
+// head branch, if any, so that the thread is tracked as the branch moves).
+func (r *discussionThreadTargetRepoDiffResolver) currentHead(rev *string) string {
+	switch {
+	case rev != nil:
+		return *rev
+	case r.t.Branch != nil:
+		return *r.t.Branch
+	default:
+		return *r.t.Revision
+	}
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) RelativePath(ctx context.Context, args *struct {
+	Rev *string
+}) (*string, error) {
+	return r.discussionThreadTargetRepoResolver.RelativePath(ctx, &struct{ Rev string }{Rev: r.currentHead(args.Rev)})
+}
+
+func (r *discussionThreadTargetRepoDiffResolver) RelativeSelection(ctx context.Context, args *struct {
+	Rev *string
+}) (*discussionSelectionRangeResolver, error) {
+	return r.discussionThreadTargetRepoResolver.RelativeSelection(ctx, &struct{ Rev string }{Rev: r.currentHead(args.Rev)})
+}
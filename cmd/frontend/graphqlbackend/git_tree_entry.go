@@ -138,6 +138,38 @@ func reposourceCloneURLToRepoName(ctx context.Context, cloneURL string) (repoNam
 		repoSources = append(repoSources, reposource.BitbucketServer{BitbucketServerConnection: c})
 	}
 
+	bitbucketClouds, err := db.ExternalServices.ListBitbucketCloudConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range bitbucketClouds {
+		repoSources = append(repoSources, reposource.BitbucketCloud{BitbucketCloudConnection: c})
+	}
+
+	gerrits, err := db.ExternalServices.ListGerritConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range gerrits {
+		repoSources = append(repoSources, reposource.Gerrit{GerritConnection: c})
+	}
+
+	giteas, err := db.ExternalServices.ListGiteaConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range giteas {
+		repoSources = append(repoSources, reposource.Gitea{GiteaConnection: c})
+	}
+
+	azureDevOpses, err := db.ExternalServices.ListAzureDevOpsConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range azureDevOpses {
+		repoSources = append(repoSources, reposource.AzureDevOps{AzureDevOpsConnection: c})
+	}
+
 	awscodecommits, err := db.ExternalServices.ListAWSCodeCommitConnections(ctx)
 	if err != nil {
 		return "", err
@@ -0,0 +1,134 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	lsp "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/lsif"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
+)
+
+// maxLSIFCommitDistance is the number of ancestors of a commit that are searched for an uploaded
+// LSIF dump if the commit itself has none.
+const maxLSIFCommitDistance = 100
+
+func (r *gitTreeEntryResolver) LSIF(ctx context.Context) (*lsifBlobResolver, error) {
+	if r.IsDirectory() {
+		return nil, nil
+	}
+	dump, commit, err := nearestLSIFDump(ctx, r.commit)
+	if err != nil || dump == nil {
+		return nil, err
+	}
+	document, err := db.LSIFDumps.GetDocument(ctx, dump.ID, r.path)
+	if err != nil || document == nil {
+		return nil, err
+	}
+	return &lsifBlobResolver{commit: commit, path: r.path, document: document}, nil
+}
+
+// nearestLSIFDump returns the LSIF dump of the commit, or of its nearest ancestor that has one, and
+// the commit of the dump. If there is no such dump, it returns nil.
+func nearestLSIFDump(ctx context.Context, commit *gitCommitResolver) (*types.LSIFDump, *gitCommitResolver, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, commit.repo.repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	commits, err := git.Commits(ctx, *cachedRepo, git.CommitsOptions{Range: string(commit.oid), N: maxLSIFCommitDistance})
+	if err != nil {
+		return nil, nil, err
+	}
+	commitIDs := make([]api.CommitID, len(commits))
+	for i, c := range commits {
+		commitIDs[i] = c.ID
+	}
+	dumps, err := db.LSIFDumps.ListByCommits(ctx, commit.repo.repo.ID, commitIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	dumpsByCommit := make(map[api.CommitID]*types.LSIFDump, len(dumps))
+	for _, dump := range dumps {
+		dumpsByCommit[dump.CommitID] = dump
+	}
+
+	// Commits are listed starting at the commit itself, so the first one with a dump is the
+	// nearest.
+	for _, c := range commits {
+		dump, ok := dumpsByCommit[c.ID]
+		if !ok {
+			continue
+		}
+		if c.ID == api.CommitID(commit.oid) {
+			return dump, commit, nil
+		}
+		return dump, toGitCommitResolver(commit.repo, c), nil
+	}
+	return nil, nil, nil
+}
+
+// lsifBlobResolver resolves the GraphQL type LSIFBlob, which answers code intelligence queries for
+// a file from the data of an uploaded LSIF dump.
+type lsifBlobResolver struct {
+	commit   *gitCommitResolver // the commit of the dump
+	path     string
+	document *lsif.Document
+}
+
+type lsifHoverArgs struct {
+	Line      int32
+	Character int32
+}
+
+func (r *lsifBlobResolver) Commit() *gitCommitResolver { return r.commit }
+
+func (r *lsifBlobResolver) Definitions(args *codeIntelPositionArgs) *locationConnectionResolver {
+	var locations []lsif.Location
+	if rng := r.rangeAt(args.Line, args.Character); rng != nil {
+		locations = r.document.Definitions(rng)
+	}
+	return r.locationConnection(locations, args.First)
+}
+
+func (r *lsifBlobResolver) References(args *codeIntelPositionArgs) *locationConnectionResolver {
+	var locations []lsif.Location
+	if rng := r.rangeAt(args.Line, args.Character); rng != nil {
+		locations = r.document.References(rng)
+	}
+	return r.locationConnection(locations, args.First)
+}
+
+func (r *lsifBlobResolver) Hover(args *lsifHoverArgs) *markdownResolver {
+	rng := r.rangeAt(args.Line, args.Character)
+	if rng == nil {
+		return nil
+	}
+	contents := r.document.Hover(rng)
+	if contents == "" {
+		return nil
+	}
+	return &markdownResolver{text: contents}
+}
+
+func (r *lsifBlobResolver) rangeAt(line, character int32) *lsif.Range {
+	return r.document.RangeAt(lsp.Position{Line: int(line), Character: int(character)})
+}
+
+func (r *lsifBlobResolver) locationConnection(locations []lsif.Location, first *int32) *locationConnectionResolver {
+	resolvers := make([]*locationResolver, len(locations))
+	for i, location := range locations {
+		lspRange := location.Range // copy
+		resolvers[i] = &locationResolver{
+			resource: &gitTreeEntryResolver{
+				commit: r.commit,
+				path:   location.Path,
+				stat:   createFileInfo(location.Path, false),
+			},
+			lspRange: &lspRange,
+		}
+	}
+	return &locationConnectionResolver{locations: resolvers, first: first}
+}
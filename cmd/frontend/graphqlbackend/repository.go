@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -170,6 +171,32 @@ func (r *repositoryResolver) Language() string {
 
 func (r *repositoryResolver) Enabled() bool { return r.repo.Enabled }
 
+func (r *repositoryResolver) IsArchived() bool { return r.repo.Archived }
+
+func (r *repositoryResolver) Topics() []string {
+	if r.repo.Topics == nil {
+		return []string{}
+	}
+	return r.repo.Topics
+}
+
+func (r *repositoryResolver) Stars() int32 { return int32(r.repo.Stars) }
+
+func (r *repositoryResolver) ExternalDefaultBranch() *string {
+	if r.repo.DefaultBranch == "" {
+		return nil
+	}
+	return &r.repo.DefaultBranch
+}
+
+func (r *repositoryResolver) Visibility() *string {
+	if r.repo.Visibility == "" {
+		return nil
+	}
+	v := strings.ToUpper(r.repo.Visibility)
+	return &v
+}
+
 func (r *repositoryResolver) CreatedAt() string {
 	return r.repo.CreatedAt.Format(time.RFC3339)
 }
@@ -0,0 +1,103 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+)
+
+func (r *schemaResolver) SetRepositoryPermissions(ctx context.Context, args *struct {
+	Input *struct {
+		Repositories       *[]string
+		RepositoryPatterns *[]string
+		Users              *[]string
+		Organizations      *[]string
+	}
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may set repository permissions, because they determine which
+	// users can read which repositories.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repoIDs, err := repositoryIDsForPermissions(ctx, args.Input.Repositories, args.Input.RepositoryPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []int32
+	if args.Input.Users != nil {
+		for _, username := range *args.Input.Users {
+			user, err := db.Users.GetByUsername(ctx, username)
+			if errcode.IsNotFound(err) && strings.Contains(username, "@") {
+				user, err = db.Users.GetByVerifiedEmail(ctx, username)
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "user %q", username)
+			}
+			userIDs = append(userIDs, user.ID)
+		}
+	}
+
+	var orgIDs []int32
+	if args.Input.Organizations != nil {
+		for _, name := range *args.Input.Organizations {
+			org, err := db.Orgs.GetByName(ctx, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "organization %q", name)
+			}
+			orgIDs = append(orgIDs, org.ID)
+		}
+	}
+
+	if err := db.RepoPermissions.Set(ctx, repoIDs, authz.Read, userIDs, orgIDs); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// repositoryIDsForPermissions returns the IDs of the repositories with the given names and of all
+// repositories whose name matches any of the given patterns. It is an error if a named repository
+// does not exist.
+func repositoryIDsForPermissions(ctx context.Context, names, patterns *[]string) ([]api.RepoID, error) {
+	seen := map[api.RepoID]struct{}{}
+	var repoIDs []api.RepoID
+	add := func(id api.RepoID) {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			repoIDs = append(repoIDs, id)
+		}
+	}
+
+	if names != nil {
+		for _, name := range *names {
+			repo, err := db.Repos.GetByName(ctx, api.RepoName(name))
+			if err != nil {
+				return nil, errors.Wrapf(err, "repository %q", name)
+			}
+			add(repo.ID)
+		}
+	}
+	if patterns != nil {
+		for _, pattern := range *patterns {
+			repos, err := db.Repos.List(ctx, db.ReposListOptions{
+				IncludePatterns: []string{pattern},
+				Enabled:         true,
+				Disabled:        true,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "repository pattern %q", pattern)
+			}
+			for _, repo := range repos {
+				add(repo.ID)
+			}
+		}
+	}
+	return repoIDs, nil
+}
@@ -8,6 +8,7 @@ import (
 	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/sourcegraph/jsonx"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/query-runner/queryrunnerapi"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/conf"
@@ -332,3 +333,33 @@ func (r *schemaResolver) SendSavedSearchTestNotification(ctx context.Context, ar
 	go queryrunnerapi.Client.TestNotification(context.Background(), spec)
 	return &EmptyResponse{}, nil
 }
+
+func (r *schemaResolver) SetWebhookSecret(ctx context.Context, args *struct {
+	Subject graphql.ID
+	Secret  *string
+}) (*EmptyResponse, error) {
+	subject, err := settingsSubjectByID(ctx, args.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if subject.user == nil && subject.org == nil {
+		return nil, errors.New("webhook secrets can only be set for users and organizations")
+	}
+
+	// 🚨 SECURITY: Check whether the viewer can administer this subject (which is equivalent to
+	// being able to mutate its settings). There is no way to read the secret back.
+	if canAdmin, err := subject.ViewerCanAdminister(ctx); err != nil {
+		return nil, err
+	} else if !canAdmin {
+		return nil, errors.New("viewer is not allowed to set the webhook secret")
+	}
+
+	var secret string
+	if args.Secret != nil {
+		secret = *args.Secret
+	}
+	if err := db.WebhookSecrets.Set(ctx, subject.toSubject(), secret); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
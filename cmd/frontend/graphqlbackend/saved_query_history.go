@@ -0,0 +1,144 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+)
+
+// defaultSavedQueryHistoryFirst is the number of executions and notifications of a saved query
+// that are returned if no limit is given.
+const defaultSavedQueryHistoryFirst = 20
+
+func (r savedQueryResolver) spec() (api.SavedQueryIDSpec, bool) {
+	if r.subject.defaultSettings != nil {
+		// Saved queries in the default settings are never run.
+		return api.SavedQueryIDSpec{}, false
+	}
+	return api.SavedQueryIDSpec{Subject: r.subject.toSubject(), Key: r.key}, true
+}
+
+func (r savedQueryResolver) Executions(ctx context.Context, args *struct{ First *int32 }) ([]*savedQueryExecutionResolver, error) {
+	spec, ok := r.spec()
+	if !ok {
+		return nil, nil
+	}
+	first := defaultSavedQueryHistoryFirst
+	if args.First != nil {
+		first = int(*args.First)
+	}
+	executions, err := db.SavedQueryExecutions.List(ctx, spec, first)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedQueryExecutionResolver, len(executions))
+	for i, e := range executions {
+		resolvers[i] = &savedQueryExecutionResolver{e: e}
+	}
+	return resolvers, nil
+}
+
+func (r savedQueryResolver) NotificationDeliveries(ctx context.Context, args *struct{ First *int32 }) ([]*savedQueryNotificationDeliveryResolver, error) {
+	spec, ok := r.spec()
+	if !ok {
+		return nil, nil
+	}
+	first := defaultSavedQueryHistoryFirst
+	if args.First != nil {
+		first = int(*args.First)
+	}
+	notifications, err := db.SavedQueryNotifications.List(ctx, spec, first)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedQueryNotificationDeliveryResolver, len(notifications))
+	for i, n := range notifications {
+		resolvers[i] = &savedQueryNotificationDeliveryResolver{n: n}
+	}
+	return resolvers, nil
+}
+
+type savedQueryExecutionResolver struct {
+	e *api.SavedQueryExecution
+}
+
+func (r *savedQueryExecutionResolver) Query() string { return r.e.Query }
+
+func (r *savedQueryExecutionResolver) ExecutedAt() string {
+	return r.e.ExecutedAt.Format(time.RFC3339)
+}
+
+func (r *savedQueryExecutionResolver) DurationMilliseconds() int32 {
+	return int32(r.e.Duration / time.Millisecond)
+}
+
+func (r *savedQueryExecutionResolver) ResultCount() int32 { return int32(r.e.ResultCount) }
+
+func (r *savedQueryExecutionResolver) Error() *string {
+	if r.e.Error == "" {
+		return nil
+	}
+	return &r.e.Error
+}
+
+type savedQueryNotificationDeliveryResolver struct {
+	n *api.SavedQueryNotification
+}
+
+func (r *savedQueryNotificationDeliveryResolver) Channel() string {
+	return strings.ToUpper(r.n.Channel)
+}
+
+func (r *savedQueryNotificationDeliveryResolver) Recipient(ctx context.Context) (*settingsSubject, error) {
+	switch {
+	case r.n.Recipient.User != nil:
+		user, err := UserByIDInt32(ctx, *r.n.Recipient.User)
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &settingsSubject{user: user}, nil
+	case r.n.Recipient.Org != nil:
+		org, err := OrgByIDInt32(ctx, *r.n.Recipient.Org)
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &settingsSubject{org: org}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r *savedQueryNotificationDeliveryResolver) State() string {
+	return strings.ToUpper(r.n.State)
+}
+
+func (r *savedQueryNotificationDeliveryResolver) Attempts() int32 { return int32(r.n.Attempts) }
+
+func (r *savedQueryNotificationDeliveryResolver) LastError() *string {
+	if r.n.LastError == "" {
+		return nil
+	}
+	return &r.n.LastError
+}
+
+func (r *savedQueryNotificationDeliveryResolver) CreatedAt() string {
+	return r.n.CreatedAt.Format(time.RFC3339)
+}
+
+func (r *savedQueryNotificationDeliveryResolver) SentAt() *string {
+	if r.n.SentAt == nil {
+		return nil
+	}
+	s := r.n.SentAt.Format(time.RFC3339)
+	return &s
+}
@@ -52,6 +52,17 @@ type Mutation {
     #
     # Only site admins may perform this mutation.
     setRepositoryEnabled(repository: ID!, enabled: Boolean!): EmptyResponse
+    # Sets which users and organizations may read the given repositories, replacing any permissions
+    # previously set with this mutation. A repository with explicit permissions is readable only by
+    # site admins, the given users and the members of the given organizations, regardless of the
+    # permissions reported by its code host. Setting no users and no organizations removes the
+    # explicit permissions of the repositories.
+    #
+    # This is intended for code hosts that Sourcegraph can't read permissions from, such as Gitolite
+    # or plain Git hosts.
+    #
+    # Only site admins may perform this mutation.
+    setRepositoryPermissions(input: SetRepositoryPermissionsInput!): EmptyResponse!
     # Enables or disables all site repositories.
     #
     # Only site admins may perform this mutation.
@@ -254,6 +265,16 @@ type Mutation {
         # ID of the saved search.
         id: ID!
     ): EmptyResponse
+    # Sets the secret that the saved search webhook notifications of the user or organization are signed with.
+    # An empty or null secret removes it. The secret can't be read back through the API.
+    #
+    # Only the user, organization members (and site admins) may perform this mutation.
+    setWebhookSecret(
+        # The ID of the user or organization.
+        subject: ID!
+        # The secret.
+        secret: String
+    ): EmptyResponse
     # All mutations that update settings (global, organization, and user settings) are under this field.
     #
     # Only the settings subject whose settings are being mutated (and site admins) may perform this mutation.
@@ -314,6 +335,18 @@ input AddExternalServiceInput {
     config: String!
 }
 
+# The repositories and the users and organizations that may read them, for setRepositoryPermissions.
+input SetRepositoryPermissionsInput {
+    # The names of repositories (such as "gitolite.example.com/my/repo"). Each repository must exist.
+    repositories: [String!]
+    # Regular expressions; all repositories whose name matches any of them are included.
+    repositoryPatterns: [String!]
+    # The usernames or verified email addresses of users that may read the repositories.
+    users: [String!]
+    # The names of organizations whose members may read the repositories.
+    organizations: [String!]
+}
+
 # Fields to update for an existing external service.
 input UpdateExternalServiceInput {
     # The id of the external service to update.
@@ -409,6 +442,41 @@ input DiscussionThreadTargetRepoInput {
     selection: DiscussionThreadTargetRepoSelectionInput
 }
 
+# A discussion thread that is centered around the diff between two revisions of a repository
+# (e.g. in the comparison view), optionally narrowed down to a file in the diff and a selection
+# (e.g. a hunk) in the head version of that file.
+input DiscussionThreadTargetRepoDiffInput {
+    # The repository in which the thread was created.
+    #
+    # One of 'repositoryID', 'repositoryGitCloneURL', or 'repositoryName' must be specified.
+    repositoryID: ID
+
+    # The repository in which the thread was created.
+    #
+    # One of 'repositoryID', 'repositoryGitCloneURL', or 'repositoryName' must be specified.
+    repositoryName: String
+
+    # The repository in which the thread was created.
+    #
+    # One of 'repositoryID', 'repositoryGitCloneURL', or 'repositoryName' must be specified.
+    repositoryGitCloneURL: String
+
+    # The base of the diff ("old" or "left-hand side"): a branch or other Git ref, or an exact
+    # Git revision.
+    base: String!
+
+    # The head of the diff ("new" or "right-hand side"): a branch or other Git ref, or an exact
+    # Git revision. If it is a branch, the thread's selection is tracked as the branch moves.
+    head: String!
+
+    # The path (relative to the repository root) of the file in the diff that the thread is
+    # referencing, if any. If the path is null, the thread is about the diff as a whole.
+    path: String
+
+    # The selection in the head version of the file that the thread was referencing, if any.
+    selection: DiscussionThreadTargetRepoSelectionInput
+}
+
 # Describes the creation of a new thread around some target (e.g. a file in a repo).
 input DiscussionThreadCreateInput {
     # An explicitly chosen title for the discussion thread. Otherwise, the title
@@ -420,7 +488,14 @@ input DiscussionThreadCreateInput {
 
     # The target repo of this discussion thread. This is nullable so that in
     # the future more target types may be added.
+    #
+    # Only one of 'targetRepo' or 'targetRepoDiff' may be specified.
     targetRepo: DiscussionThreadTargetRepoInput
+
+    # The target diff of this discussion thread.
+    #
+    # Only one of 'targetRepo' or 'targetRepoDiff' may be specified.
+    targetRepoDiff: DiscussionThreadTargetRepoDiffInput
 }
 
 # Describes an update mutation to an existing thread.
@@ -434,6 +509,17 @@ input DiscussionThreadUpdateInput {
     # When non-null, indicates that the thread should be deleted. Only admins
     # can perform this action.
     Delete: Boolean
+
+    # When non-null, indicates that the thread should be resolved (true) or
+    # reopened (false).
+    Resolve: Boolean
+
+    # When non-null, replaces the labels of the thread.
+    Labels: [String!]
+
+    # When non-null, replaces the users (by user ID) that the thread is
+    # assigned to.
+    Assignees: [ID!]
 }
 
 # Describes an update mutation to an existing comment in a thread.
@@ -441,6 +527,11 @@ input DiscussionCommentUpdateInput {
     # The ID of the comment to update.
     commentID: ID!
 
+    # When non-null, replaces the contents of the comment. The previous
+    # contents remain in the comment's revisions. Only admins and the author of
+    # the comment can perform this action.
+    contents: String
+
     # When non-null, indicates that the thread should be deleted. Only admins
     # can perform this action.
     delete: Boolean
@@ -450,6 +541,10 @@ input DiscussionCommentUpdateInput {
     # An error will be returned if the comment's canReport field is false.
     report: String
 
+    # The ID of the revision of the comment being reported. When null, the
+    # latest revision is reported.
+    reportRevisionID: ID
+
     # When non-null, indicates that the reports on the thread should be
     # cleared. Only admins can perform this action.
     #
@@ -467,11 +562,37 @@ type DiscussionsMutation {
     # Returns null if the thread was deleted.
     updateThread(input: DiscussionThreadUpdateInput!): DiscussionThread
 
-    # Adds a new comment to a thread. Returns the updated thread.
-    addCommentToThread(threadID: ID!, contents: String!): DiscussionThread!
+    # Adds a new comment to a thread, as a reply to the comment parentCommentID
+    # (in the same thread) if specified. Returns the updated thread.
+    addCommentToThread(threadID: ID!, contents: String!, parentCommentID: ID): DiscussionThread!
 
     # Updates an existing comment. Returns the updated thread.
     updateComment(input: DiscussionCommentUpdateInput!): DiscussionThread!
+
+    # Adds the viewer's reaction (one of the emoji listed in the comment's
+    # reactions field) to a comment. Returns the updated comment.
+    addReactionToComment(commentID: ID!, reaction: String!): DiscussionComment!
+
+    # Removes the viewer's reaction from a comment. Returns the updated comment.
+    removeReactionFromComment(commentID: ID!, reaction: String!): DiscussionComment!
+
+    # Subscribes the viewer to notifications about a thread, undoing a previous
+    # unwatchThread. Returns the thread.
+    watchThread(threadID: ID!): DiscussionThread!
+
+    # Mutes a thread for the viewer, so that they are not notified about it (even
+    # if they watch its repository, or commented or were mentioned in it) unless
+    # they are mentioned in a new comment. Returns the thread.
+    unwatchThread(threadID: ID!): DiscussionThread!
+
+    # Subscribes the viewer to notifications about the threads on a repository.
+    # If pathPrefix is specified, only threads on files under that path prefix
+    # are included.
+    watchRepository(repository: ID!, pathPrefix: String): EmptyResponse!
+
+    # Removes the viewer's subscription to the threads on a repository (with the
+    # same pathPrefix) that was created by watchRepository.
+    unwatchRepository(repository: ID!, pathPrefix: String): EmptyResponse!
 }
 
 # Describes options for rendering Markdown.
@@ -735,6 +856,12 @@ type Query {
         #
         # If the path ends with "/**", any path below that is matched.
         targetRepositoryPath: String
+        # When present, lists only the threads whose target is a diff (true) or not a diff (false).
+        targetRepositoryDiff: Boolean
+        # When present, lists only the threads whose target is a diff with this exact base revision.
+        targetRepositoryBaseRevision: String
+        # When present, lists only the threads whose target is a diff with this exact head revision.
+        targetRepositoryHeadRevision: String
     ): DiscussionThreadConnection!
     # Lists discussion comments.
     discussionComments(
@@ -957,6 +1084,76 @@ type SavedQuery {
     notify: Boolean!
     # Whether or not to notify on Slack.
     notifySlack: Boolean!
+    # The most recent runs of this saved query by the query runner, newest first. Runs are kept
+    # for 30 days.
+    executions(
+        # Returns the first n executions from the list.
+        first: Int
+    ): [SavedQueryExecution!]!
+    # The most recent notifications about the results of this saved query, newest first, including
+    # notifications that are not yet delivered. Delivered and failed notifications are kept for
+    # 30 days.
+    notificationDeliveries(
+        # Returns the first n notifications from the list.
+        first: Int
+    ): [SavedQueryNotificationDelivery!]!
+}
+
+# A run of a saved query by the query runner.
+type SavedQueryExecution {
+    # The search query that was run. For commit and diff searches, this includes the "after:"
+    # filter that limits the results to new ones.
+    query: String!
+    # The time when the saved query was run.
+    executedAt: String!
+    # How long the search took, in milliseconds.
+    durationMilliseconds: Int!
+    # The number of results the search found.
+    resultCount: Int!
+    # The error that caused the search to fail, if any.
+    error: String
+}
+
+# A notification about the results of a saved query.
+type SavedQueryNotificationDelivery {
+    # The channel that the notification is sent on.
+    channel: SavedQueryNotificationChannel!
+    # The user or organization that the notification is sent to. Null if the recipient no longer
+    # exists.
+    recipient: SettingsSubject
+    # The delivery state of the notification.
+    state: SavedQueryNotificationDeliveryState!
+    # The number of attempts to deliver the notification.
+    attempts: Int!
+    # The error of the last failed delivery attempt, if any.
+    lastError: String
+    # The time when the notification was queued.
+    createdAt: String!
+    # The time when the notification was delivered, if it was.
+    sentAt: String
+}
+
+# A channel that notifications about the results of saved queries are sent on.
+enum SavedQueryNotificationChannel {
+    # An email to the recipient user.
+    EMAIL
+    # A message to the recipient's Slack webhook.
+    SLACK
+    # A request to the saved query's webhook, or else to the recipient's webhook.
+    WEBHOOK
+    # An action of the saved query (a code monitor), such as opening an issue. Actions have no
+    # recipient.
+    ACTION
+}
+
+# The delivery state of a notification about the results of a saved query.
+enum SavedQueryNotificationDeliveryState {
+    # The notification is waiting to be delivered (or to be retried after a failed attempt).
+    QUEUED
+    # The notification was delivered.
+    SENT
+    # The notification could not be delivered, and won't be retried.
+    DEAD
 }
 
 # A search query description.
@@ -1048,7 +1245,11 @@ type ExternalServiceConnection {
 # A specific kind of external service.
 enum ExternalServiceKind {
     AWSCODECOMMIT
+    AZUREDEVOPS
+    BITBUCKETCLOUD
     BITBUCKETSERVER
+    GERRIT
+    GITEA
     GITHUB
     GITLAB
     GITOLITE
@@ -1131,6 +1332,17 @@ type Repository implements Node & GenericSearchResultInterface {
     # Information about this repository from the external service that it originates from (such as GitHub, GitLab,
     # Phabricator, etc.).
     externalRepository: ExternalRepository
+    # Whether the repository is archived on its code host.
+    isArchived: Boolean!
+    # The topics (also called labels or tags) of the repository on its code host.
+    topics: [String!]!
+    # The number of stars of the repository on its code host.
+    stars: Int!
+    # The name of the repository's default branch on its code host, or null if unknown. Unlike defaultBranch, it
+    # is available before the repository is cloned.
+    externalDefaultBranch: String
+    # The visibility of the repository on its code host, or null if unknown.
+    visibility: RepositoryVisibility
     # Whether the repository is currently being cloned.
     cloneInProgress: Boolean! @deprecated(reason: "use Repository.mirrorInfo.cloneInProgress instead")
     # Information about the text search index for this repository, or null if text search indexing
@@ -1205,6 +1417,16 @@ type Repository implements Node & GenericSearchResultInterface {
     matches: [SearchResultMatch!]!
 }
 
+# The visibility of a repository on its code host.
+enum RepositoryVisibility {
+    # The repository is visible to everyone.
+    PUBLIC
+    # The repository is only visible to users who were granted access to it.
+    PRIVATE
+    # The repository is visible to all signed-in users of the code host.
+    INTERNAL
+}
+
 # A URL to a resource on an external service, such as the URL to a repository on its external (origin) code host.
 type ExternalLink {
     # The URL to the resource.
@@ -1479,6 +1701,14 @@ type Location {
     canonicalURL: String!
 }
 
+# A list of locations.
+type LocationConnection {
+    # A list of locations.
+    nodes: [Location!]!
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
 # A range inside a file. The start position is inclusive, and the end position is exclusive.
 type Range {
     # The start position of the range (inclusive).
@@ -1960,6 +2190,32 @@ type GitBlob implements TreeEntry & File2 {
         # Return symbols matching the query.
         query: String
     ): SymbolConnection!
+    # Best-effort definition locations of the token at the given position, computed from the symbols
+    # index (not from a language server, so results may be imprecise). Definitions in the same
+    # repository and commit are returned if there are any; otherwise other repositories are searched.
+    definition(
+        # The line (zero-based) of the position.
+        line: Int!
+        # The character offset (zero-based) in the line of the position.
+        character: Int!
+        # Returns the first n locations from the list.
+        first: Int
+    ): LocationConnection!
+    # Best-effort (textual) references to the token at the given position, found by a word-boundary
+    # search for the token. References in the same repository and commit are listed first, followed
+    # by references in other repositories.
+    references(
+        # The line (zero-based) of the position.
+        line: Int!
+        # The character offset (zero-based) in the line of the position.
+        character: Int!
+        # Returns the first n locations from the list.
+        first: Int
+    ): LocationConnection!
+    # Precise code intelligence for this blob from an uploaded LSIF (Language Server Index Format)
+    # dump, or null if neither this commit nor one of its recent ancestors has a dump with data for
+    # this blob.
+    lsif: LSIFBlob
     # Always false, since a blob is a file, not directory.
     isSingleChild(
         # Returns the first n files in the tree.
@@ -1971,6 +2227,39 @@ type GitBlob implements TreeEntry & File2 {
     ): Boolean!
 }
 
+# Precise code intelligence for a file from an uploaded LSIF (Language Server Index Format) dump.
+type LSIFBlob {
+    # The commit of the LSIF dump. This is the commit of the blob if a dump was uploaded for it, and
+    # otherwise the nearest ancestor commit with a dump. Positions given as arguments refer to the
+    # file's contents at this commit, and returned locations are at this commit.
+    commit: GitCommit!
+    # The definitions of the symbol at the given position.
+    definitions(
+        # The line (zero-based) of the position.
+        line: Int!
+        # The character offset (zero-based) in the line of the position.
+        character: Int!
+        # Returns the first n locations from the list.
+        first: Int
+    ): LocationConnection!
+    # The references to the symbol at the given position, including its definitions.
+    references(
+        # The line (zero-based) of the position.
+        line: Int!
+        # The character offset (zero-based) in the line of the position.
+        character: Int!
+        # Returns the first n locations from the list.
+        first: Int
+    ): LocationConnection!
+    # The hover contents of the symbol at the given position, or null if there are none.
+    hover(
+        # The line (zero-based) of the position.
+        line: Int!
+        # The character offset (zero-based) in the line of the position.
+        character: Int!
+    ): Markdown
+}
+
 # A highlighted file.
 type HighlightedFile {
     # Whether or not it was aborted.
@@ -2090,6 +2379,12 @@ type User implements Node & SettingsSubject {
     #
     # Only the user and site admins can access this field.
     tags: [String!]!
+    # The last time that the user's repository permissions were synced from the code hosts, or null if
+    # they have never been synced (or there are no code hosts that Sourcegraph enforces permissions
+    # of).
+    #
+    # Only the user and site admins can access this field.
+    permissionsSyncedAt: String
     # The user's usage statistics on Sourcegraph.
     usageStatistics: UserUsageStatistics!
     # The user's email addresses.
@@ -2121,6 +2416,11 @@ type User implements Node & SettingsSubject {
     #
     # Only the user and site admins can access this field.
     surveyResponses: [SurveyResponse!]!
+    # The user's subscriptions to discussion threads and repositories, including
+    # muted threads.
+    #
+    # Only the user and site admins can access this field.
+    discussionSubscriptions: [DiscussionSubscription!]!
     # The URL to view this user's customer information (for Sourcegraph.com site admins).
     #
     # Only Sourcegraph.com site admins may query this field.
@@ -2460,11 +2760,55 @@ type DiscussionThreadTargetRepo {
     relativeSelection(rev: String!): DiscussionSelectionRange
 }
 
-# The target of a discussion thread. Today, the only possible target is a
-# repository. In the future, this may be extended to include other targets such
-# as user profiles, extensions, etc. Clients should ignore target types they
-# do not understand gracefully.
-union DiscussionThreadTarget = DiscussionThreadTargetRepo
+# A discussion thread that is centered around the diff between two revisions of a
+# repository, and optionally a file in the diff and a selection (e.g. a hunk) in the head
+# version of that file.
+type DiscussionThreadTargetRepoDiff {
+    # The repository in which the thread was created.
+    repository: Repository!
+
+    # The path (relative to the repository root) of the file in the diff that the thread is
+    # referencing, if any. If the path is null, the thread is about the diff as a whole.
+    path: String
+
+    # The branch or other human-readable Git ref of the base of the diff, if any.
+    baseBranch: GitRef
+
+    # The exact revision of the base of the diff.
+    baseRevision: GitRef!
+
+    # The branch or other human-readable Git ref of the head of the diff, if any.
+    headBranch: GitRef
+
+    # The exact revision of the head of the diff (when the thread was created).
+    headRevision: GitRef!
+
+    # The comparison between the base and head revisions.
+    comparison: RepositoryComparison!
+
+    # The selection in the head version of the file that the thread was referencing, if any.
+    selection: DiscussionThreadTargetRepoSelection
+
+    # Where the path would be relative to the given Git revision specifier
+    # (branch/commit/etc), or else to the current head (the head branch, if any).
+    #
+    # null is returned if there is no path relative to the revision, e.g. if
+    # the file was deleted or the path field was null.
+    relativePath(rev: String): String
+
+    # Where the selection would be relative to the given Git revision specifier
+    # (branch/commit/etc), or else to the current head (the head branch, if
+    # any). This tracks the selection forward as the head moves.
+    #
+    # See DiscussionThreadTargetRepo.relativeSelection for the caveats.
+    relativeSelection(rev: String): DiscussionSelectionRange
+}
+
+# The target of a discussion thread: a repository (or a file or selection in it),
+# or a diff in a repository. In the future, this may be extended to include
+# other targets such as user profiles, extensions, etc. Clients should ignore
+# target types they do not understand gracefully.
+union DiscussionThreadTarget = DiscussionThreadTargetRepo | DiscussionThreadTargetRepoDiff
 
 # A discussion thread around some target (e.g. a file in a repo).
 type DiscussionThread {
@@ -2483,10 +2827,11 @@ type DiscussionThread {
     # The target of this discussion thread.
     target: DiscussionThreadTarget!
 
-    # The URL at which this thread can be viewed inline (i.e. in the file blob view).
+    # The URL at which this thread can be viewed inline (i.e. in the file blob view,
+    # or in the comparison view for a DiscussionThreadTargetRepoDiff).
     #
-    # This will be null if the thread target is not DiscussionThreadTargetRepo
-    # OR if it was created without a path string.
+    # This will be null if the thread target is a DiscussionThreadTargetRepo
+    # that was created without a path string.
     inlineURL: String
 
     # The date when the discussion thread was created.
@@ -2498,11 +2843,65 @@ type DiscussionThread {
     # The date when the discussion thread was archived (or null if it has not).
     archivedAt: String
 
+    # The status of the discussion thread.
+    status: DiscussionThreadStatus!
+
+    # The date when the discussion thread was resolved (or null if it is open).
+    resolvedAt: String
+
+    # The users that the discussion thread is assigned to.
+    assignees: [User!]!
+
+    # The free-form labels of the discussion thread, e.g. "security".
+    labels: [String!]!
+
     # The comments in the discussion thread.
     comments(
         # Returns the first n comments from the list.
         first: Int
     ): DiscussionCommentConnection!
+
+    # Whether the viewer is notified about the discussion thread.
+    viewerSubscription: DiscussionSubscriptionState!
+}
+
+# The status of a discussion thread.
+enum DiscussionThreadStatus {
+    # The discussion thread is open.
+    OPEN
+    # The discussion thread has been resolved.
+    RESOLVED
+}
+
+# Whether a user is notified about a discussion thread.
+enum DiscussionSubscriptionState {
+    # The user watches the thread, or its repository or a path prefix of its file.
+    WATCHING
+    # The user muted the thread.
+    MUTED
+    # The user does not watch the thread. They may still be notified about it if
+    # they authored a comment in it, were mentioned in it, or are assigned to it.
+    NOT_WATCHING
+}
+
+# A user's subscription to notifications about a discussion thread, or about the
+# threads on a repository (or a path prefix in it).
+type DiscussionSubscription {
+    # The thread, for a subscription to a single thread.
+    thread: DiscussionThread
+
+    # The repository, for a subscription to the threads on a repository.
+    repository: Repository
+
+    # The path prefix that threads' files must have, for a subscription to the
+    # threads on a repository. An empty string means all threads on the repository.
+    pathPrefix: String
+
+    # Whether the thread is muted (i.e., the user is not notified about it).
+    muted: Boolean!
+
+    # The date when the subscription was created.
+    createdAt: String!
 }
 
 # A comment made within a discussion thread.
@@ -2540,6 +2939,24 @@ type DiscussionComment {
     # The date when the discussion thread was last updated.
     updatedAt: String!
 
+    # The comment that this comment is a reply to, if any.
+    parentComment: DiscussionComment
+
+    # The replies to this comment.
+    replies(
+        # Returns the first n replies from the list.
+        first: Int
+    ): DiscussionCommentConnection!
+
+    # The reactions to this comment, one for each emoji that users may react
+    # with (including those that no user has reacted with yet).
+    reactions: [DiscussionCommentReactionGroup!]!
+
+    # The revisions of the comment's contents, oldest first. The last revision
+    # has the current contents. Only admins and the author of the comment will
+    # receive a non empty list of revisions.
+    revisions: [DiscussionCommentRevision!]!
+
     # Reports filed by users about this comment. Only admins will receive a non
     # empty list of reports.
     #
@@ -2561,6 +2978,48 @@ type DiscussionComment {
     canClearReports: Boolean!
 }
 
+# The users who reacted to a discussion comment with the same emoji.
+type DiscussionCommentReactionGroup {
+    # The emoji, e.g. "👍".
+    reaction: String!
+
+    # The users who reacted with the emoji.
+    users: [User!]!
+
+    # The number of users who reacted with the emoji.
+    totalCount: Int!
+
+    # Whether the viewer reacted with the emoji.
+    viewerHasReacted: Boolean!
+}
+
+# A version of the contents of a discussion comment.
+type DiscussionCommentRevision {
+    # The revision ID.
+    id: ID!
+
+    # The user who wrote this version of the contents (the comment author, or an
+    # admin who edited the comment).
+    author: User!
+
+    # The markdown contents of the comment in this revision.
+    contents: String!
+
+    # The markdown contents rendered as an HTML string. It is already sanitized
+    # and escaped and thus is always safe to render.
+    html(options: MarkdownOptions): String!
+
+    # The date when the revision was created.
+    createdAt: String!
+
+    # Reports filed by users about this revision. Only admins will receive a
+    # non empty list of reports.
+    #
+    # When discussions.abuseProtection in the site config is set to false, this
+    # will always be an empty list.
+    reports: [String!]!
+}
+
 # A list of discussion threads.
 type DiscussionThreadConnection {
     # A list of discussion threads.
@@ -223,6 +223,7 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 	if effectiveRepoFieldValues != nil {
 		repoFilters = effectiveRepoFieldValues
 	}
+	topics, notTopics := r.query.RepoHasTopics()
 	repoGroupFilters, _ := r.query.StringValues(query.FieldRepoGroup)
 
 	forkStr, _ := r.query.StringValue(query.FieldFork)
@@ -231,6 +232,12 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 	archivedStr, _ := r.query.StringValue(query.FieldArchived)
 	archived := parseYesNoOnly(archivedStr)
 
+	visibilityStr, _ := r.query.StringValue(query.FieldVisibility)
+	visibility, err := parseVisibility(visibilityStr)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
 	tr.LazyPrintf("resolveRepositories - start")
 	repoRevs, missingRepoRevs, repoResults, overLimit, err = resolveRepositories(ctx, resolveRepoOp{
 		repoFilters:      repoFilters,
@@ -240,6 +247,9 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 		noForks:          fork == No || fork == False,
 		onlyArchived:     archived == Only || archived == True,
 		noArchived:       archived == No || archived == False,
+		topics:           topics,
+		notTopics:        notTopics,
+		visibility:       visibility,
 	})
 	tr.LazyPrintf("resolveRepositories - done")
 	if effectiveRepoFieldValues == nil {
@@ -353,6 +363,22 @@ type resolveRepoOp struct {
 	onlyForks        bool
 	noArchived       bool
 	onlyArchived     bool
+	topics           []string // repositories must have all of these topics
+	notTopics        []string // repositories must have none of these topics
+	visibility       string   // one of the api.RepoVisibility constants, or empty for any visibility
+}
+
+// parseVisibility parses the value of the visibility: field. It returns an empty string for
+// any visibility.
+func parseVisibility(s string) (string, error) {
+	switch v := strings.ToLower(s); v {
+	case "", "any":
+		return "", nil
+	case api.RepoVisibilityPublic, api.RepoVisibilityPrivate, api.RepoVisibilityInternal:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid value %q for visibility: (must be public, private, internal or any)", s)
+	}
 }
 
 func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions, missingRepoRevisions []*search.RepositoryRevisions, repoResolvers []*searchSuggestionResolver, overLimit bool, err error) {
@@ -411,6 +437,9 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 		OnlyForks:    op.onlyForks,
 		NoArchived:   op.noArchived,
 		OnlyArchived: op.onlyArchived,
+		Topics:       op.topics,
+		NotTopics:    op.notTopics,
+		Visibility:   op.visibility,
 	})
 	tr.LazyPrintf("Repos.List - done")
 	if err != nil {
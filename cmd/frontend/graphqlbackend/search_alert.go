@@ -45,6 +45,15 @@ func (r *searchResolver) alertForNoResolvedRepos(ctx context.Context) (*searchAl
 	fork, _ := r.query.StringValue(query.FieldFork)
 	onlyForks, noForks := fork == "only", fork == "no"
 
+	// Handle has.topic-only scenarios. has.topic(x) values are not repo: patterns, so they are
+	// not proposed to be changed below.
+	if topics, _ := r.query.RepoHasTopics(); len(topics) > 0 && len(repoFilters) == 0 && len(repoGroupFilters) == 0 {
+		return &searchAlert{
+			title:       "No repositories have the topics in your repo:has.topic() filters",
+			description: "Topics are synced from GitHub and GitLab. Check the topics of the repositories on your code host.",
+		}, nil
+	}
+
 	// Handle repogroup-only scenarios.
 	if len(repoFilters) == 0 && len(repoGroupFilters) == 0 {
 		return &searchAlert{
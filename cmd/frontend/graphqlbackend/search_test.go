@@ -1,5 +1,7 @@
 package graphqlbackend
 
+import "testing"
+
 func testStringResult(result *searchSuggestionResolver) string {
 	var name string
 	switch r := result.result.(type) {
@@ -15,3 +17,27 @@ func testStringResult(result *searchSuggestionResolver) string {
 	}
 	return name
 }
+
+func TestParseVisibility(t *testing.T) {
+	tests := map[string]string{
+		"":         "",
+		"any":      "",
+		"public":   "public",
+		"Private":  "private",
+		"internal": "internal",
+	}
+	for input, want := range tests {
+		got, err := parseVisibility(input)
+		if err != nil {
+			t.Errorf("%q: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %q, want %q", input, got, want)
+		}
+	}
+
+	if _, err := parseVisibility("secret"); err == nil {
+		t.Error("got nil error for invalid visibility")
+	}
+}
@@ -211,6 +211,21 @@ func (r *UserResolver) Tags(ctx context.Context) ([]string, error) {
 	return r.user.Tags, nil
 }
 
+func (r *UserResolver) PermissionsSyncedAt(ctx context.Context) (*string, error) {
+	// 🚨 SECURITY: Only the user and admins are allowed to see when the user's permissions were
+	// synced.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	syncedAt, err := db.UserRepoPermissions.SyncedAt(ctx, r.user.ID)
+	if err != nil || syncedAt == nil {
+		return nil, err
+	}
+	t := syncedAt.Format(time.RFC3339)
+	return &t, nil
+}
+
 func (r *UserResolver) SurveyResponses(ctx context.Context) ([]*surveyResponseResolver, error) {
 	// 🚨 SECURITY: Only the user and admins are allowed to access the user's survey responses.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
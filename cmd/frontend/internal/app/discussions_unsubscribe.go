@@ -0,0 +1,68 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// serveDiscussionsUnsubscribe handles the unsubscribe links in discussion
+// notification emails, which mute the thread for the user whom the token was
+// generated for. It does not require the user to be signed in.
+//
+// A GET request only shows a page that asks the user to confirm, because mail
+// link scanners and prefetchers follow links in emails. The thread is muted by
+// a POST request, which is sent by the confirmation page or directly by the
+// mail client (one-click unsubscribe, see RFC 8058).
+func serveDiscussionsUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: The token identifies both the user and the thread, and only
+	// grants muting that thread for that user.
+	token := r.URL.Query().Get("token")
+	userID, threadID, err := db.DiscussionUnsubscribeTokens.Get(ctx, token)
+	if err == db.ErrInvalidToken {
+		http.Error(w, "Invalid or expired unsubscribe link.", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log15.Error("Failed to look up discussion unsubscribe token.", "error", err)
+		http.Error(w, "Unexpected error when unsubscribing.", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method != "POST" {
+		var buf bytes.Buffer
+		if err := unsubscribePageTemplate.Execute(&buf, struct{ Token string }{token}); err != nil {
+			log15.Error("Error rendering discussions unsubscribe page template.", "err", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		buf.WriteTo(w)
+		return
+	}
+
+	if err := db.DiscussionSubscriptions.MuteThread(ctx, userID, threadID); err != nil {
+		log15.Error("Failed to mute discussion thread.", "userID", userID, "threadID", threadID, "error", err)
+		http.Error(w, "Unexpected error when unsubscribing.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "You have been unsubscribed from this discussion thread. You will only be notified about it again if you are mentioned.")
+}
+
+var unsubscribePageTemplate = template.Must(template.New("").Parse(`
+<pre>
+<strong>Unsubscribe from this discussion thread?</strong>
+<br>
+You will only be notified about it again if you are mentioned.
+<br>
+<form method="post" action="?token={{.Token}}"><button type="submit">Unsubscribe</button></form>
+</pre>
+`))
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+func TestServeDiscussionsUnsubscribe(t *testing.T) {
+	db.Mocks.DiscussionUnsubscribeTokens.Get = func(ctx context.Context, token string) (int32, int64, error) {
+		if token != "t" {
+			return 0, 0, db.ErrInvalidToken
+		}
+		return 1, 2, nil
+	}
+	var muted bool
+	db.Mocks.DiscussionSubscriptions.MuteThread = func(ctx context.Context, userID int32, threadID int64) error {
+		if userID != 1 || threadID != 2 {
+			t.Errorf("got user %d and thread %d, want user 1 and thread 2", userID, threadID)
+		}
+		muted = true
+		return nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	t.Run("GET only asks for confirmation", func(t *testing.T) {
+		muted = false
+		rec := httptest.NewRecorder()
+		serveDiscussionsUnsubscribe(rec, httptest.NewRequest("GET", "/-/discussions/unsubscribe?token=t", nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `<form method="post" action="?token=t">`) {
+			t.Errorf("got %d %q, want a confirmation form", rec.Code, rec.Body.String())
+		}
+		if muted {
+			t.Error("thread was muted by a GET request")
+		}
+	})
+
+	t.Run("POST mutes the thread", func(t *testing.T) {
+		muted = false
+		rec := httptest.NewRecorder()
+		serveDiscussionsUnsubscribe(rec, httptest.NewRequest("POST", "/-/discussions/unsubscribe?token=t", strings.NewReader("List-Unsubscribe=One-Click")))
+		if rec.Code != http.StatusOK || !muted {
+			t.Errorf("got %d (muted: %v), want the thread to be muted", rec.Code, muted)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		muted = false
+		rec := httptest.NewRecorder()
+		serveDiscussionsUnsubscribe(rec, httptest.NewRequest("POST", "/-/discussions/unsubscribe?token=x", nil))
+		if rec.Code != http.StatusBadRequest || muted {
+			t.Errorf("got %d (muted: %v), want %d", rec.Code, muted, http.StatusBadRequest)
+		}
+	})
+}
@@ -28,6 +28,8 @@ const (
 	ResetPasswordInit = "reset-password.init"
 	ResetPasswordCode = "reset-password.code"
 
+	DiscussionsUnsubscribe = "discussions.unsubscribe"
+
 	RegistryExtensionBundle = "registry.extension.bundle"
 
 	OldToolsRedirect = "old-tools-redirect"
@@ -70,6 +72,8 @@ func newRouter() *mux.Router {
 	base.Path("/-/reset-password-init").Methods("POST").Name(ResetPasswordInit)
 	base.Path("/-/reset-password-code").Methods("POST").Name(ResetPasswordCode)
 
+	base.Path("/-/discussions/unsubscribe").Methods("GET", "POST").Name(DiscussionsUnsubscribe)
+
 	base.Path("/-/static/extension/{RegistryExtensionReleaseFilename}").Methods("GET").Name(RegistryExtensionBundle)
 
 	base.Path("/-/godoc/refs").Methods("GET").Name(GDDORefs)
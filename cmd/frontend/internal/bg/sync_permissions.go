@@ -0,0 +1,104 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/actor"
+	"github.com/sourcegraph/sourcegraph/pkg/env"
+	"github.com/sourcegraph/sourcegraph/pkg/rcache"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var permissionsSyncInterval, _ = time.ParseDuration(env.Get("PERMISSIONS_SYNC_INTERVAL", "1h", "interval at which the repository permissions of each user are synced from code hosts"))
+
+const (
+	// permissionsSyncBatchSize is the maximum number of users and of repositories to sync per tick.
+	permissionsSyncBatchSize = 100
+	permissionsSyncTick      = 10 * time.Second
+)
+
+// SyncPermissions syncs the repository permissions of users and repositories from the authz
+// providers to the database, which is where authz filtering reads them from. Each user's
+// permissions are synced every PERMISSIONS_SYNC_INTERVAL (and when they sign in), and so are the
+// permissions of each repository. The permissions of new users and repositories are synced first.
+//
+// It should be invoked in a separate goroutine.
+func SyncPermissions() {
+	// Only one frontend instance should ever run this worker, so we use a distributed lock to
+	// guarantee this. If the frontend with the lock acquired dies, it will be released after 1
+	// minute.
+	for {
+		ctx, release, ok := rcache.TryAcquireMutex(context.Background(), "permissionsSyncWorker")
+		if !ok {
+			// Failed to acquire the mutex. Wait before trying again.
+			time.Sleep(30 * time.Second)
+			continue
+		}
+
+		log15.Debug("permissions sync worker running")
+		var anonymousSyncedAt time.Time
+		for ctx.Err() == nil {
+			if _, authzProviders := authz.GetProviders(); len(authzProviders) > 0 {
+				if time.Since(anonymousSyncedAt) > permissionsSyncInterval {
+					if err := db.SyncUserPermissions(ctx, nil); err != nil {
+						log15.Error("Syncing permissions of anonymous users failed.", "error", err)
+					} else {
+						anonymousSyncedAt = time.Now()
+					}
+				}
+				syncRepoPermissions(ctx)
+				syncUserPermissions(ctx)
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(permissionsSyncTick):
+			}
+		}
+		log15.Debug("permissions sync worker stopped", "ctx", ctx.Err())
+		release()
+	}
+}
+
+// syncRepoPermissions syncs the permissions of repositories whose permissions were last synced
+// longer than the sync interval ago, least recently synced first.
+func syncRepoPermissions(ctx context.Context) {
+	repoIDs, err := db.UserRepoPermissions.ListReposToSync(ctx, time.Now().Add(-permissionsSyncInterval), permissionsSyncBatchSize)
+	if err != nil {
+		log15.Error("Listing repositories to sync permissions of failed.", "error", err)
+		return
+	}
+	for _, repoID := range repoIDs {
+		// 🚨 SECURITY: The repository is only used to sync its permissions, so it is safe to get it
+		// regardless of permissions.
+		repo, err := db.Repos.Get(actor.WithActor(ctx, &actor.Actor{Internal: true}), repoID)
+		if err == nil {
+			err = db.SyncRepoPermissions(ctx, repo)
+		}
+		if err != nil {
+			log15.Error("Syncing repository permissions failed.", "repo", repoID, "error", err)
+		}
+	}
+}
+
+// syncUserPermissions syncs the permissions of users whose permissions were last synced longer than
+// the sync interval ago, least recently synced first.
+func syncUserPermissions(ctx context.Context) {
+	userIDs, err := db.UserRepoPermissions.ListUsersToSync(ctx, time.Now().Add(-permissionsSyncInterval), permissionsSyncBatchSize)
+	if err != nil {
+		log15.Error("Listing users to sync permissions of failed.", "error", err)
+		return
+	}
+	for _, userID := range userIDs {
+		user, err := db.Users.GetByID(ctx, userID)
+		if err == nil {
+			err = db.SyncUserPermissions(ctx, user)
+		}
+		if err != nil {
+			log15.Error("Syncing user permissions failed.", "user", userID, "error", err)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/hooks"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/assetsutil"
+	approuter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/router"
 	internalauth "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli/middleware"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi"
@@ -44,10 +45,10 @@ func newExternalHTTPHandler(ctx context.Context) (http.Handler, error) {
 
 	// App handler (HTML pages).
 	appHandler := app.NewHandler()
-	appHandler = handlerutil.CSRFMiddleware(appHandler, globals.ExternalURL.Scheme == "https") // after appAuthMiddleware because SAML IdP posts data to us w/o a CSRF token
-	appHandler = authMiddlewares.App(appHandler)                                               // 🚨 SECURITY: auth middleware
-	appHandler = session.CookieMiddleware(appHandler)                                          // app accepts cookies
-	appHandler = httpapi.AccessTokenAuthMiddleware(appHandler)                                 // app accepts access tokens
+	appHandler = csrfMiddleware(appHandler, globals.ExternalURL.Scheme == "https") // after appAuthMiddleware because SAML IdP posts data to us w/o a CSRF token
+	appHandler = authMiddlewares.App(appHandler)                                   // 🚨 SECURITY: auth middleware
+	appHandler = session.CookieMiddleware(appHandler)                              // app accepts cookies
+	appHandler = httpapi.AccessTokenAuthMiddleware(appHandler)                     // app accepts access tokens
 
 	// Mount handlers and assets.
 	sm := http.NewServeMux()
@@ -76,6 +77,21 @@ func newExternalHTTPHandler(ctx context.Context) (http.Handler, error) {
 	return h, nil
 }
 
+// csrfMiddleware applies the CSRF middleware to all requests except those to discussion
+// unsubscribe links. These are authorized by the token in their URL (not by cookies), and mail
+// clients POST to them without a CSRF token for one-click unsubscribe (RFC 8058).
+func csrfMiddleware(next http.Handler, secure bool) http.Handler {
+	protected := handlerutil.CSRFMiddleware(next, secure)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m mux.RouteMatch
+		if approuter.Router().Match(r, &m) && m.Route != nil && m.Route.GetName() == approuter.DiscussionsUnsubscribe {
+			next.ServeHTTP(w, r)
+			return
+		}
+		protected.ServeHTTP(w, r)
+	})
+}
+
 func healthCheckMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
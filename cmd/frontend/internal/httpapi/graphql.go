@@ -3,10 +3,13 @@ package httpapi
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/pkg/actor"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
 )
 
 var relayHandler = &relay.Handler{Schema: graphqlbackend.GraphQLSchema}
@@ -21,3 +24,17 @@ func serveGraphQL(w http.ResponseWriter, r *http.Request) (err error) {
 	relayHandler.ServeHTTP(w, r)
 	return nil
 }
+
+// serveInternalGraphQL serves the GraphQL API to other services. If the request has the
+// api.ActorUserIDHeader header, it is served as that user (or as an anonymous user) instead of as
+// the internal actor.
+func serveInternalGraphQL(w http.ResponseWriter, r *http.Request) error {
+	if v := r.Header.Get(api.ActorUserIDHeader); v != "" {
+		uid, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return err
+		}
+		r = r.WithContext(actor.WithActor(r.Context(), actor.FromUser(int32(uid))))
+	}
+	return serveGraphQL(w, r)
+}
@@ -37,8 +37,14 @@ func NewHandler(m *mux.Router) http.Handler {
 
 	m.Get(apirouter.RepoRefresh).Handler(trace.TraceRoute(handler(serveRepoRefresh)))
 
+	m.Get(apirouter.RepoLSIF).Handler(trace.TraceRoute(handler(serveLSIFUpload)))
+
 	m.Get(apirouter.Telemetry).Handler(trace.TraceRoute(telemetryHandler))
 
+	m.Get(apirouter.GitHubWebhook).Handler(trace.TraceRoute(handler(serveGitHubWebhook)))
+	m.Get(apirouter.GitLabWebhook).Handler(trace.TraceRoute(handler(serveGitLabWebhook)))
+	m.Get(apirouter.BitbucketServerWebhook).Handler(trace.TraceRoute(handler(serveBitbucketServerWebhook)))
+
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET").Name("updatecheck").Handler(trace.TraceRoute(http.HandlerFunc(updatecheck.Handler)))
 	}
@@ -76,12 +82,19 @@ func NewInternalHandler(m *mux.Router) http.Handler {
 	m.Get(apirouter.ReposInventoryUncached).Handler(trace.TraceRoute(handler(serveReposInventoryUncached)))
 	m.Get(apirouter.ReposList).Handler(trace.TraceRoute(handler(serveReposList)))
 	m.Get(apirouter.ReposListEnabled).Handler(trace.TraceRoute(handler(serveReposListEnabled)))
+	m.Get(apirouter.ReposMarkDeleted).Handler(trace.TraceRoute(handler(serveReposMarkDeleted)))
 	m.Get(apirouter.ReposGetByName).Handler(trace.TraceRoute(handler(serveReposGetByName)))
 	m.Get(apirouter.SettingsGetForSubject).Handler(trace.TraceRoute(handler(serveSettingsGetForSubject)))
+	m.Get(apirouter.WebhookSecretsGet).Handler(trace.TraceRoute(handler(serveWebhookSecretsGet)))
 	m.Get(apirouter.SavedQueriesListAll).Handler(trace.TraceRoute(handler(serveSavedQueriesListAll)))
 	m.Get(apirouter.SavedQueriesGetInfo).Handler(trace.TraceRoute(handler(serveSavedQueriesGetInfo)))
 	m.Get(apirouter.SavedQueriesSetInfo).Handler(trace.TraceRoute(handler(serveSavedQueriesSetInfo)))
 	m.Get(apirouter.SavedQueriesDeleteInfo).Handler(trace.TraceRoute(handler(serveSavedQueriesDeleteInfo)))
+	m.Get(apirouter.SavedQueriesRecordExecution).Handler(trace.TraceRoute(handler(serveSavedQueriesRecordExecution)))
+	m.Get(apirouter.SavedQueryNotificationsEnqueue).Handler(trace.TraceRoute(handler(serveSavedQueryNotificationsEnqueue)))
+	m.Get(apirouter.SavedQueryNotificationsDequeue).Handler(trace.TraceRoute(handler(serveSavedQueryNotificationsDequeue)))
+	m.Get(apirouter.SavedQueryNotificationsUpdate).Handler(trace.TraceRoute(handler(serveSavedQueryNotificationsUpdate)))
+	m.Get(apirouter.CodeMonitorsRunAction).Handler(trace.TraceRoute(handler(serveCodeMonitorsRunAction)))
 	m.Get(apirouter.OrgsListUsers).Handler(trace.TraceRoute(handler(serveOrgsListUsers)))
 	m.Get(apirouter.OrgsGetByName).Handler(trace.TraceRoute(handler(serveOrgsGetByName)))
 	m.Get(apirouter.UsersGetByUsername).Handler(trace.TraceRoute(handler(serveUsersGetByUsername)))
@@ -95,7 +108,7 @@ func NewInternalHandler(m *mux.Router) http.Handler {
 	m.Get(apirouter.GitTar).Handler(trace.TraceRoute(handler(serveGitTar)))
 	m.Get(apirouter.GitUploadPack).Handler(trace.TraceRoute(handler(serveGitUploadPack)))
 	m.Get(apirouter.Telemetry).Handler(trace.TraceRoute(telemetryHandler))
-	m.Get(apirouter.GraphQL).Handler(trace.TraceRoute(handler(serveGraphQL)))
+	m.Get(apirouter.GraphQL).Handler(trace.TraceRoute(handler(serveInternalGraphQL)))
 	m.Get(apirouter.Configuration).Handler(trace.TraceRoute(handler(serveConfiguration)))
 	m.Path("/ping").Methods("GET").Name("ping").HandlerFunc(handlePing)
 
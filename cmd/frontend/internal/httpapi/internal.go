@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/codemonitors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/conf"
@@ -75,12 +76,24 @@ func serveReposUpdateMetadata(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
-	if err := db.Repos.UpdateRepositoryMetadata(r.Context(), repo.RepoName, repo.Description, repo.Fork, repo.Archived); err != nil {
+	if err := db.Repos.UpdateRepositoryMetadata(r.Context(), repo); err != nil {
 		return errors.Wrap(err, "Repos.UpdateRepositoryMetadata failed")
 	}
 	return nil
 }
 
+func serveReposMarkDeleted(w http.ResponseWriter, r *http.Request) error {
+	var req api.ReposMarkDeletedRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+	if err := db.Repos.MarkDeleted(r.Context(), req.RepoName); err != nil {
+		return errors.Wrap(err, "Repos.MarkDeleted failed")
+	}
+	return nil
+}
+
 func servePhabricatorRepoCreate(w http.ResponseWriter, r *http.Request) error {
 	var repo api.PhabricatorRepoCreateRequest
 	err := json.NewDecoder(r.Body).Decode(&repo)
@@ -285,12 +298,12 @@ func serveSavedQueriesListAll(w http.ResponseWriter, r *http.Request) error {
 }
 
 func serveSavedQueriesGetInfo(w http.ResponseWriter, r *http.Request) error {
-	var query string
-	err := json.NewDecoder(r.Body).Decode(&query)
+	var key api.SavedQueryInfoKey
+	err := json.NewDecoder(r.Body).Decode(&key)
 	if err != nil {
 		return errors.Wrap(err, "Decode")
 	}
-	info, err := db.SavedQueries.Get(r.Context(), query)
+	info, err := db.SavedQueries.Get(r.Context(), key.Spec, key.Query)
 	if err != nil {
 		return errors.Wrap(err, "SavedQueries.Get")
 	}
@@ -307,6 +320,7 @@ func serveSavedQueriesSetInfo(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(err, "Decode")
 	}
 	err = db.SavedQueries.Set(r.Context(), &db.SavedQueryInfo{
+		Spec:         info.Spec,
 		Query:        info.Query,
 		LastExecuted: info.LastExecuted,
 		LatestResult: info.LatestResult,
@@ -321,12 +335,12 @@ func serveSavedQueriesSetInfo(w http.ResponseWriter, r *http.Request) error {
 }
 
 func serveSavedQueriesDeleteInfo(w http.ResponseWriter, r *http.Request) error {
-	var query string
-	err := json.NewDecoder(r.Body).Decode(&query)
+	var key api.SavedQueryInfoKey
+	err := json.NewDecoder(r.Body).Decode(&key)
 	if err != nil {
 		return errors.Wrap(err, "Decode")
 	}
-	err = db.SavedQueries.Delete(r.Context(), query)
+	err = db.SavedQueries.Delete(r.Context(), key.Spec, key.Query)
 	if err != nil {
 		return errors.Wrap(err, "SavedQueries.Delete")
 	}
@@ -335,6 +349,73 @@ func serveSavedQueriesDeleteInfo(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func serveSavedQueriesRecordExecution(w http.ResponseWriter, r *http.Request) error {
+	var execution api.SavedQueryExecution
+	if err := json.NewDecoder(r.Body).Decode(&execution); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	if err := db.SavedQueryExecutions.Create(r.Context(), &execution); err != nil {
+		return errors.Wrap(err, "SavedQueryExecutions.Create")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+	return nil
+}
+
+func serveSavedQueryNotificationsEnqueue(w http.ResponseWriter, r *http.Request) error {
+	var notifications []*api.SavedQueryNotification
+	if err := json.NewDecoder(r.Body).Decode(&notifications); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	if err := db.SavedQueryNotifications.Enqueue(r.Context(), notifications); err != nil {
+		return errors.Wrap(err, "SavedQueryNotifications.Enqueue")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+	return nil
+}
+
+func serveSavedQueryNotificationsDequeue(w http.ResponseWriter, r *http.Request) error {
+	var limit int
+	if err := json.NewDecoder(r.Body).Decode(&limit); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	notifications, err := db.SavedQueryNotifications.Dequeue(r.Context(), limit)
+	if err != nil {
+		return errors.Wrap(err, "SavedQueryNotifications.Dequeue")
+	}
+	if err := json.NewEncoder(w).Encode(notifications); err != nil {
+		return errors.Wrap(err, "Encode")
+	}
+	return nil
+}
+
+func serveSavedQueryNotificationsUpdate(w http.ResponseWriter, r *http.Request) error {
+	var req api.SavedQueryNotificationsUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	if err := db.SavedQueryNotifications.Update(r.Context(), req.ID, req.Error, req.Permanent); err != nil {
+		return errors.Wrap(err, "SavedQueryNotifications.Update")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+	return nil
+}
+
+func serveCodeMonitorsRunAction(w http.ResponseWriter, r *http.Request) error {
+	var req api.CodeMonitorRunActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	if err := codemonitors.RunAction(r.Context(), &req); err != nil {
+		return errors.Wrap(err, "codemonitors.RunAction")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+	return nil
+}
+
 func serveSettingsGetForSubject(w http.ResponseWriter, r *http.Request) error {
 	var subject api.SettingsSubject
 	if err := json.NewDecoder(r.Body).Decode(&subject); err != nil {
@@ -350,6 +431,21 @@ func serveSettingsGetForSubject(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func serveWebhookSecretsGet(w http.ResponseWriter, r *http.Request) error {
+	var subject api.SettingsSubject
+	if err := json.NewDecoder(r.Body).Decode(&subject); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	secret, err := db.WebhookSecrets.Get(r.Context(), subject)
+	if err != nil {
+		return errors.Wrap(err, "WebhookSecrets.Get")
+	}
+	if err := json.NewEncoder(w).Encode(secret); err != nil {
+		return errors.Wrap(err, "Encode")
+	}
+	return nil
+}
+
 func serveOrgsListUsers(w http.ResponseWriter, r *http.Request) error {
 	var orgID int32
 	err := json.NewDecoder(r.Body).Decode(&orgID)
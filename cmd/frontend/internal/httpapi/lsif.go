@@ -0,0 +1,110 @@
+package httpapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/handlerutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/lsif"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
+)
+
+// serveLSIFUpload stores an LSIF dump (in the request body, optionally gzip-encoded) for the
+// commit given by the "commit" query parameter, e.g. by POSTing the dump to
+// /.api/repos/github.com/foo/bar/-/lsif?commit=$COMMIT.
+func serveLSIFUpload(w http.ResponseWriter, r *http.Request) error {
+	// 🚨 SECURITY: Only site admins may upload LSIF dumps, because the data in the dump (such as
+	// hover contents) is shown to all users who can view the repository.
+	if err := backend.CheckCurrentUserIsSiteAdmin(r.Context()); err != nil {
+		return err
+	}
+
+	repo, err := handlerutil.GetRepo(r.Context(), mux.Vars(r))
+	if err != nil {
+		return err
+	}
+	commitID := r.URL.Query().Get("commit")
+	if !git.IsAbsoluteRevision(commitID) {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("the commit query parameter must be a 40-character commit ID")}
+	}
+
+	// Limit both the size of the request body and, for gzip-encoded dumps, the size of the
+	// decompressed dump (to guard against gzip bombs). The request body is allowed one more byte
+	// than the maximum so that rawBody notices when the maximum is exceeded.
+	maxSize := lsifUploadMaxBytes()
+	rawBody := &sizeLimitedReader{r: http.MaxBytesReader(w, r.Body, maxSize+1), n: maxSize}
+	body := rawBody
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(rawBody)
+		if err != nil {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+		}
+		defer gzipReader.Close()
+		body = &sizeLimitedReader{r: gzipReader, n: maxSize}
+	}
+	documents, err := lsif.Parse(body)
+	if rawBody.exceeded || body.exceeded {
+		return &errcode.HTTPErr{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("LSIF dump exceeds the maximum size of %d bytes (configured in the site configuration property lsif.uploadMaxBytes)", maxSize)}
+	}
+	if err != nil {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+	}
+
+	dump, err := db.LSIFDumps.Create(r.Context(), repo.ID, api.CommitID(commitID), documents)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(struct {
+		ID        int64
+		Documents int
+	}{ID: dump.ID, Documents: len(documents)})
+}
+
+// defaultLSIFUploadMaxBytes is the maximum size of an LSIF dump if the site configuration
+// property lsif.uploadMaxBytes is not set.
+const defaultLSIFUploadMaxBytes = 100 << 20
+
+func lsifUploadMaxBytes() int64 {
+	if max := conf.Get().LsifUploadMaxBytes; max > 0 {
+		return int64(max)
+	}
+	return defaultLSIFUploadMaxBytes
+}
+
+var errLSIFUploadTooLarge = errors.New("LSIF dump too large")
+
+// sizeLimitedReader reads from r until more than n bytes have been read, after which it returns
+// errLSIFUploadTooLarge and records that the limit was exceeded. Unlike io.LimitReader, it does
+// not silently truncate the input (which could turn a too-large dump into a valid but partial
+// one).
+type sizeLimitedReader struct {
+	r        io.Reader
+	n        int64 // remaining number of bytes that may be read
+	exceeded bool
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.exceeded {
+		return 0, errLSIFUploadTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		l.exceeded = true
+		return n, errLSIFUploadTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestSizeLimitedReader(t *testing.T) {
+	tests := map[string]struct {
+		input        string
+		n            int64
+		wantExceeded bool
+	}{
+		"under limit": {input: "abc", n: 4},
+		"at limit":    {input: "abcd", n: 4},
+		"over limit":  {input: "abcde", n: 4, wantExceeded: true},
+		"empty":       {input: "", n: 0},
+		"zero limit":  {input: "a", n: 0, wantExceeded: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &sizeLimitedReader{r: strings.NewReader(test.input), n: test.n}
+			data, err := ioutil.ReadAll(r)
+			if r.exceeded != test.wantExceeded {
+				t.Errorf("got exceeded %v, want %v", r.exceeded, test.wantExceeded)
+			}
+			if test.wantExceeded {
+				if err != errLSIFUploadTooLarge {
+					t.Errorf("got error %v, want %v", err, errLSIFUploadTooLarge)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.input {
+				t.Errorf("got %q, want %q", data, test.input)
+			}
+		})
+	}
+}
+
+func TestSizeLimitedReader_gzipBomb(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(make([]byte, 10<<20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rawBody := &sizeLimitedReader{r: &buf, n: 1 << 20}
+	gr, err := gzip.NewReader(rawBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := &sizeLimitedReader{r: gr, n: 1 << 20}
+	if _, err := ioutil.ReadAll(body); err != errLSIFUploadTooLarge {
+		t.Errorf("got error %v, want %v", err, errLSIFUploadTooLarge)
+	}
+	if rawBody.exceeded {
+		t.Error("want the compressed body to be under the limit")
+	}
+	if !body.exceeded {
+		t.Error("want the decompressed body to exceed the limit")
+	}
+}
@@ -13,37 +13,49 @@ const (
 
 	RepoShield  = "repo.shield"
 	RepoRefresh = "repo.refresh"
+	RepoLSIF    = "repo.lsif"
 	Telemetry   = "telemetry"
 
-	SavedQueriesListAll    = "internal.saved-queries.list-all"
-	SavedQueriesGetInfo    = "internal.saved-queries.get-info"
-	SavedQueriesSetInfo    = "internal.saved-queries.set-info"
-	SavedQueriesDeleteInfo = "internal.saved-queries.delete-info"
-	SettingsGetForSubject  = "internal.settings.get-for-subject"
-	OrgsListUsers          = "internal.orgs.list-users"
-	OrgsGetByName          = "internal.orgs.get-by-name"
-	UsersGetByUsername     = "internal.users.get-by-username"
-	UserEmailsGetEmail     = "internal.user-emails.get-email"
-	ExternalURL            = "internal.app-url"
-	GitServerAddrs         = "internal.git-server-addrs"
-	CanSendEmail           = "internal.can-send-email"
-	SendEmail              = "internal.send-email"
-	Extension              = "internal.extension"
-	GitInfoRefs            = "internal.git.info-refs"
-	GitResolveRevision     = "internal.git.resolve-revision"
-	GitTar                 = "internal.git.tar"
-	GitUploadPack          = "internal.git.upload-pack"
-	PhabricatorRepoCreate  = "internal.phabricator.repo.create"
-	ReposCreateIfNotExists = "internal.repos.create-if-not-exists"
-	ReposGetByName         = "internal.repos.get-by-name"
-	ReposInventoryUncached = "internal.repos.inventory-uncached"
-	ReposInventory         = "internal.repos.inventory"
-	ReposList              = "internal.repos.list"
-	ReposListEnabled       = "internal.repos.list-enabled"
-	ReposUpdateMetadata    = "internal.repos.update-metadata"
-	Configuration          = "internal.configuration"
-	ExternalServiceConfigs = "internal.external-services.configs"
-	ExternalServicesList   = "internal.external-services.list"
+	GitHubWebhook          = "webhooks.github"
+	GitLabWebhook          = "webhooks.gitlab"
+	BitbucketServerWebhook = "webhooks.bitbucket-server"
+
+	SavedQueriesListAll            = "internal.saved-queries.list-all"
+	SavedQueriesGetInfo            = "internal.saved-queries.get-info"
+	SavedQueriesSetInfo            = "internal.saved-queries.set-info"
+	SavedQueriesDeleteInfo         = "internal.saved-queries.delete-info"
+	SavedQueriesRecordExecution    = "internal.saved-queries.record-execution"
+	SavedQueryNotificationsEnqueue = "internal.saved-query-notifications.enqueue"
+	SavedQueryNotificationsDequeue = "internal.saved-query-notifications.dequeue"
+	SavedQueryNotificationsUpdate  = "internal.saved-query-notifications.update"
+	CodeMonitorsRunAction          = "internal.code-monitors.run-action"
+	SettingsGetForSubject          = "internal.settings.get-for-subject"
+	WebhookSecretsGet              = "internal.webhook-secrets.get"
+	OrgsListUsers                  = "internal.orgs.list-users"
+	OrgsGetByName                  = "internal.orgs.get-by-name"
+	UsersGetByUsername             = "internal.users.get-by-username"
+	UserEmailsGetEmail             = "internal.user-emails.get-email"
+	ExternalURL                    = "internal.app-url"
+	GitServerAddrs                 = "internal.git-server-addrs"
+	CanSendEmail                   = "internal.can-send-email"
+	SendEmail                      = "internal.send-email"
+	Extension                      = "internal.extension"
+	GitInfoRefs                    = "internal.git.info-refs"
+	GitResolveRevision             = "internal.git.resolve-revision"
+	GitTar                         = "internal.git.tar"
+	GitUploadPack                  = "internal.git.upload-pack"
+	PhabricatorRepoCreate          = "internal.phabricator.repo.create"
+	ReposCreateIfNotExists         = "internal.repos.create-if-not-exists"
+	ReposGetByName                 = "internal.repos.get-by-name"
+	ReposInventoryUncached         = "internal.repos.inventory-uncached"
+	ReposInventory                 = "internal.repos.inventory"
+	ReposList                      = "internal.repos.list"
+	ReposListEnabled               = "internal.repos.list-enabled"
+	ReposMarkDeleted               = "internal.repos.mark-deleted"
+	ReposUpdateMetadata            = "internal.repos.update-metadata"
+	Configuration                  = "internal.configuration"
+	ExternalServiceConfigs         = "internal.external-services.configs"
+	ExternalServicesList           = "internal.external-services.list"
 )
 
 // New creates a new API router with route URL pattern definitions but
@@ -59,6 +71,10 @@ func New(base *mux.Router) *mux.Router {
 	addGraphQLRoute(base)
 	addTelemetryRoute(base)
 
+	base.Path("/webhooks/github").Methods("POST").Name(GitHubWebhook)
+	base.Path("/webhooks/gitlab").Methods("POST").Name(GitLabWebhook)
+	base.Path("/webhooks/bitbucket-server").Methods("POST").Name(BitbucketServerWebhook)
+
 	// repo contains routes that are NOT specific to a revision. In these routes, the URL may not contain a revspec after the repo (that is, no "github.com/foo/bar@myrevspec").
 	repoPath := `/repos/` + routevar.Repo
 
@@ -67,6 +83,7 @@ func New(base *mux.Router) *mux.Router {
 	repo := base.PathPrefix(repoPath + "/" + routevar.RepoPathDelim + "/").Subrouter()
 	repo.Path("/shield").Methods("GET").Name(RepoShield)
 	repo.Path("/refresh").Methods("POST").Name(RepoRefresh)
+	repo.Path("/lsif").Methods("POST").Name(RepoLSIF)
 
 	return base
 }
@@ -83,7 +100,13 @@ func NewInternal(base *mux.Router) *mux.Router {
 	base.Path("/saved-queries/get-info").Methods("POST").Name(SavedQueriesGetInfo)
 	base.Path("/saved-queries/set-info").Methods("POST").Name(SavedQueriesSetInfo)
 	base.Path("/saved-queries/delete-info").Methods("POST").Name(SavedQueriesDeleteInfo)
+	base.Path("/saved-queries/record-execution").Methods("POST").Name(SavedQueriesRecordExecution)
+	base.Path("/saved-query-notifications/enqueue").Methods("POST").Name(SavedQueryNotificationsEnqueue)
+	base.Path("/saved-query-notifications/dequeue").Methods("POST").Name(SavedQueryNotificationsDequeue)
+	base.Path("/saved-query-notifications/update").Methods("POST").Name(SavedQueryNotificationsUpdate)
+	base.Path("/code-monitors/run-action").Methods("POST").Name(CodeMonitorsRunAction)
 	base.Path("/settings/get-for-subject").Methods("POST").Name(SettingsGetForSubject)
+	base.Path("/webhook-secrets/get").Methods("POST").Name(WebhookSecretsGet)
 	base.Path("/orgs/list-users").Methods("POST").Name(OrgsListUsers)
 	base.Path("/orgs/get-by-name").Methods("POST").Name(OrgsGetByName)
 	base.Path("/users/get-by-username").Methods("POST").Name(UsersGetByUsername)
@@ -105,6 +128,7 @@ func NewInternal(base *mux.Router) *mux.Router {
 	base.Path("/repos/inventory").Methods("POST").Name(ReposInventory)
 	base.Path("/repos/list").Methods("POST").Name(ReposList)
 	base.Path("/repos/list-enabled").Methods("POST").Name(ReposListEnabled)
+	base.Path("/repos/mark-deleted").Methods("POST").Name(ReposMarkDeleted)
 	base.Path("/repos/update-metadata").Methods("POST").Name(ReposUpdateMetadata)
 	base.Path("/repos/{RepoName:.*}").Methods("POST").Name(ReposGetByName)
 	base.Path("/configuration").Methods("POST").Name(Configuration)
@@ -0,0 +1,237 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maxWebhookPayloadSize is the maximum size of a webhook request body that is read. Push event
+// payloads list the pushed commits, so they can be large, but we don't need more than this.
+const maxWebhookPayloadSize = 25 << 20 // 25 MB
+
+var errInvalidWebhookSignature = &errcode.HTTPErr{Status: http.StatusUnauthorized, Err: errors.New("webhook secret does not match the secret of any external service")}
+
+// serveGitHubWebhook handles a GitHub webhook. If it is a push event, it enqueues an update of the
+// pushed repository.
+//
+// 🚨 SECURITY: Webhooks are sent by code hosts, which are not authenticated as any user. The
+// request is only trusted if its X-Hub-Signature is the HMAC of the payload keyed by the
+// webhookSecret of a configured GitHub connection.
+func serveGitHubWebhook(w http.ResponseWriter, r *http.Request) error {
+	payload, err := readWebhookPayload(w, r)
+	if err != nil {
+		return err
+	}
+	conns, err := db.ExternalServices.ListGitHubConnections(r.Context())
+	if err != nil {
+		return err
+	}
+	var conn *schema.GitHubConnection
+	for _, c := range conns {
+		if c.WebhookSecret != "" && validHubSignature(sha1.New, "sha1=", c.WebhookSecret, r.Header.Get("X-Hub-Signature"), payload) {
+			conn = c
+			break
+		}
+	}
+	if conn == nil {
+		return errInvalidWebhookSignature
+	}
+
+	if r.Header.Get("X-GitHub-Event") != "push" {
+		w.WriteHeader(http.StatusNoContent) // e.g. the "ping" event that is sent on webhook creation
+		return nil
+	}
+	var event struct {
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.Repository.FullName == "" {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("invalid GitHub push event payload")}
+	}
+	host, err := connectionHostname(conn.Url)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhookRepoUpdate(r.Context(), w, reposource.GitHubRepoName(conn.RepositoryPathPattern, host, event.Repository.FullName))
+}
+
+// serveGitLabWebhook handles a GitLab webhook. If it is a push event, it enqueues an update of the
+// pushed project.
+//
+// 🚨 SECURITY: Webhooks are sent by code hosts, which are not authenticated as any user. The
+// request is only trusted if its X-Gitlab-Token is the webhookSecret of a configured GitLab
+// connection.
+func serveGitLabWebhook(w http.ResponseWriter, r *http.Request) error {
+	payload, err := readWebhookPayload(w, r)
+	if err != nil {
+		return err
+	}
+	conns, err := db.ExternalServices.ListGitLabConnections(r.Context())
+	if err != nil {
+		return err
+	}
+	token := r.Header.Get("X-Gitlab-Token")
+	var conn *schema.GitLabConnection
+	for _, c := range conns {
+		if c.WebhookSecret != "" && subtle.ConstantTimeCompare([]byte(c.WebhookSecret), []byte(token)) == 1 {
+			conn = c
+			break
+		}
+	}
+	if conn == nil {
+		return errInvalidWebhookSignature
+	}
+
+	var event struct {
+		ObjectKind string `json:"object_kind"`
+		Project    struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("invalid GitLab event payload")}
+	}
+	if event.ObjectKind != "push" && event.ObjectKind != "tag_push" {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	if event.Project.PathWithNamespace == "" {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("invalid GitLab push event payload")}
+	}
+	host, err := connectionHostname(conn.Url)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhookRepoUpdate(r.Context(), w, reposource.GitLabRepoName(conn.RepositoryPathPattern, host, event.Project.PathWithNamespace))
+}
+
+// serveBitbucketServerWebhook handles a Bitbucket Server webhook. If it is a repo:refs_changed
+// event, it enqueues an update of the repository.
+//
+// 🚨 SECURITY: Webhooks are sent by code hosts, which are not authenticated as any user. The
+// request is only trusted if its X-Hub-Signature is the HMAC of the payload keyed by the
+// webhookSecret of a configured Bitbucket Server connection.
+func serveBitbucketServerWebhook(w http.ResponseWriter, r *http.Request) error {
+	payload, err := readWebhookPayload(w, r)
+	if err != nil {
+		return err
+	}
+	conns, err := db.ExternalServices.ListBitbucketServerConnections(r.Context())
+	if err != nil {
+		return err
+	}
+	var conn *schema.BitbucketServerConnection
+	for _, c := range conns {
+		if c.WebhookSecret != "" && validHubSignature(sha256.New, "sha256=", c.WebhookSecret, r.Header.Get("X-Hub-Signature"), payload) {
+			conn = c
+			break
+		}
+	}
+	if conn == nil {
+		return errInvalidWebhookSignature
+	}
+
+	if r.Header.Get("X-Event-Key") != "repo:refs_changed" {
+		w.WriteHeader(http.StatusNoContent) // e.g. the "diagnostics:ping" event
+		return nil
+	}
+	var event struct {
+		Repository struct {
+			Slug    string `json:"slug"`
+			Project struct {
+				Key string `json:"key"`
+			} `json:"project"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.Repository.Slug == "" || event.Repository.Project.Key == "" {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("invalid Bitbucket Server repo:refs_changed event payload")}
+	}
+	host, err := connectionHostname(conn.Url)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhookRepoUpdate(r.Context(), w, reposource.BitbucketServerRepoName(conn.RepositoryPathPattern, host, event.Repository.Project.Key, event.Repository.Slug))
+}
+
+// readWebhookPayload reads the request body. Payloads larger than maxWebhookPayloadSize are
+// rejected instead of being truncated.
+func readWebhookPayload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body := &sizeLimitedReader{r: http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize+1), n: maxWebhookPayloadSize}
+	payload, err := ioutil.ReadAll(body)
+	if body.exceeded {
+		return nil, &errcode.HTTPErr{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("webhook payload exceeds the maximum size of %d bytes", maxWebhookPayloadSize)}
+	}
+	if err != nil {
+		return nil, &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+	}
+	return payload, nil
+}
+
+// validHubSignature reports whether signature (the value of an X-Hub-Signature header, such as
+// "sha1=0123abcd...") is the hex-encoded HMAC of payload keyed by secret.
+func validHubSignature(h func() hash.Hash, prefix, secret, signature string, payload []byte) bool {
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// connectionHostname returns the hostname that repository names of the code host at baseURL are
+// prefixed with (by default).
+func connectionHostname(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	return reposource.NormalizeBaseURL(u).Hostname(), nil
+}
+
+// enqueueWebhookRepoUpdate enqueues an update of the repository, which repo-updater performs ahead
+// of its regularly scheduled updates. Unknown repositories (such as ones that are excluded from
+// syncing) are ignored.
+func enqueueWebhookRepoUpdate(ctx context.Context, w http.ResponseWriter, name api.RepoName) error {
+	repo, err := db.Repos.GetByName(ctx, name)
+	if errcode.IsNotFound(err) {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	gitserverRepo, err := backend.GitRepo(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, gitserverRepo); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
@@ -0,0 +1,196 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/gitserver"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+)
+
+func TestValidHubSignature(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/master"}`)
+	mac := hmac.New(sha1.New, []byte("s3cret"))
+	mac.Write(payload)
+	signature := "sha1=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !validHubSignature(sha1.New, "sha1=", "s3cret", signature, payload) {
+		t.Error("want valid signature")
+	}
+	for name, test := range map[string]struct {
+		secret, signature string
+		payload           []byte
+	}{
+		"wrong secret":  {secret: "other", signature: signature, payload: payload},
+		"wrong payload": {secret: "s3cret", signature: signature, payload: []byte("{}")},
+		"no prefix":     {secret: "s3cret", signature: signature[len("sha1="):], payload: payload},
+		"not hex":       {secret: "s3cret", signature: "sha1=zz", payload: payload},
+		"empty":         {secret: "s3cret", signature: "", payload: payload},
+	} {
+		if validHubSignature(sha1.New, "sha1=", test.secret, test.signature, test.payload) {
+			t.Errorf("%s: want invalid signature", name)
+		}
+	}
+	if validHubSignature(sha256.New, "sha1=", "s3cret", signature, payload) {
+		t.Error("want invalid signature for different hash function")
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	c := newTest()
+
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		switch opt.Kinds[0] {
+		case "GITHUB":
+			return []*types.ExternalService{
+				{Kind: "GITHUB", Config: `{"url": "https://github.example.com", "token": "t"}`},
+				{Kind: "GITHUB", Config: `{"url": "https://github.example.com", "token": "t", "webhookSecret": "gh"}`},
+			}, nil
+		case "GITLAB":
+			return []*types.ExternalService{
+				{Kind: "GITLAB", Config: `{"url": "https://GitLab.example.com", "token": "t", "webhookSecret": "gl", "repositoryPathPattern": "gl/{pathWithNamespace}"}`},
+			}, nil
+		case "BITBUCKET":
+			return []*types.ExternalService{
+				{Kind: "BITBUCKET", Config: `{"url": "https://bitbucket.example.com", "token": "t", "webhookSecret": "bb"}`},
+			}, nil
+		}
+		return nil, nil
+	}
+	db.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 1, Name: name}, nil
+	}
+	repoupdater.MockRepoLookup = func(args protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+		return &protocol.RepoLookupResult{Repo: &protocol.RepoInfo{Name: args.Repo, VCS: protocol.VCSInfo{URL: "https://" + string(args.Repo) + ".git"}}}, nil
+	}
+	var enqueued []api.RepoName
+	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo gitserver.Repo) error {
+		enqueued = append(enqueued, repo.Name)
+		return nil
+	}
+	defer func() {
+		db.Mocks = db.MockStores{}
+		repoupdater.MockRepoLookup = nil
+		repoupdater.MockEnqueueRepoUpdate = nil
+	}()
+
+	sign := func(secret, prefix string, payload []byte) string {
+		h := sha1.New
+		if prefix == "sha256=" {
+			h = sha256.New
+		}
+		mac := hmac.New(h, []byte(secret))
+		mac.Write(payload)
+		return prefix + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	githubPush := []byte(`{"ref":"refs/heads/master","repository":{"full_name":"foo/bar"}}`)
+	gitlabPush := []byte(`{"object_kind":"push","project":{"path_with_namespace":"foo/baz"}}`)
+	githubPushTooLarge := append(append([]byte(`{"ref":"refs/heads/master","repository":{"full_name":"foo/bar"},"x":"`), bytes.Repeat([]byte("x"), maxWebhookPayloadSize)...), `"}`...)
+	bitbucketPush := []byte(`{"eventKey":"repo:refs_changed","repository":{"slug":"qux","project":{"key":"FOO"}}}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		headers    map[string]string
+		payload    []byte
+		wantStatus int
+		wantRepo   api.RepoName
+	}{
+		{
+			name:       "GitHub push",
+			path:       "/webhooks/github",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature": sign("gh", "sha1=", githubPush)},
+			payload:    githubPush,
+			wantStatus: http.StatusAccepted,
+			wantRepo:   "github.example.com/foo/bar",
+		},
+		{
+			name:       "GitHub ping",
+			path:       "/webhooks/github",
+			headers:    map[string]string{"X-GitHub-Event": "ping", "X-Hub-Signature": sign("gh", "sha1=", []byte(`{}`))},
+			payload:    []byte(`{}`),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "GitHub invalid signature",
+			path:       "/webhooks/github",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature": sign("wrong", "sha1=", githubPush)},
+			payload:    githubPush,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "GitHub payload too large",
+			path:       "/webhooks/github",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature": sign("gh", "sha1=", githubPushTooLarge)},
+			payload:    githubPushTooLarge,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "GitLab push",
+			path:       "/webhooks/gitlab",
+			headers:    map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "gl"},
+			payload:    gitlabPush,
+			wantStatus: http.StatusAccepted,
+			wantRepo:   "gl/foo/baz",
+		},
+		{
+			name:       "GitLab invalid token",
+			path:       "/webhooks/gitlab",
+			headers:    map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "wrong"},
+			payload:    gitlabPush,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Bitbucket Server refs changed",
+			path:       "/webhooks/bitbucket-server",
+			headers:    map[string]string{"X-Event-Key": "repo:refs_changed", "X-Hub-Signature": sign("bb", "sha256=", bitbucketPush)},
+			payload:    bitbucketPush,
+			wantStatus: http.StatusAccepted,
+			wantRepo:   "bitbucket.example.com/FOO/qux",
+		},
+		{
+			name:       "Bitbucket Server SHA-1 signature",
+			path:       "/webhooks/bitbucket-server",
+			headers:    map[string]string{"X-Event-Key": "repo:refs_changed", "X-Hub-Signature": sign("bb", "sha1=", bitbucketPush)},
+			payload:    bitbucketPush,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enqueued = nil
+			req, err := http.NewRequest("POST", test.path, bytes.NewReader(test.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("got HTTP %d, want %d", resp.StatusCode, test.wantStatus)
+			}
+			var want []api.RepoName
+			if test.wantRepo != "" {
+				want = []api.RepoName{test.wantRepo}
+			}
+			if len(enqueued) != len(want) || (len(want) == 1 && enqueued[0] != want[0]) {
+				t.Errorf("got enqueued repo updates %v, want %v", enqueued, want)
+			}
+		})
+	}
+}
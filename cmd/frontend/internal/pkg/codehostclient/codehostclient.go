@@ -0,0 +1,98 @@
+// Package codehostclient creates API clients for the code hosts that repositories are mirrored
+// from, using the tokens of the configured external services.
+package codehostclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitHubRepo is a repository on GitHub and a client for the GitHub API.
+type GitHubRepo struct {
+	Client      *github.Client
+	Owner, Name string
+	Conn        *schema.GitHubConnection // the external service connection whose token the client uses
+}
+
+// GitLabProject is a project on GitLab and a client for the GitLab API.
+type GitLabProject struct {
+	Client    *gitlab.Client
+	ProjectID int
+	Conn      *schema.GitLabConnection // the external service connection whose token the client uses
+}
+
+// ForRepo returns a client for the code host of the repository, using the configured external
+// service that the repository was mirrored from. Only GitHub and GitLab are supported. If the
+// error is nil, exactly one of the returned values is non-nil.
+func ForRepo(ctx context.Context, repo *types.Repo) (*GitHubRepo, *GitLabProject, error) {
+	if repo.ExternalRepo == nil {
+		return nil, nil, fmt.Errorf("repository %s has no external service", repo.Name)
+	}
+
+	switch repo.ExternalRepo.ServiceType {
+	case github.ServiceType:
+		conns, err := db.ExternalServices.ListGitHubConnections(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range conns {
+			baseURL, ok := matchServiceID(c.Url, repo.ExternalRepo.ServiceID)
+			if !ok {
+				continue
+			}
+			apiURL, _ := github.APIRoot(baseURL)
+			client := github.NewClient(apiURL, c.Token, nil)
+			ghRepo, err := client.GetRepositoryByNodeID(ctx, "", repo.ExternalRepo.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			owner, name, err := github.SplitRepositoryNameWithOwner(ghRepo.NameWithOwner)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &GitHubRepo{Client: client, Owner: owner, Name: name, Conn: c}, nil, nil
+		}
+
+	case gitlab.ServiceType:
+		conns, err := db.ExternalServices.ListGitLabConnections(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range conns {
+			baseURL, ok := matchServiceID(c.Url, repo.ExternalRepo.ServiceID)
+			if !ok {
+				continue
+			}
+			projectID, err := strconv.Atoi(repo.ExternalRepo.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid GitLab project ID %q of repository %s", repo.ExternalRepo.ID, repo.Name)
+			}
+			client := gitlab.NewClientProvider(baseURL, nil).GetPATClient(c.Token)
+			return nil, &GitLabProject{Client: client, ProjectID: projectID, Conn: c}, nil
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("repository %s is on %s, which is not supported (only GitHub and GitLab are)", repo.Name, repo.ExternalRepo.ServiceType)
+	}
+	return nil, nil, fmt.Errorf("no external service configured for repository %s", repo.Name)
+}
+
+// matchServiceID reports whether the URL of an external service connection refers to the
+// external service with the given ID (its normalized base URL).
+func matchServiceID(connURL, serviceID string) (*url.URL, bool) {
+	baseURL, err := url.Parse(connURL)
+	if err != nil {
+		return nil, false
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+	return baseURL, baseURL.String() == serviceID
+}
@@ -0,0 +1,21 @@
+package codehostclient
+
+import "testing"
+
+func TestMatchServiceID(t *testing.T) {
+	tests := []struct {
+		connURL, serviceID string
+		want               bool
+	}{
+		{"https://github.com", "https://github.com/", true},
+		{"https://GitHub.com/", "https://github.com/", true},
+		{"https://github.example.com", "https://github.com/", false},
+		{"https://gitlab.example.com/gitlab", "https://gitlab.example.com/gitlab/", true},
+		{"://invalid", "https://github.com/", false},
+	}
+	for _, test := range tests {
+		if _, got := matchServiceID(test.connURL, test.serviceID); got != test.want {
+			t.Errorf("%q, %q: got %v, want %v", test.connURL, test.serviceID, got, test.want)
+		}
+	}
+}
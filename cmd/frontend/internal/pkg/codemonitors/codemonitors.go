@@ -0,0 +1,65 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// RunAction runs the action of a code monitor for those of the results that the action didn't
+// already handle in a previous run.
+//
+// Results are recorded as handled as soon as the action handled them, so it is safe to retry
+// RunAction with the same request after it failed.
+func RunAction(ctx context.Context, req *api.CodeMonitorRunActionRequest) error {
+	key, err := actionKey(req.Action)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(req.Results))
+	for i, r := range req.Results {
+		keys[i] = r.Key
+	}
+	handled, err := db.CodeMonitorResults.Handled(ctx, req.Spec, key, keys)
+	if err != nil {
+		return err
+	}
+	var results []*api.CodeMonitorResult
+	for _, r := range req.Results {
+		if !handled[r.Key] {
+			results = append(results, r)
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	switch req.Action.Type {
+	case "issue":
+		return runIssueAction(ctx, req, key, results)
+	case "discussionThread":
+		return runDiscussionThreadAction(ctx, req, key, results)
+	default:
+		return fmt.Errorf("unknown code monitor action type %q", req.Action.Type)
+	}
+}
+
+// actionKey returns the key that identifies the action among the actions of its saved query.
+//
+// Only the type and the repository of the action are part of its identity, so that changing
+// e.g. the title of an issue action keeps updating the same issue.
+func actionKey(action schema.SavedQueryAction) (string, error) {
+	b, err := json.Marshal(schema.SavedQueryAction{Type: action.Type, Repository: action.Repository})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
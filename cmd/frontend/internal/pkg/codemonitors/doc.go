@@ -0,0 +1,3 @@
+// Package codemonitors runs the actions of code monitors (saved queries with actions), such as
+// opening an issue or a discussion thread, for the new results of the saved queries.
+package codemonitors
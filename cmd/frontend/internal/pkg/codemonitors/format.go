@@ -0,0 +1,88 @@
+package codemonitors
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+// maxListedResults is the maximum number of results that are listed in an issue or comment.
+const maxListedResults = 50
+
+// formatResults returns a Markdown list of the results, linking each result to Sourcegraph.
+//
+// If previews is false, each result is only described by its location (such as its repository,
+// file path and line number), not by its label (which contains the matching line or commit
+// message). Results posted outside of Sourcegraph must not contain code.
+func formatResults(results []*api.CodeMonitorResult, previews bool) string {
+	var buf bytes.Buffer
+	for i, r := range results {
+		if i == maxListedResults {
+			fmt.Fprintf(&buf, "- ...and %d more\n", len(results)-maxListedResults)
+			break
+		}
+		label := r.Label
+		if !previews {
+			label = resultLocation(r)
+		}
+		// Backticks can't be escaped in a code span.
+		label = strings.Replace(label, "`", "'", -1)
+		fmt.Fprintf(&buf, "- [`%s`](%s)\n", label, resultURL(r))
+	}
+	return buf.String()
+}
+
+// resultLocation describes where the result is, such as "github.com/a/b/c.go:3".
+func resultLocation(r *api.CodeMonitorResult) string {
+	switch {
+	case r.Commit != "":
+		commit := string(r.Commit)
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		return string(r.Repo) + "@" + commit
+	case r.Path != "" && r.Line > 0:
+		return fmt.Sprintf("%s/%s:%d", r.Repo, r.Path, r.Line)
+	case r.Path != "":
+		return string(r.Repo) + "/" + r.Path
+	default:
+		return string(r.Repo)
+	}
+}
+
+// resultURL returns the URL of the result on Sourcegraph.
+func resultURL(r *api.CodeMonitorResult) *url.URL {
+	repoRev := string(r.Repo)
+	if r.Rev != "" {
+		repoRev += "@" + r.Rev
+	}
+
+	var u *url.URL
+	switch {
+	case r.Commit != "":
+		u = &url.URL{Path: path.Join("/", string(r.Repo), "/-/commit/", string(r.Commit))}
+	case r.Path != "":
+		u = &url.URL{Path: path.Join("/", repoRev, "/-/blob/", r.Path)}
+		if r.Line > 0 {
+			u.Fragment = fmt.Sprintf("L%d", r.Line)
+		}
+	default:
+		u = &url.URL{Path: path.Join("/", repoRev)}
+	}
+	return globals.ExternalURL.ResolveReference(u)
+}
+
+// formatIntro returns the Markdown sentence that introduces the results of the saved query.
+func formatIntro(req *api.CodeMonitorRunActionRequest, count int) string {
+	noun := "new results"
+	if count == 1 {
+		noun = "a new result"
+	}
+	u := globals.ExternalURL.ResolveReference(&url.URL{Path: "/search", RawQuery: url.Values{"q": {req.Query}}.Encode()})
+	return fmt.Sprintf("The saved search [%s](%s) found %s:", req.Description, u, noun)
+}
@@ -0,0 +1,92 @@
+package codemonitors
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	globals.ExternalURL = &url.URL{Scheme: "https", Host: "sourcegraph.example.com"}
+}
+
+func TestResultURL(t *testing.T) {
+	tests := map[string]struct {
+		result *api.CodeMonitorResult
+		want   string
+	}{
+		"repository": {
+			result: &api.CodeMonitorResult{Repo: "github.com/a/b"},
+			want:   "https://sourcegraph.example.com/github.com/a/b",
+		},
+		"file": {
+			result: &api.CodeMonitorResult{Repo: "github.com/a/b", Rev: "dev", Path: "c/d.go"},
+			want:   "https://sourcegraph.example.com/github.com/a/b@dev/-/blob/c/d.go",
+		},
+		"line": {
+			result: &api.CodeMonitorResult{Repo: "github.com/a/b", Path: "c/d.go", Line: 3},
+			want:   "https://sourcegraph.example.com/github.com/a/b/-/blob/c/d.go#L3",
+		},
+		"commit": {
+			result: &api.CodeMonitorResult{Repo: "github.com/a/b", Commit: "abc"},
+			want:   "https://sourcegraph.example.com/github.com/a/b/-/commit/abc",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := resultURL(test.result).String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatResults(t *testing.T) {
+	result := &api.CodeMonitorResult{Repo: "github.com/a/b", Path: "c.go", Line: 1, Label: "c.go:1: `x`"}
+	got := formatResults([]*api.CodeMonitorResult{result}, true)
+	want := "- [`c.go:1: 'x'`](https://sourcegraph.example.com/github.com/a/b/-/blob/c.go#L1)\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Without previews, only the location of the result is posted.
+	got = formatResults([]*api.CodeMonitorResult{result, {Repo: "github.com/a/b", Commit: "abcdef0123", Label: "github.com/a/b@abcdef0: secret"}}, false)
+	want = "- [`github.com/a/b/c.go:1`](https://sourcegraph.example.com/github.com/a/b/-/blob/c.go#L1)\n" +
+		"- [`github.com/a/b@abcdef0`](https://sourcegraph.example.com/github.com/a/b/-/commit/abcdef0123)\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	results := make([]*api.CodeMonitorResult, maxListedResults+2)
+	for i := range results {
+		results[i] = &api.CodeMonitorResult{Repo: "r"}
+	}
+	got = formatResults(results, true)
+	if n := strings.Count(got, "\n"); n != maxListedResults+1 {
+		t.Errorf("got %d lines, want %d", n, maxListedResults+1)
+	}
+	if !strings.HasSuffix(got, "- ...and 2 more\n") {
+		t.Errorf("got %q, want it to end with the number of unlisted results", got)
+	}
+}
+
+func TestActionKey(t *testing.T) {
+	key := func(action schema.SavedQueryAction) string {
+		k, err := actionKey(action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+	issue := schema.SavedQueryAction{Type: "issue", Repository: "github.com/a/b", Title: "t"}
+	if key(issue) != key(schema.SavedQueryAction{Type: "issue", Repository: "github.com/a/b", Title: "u", Labels: []string{"l"}}) {
+		t.Error("got different keys for actions that differ only in title and labels, want the same key")
+	}
+	if key(issue) == key(schema.SavedQueryAction{Type: "issue", Repository: "github.com/a/c"}) {
+		t.Error("got the same key for actions on different repositories, want different keys")
+	}
+}
@@ -0,0 +1,136 @@
+package codemonitors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/codehostclient"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitlab"
+)
+
+// runIssueAction opens an issue listing the results in the action's repository. Once the issue
+// is open, the results of later runs are added to it as comments instead of opening new issues.
+//
+// Issues are opened with the token of a site-configured external service connection, so only
+// saved queries in global settings (which only site admins can edit) may have issue actions.
+func runIssueAction(ctx context.Context, req *api.CodeMonitorRunActionRequest, actionKey string, results []*api.CodeMonitorResult) error {
+	if !req.Spec.Subject.Site {
+		return errors.New("issue actions are only supported for saved queries in global settings")
+	}
+	if req.Action.Repository == "" {
+		return errors.New("issue action has no repository")
+	}
+	repo, err := db.Repos.GetByName(ctx, api.RepoName(req.Action.Repository))
+	if err != nil {
+		return err
+	}
+	tracker, err := issueTrackerForRepo(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	targetID, targetURL, err := db.CodeMonitorResults.LatestTarget(ctx, req.Spec, actionKey)
+	if err != nil {
+		return err
+	}
+	body := formatIntro(req, len(results)) + "\n\n" + formatResults(results, false)
+	if targetID == "" {
+		title := req.Action.Title
+		if title == "" {
+			title = "Saved search: " + req.Description
+		}
+		targetID, targetURL, err = tracker.CreateIssue(ctx, title, body, req.Action.Labels)
+	} else {
+		err = tracker.CommentOnIssue(ctx, targetID, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(results))
+	for i, r := range results {
+		keys[i] = r.Key
+	}
+	return db.CodeMonitorResults.Create(ctx, req.Spec, actionKey, keys, targetID, targetURL)
+}
+
+// issueTracker opens and comments on issues of a single repository.
+type issueTracker interface {
+	// CreateIssue opens an issue and returns its ID (in the repository) and URL.
+	CreateIssue(ctx context.Context, title, body string, labels []string) (id, webURL string, err error)
+
+	// CommentOnIssue adds a comment to the issue with the given ID.
+	CommentOnIssue(ctx context.Context, id, body string) error
+}
+
+// issueTrackerForRepo returns the issue tracker of the repository, using the configured external
+// service that the repository was mirrored from. The connection must have allowCodeMonitorIssues
+// set.
+func issueTrackerForRepo(ctx context.Context, repo *types.Repo) (issueTracker, error) {
+	gh, gl, err := codehostclient.ForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if gh != nil {
+		if !gh.Conn.AllowCodeMonitorIssues {
+			return nil, errIssuesNotAllowed(repo)
+		}
+		return &githubIssueTracker{client: gh.Client, owner: gh.Owner, name: gh.Name}, nil
+	}
+	if !gl.Conn.AllowCodeMonitorIssues {
+		return nil, errIssuesNotAllowed(repo)
+	}
+	return &gitlabIssueTracker{client: gl.Client, projectID: gl.ProjectID}, nil
+}
+
+func errIssuesNotAllowed(repo *types.Repo) error {
+	return fmt.Errorf("the external service connection of repository %s does not allow code monitors to open issues (set allowCodeMonitorIssues in its site configuration to allow it)", repo.Name)
+}
+
+type githubIssueTracker struct {
+	client      *github.Client
+	owner, name string
+}
+
+func (t *githubIssueTracker) CreateIssue(ctx context.Context, title, body string, labels []string) (id, webURL string, err error) {
+	issue, err := t.client.CreateIssue(ctx, t.owner, t.name, title, body, labels)
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.Itoa(issue.Number), issue.HTMLURL, nil
+}
+
+func (t *githubIssueTracker) CommentOnIssue(ctx context.Context, id, body string) error {
+	number, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+	return t.client.CreateIssueComment(ctx, t.owner, t.name, number, body)
+}
+
+type gitlabIssueTracker struct {
+	client    *gitlab.Client
+	projectID int
+}
+
+func (t *gitlabIssueTracker) CreateIssue(ctx context.Context, title, body string, labels []string) (id, webURL string, err error) {
+	issue, err := t.client.CreateIssue(ctx, t.projectID, title, body, labels)
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.Itoa(issue.IID), issue.WebURL, nil
+}
+
+func (t *gitlabIssueTracker) CommentOnIssue(ctx context.Context, id, body string) error {
+	iid, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+	return t.client.CreateIssueNote(ctx, t.projectID, iid, body)
+}
@@ -0,0 +1,136 @@
+package codemonitors
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/actor"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// maxDiscussionThreads is the maximum number of discussion threads that a single run of a
+// discussion thread action creates. If there are more results, a single thread lists them all.
+const maxDiscussionThreads = 10
+
+// runDiscussionThreadAction creates a discussion thread for each result, anchored at the matched
+// line (or file, or repository). The threads are authored by the user whose saved query it is.
+func runDiscussionThreadAction(ctx context.Context, req *api.CodeMonitorRunActionRequest, actionKey string, results []*api.CodeMonitorResult) error {
+	if req.Spec.Subject.User == nil {
+		return errors.New("discussion thread actions are only supported for saved queries in user settings")
+	}
+
+	// Look up the repositories as the user, so that no thread is created for a result in a
+	// repository that the user can't access.
+	ctx = actor.WithActor(ctx, actor.FromUser(*req.Spec.Subject.User))
+	var (
+		repos      []*types.Repo
+		accessible []*api.CodeMonitorResult
+	)
+	for _, r := range results {
+		repo, err := db.Repos.GetByName(ctx, r.Repo)
+		if errcode.IsNotFound(err) {
+			// The repository was removed since the saved query ran, or the user can't access it.
+			log15.Warn("code monitor: skipping result in unknown repository", "repo", r.Repo)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		repos = append(repos, repo)
+		accessible = append(accessible, r)
+	}
+
+	if len(accessible) > maxDiscussionThreads {
+		// Don't flood the repositories with threads. Create a single thread that lists all of
+		// the results instead, in the repository of the first result.
+		return createResultsThread(ctx, req, actionKey, &types.DiscussionThreadTargetRepo{RepoID: repos[0].ID}, accessible)
+	}
+	for i, r := range accessible {
+		if err := createResultsThread(ctx, req, actionKey, threadTarget(ctx, repos[i], r), []*api.CodeMonitorResult{r}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createResultsThread creates a discussion thread that lists the results and records the results
+// as handled.
+func createResultsThread(ctx context.Context, req *api.CodeMonitorRunActionRequest, actionKey string, target *types.DiscussionThreadTargetRepo, results []*api.CodeMonitorResult) error {
+	thread, err := db.DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: *req.Spec.Subject.User,
+		Title:        req.Description,
+		TargetRepo:   target,
+	})
+	if err != nil {
+		return err
+	}
+	comment, err := db.DiscussionComments.Create(ctx, &types.DiscussionComment{
+		ThreadID:     thread.ID,
+		AuthorUserID: *req.Spec.Subject.User,
+		Contents:     formatIntro(req, len(results)) + "\n\n" + formatResults(results, true),
+	})
+	if err != nil {
+		return err
+	}
+	discussions.NotifyNewThread(thread, comment)
+
+	var targetURL string
+	if u, err := discussions.URLToInlineThread(ctx, thread); err == nil && u != nil {
+		targetURL = u.String()
+	}
+	keys := make([]string, len(results))
+	for i, r := range results {
+		keys[i] = r.Key
+	}
+	return db.CodeMonitorResults.Create(ctx, req.Spec, actionKey, keys, strconv.FormatInt(thread.ID, 10), targetURL)
+}
+
+// threadTarget returns the target of the discussion thread for the result. For a line match, the
+// thread is anchored at the matched line, with the surrounding lines read from the repository.
+func threadTarget(ctx context.Context, repo *types.Repo, r *api.CodeMonitorResult) *types.DiscussionThreadTargetRepo {
+	target := &types.DiscussionThreadTargetRepo{RepoID: repo.ID}
+	if r.Rev != "" {
+		rev := r.Rev
+		target.Branch = &rev
+	}
+	if r.Commit != "" {
+		commit := string(r.Commit)
+		target.Revision = &commit
+	}
+	if r.Path == "" {
+		return target
+	}
+	path := r.Path
+	target.Path = &path
+	if r.Line <= 0 {
+		return target
+	}
+
+	startLine, endLine, zero := int32(r.Line-1), int32(r.Line), int32(0)
+	target.StartLine, target.EndLine = &startLine, &endLine
+	target.StartCharacter, target.EndCharacter = &zero, &zero
+
+	linesBefore, lines, linesAfter := []string{}, []string{r.Preview}, []string{}
+	if commit, err := backend.Repos.ResolveRev(ctx, repo, r.Rev); err == nil {
+		revision := string(commit)
+		target.Revision = &revision
+		if gitRepo, err := backend.CachedGitRepo(ctx, repo); err == nil {
+			if content, err := git.ReadFile(ctx, *gitRepo, commit, r.Path); err == nil {
+				linesBefore, lines, linesAfter = discussions.LinesForSelection(string(content), discussions.LineRange{
+					StartLine: int(startLine),
+					EndLine:   int(endLine),
+				})
+			}
+		}
+	}
+	target.LinesBefore, target.Lines, target.LinesAfter = &linesBefore, &lines, &linesAfter
+	return target
+}
@@ -0,0 +1,71 @@
+package codehostsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/codehostclient"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+// codeHost lists and replies to the review comments on the open pull requests of a single
+// repository.
+type codeHost interface {
+	// OpenPullRequests lists the open pull requests.
+	OpenPullRequests(ctx context.Context) ([]*pullRequest, error)
+
+	// ReviewThreads lists the threads of review comments on the pull request.
+	ReviewThreads(ctx context.Context, pr *pullRequest) ([]*reviewThread, error)
+
+	// Reply adds a comment to the review thread with the given ID, and returns the ID of the
+	// comment.
+	Reply(ctx context.Context, pullRequestID, threadID, body string) (id string, err error)
+}
+
+// pullRequest is an open pull request (GitHub) or merge request (GitLab).
+type pullRequest struct {
+	ID        string    // the number (GitHub) or IID (GitLab) of the pull request
+	UpdatedAt time.Time // when the pull request (including its review comments) last changed
+
+	raw interface{} // the pull request as returned by the code host's API
+}
+
+// reviewThread is a thread of review comments on a line of the diff of a pull request.
+type reviewThread struct {
+	PullRequestID string // the number (GitHub) or IID (GitLab) of the pull request
+	ID            string // the ID of the first comment (GitHub) or of the discussion (GitLab)
+
+	BaseBranch, BaseRevision string // the branch that the pull request is merged into, at the commit the comment was made against
+	HeadBranch, HeadRevision string // the branch of the pull request, at the commented commit
+
+	Path   string
+	Line   int  // the (1-based) line commented on
+	OnBase bool // whether the line was removed (so Line is a line of the base revision)
+
+	// Hunk is the line commented on as described by the diff hunk, if the code host provides it.
+	Hunk *hunkLine
+
+	// Comments are the comments of the thread, oldest first.
+	Comments []*reviewComment
+}
+
+// reviewComment is a comment in a review thread.
+type reviewComment struct {
+	ID     string
+	Author string // the username of the author on the code host
+	Body   string
+	URL    string // the web URL of the comment
+}
+
+// codeHostForRepo returns the code host of the repository, using the configured external service
+// that the repository was mirrored from.
+func codeHostForRepo(ctx context.Context, repo *types.Repo) (codeHost, error) {
+	gh, gl, err := codehostclient.ForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if gh != nil {
+		return &githubCodeHost{client: gh.Client, owner: gh.Owner, name: gh.Name}, nil
+	}
+	return &gitlabCodeHost{client: gl.Client, projectID: gl.ProjectID}, nil
+}
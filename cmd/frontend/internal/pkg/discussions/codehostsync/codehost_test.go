@@ -0,0 +1,128 @@
+package codehostsync
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitlab"
+)
+
+func TestGitHubReviewThreads(t *testing.T) {
+	pr := &github.PullRequest{Number: 12}
+	pr.Base.Ref, pr.Base.SHA = "master", "e242ed3bffccdf271b7fbaf34ed72d089537b42f"
+	pr.Head.Ref, pr.Head.SHA = "retry-fetch", "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15"
+
+	comment := func(id, inReplyTo int64, login, body string) *github.PullRequestReviewComment {
+		c := &github.PullRequestReviewComment{
+			ID:               id,
+			InReplyToID:      inReplyTo,
+			Path:             "fetch.go",
+			OriginalCommitID: "0c1a96370c1a96370c1a96370c1a96370c1a9637",
+			DiffHunk:         "@@ -10,2 +10,3 @@\n \ta\n+\tb",
+			Body:             body,
+			HTMLURL:          "https://github.com/o/r/pull/12#discussion_r1",
+		}
+		c.User.Login = login
+		return c
+	}
+	invalid := comment(3, 0, "carol", "invalid")
+	invalid.DiffHunk = "invalid"
+
+	threads := githubReviewThreads(pr, []*github.PullRequestReviewComment{
+		comment(1, 0, "bob", "Should this give up?"),
+		invalid,
+		comment(2, 1, "alice", "It does."),
+		comment(4, 3, "alice", "reply to invalid"),
+	})
+	if len(threads) != 1 {
+		t.Fatalf("got %d threads, want 1", len(threads))
+	}
+	got := threads[0]
+	want := &reviewThread{
+		PullRequestID: "12",
+		ID:            "1",
+		BaseBranch:    "master",
+		HeadBranch:    "retry-fetch",
+		HeadRevision:  "0c1a96370c1a96370c1a96370c1a96370c1a9637",
+		Path:          "fetch.go",
+		Line:          11,
+		Hunk:          &hunkLine{line: 11, linesBefore: []string{"\ta"}, content: "\tb"},
+		Comments: []*reviewComment{
+			{ID: "1", Author: "bob", Body: "Should this give up?", URL: "https://github.com/o/r/pull/12#discussion_r1"},
+			{ID: "2", Author: "alice", Body: "It does.", URL: "https://github.com/o/r/pull/12#discussion_r1"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGitLabReviewThreads(t *testing.T) {
+	mr := &gitlab.MergeRequest{IID: 4, WebURL: "https://gitlab.com/o/r/merge_requests/4", SourceBranch: "retry-fetch", TargetBranch: "master"}
+	note := func(id int, username, body string, system bool, pos *gitlab.NotePosition) *gitlab.Note {
+		n := &gitlab.Note{ID: id, Body: body, System: system, Position: pos}
+		n.Author.Username = username
+		return n
+	}
+	line := 14
+	added := &gitlab.NotePosition{BaseSHA: "b", HeadSHA: "h", OldPath: "old.go", NewPath: "new.go", NewLine: &line}
+	removed := &gitlab.NotePosition{BaseSHA: "b", HeadSHA: "h", OldPath: "old.go", NewPath: "new.go", OldLine: &line}
+
+	threads := gitlabReviewThreads(mr, []*gitlab.Discussion{
+		{ID: "system", IndividualNote: true, Notes: []*gitlab.Note{note(1, "alice", "added 1 commit", true, nil)}},
+		{ID: "general", Notes: []*gitlab.Note{note(2, "bob", "Looks good", false, nil)}},
+		{ID: "added", Notes: []*gitlab.Note{note(3, "bob", "Should this give up?", false, added), note(4, "alice", "resolved", true, added), note(5, "alice", "It does.", false, added)}},
+		{ID: "removed", Notes: []*gitlab.Note{note(6, "bob", "Why remove this?", false, removed)}},
+	})
+	if len(threads) != 2 {
+		t.Fatalf("got %d threads, want 2", len(threads))
+	}
+	want := &reviewThread{
+		PullRequestID: "4",
+		ID:            "added",
+		BaseBranch:    "master",
+		BaseRevision:  "b",
+		HeadBranch:    "retry-fetch",
+		HeadRevision:  "h",
+		Path:          "new.go",
+		Line:          14,
+		Comments: []*reviewComment{
+			{ID: "3", Author: "bob", Body: "Should this give up?", URL: "https://gitlab.com/o/r/merge_requests/4#note_3"},
+			{ID: "5", Author: "alice", Body: "It does.", URL: "https://gitlab.com/o/r/merge_requests/4#note_5"},
+		},
+	}
+	if !reflect.DeepEqual(threads[0], want) {
+		t.Errorf("got %+v, want %+v", threads[0], want)
+	}
+	if got := threads[1]; got.Path != "old.go" || got.Line != 14 || !got.OnBase {
+		t.Errorf("got thread on %s:%d (on base: %v), want old.go:14 on base", got.Path, got.Line, got.OnBase)
+	}
+}
+
+func TestThreadTitle(t *testing.T) {
+	tests := map[string]string{
+		"":                           "Review comment on a.go",
+		"  \n":                       "Review comment on a.go",
+		"Should this give up?\nok":   "Should this give up?",
+		strings.Repeat("x", 100):     strings.Repeat("x", maxTitleLength-1) + "…",
+		"\n  Leading whitespace  \n": "Leading whitespace",
+	}
+	for body, want := range tests {
+		if got := threadTitle(&reviewThread{Path: "a.go", Comments: []*reviewComment{{Body: body}}}); got != want {
+			t.Errorf("%q: got %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestFormatReply(t *testing.T) {
+	u, _ := url.Parse("https://sourcegraph.example.com/github.com/o/r/-/compare/a...b#tab=discussions&threadID=1")
+	if got, want := formatReply("alice", u, "It does."), "**@alice** replied on [Sourcegraph](https://sourcegraph.example.com/github.com/o/r/-/compare/a...b#tab=discussions&threadID=1):\n\nIt does."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := formatReply("alice", nil, "It does."), "**@alice** replied on Sourcegraph:\n\nIt does."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
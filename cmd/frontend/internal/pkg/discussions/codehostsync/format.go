@@ -0,0 +1,43 @@
+package codehostsync
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxTitleLength is the maximum length (in characters) of the titles of imported threads.
+const maxTitleLength = 80
+
+// threadTitle returns the title of the discussion thread for the review thread: the first line of
+// its first comment.
+func threadTitle(t *reviewThread) string {
+	var title string
+	if len(t.Comments) > 0 {
+		title = strings.TrimSpace(strings.SplitN(strings.TrimSpace(t.Comments[0].Body), "\n", 2)[0])
+	}
+	if title == "" {
+		return "Review comment on " + t.Path
+	}
+	if r := []rune(title); len(r) > maxTitleLength {
+		title = string(r[:maxTitleLength-1]) + "…"
+	}
+	return title
+}
+
+// formatImported returns the contents of the discussion comment imported from the review comment.
+// Imported comments are all authored by the same Sourcegraph user, so the contents name the
+// author on the code host.
+func formatImported(c *reviewComment) string {
+	return fmt.Sprintf("**@%s** commented on [the pull request](%s):\n\n%s", c.Author, c.URL, c.Body)
+}
+
+// formatReply returns the body of the review comment that posts a comment made on Sourcegraph to
+// the code host. Replies are all posted with the token of the external service, so the body names
+// the author on Sourcegraph.
+func formatReply(username string, threadURL *url.URL, contents string) string {
+	if threadURL == nil {
+		return fmt.Sprintf("**@%s** replied on Sourcegraph:\n\n%s", username, contents)
+	}
+	return fmt.Sprintf("**@%s** replied on [Sourcegraph](%s):\n\n%s", username, threadURL, contents)
+}
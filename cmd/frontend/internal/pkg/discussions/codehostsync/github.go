@@ -0,0 +1,126 @@
+package codehostsync
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/rcache"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+type githubCodeHost struct {
+	client      *github.Client
+	owner, name string
+}
+
+func (h *githubCodeHost) OpenPullRequests(ctx context.Context) ([]*pullRequest, error) {
+	pulls, err := h.client.ListOpenPullRequests(ctx, h.owner, h.name)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*pullRequest, len(pulls))
+	for i, pr := range pulls {
+		prs[i] = &pullRequest{ID: strconv.Itoa(pr.Number), UpdatedAt: pr.UpdatedAt, raw: pr}
+	}
+	return prs, nil
+}
+
+func (h *githubCodeHost) ReviewThreads(ctx context.Context, pr *pullRequest) ([]*reviewThread, error) {
+	pull := pr.raw.(*github.PullRequest)
+	comments, err := h.client.ListPullRequestReviewComments(ctx, h.owner, h.name, pull.Number)
+	if err != nil {
+		return nil, err
+	}
+	threads := githubReviewThreads(pull, comments)
+	for _, t := range threads {
+		t.BaseRevision, err = h.mergeBase(ctx, pull.Base.SHA, t.HeadRevision)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return threads, nil
+}
+
+// mergeBaseCache caches merge bases, which never change, by repository and commits.
+var mergeBaseCache = rcache.New("discussions-codehostsync-mergebase")
+
+// mergeBase returns the base commit that a review comment on the head commit was made against:
+// the merge base of the head commit and the base branch. The merge base stays the same when the
+// base branch moves (unless it is merged into the pull request, which creates a new head commit),
+// so the current revision of the base branch can be used.
+func (h *githubCodeHost) mergeBase(ctx context.Context, base, head string) (string, error) {
+	key := h.owner + "/" + h.name + ":" + base + "..." + head
+	if b, ok := mergeBaseCache.Get(key); ok {
+		return string(b), nil
+	}
+	mergeBase, err := h.client.GetMergeBase(ctx, h.owner, h.name, base, head)
+	if err != nil {
+		return "", err
+	}
+	mergeBaseCache.Set(key, []byte(mergeBase))
+	return mergeBase, nil
+}
+
+// githubReviewThreads groups the review comments of the pull request into threads. GitHub lists
+// replies after the comments they reply to, and all replies in a thread refer to its first comment.
+//
+// The BaseRevision of the threads is left empty, because GitHub doesn't return the base commit
+// that a comment was made against (see mergeBase).
+func githubReviewThreads(pr *github.PullRequest, comments []*github.PullRequestReviewComment) []*reviewThread {
+	var (
+		threads []*reviewThread
+		byID    = map[int64]*reviewThread{}
+	)
+	for _, c := range comments {
+		comment := &reviewComment{
+			ID:     strconv.FormatInt(c.ID, 10),
+			Author: c.User.Login,
+			Body:   c.Body,
+			URL:    c.HTMLURL,
+		}
+		if c.InReplyToID != 0 {
+			if t := byID[c.InReplyToID]; t != nil {
+				t.Comments = append(t.Comments, comment)
+			}
+			continue
+		}
+
+		hunk, err := parseDiffHunk(c.DiffHunk)
+		if err != nil {
+			log15.Warn("discussions: code host sync: skipping review comment with invalid diff hunk", "url", c.HTMLURL, "error", err)
+			continue
+		}
+		t := &reviewThread{
+			PullRequestID: strconv.Itoa(pr.Number),
+			ID:            comment.ID,
+			BaseBranch:    pr.Base.Ref,
+			HeadBranch:    pr.Head.Ref,
+			HeadRevision:  c.OriginalCommitID,
+			Path:          c.Path,
+			Line:          hunk.line,
+			OnBase:        hunk.onBase,
+			Hunk:          hunk,
+			Comments:      []*reviewComment{comment},
+		}
+		threads = append(threads, t)
+		byID[c.ID] = t
+	}
+	return threads
+}
+
+func (h *githubCodeHost) Reply(ctx context.Context, pullRequestID, threadID, body string) (string, error) {
+	number, err := strconv.Atoi(pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	inReplyTo, err := strconv.ParseInt(threadID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	comment, err := h.client.CreatePullRequestReviewCommentReply(ctx, h.owner, h.name, number, inReplyTo, body)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(comment.ID, 10), nil
+}
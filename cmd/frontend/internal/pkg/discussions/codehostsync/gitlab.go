@@ -0,0 +1,92 @@
+package codehostsync
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitlab"
+)
+
+type gitlabCodeHost struct {
+	client    *gitlab.Client
+	projectID int
+}
+
+func (h *gitlabCodeHost) OpenPullRequests(ctx context.Context) ([]*pullRequest, error) {
+	mrs, err := h.client.ListOpenMergeRequests(ctx, h.projectID)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*pullRequest, len(mrs))
+	for i, mr := range mrs {
+		prs[i] = &pullRequest{ID: strconv.Itoa(mr.IID), UpdatedAt: mr.UpdatedAt, raw: mr}
+	}
+	return prs, nil
+}
+
+func (h *gitlabCodeHost) ReviewThreads(ctx context.Context, pr *pullRequest) ([]*reviewThread, error) {
+	mr := pr.raw.(*gitlab.MergeRequest)
+	discussions, err := h.client.ListMergeRequestDiscussions(ctx, h.projectID, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+	return gitlabReviewThreads(mr, discussions), nil
+}
+
+// gitlabReviewThreads returns the discussions of the merge request that were started on a line of
+// its diff. Other discussions and system notes (such as "added 1 commit") are omitted.
+func gitlabReviewThreads(mr *gitlab.MergeRequest, discussions []*gitlab.Discussion) []*reviewThread {
+	var threads []*reviewThread
+	for _, d := range discussions {
+		if d.IndividualNote || len(d.Notes) == 0 {
+			continue
+		}
+		first := d.Notes[0]
+		if first.System || first.Position == nil {
+			continue
+		}
+
+		pos := first.Position
+		t := &reviewThread{
+			PullRequestID: strconv.Itoa(mr.IID),
+			ID:            d.ID,
+			BaseBranch:    mr.TargetBranch,
+			BaseRevision:  pos.BaseSHA,
+			HeadBranch:    mr.SourceBranch,
+			HeadRevision:  pos.HeadSHA,
+		}
+		switch {
+		case pos.NewLine != nil:
+			t.Path, t.Line = pos.NewPath, *pos.NewLine
+		case pos.OldLine != nil:
+			t.Path, t.Line, t.OnBase = pos.OldPath, *pos.OldLine, true
+		default:
+			continue
+		}
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			t.Comments = append(t.Comments, &reviewComment{
+				ID:     strconv.Itoa(n.ID),
+				Author: n.Author.Username,
+				Body:   n.Body,
+				URL:    mr.WebURL + "#note_" + strconv.Itoa(n.ID),
+			})
+		}
+		threads = append(threads, t)
+	}
+	return threads
+}
+
+func (h *gitlabCodeHost) Reply(ctx context.Context, pullRequestID, threadID, body string) (string, error) {
+	iid, err := strconv.Atoi(pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	note, err := h.client.CreateMergeRequestDiscussionNote(ctx, h.projectID, iid, threadID, body)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(note.ID), nil
+}
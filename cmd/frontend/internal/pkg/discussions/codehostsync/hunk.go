@@ -0,0 +1,78 @@
+package codehostsync
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkLine is the line that a review comment was made on, as described by the diff hunk that GitHub
+// includes with each review comment (which ends with the line commented on).
+type hunkLine struct {
+	// line is the (1-based) line number, in the old file if onBase, else in the new file.
+	line int
+
+	// onBase is whether the line was removed, so that it only exists in the base of the diff.
+	onBase bool
+
+	// linesBefore are the lines of the same file preceding the line in the hunk (at most
+	// contextLines of them), and content is the line itself.
+	linesBefore []string
+	content     string
+}
+
+// contextLines is the number of lines before the commented line that are stored with the thread,
+// as in discussions.LinesForSelection.
+const contextLines = 3
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseDiffHunk returns the last line of the diff hunk (the line commented on).
+func parseDiffHunk(hunk string) (*hunkLine, error) {
+	lines := strings.Split(strings.TrimSuffix(hunk, "\n"), "\n")
+	m := hunkHeader.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil, fmt.Errorf("invalid diff hunk header %q", lines[0])
+	}
+	oldLine, _ := strconv.Atoi(m[1])
+	newLine, _ := strconv.Atoi(m[2])
+
+	var (
+		h                  *hunkLine
+		oldLines, newLines []string
+	)
+	for _, l := range lines[1:] {
+		if l == "" {
+			// Some diffs omit the leading space of empty context lines.
+			l = " "
+		}
+		switch l[0] {
+		case ' ':
+			h = &hunkLine{line: newLine, linesBefore: newLines, content: l[1:]}
+			oldLines, newLines = append(oldLines, l[1:]), append(newLines, l[1:])
+			oldLine++
+			newLine++
+		case '-':
+			h = &hunkLine{line: oldLine, onBase: true, linesBefore: oldLines, content: l[1:]}
+			oldLines = append(oldLines, l[1:])
+			oldLine++
+		case '+':
+			h = &hunkLine{line: newLine, linesBefore: newLines, content: l[1:]}
+			newLines = append(newLines, l[1:])
+			newLine++
+		case '\\':
+			// "\ No newline at end of file"
+		default:
+			return nil, fmt.Errorf("invalid diff hunk line %q", l)
+		}
+	}
+	if h == nil {
+		return nil, errors.New("empty diff hunk")
+	}
+	if n := len(h.linesBefore); n > contextLines {
+		h.linesBefore = h.linesBefore[n-contextLines:]
+	}
+	return h, nil
+}
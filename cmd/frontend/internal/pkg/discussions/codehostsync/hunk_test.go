@@ -0,0 +1,51 @@
+package codehostsync
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffHunk(t *testing.T) {
+	tests := map[string]struct {
+		hunk string
+		want *hunkLine
+	}{
+		"added line": {
+			hunk: "@@ -10,6 +10,9 @@ func fetch() error {\n \ta\n \tb\n-\tc\n+\td\n+\te\n+\tf",
+			want: &hunkLine{line: 14, linesBefore: []string{"\tb", "\td", "\te"}, content: "\tf"},
+		},
+		"removed line": {
+			hunk: "@@ -10,6 +10,9 @@\n \ta\n \tb\n-\tc",
+			want: &hunkLine{line: 12, onBase: true, linesBefore: []string{"\ta", "\tb"}, content: "\tc"},
+		},
+		"context line": {
+			hunk: "@@ -1 +1,2 @@\n+a\n b\n\\ No newline at end of file\n",
+			want: &hunkLine{line: 2, linesBefore: []string{"a"}, content: "b"},
+		},
+		"empty context line": {
+			hunk: "@@ -3,2 +3,2 @@\n a\n",
+			want: &hunkLine{line: 3, linesBefore: nil, content: "a"},
+		},
+		"empty line without leading space": {
+			hunk: "@@ -3,2 +3,2 @@\n a\n\n+b",
+			want: &hunkLine{line: 5, linesBefore: []string{"a", ""}, content: "b"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseDiffHunk(test.hunk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	for _, hunk := range []string{"", "@@ -1 +1 @@", "not a hunk\n+a", "@@ -1 +1 @@\n?a"} {
+		if _, err := parseDiffHunk(hunk); err == nil {
+			t.Errorf("got no error for invalid hunk %q", hunk)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package codehostsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/rcache"
+	"github.com/sourcegraph/sourcegraph/pkg/vcs/git"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// syncRepo imports the review comments on the open pull requests of the repository that were not
+// imported yet, and then posts the replies made on Sourcegraph to the imported threads. A pull
+// request or review thread that fails to import doesn't prevent the others from being imported or
+// the replies from being posted.
+func syncRepo(ctx context.Context, author *types.User, repo *types.Repo) error {
+	host, err := codeHostForRepo(ctx, repo)
+	if err != nil {
+		return err
+	}
+	prs, err := host.OpenPullRequests(ctx)
+	if err != nil {
+		log15.Error("discussions: code host sync: unable to list pull requests", "repo", repo.Name, "error", err)
+	}
+	for _, pr := range prs {
+		importPullRequest(ctx, author, repo, host, pr)
+	}
+	return postReplies(ctx, host, repo)
+}
+
+// syncedPullRequests records, by repository and pull request, when each pull request was last
+// updated as of the last time all of its review comments were imported. Adding a review comment
+// updates the pull request, so unchanged pull requests don't need to be listed again.
+var syncedPullRequests = rcache.NewWithTTL("discussions-codehostsync-pulls", 30*24*60*60)
+
+// importPullRequest imports the review threads of the pull request, unless it is unchanged since
+// they were last imported. Errors are logged.
+func importPullRequest(ctx context.Context, author *types.User, repo *types.Repo, host codeHost, pr *pullRequest) {
+	key := fmt.Sprintf("%d:%s", repo.ID, pr.ID)
+	updatedAt := pr.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	if b, ok := syncedPullRequests.Get(key); ok && string(b) == updatedAt {
+		return
+	}
+
+	threads, err := host.ReviewThreads(ctx, pr)
+	if err != nil {
+		log15.Error("discussions: code host sync: unable to list review comments", "repo", repo.Name, "pullRequest", pr.ID, "error", err)
+		return
+	}
+	imported := true
+	for _, t := range threads {
+		if err := importThread(ctx, author, repo, t); err != nil {
+			log15.Error("discussions: code host sync: unable to import review thread", "repo", repo.Name, "pullRequest", pr.ID, "thread", t.ID, "error", err)
+			imported = false
+		}
+	}
+	if imported && !pr.UpdatedAt.IsZero() {
+		syncedPullRequests.Set(key, []byte(updatedAt))
+	}
+}
+
+// importThread imports the review thread as a discussion thread authored by the given user (or, if
+// it was imported before, the comments that were added to it since).
+func importThread(ctx context.Context, author *types.User, repo *types.Repo, t *reviewThread) error {
+	if len(t.Comments) == 0 {
+		return nil
+	}
+	first, err := db.DiscussionExternalComments.GetByExternalID(ctx, repo.ExternalRepo.ServiceType, repo.ExternalRepo.ServiceID, t.Comments[0].ID)
+	if err != nil {
+		return err
+	}
+
+	var thread *types.DiscussionThread
+	if first == nil {
+		thread, err = db.DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: author.ID,
+			Title:        threadTitle(t),
+			TargetRepo:   threadTarget(ctx, repo, t),
+		})
+		if err != nil {
+			return err
+		}
+		comment, err := importComment(ctx, author, repo, thread, t.PullRequestID, t.ID, t.Comments[0])
+		if err != nil {
+			return err
+		}
+		discussions.NotifyNewThread(thread, comment)
+	} else {
+		thread, err = db.DiscussionThreads.Get(ctx, first.ThreadID)
+		if _, ok := err.(*db.ErrThreadNotFound); ok {
+			return nil // the thread was deleted on Sourcegraph
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range t.Comments[1:] {
+		existing, err := db.DiscussionExternalComments.GetByExternalID(ctx, repo.ExternalRepo.ServiceType, repo.ExternalRepo.ServiceID, c.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+		comment, err := importComment(ctx, author, repo, thread, t.PullRequestID, t.ID, c)
+		if err != nil {
+			return err
+		}
+		discussions.NotifyNewComment(thread, comment)
+	}
+	return nil
+}
+
+// importComment adds the review comment to the discussion thread, and records that it was imported.
+func importComment(ctx context.Context, author *types.User, repo *types.Repo, thread *types.DiscussionThread, pullRequestID, threadID string, c *reviewComment) (*types.DiscussionComment, error) {
+	comment, err := db.DiscussionComments.Create(ctx, &types.DiscussionComment{
+		ThreadID:     thread.ID,
+		AuthorUserID: author.ID,
+		Contents:     formatImported(c),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := record(ctx, repo, comment, pullRequestID, threadID, c.ID); err != nil {
+		// Delete the comment, or else it would be posted back to the code host as a reply.
+		if _, err := db.DiscussionComments.Update(ctx, comment.ID, &db.DiscussionCommentsUpdateOptions{Delete: true}); err != nil {
+			log15.Error("discussions: code host sync: unable to delete unrecorded comment", "comment", comment.ID, "error", err)
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// postReplies posts the comments made on Sourcegraph in the threads imported from the repository
+// to the code host, and records that they were posted.
+func postReplies(ctx context.Context, host codeHost, repo *types.Repo) error {
+	replies, err := db.DiscussionExternalComments.ListPendingReplies(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	for _, r := range replies {
+		author, err := db.Users.GetByID(ctx, r.Comment.AuthorUserID)
+		if err != nil {
+			return err
+		}
+		thread, err := db.DiscussionThreads.Get(ctx, r.Comment.ThreadID)
+		if err != nil {
+			return err
+		}
+		threadURL, err := discussions.URLToInlineThread(ctx, thread)
+		if err != nil {
+			return err
+		}
+
+		id, err := host.Reply(ctx, r.Thread.PullRequestID, r.Thread.ExternalThreadID, formatReply(author.Username, threadURL, r.Comment.Contents))
+		if err != nil {
+			// Don't hold up the other replies, e.g. if the pull request was deleted.
+			log15.Error("discussions: code host sync: unable to post reply", "repo", repo.Name, "pullRequest", r.Thread.PullRequestID, "comment", r.Comment.ID, "error", err)
+			continue
+		}
+		if err := record(ctx, repo, r.Comment, r.Thread.PullRequestID, r.Thread.ExternalThreadID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func record(ctx context.Context, repo *types.Repo, comment *types.DiscussionComment, pullRequestID, threadID, externalID string) error {
+	return db.DiscussionExternalComments.Create(ctx, &types.DiscussionExternalComment{
+		CommentID:        comment.ID,
+		ThreadID:         comment.ThreadID,
+		RepoID:           repo.ID,
+		ServiceType:      repo.ExternalRepo.ServiceType,
+		ServiceID:        repo.ExternalRepo.ServiceID,
+		PullRequestID:    pullRequestID,
+		ExternalThreadID: threadID,
+		ExternalID:       externalID,
+	})
+}
+
+// threadTarget returns the target of the discussion thread for the review thread: the commented
+// line on the diff of the pull request, or (for removed lines) on its base. The surrounding lines
+// are read from the repository if it has the commit, or else taken from the diff hunk.
+func threadTarget(ctx context.Context, repo *types.Repo, t *reviewThread) *types.DiscussionThreadTargetRepo {
+	path := t.Path
+	target := &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Path: &path}
+	branch, revision := t.HeadBranch, t.HeadRevision
+	if t.OnBase {
+		branch, revision = t.BaseBranch, t.BaseRevision
+	} else {
+		baseBranch, baseRevision := t.BaseBranch, t.BaseRevision
+		target.BaseBranch, target.BaseRevision = &baseBranch, &baseRevision
+	}
+	target.Branch, target.Revision = &branch, &revision
+
+	startLine, endLine, zero := int32(t.Line-1), int32(t.Line), int32(0)
+	target.StartLine, target.EndLine = &startLine, &endLine
+	target.StartCharacter, target.EndCharacter = &zero, &zero
+
+	linesBefore, lines, linesAfter := []string{}, []string{}, []string{}
+	if t.Hunk != nil {
+		linesBefore, lines = t.Hunk.linesBefore, []string{t.Hunk.content}
+	}
+	if gitRepo, err := backend.CachedGitRepo(ctx, repo); err == nil {
+		if content, err := git.ReadFile(ctx, *gitRepo, api.CommitID(revision), path); err == nil {
+			linesBefore, lines, linesAfter = discussions.LinesForSelection(string(content), discussions.LineRange{
+				StartLine: int(startLine),
+				EndLine:   int(endLine),
+			})
+		}
+	}
+	target.LinesBefore, target.Lines, target.LinesAfter = &linesBefore, &lines, &linesAfter
+	return target
+}
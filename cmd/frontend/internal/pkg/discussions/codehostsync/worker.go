@@ -0,0 +1,105 @@
+// Package codehostsync syncs discussion threads with the review comments on
+// pull requests (GitHub) and merge requests (GitLab). Review comments are
+// imported as discussion threads on the commented line, and replies made on
+// Sourcegraph are posted back to the code host. The IDs of synced comments are
+// recorded (see db.DiscussionExternalComments) so that no comment is synced
+// twice.
+package codehostsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/rcache"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// defaultInterval is the interval between syncs if none is configured.
+const defaultInterval = 60 * time.Second
+
+// StartWorker should be invoked only after the DB has been initialized. It
+// starts the background worker which syncs discussion threads with the review
+// comments of code hosts, when enabled in the site configuration.
+//
+// It should be invoked in a separate goroutine.
+func StartWorker() {
+	ctx := context.Background()
+
+	// Only one frontend instance should ever run this worker, so we use a
+	// distributed lock to guarantee this. If the frontend with the lock
+	// acquired dies, it will be released after 1 minute.
+	for {
+		mutexCtx, release, ok := rcache.TryAcquireMutex(ctx, "discussionsCodeHostSyncWorker")
+		if !ok {
+			// Failed to acquire the mutex. Wait before trying again.
+			if sleep(ctx, 30*time.Second) != nil {
+				return
+			}
+			continue
+		}
+
+		// Acquired the mutex, perform work under it.
+		log15.Debug("discussions: code host sync worker running")
+		workForever(mutexCtx)
+		log15.Debug("discussions: code host sync worker stopped", "ctx", mutexCtx.Err())
+		release()
+	}
+}
+
+func workForever(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return // e.g. if we lost the distributed mutex
+		}
+		interval := defaultInterval
+		if dc := conf.Get().Discussions; dc != nil && dc.CodeHostSync != nil {
+			if dc.CodeHostSync.IntervalSeconds > 0 {
+				interval = time.Duration(dc.CodeHostSync.IntervalSeconds) * time.Second
+			}
+			if err := syncAll(ctx, dc.CodeHostSync); err != nil {
+				log15.Error("discussions: code host sync worker: error while working", "error", err)
+			}
+		}
+		if sleep(ctx, interval) != nil {
+			return // e.g. if we lost the distributed mutex
+		}
+	}
+}
+
+// sleep waits for the duration to elapse, or for ctx to be done (in which case it returns
+// ctx.Err()).
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// syncAll syncs the configured repositories. A repository that fails to sync doesn't prevent
+// the others from syncing.
+func syncAll(ctx context.Context, cfg *schema.CodeHostSync) error {
+	author, err := db.Users.GetByUsername(ctx, cfg.Username)
+	if err != nil {
+		return errors.Wrapf(err, "looking up user %q", cfg.Username)
+	}
+	for _, name := range cfg.Repositories {
+		repo, err := db.Repos.GetByName(ctx, api.RepoName(name))
+		if err != nil {
+			log15.Error("discussions: code host sync worker: unable to look up repository", "repo", name, "error", err)
+			continue
+		}
+		if err := syncRepo(ctx, author, repo); err != nil {
+			log15.Error("discussions: code host sync worker: unable to sync repository", "repo", name, "error", err)
+		}
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	neturl "net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
@@ -48,9 +50,63 @@ func NotifyNewComment(updatedThread *types.DiscussionThread, newComment *types.D
 	})
 }
 
+// NotifyThreadStatusChanged should be invoked after a discussion thread has
+// been resolved or reopened by the given user, in order to send relevant
+// notifications.
+//
+// It returns immediately and does not block.
+func NotifyThreadStatusChanged(updatedThread *types.DiscussionThread, userID int32) {
+	event := "reopened this thread"
+	if updatedThread.ResolvedAt != nil {
+		event = "resolved this thread"
+	}
+	notifyMentions(&notifier{
+		typ:               threadStatusNotification,
+		eventAuthorUserID: userID,
+		thread:            updatedThread,
+		event:             event,
+		template:          threadEventEmailTemplate,
+	})
+}
+
+// NotifyThreadAssigned should be invoked after a discussion thread has been
+// assigned to new users by the given user, in order to send relevant
+// notifications. The new assignees are notified along with everyone else
+// subscribed to the thread.
+//
+// It returns immediately and does not block.
+func NotifyThreadAssigned(updatedThread *types.DiscussionThread, userID int32, newAssigneeUserIDs []int32) {
+	n := &notifier{
+		typ:               threadAssignedNotification,
+		eventAuthorUserID: userID,
+		thread:            updatedThread,
+		template:          threadEventEmailTemplate,
+	}
+	n.eventFunc = func(ctx context.Context) (string, error) {
+		var usernames []string
+		for _, assigneeUserID := range newAssigneeUserIDs {
+			assignee, err := db.Users.GetByID(ctx, assigneeUserID)
+			if err != nil {
+				return "", errors.Wrap(err, "Assignee: GetByID")
+			}
+			usernames = append(usernames, "@"+assignee.Username)
+		}
+		return "assigned this thread to " + strings.Join(usernames, ", "), nil
+	}
+	notifyMentions(n)
+}
+
 func notifyMentions(n *notifier) {
 	goroutine.Go(func() {
 		ctx := context.Background()
+		if n.eventFunc != nil {
+			event, err := n.eventFunc(ctx)
+			if err != nil {
+				log15.Error("discussions: describing event", "error", err)
+				return
+			}
+			n.event = event
+		}
 		subscribers, err := n.subscribers(ctx)
 		if err != nil {
 			log15.Error("discussions: determining subscribers", "error", err)
@@ -66,26 +122,40 @@ func notifyMentions(n *notifier) {
 type notificationType int
 
 const (
-	newThreadNotification  notificationType = iota
-	newCommentNotification notificationType = iota
+	newThreadNotification      notificationType = iota
+	newCommentNotification     notificationType = iota
+	threadStatusNotification   notificationType = iota
+	threadAssignedNotification notificationType = iota
 )
 
 type notifier struct {
 	typ               notificationType
 	eventAuthorUserID int32
 	thread            *types.DiscussionThread
-	comment           *types.DiscussionComment
 	template          txtypes.Templates
+
+	// comment is the new comment, for new thread and new comment
+	// notifications.
+	comment *types.DiscussionComment
+
+	// event describes the change made to the thread for notifications that
+	// are not about a comment, e.g. "resolved this thread". If eventFunc is
+	// set, it is invoked to compute the event before sending notifications.
+	event     string
+	eventFunc func(ctx context.Context) (string, error)
 }
 
 // subscribers returns a list of all usernames who are subscribed to receive
-// notifications from the thread. Currently, there is no underlying
-// subscription store, so we rely on some simple mechanics to get a good-enough
-// result:
+// notifications from the thread:
 //
 // 	1. If you were previously mentioned in the thread, you are subscribed.
 // 	2. If you previously authored a comment, you are subscribed.
+// 	3. If the thread is assigned to you, you are subscribed.
+// 	4. If you watch the thread, or its repository or a path prefix of its
+// 	   file, you are subscribed.
 //
+// Users who muted the thread are not subscribed, unless they are mentioned in
+// the new comment.
 func (n *notifier) subscribers(ctx context.Context) ([]string, error) {
 	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
 		LimitOffset: &db.LimitOffset{
@@ -122,7 +192,65 @@ func (n *notifier) subscribers(ctx context.Context) ([]string, error) {
 			}
 		}
 	}
-	return subscribers, nil
+	for _, assigneeUserID := range n.thread.AssigneeUserIDs {
+		assignee, err := db.Users.GetByID(ctx, assigneeUserID)
+		if errcode.IsNotFound(err) {
+			continue // the user was deleted
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Assignee: GetByID")
+		}
+		if _, ok := set[assignee.Username]; !ok {
+			set[assignee.Username] = struct{}{}
+			subscribers = append(subscribers, assignee.Username)
+		}
+	}
+
+	watching, muted, err := db.DiscussionSubscriptions.Subscribers(ctx, n.thread)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionSubscriptions.Subscribers")
+	}
+	for _, watcherUserID := range watching {
+		watcher, err := db.Users.GetByID(ctx, watcherUserID)
+		if errcode.IsNotFound(err) {
+			continue // the user was deleted
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Watcher: GetByID")
+		}
+		if _, ok := set[watcher.Username]; !ok {
+			set[watcher.Username] = struct{}{}
+			subscribers = append(subscribers, watcher.Username)
+		}
+	}
+	if len(muted) == 0 {
+		return subscribers, nil
+	}
+	mentioned := make(map[string]struct{})
+	if n.comment != nil {
+		for _, mention := range mentions.Parse(n.comment.Contents) {
+			mentioned[mention] = struct{}{}
+		}
+	}
+	for _, mutedUserID := range muted {
+		mutedUser, err := db.Users.GetByID(ctx, mutedUserID)
+		if errcode.IsNotFound(err) {
+			continue // the user was deleted
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Muted: GetByID")
+		}
+		if _, ok := mentioned[mutedUser.Username]; !ok {
+			delete(set, mutedUser.Username)
+		}
+	}
+	filtered := subscribers[:0]
+	for _, username := range subscribers {
+		if _, ok := set[username]; ok {
+			filtered = append(filtered, username)
+		}
+	}
+	return filtered, nil
 }
 
 func (n *notifier) notifyUsername(ctx context.Context, username string) error {
@@ -168,8 +296,10 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		msgID := func(commentID int64) string {
 			return fmt.Sprintf("%s+%d.%d@%s", emailParts[0], n.thread.ID, commentID, emailParts[1])
 		}
-		id := msgID(n.comment.ID)
-		messageID = &id
+		if n.comment != nil {
+			id := msgID(n.comment.ID)
+			messageID = &id
+		}
 
 		// Get a list of prior comments in the thread and generate the
 		// references list. This makes e.g. Gmail understand that this email is
@@ -184,16 +314,36 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 			return errors.Wrap(err, "DiscussionComments.List")
 		}
 		for _, comment := range comments {
-			if comment.ID == n.comment.ID {
+			if n.comment != nil && comment.ID == n.comment.ID {
 				continue
 			}
 			references = append(references, msgID(comment.ID))
 		}
 	}
 
-	url, err := URLToInlineComment(ctx, n.thread, n.comment)
+	// Generate a token for the unsubscribe link, which mutes the thread for
+	// the notified user.
+	//
+	// 🚨 SECURITY: It is crucial that the user ID and thread ID passed here
+	// are correct, as the token allows anyone to mute the specified thread for
+	// the specified user.
+	unsubscribeToken, err := db.DiscussionUnsubscribeTokens.Generate(ctx, user.ID, n.thread.ID)
 	if err != nil {
-		return errors.Wrap(err, "URLToInlineComment")
+		return errors.Wrap(err, "DiscussionUnsubscribeTokens.Generate")
+	}
+	unsubscribeURL := URLToUnsubscribe(unsubscribeToken).String()
+
+	var url *neturl.URL
+	if n.comment != nil {
+		url, err = URLToInlineComment(ctx, n.thread, n.comment)
+		if err != nil {
+			return errors.Wrap(err, "URLToInlineComment")
+		}
+	} else {
+		url, err = URLToInlineThread(ctx, n.thread)
+		if err != nil {
+			return errors.Wrap(err, "URLToInlineThread")
+		}
 	}
 	if url == nil {
 		return nil // can't generate a link to this thread target type
@@ -238,7 +388,7 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		}
 	}
 
-	commentAuthor, err := db.Users.GetByID(ctx, n.comment.AuthorUserID)
+	commentAuthor, err := db.Users.GetByID(ctx, n.eventAuthorUserID)
 	if err != nil {
 		return errors.Wrap(err, "CommentAuthor: GetByID")
 	}
@@ -247,25 +397,38 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		fromName = commentAuthor.Username
 	}
 
-	commentContentsHTML, err := markdown.Render(n.comment.Contents, nil)
-	if err != nil {
-		return errors.Wrap(err, "render comment contents Markdown")
+	var (
+		commentContents     string
+		commentContentsHTML string
+		uniqueValue         = fmt.Sprint(time.Now().UnixNano())
+	)
+	if n.comment != nil {
+		commentContents = n.comment.Contents
+		commentContentsHTML, err = markdown.Render(n.comment.Contents, nil)
+		if err != nil {
+			return errors.Wrap(err, "render comment contents Markdown")
+		}
+		uniqueValue = fmt.Sprint(n.comment.ID)
 	}
 
 	return txemail.Send(ctx, txemail.Message{
-		To:         []string{email},
-		FromName:   fromName,
-		ReplyTo:    replyTo,
-		MessageID:  messageID,
-		References: references,
-		Template:   n.template,
+		To:                      []string{email},
+		FromName:                fromName,
+		ReplyTo:                 replyTo,
+		MessageID:               messageID,
+		References:              references,
+		ListUnsubscribe:         &unsubscribeURL,
+		ListUnsubscribeOneClick: true,
+		Template:                n.template,
 		Data: struct {
 			ThreadTitle           string
 			CommentAuthorUsername string
 			CommentContents       string
 			CommentContentsHTML   template.HTML
+			Event                 string
 			URL                   string
 			UniqueValue           string
+			UnsubscribeURL        string
 			CanReply              bool
 
 			// These fields may be empty strings depending on the type of comment..
@@ -276,10 +439,12 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		}{
 			ThreadTitle:           n.thread.Title,
 			CommentAuthorUsername: commentAuthor.Username,
-			CommentContents:       n.comment.Contents,
+			CommentContents:       commentContents,
 			CommentContentsHTML:   template.HTML(commentContentsHTML),
+			Event:                 n.event,
 			URL:                   url.String(),
-			UniqueValue:           fmt.Sprint(n.comment.ID),
+			UniqueValue:           uniqueValue,
+			UnsubscribeURL:        unsubscribeURL,
 			CanReply:              conf.CanReadEmail(),
 
 			RepoName:        repoShortName,
@@ -317,6 +482,10 @@ var (
 {{- end -}}
 {{- "\n" -}}
 {{- "  " -}}{{- .URL -}}
+{{- "\n\n" -}}
+{{- "Unsubscribe from this thread:\n" -}}
+{{- "\n" -}}
+{{- "  " -}}{{- .UnsubscribeURL -}}
 {{- "\n" -}}
 `
 
@@ -345,11 +514,55 @@ var (
 {{else}}
 	<p style="font-size: small; color: #666;">—<br/><a href="{{.URL}}">View and reply on Sourcegraph</a></p>
 {{end}}
+<p style="font-size: small; color: #666;"><a href="{{.UnsubscribeURL}}">Unsubscribe</a> from this thread.</p>
 <!-- this ensures Gmail doesn't trim the email -->
 <span style="opacity: 0">{{.UniqueValue}}</span>
 </body>
 </html>
 `
+	threadEventTextTemplate = `
+{{- "@" -}}{{- .CommentAuthorUsername -}}{{- " " -}}{{- .Event -}}
+	{{- with .FileName -}}{{- " on " -}}{{- . -}}{{- end -}}
+	{{- ".\n" -}}
+{{- with .CodeContextText -}}
+	{{- "--------------------------------------------------------------------------------\n" -}}
+	{{- . -}}
+	{{- "\n" -}}
+{{- end -}}
+{{- "—\n" -}}
+{{- if .CanReply -}}
+	{{- "Reply to this email directly, or view it on Sourcegraph:\n" -}}
+{{- else -}}
+	{{- "View and reply on Sourcegraph:\n" -}}
+{{- end -}}
+{{- "\n" -}}
+{{- "  " -}}{{- .URL -}}
+{{- "\n\n" -}}
+{{- "Unsubscribe from this thread:\n" -}}
+{{- "\n" -}}
+{{- "  " -}}{{- .UnsubscribeURL -}}
+{{- "\n" -}}
+`
+
+	threadEventHTMLTemplate = `
+<html>
+<body>
+<p><strong>@{{.CommentAuthorUsername}}</strong> {{.Event}}{{with .FileName}} on <strong>{{.}}</strong>{{end}}.</p>
+{{with .CodeContextHTML}}
+	{{.}}
+{{end}}
+{{if .CanReply}}
+	<p style="font-size: small; color: #666;">—<br/>Reply to this email directly or <a href="{{.URL}}">view it on Sourcegraph</a>.</p>
+{{else}}
+	<p style="font-size: small; color: #666;">—<br/><a href="{{.URL}}">View and reply on Sourcegraph</a></p>
+{{end}}
+<p style="font-size: small; color: #666;"><a href="{{.UnsubscribeURL}}">Unsubscribe</a> from this thread.</p>
+<!-- this ensures Gmail doesn't trim the email -->
+<span style="opacity: 0">{{.UniqueValue}}</span>
+</body>
+</html>
+`
+
 	newThreadEmailTemplate = txemail.MustValidate(txtypes.Templates{
 		Subject: sharedCommentSubjectTemplate,
 		Text:    sharedCommentTextTemplate,
@@ -361,4 +574,10 @@ var (
 		Text:    sharedCommentTextTemplate,
 		HTML:    sharedCommentHTMLTemplate,
 	})
+
+	threadEventEmailTemplate = txemail.MustValidate(txtypes.Templates{
+		Subject: sharedCommentSubjectTemplate,
+		Text:    threadEventTextTemplate,
+		HTML:    threadEventHTMLTemplate,
+	})
 )
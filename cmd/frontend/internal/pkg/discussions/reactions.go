@@ -0,0 +1,14 @@
+package discussions
+
+// Reactions are the emoji that users may react to discussion comments with.
+var Reactions = []string{"👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"}
+
+// IsValidReaction tells if the reaction is one of Reactions.
+func IsValidReaction(reaction string) bool {
+	for _, r := range Reactions {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
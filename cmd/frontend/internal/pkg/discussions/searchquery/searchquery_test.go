@@ -30,6 +30,12 @@ func TestParse(t *testing.T) {
 			wantRemaining:  "abc efg 123 456",
 			wantOperations: [][2]string{{"foo", "bar"}, {"baz", "bam"}},
 		},
+		{
+			name:           "status_assignee_label",
+			input:          `is:open assignee:@me label:security -label:wontfix leak`,
+			wantRemaining:  "leak",
+			wantOperations: [][2]string{{"is", "open"}, {"assignee", "@me"}, {"label", "security"}, {"-label", "wontfix"}},
+		},
 		{
 			name:           "empty_operation",
 			input:          `fuzzytitleprefixmatch: foo:bar`,
@@ -42,6 +42,15 @@ func urlToInline(ctx context.Context, t *types.DiscussionThread, c *types.Discus
 		if err != nil {
 			return nil, errors.Wrap(err, "db.Repos.Get")
 		}
+		if t.TargetRepo.IsDiff() {
+			// Threads on a diff are viewed inline in the comparison view.
+			u = &url.URL{Path: path.Join("/", string(repo.Name), "/-/compare/", *t.TargetRepo.BaseRevision+"..."+*t.TargetRepo.Revision)}
+			u.Fragment = fmt.Sprintf("tab=discussions&threadID=%v", t.ID)
+			if c != nil {
+				u.Fragment += fmt.Sprintf("&commentID=%v", c.ID)
+			}
+			break
+		}
 		if t.TargetRepo.Path == nil {
 			return nil, nil // Can't generate a link to this yet, we don't have a UI for it yet.
 		}
@@ -63,3 +72,11 @@ func urlToInline(ctx context.Context, t *types.DiscussionThread, c *types.Discus
 	}
 	return globals.ExternalURL.ResolveReference(u), nil
 }
+
+// URLToUnsubscribe returns the absolute URL of the unsubscribe link that mutes
+// a thread for the user whom the given unsubscribe token was generated for. A
+// GET request asks for confirmation, and a POST request mutes the thread.
+func URLToUnsubscribe(token string) *url.URL {
+	u := &url.URL{Path: "/-/discussions/unsubscribe", RawQuery: url.Values{"token": []string{token}}.Encode()}
+	return globals.ExternalURL.ResolveReference(u)
+}
@@ -0,0 +1,89 @@
+// Package lsif converts LSIF (Language Server Index Format) dumps into per-document code
+// intelligence data that can be stored and queried without a language server.
+//
+// See https://github.com/Microsoft/language-server-protocol/blob/master/indexFormat/specification.md.
+package lsif
+
+import (
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// Location is a range in a document of the dump. The path is relative to the root of the
+// repository.
+type Location struct {
+	Path  string    `json:"path"`
+	Range lsp.Range `json:"range"`
+}
+
+// Range is a range in a document that has code intelligence data (such as a hover or
+// definitions) attached to it.
+type Range struct {
+	Range lsp.Range `json:"range"`
+
+	// Hover, Definitions and References are indexes into the Hovers and Results of the Document
+	// containing the range, or -1 if the range has no such data.
+	Hover       int `json:"hover"`
+	Definitions int `json:"definitions"`
+	References  int `json:"references"`
+}
+
+// Document is the code intelligence data for a single document in a dump. Hovers and results are
+// stored once per document and referred to by index from the ranges, because many ranges (e.g.,
+// all references of a symbol) usually share the same data.
+type Document struct {
+	Ranges  []Range      `json:"ranges"`
+	Hovers  []string     `json:"hovers,omitempty"`
+	Results [][]Location `json:"results,omitempty"`
+}
+
+// RangeAt returns the innermost range in the document that contains the position, or nil if there
+// is none.
+func (d *Document) RangeAt(pos lsp.Position) *Range {
+	var innermost *Range
+	for i := range d.Ranges {
+		r := &d.Ranges[i]
+		if !rangeContains(r.Range, pos) {
+			continue
+		}
+		if innermost == nil || rangeContains(innermost.Range, r.Range.Start) && rangeContains(innermost.Range, r.Range.End) {
+			innermost = r
+		}
+	}
+	return innermost
+}
+
+// Hover returns the hover contents (as Markdown) of the range, or the empty string if there is
+// none.
+func (d *Document) Hover(r *Range) string {
+	if r.Hover < 0 || r.Hover >= len(d.Hovers) {
+		return ""
+	}
+	return d.Hovers[r.Hover]
+}
+
+// Definitions returns the definition locations of the range.
+func (d *Document) Definitions(r *Range) []Location {
+	return d.result(r.Definitions)
+}
+
+// References returns the reference locations of the range.
+func (d *Document) References(r *Range) []Location {
+	return d.result(r.References)
+}
+
+func (d *Document) result(i int) []Location {
+	if i < 0 || i >= len(d.Results) {
+		return nil
+	}
+	return d.Results[i]
+}
+
+// rangeContains reports whether pos is inside r (the start is inclusive, and the end is
+// inclusive so that a position just after an identifier still refers to it).
+func rangeContains(r lsp.Range, pos lsp.Position) bool {
+	return !positionBefore(pos, r.Start) && !positionBefore(r.End, pos)
+}
+
+func positionBefore(a, b lsp.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
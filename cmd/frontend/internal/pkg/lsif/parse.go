@@ -0,0 +1,404 @@
+package lsif
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// id is the ID of a vertex or edge. LSIF allows both numbers and strings as IDs.
+type id string
+
+func (i *id) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*i = id(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*i = id(n)
+	return nil
+}
+
+// element is a vertex or an edge of an LSIF dump. Only the fields we need are decoded.
+type element struct {
+	ID    id     `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+
+	// Vertex fields.
+	ProjectRoot string          `json:"projectRoot"` // metaData
+	URI         string          `json:"uri"`         // document
+	Start       lsp.Position    `json:"start"`       // range
+	End         lsp.Position    `json:"end"`         // range
+	Result      json.RawMessage `json:"result"`      // hoverResult (and definitionResult in older LSIF versions)
+
+	// Vertex fields of referenceResult in older LSIF versions.
+	Declarations     []id `json:"declarations"`
+	Definitions      []id `json:"definitions"`
+	References       []id `json:"references"`
+	ReferenceResults []id `json:"referenceResults"`
+
+	// Edge fields.
+	OutV     id     `json:"outV"`
+	InV      id     `json:"inV"`
+	InVs     []id   `json:"inVs"`
+	Property string `json:"property"`
+}
+
+// item is the target of an "item" edge from a definition or reference result.
+type item struct {
+	property string
+	inVs     []id
+}
+
+// dump holds the (partially) indexed elements of an LSIF dump while it is being converted.
+type dump struct {
+	projectRoot string
+
+	documents map[id]string // document ID -> path
+	ranges    map[id]lsp.Range
+	rangeDocs map[id]id // range ID -> document ID
+
+	next       map[id]id // range or result set ID -> result set ID
+	definition map[id]id // range or result set ID -> definitionResult ID
+	references map[id]id // range or result set ID -> referenceResult ID
+	hover      map[id]id // range or result set ID -> hoverResult ID
+
+	hoverResults map[id]string
+	items        map[id][]item  // result ID -> items
+	results      map[id]element // definitionResult and referenceResult vertices
+}
+
+// Parse reads an LSIF dump and returns the code intelligence data for each document in it, keyed
+// by the document's path relative to the project root. The dump may either be a JSON array of
+// elements or contain one JSON element per line.
+func Parse(r io.Reader) (map[string]*Document, error) {
+	d := &dump{
+		documents:    map[id]string{},
+		ranges:       map[id]lsp.Range{},
+		rangeDocs:    map[id]id{},
+		next:         map[id]id{},
+		definition:   map[id]id{},
+		references:   map[id]id{},
+		hover:        map[id]id{},
+		hoverResults: map[id]string{},
+		items:        map[id][]item{},
+		results:      map[id]element{},
+	}
+
+	var documentURIs = map[id]string{}
+	err := readElements(r, func(e *element) error {
+		switch e.Type {
+		case "vertex":
+			switch e.Label {
+			case "metaData":
+				d.projectRoot = e.ProjectRoot
+			case "document":
+				documentURIs[e.ID] = e.URI
+			case "range":
+				d.ranges[e.ID] = lsp.Range{Start: e.Start, End: e.End}
+			case "hoverResult":
+				contents, err := hoverContents(e.Result)
+				if err != nil {
+					return fmt.Errorf("invalid hoverResult %s: %s", e.ID, err)
+				}
+				d.hoverResults[e.ID] = contents
+			case "definitionResult", "referenceResult":
+				d.results[e.ID] = *e
+			}
+		case "edge":
+			switch e.Label {
+			case "contains":
+				for _, inV := range e.InVs {
+					d.rangeDocs[inV] = e.OutV
+				}
+			case "next":
+				d.next[e.OutV] = e.InV
+			case "textDocument/definition":
+				d.definition[e.OutV] = e.InV
+			case "textDocument/references":
+				d.references[e.OutV] = e.InV
+			case "textDocument/hover":
+				d.hover[e.OutV] = e.InV
+			case "item":
+				inVs := e.InVs
+				if e.InV != "" {
+					inVs = append(inVs, e.InV)
+				}
+				d.items[e.OutV] = append(d.items[e.OutV], item{property: e.Property, inVs: inVs})
+			}
+		default:
+			return fmt.Errorf("element %s has invalid type %q", e.ID, e.Type)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for docID, uri := range documentURIs {
+		path, err := d.relativePath(uri)
+		if err != nil {
+			return nil, err
+		}
+		d.documents[docID] = path
+	}
+	return d.convert(), nil
+}
+
+// readElements calls f for each element in the dump.
+func readElements(r io.Reader, f func(*element) error) error {
+	br := bufio.NewReader(r)
+	isArray := false
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return nil // empty dump
+		}
+		if err != nil {
+			return err
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		isArray = b[0] == '['
+		break
+	}
+
+	dec := json.NewDecoder(br)
+	if isArray {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+	for {
+		if isArray && !dec.More() {
+			return nil
+		}
+		var e element
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF && !isArray {
+				return nil
+			}
+			return err
+		}
+		if err := f(&e); err != nil {
+			return err
+		}
+	}
+}
+
+// relativePath returns the path of the document URI relative to the project root.
+func (d *dump) relativePath(uri string) (string, error) {
+	if d.projectRoot != "" && strings.HasPrefix(uri, d.projectRoot) {
+		return strings.TrimPrefix(strings.TrimPrefix(uri, d.projectRoot), "/"), nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid document URI %q: %s", uri, err)
+	}
+	return strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// documentBuilder builds a Document, storing each hover and result at most once.
+type documentBuilder struct {
+	doc     *Document
+	hovers  map[id]int
+	results map[id]int
+}
+
+func (d *dump) convert() map[string]*Document {
+	builders := map[id]*documentBuilder{}
+	for rangeID, r := range d.ranges {
+		docID, ok := d.rangeDocs[rangeID]
+		if !ok {
+			continue
+		}
+		if _, ok := d.documents[docID]; !ok {
+			continue
+		}
+		b, ok := builders[docID]
+		if !ok {
+			b = &documentBuilder{doc: &Document{}, hovers: map[id]int{}, results: map[id]int{}}
+			builders[docID] = b
+		}
+
+		rng := Range{Range: r, Hover: -1, Definitions: -1, References: -1}
+		if hoverID, ok := d.lookup(d.hover, rangeID); ok {
+			if _, ok := b.hovers[hoverID]; !ok {
+				b.hovers[hoverID] = len(b.doc.Hovers)
+				b.doc.Hovers = append(b.doc.Hovers, d.hoverResults[hoverID])
+			}
+			rng.Hover = b.hovers[hoverID]
+		}
+		if resultID, ok := d.lookup(d.definition, rangeID); ok {
+			rng.Definitions = b.result(resultID, d.definitionLocations)
+		}
+		if resultID, ok := d.lookup(d.references, rangeID); ok {
+			rng.References = b.result(resultID, d.referenceLocations)
+		}
+		if rng.Hover == -1 && rng.Definitions == -1 && rng.References == -1 {
+			continue
+		}
+		b.doc.Ranges = append(b.doc.Ranges, rng)
+	}
+
+	docs := make(map[string]*Document, len(builders))
+	for docID, b := range builders {
+		sort.Slice(b.doc.Ranges, func(i, j int) bool {
+			return positionBefore(b.doc.Ranges[i].Range.Start, b.doc.Ranges[j].Range.Start)
+		})
+		docs[d.documents[docID]] = b.doc
+	}
+	return docs
+}
+
+func (b *documentBuilder) result(resultID id, locations func(id) []Location) int {
+	if i, ok := b.results[resultID]; ok {
+		return i
+	}
+	i := len(b.doc.Results)
+	b.results[resultID] = i
+	b.doc.Results = append(b.doc.Results, locations(resultID))
+	return i
+}
+
+// lookup returns the target of the edge (in edges) from the range or result set v, following
+// "next" edges to result sets until one is found.
+func (d *dump) lookup(edges map[id]id, v id) (id, bool) {
+	for i := 0; i < 100 && v != ""; i++ { // guard against cycles
+		if target, ok := edges[v]; ok {
+			return target, true
+		}
+		v = d.next[v]
+	}
+	return "", false
+}
+
+func (d *dump) definitionLocations(resultID id) []Location {
+	var rangeIDs []id
+	if result, ok := d.results[resultID]; ok && len(result.Result) > 0 {
+		var ids []id
+		if err := json.Unmarshal(result.Result, &ids); err == nil {
+			rangeIDs = append(rangeIDs, ids...)
+		}
+	}
+	for _, item := range d.items[resultID] {
+		rangeIDs = append(rangeIDs, item.inVs...)
+	}
+	return d.locations(rangeIDs)
+}
+
+func (d *dump) referenceLocations(resultID id) []Location {
+	var rangeIDs []id
+	seen := map[id]bool{}
+	var collect func(resultID id)
+	collect = func(resultID id) {
+		if seen[resultID] {
+			return
+		}
+		seen[resultID] = true
+
+		result := d.results[resultID]
+		rangeIDs = append(rangeIDs, result.Declarations...)
+		rangeIDs = append(rangeIDs, result.Definitions...)
+		rangeIDs = append(rangeIDs, result.References...)
+		for _, r := range result.ReferenceResults {
+			collect(r)
+		}
+		for _, item := range d.items[resultID] {
+			if item.property == "referenceResults" {
+				for _, r := range item.inVs {
+					collect(r)
+				}
+				continue
+			}
+			rangeIDs = append(rangeIDs, item.inVs...)
+		}
+	}
+	collect(resultID)
+	return d.locations(rangeIDs)
+}
+
+// locations returns the (deduplicated) locations of the ranges.
+func (d *dump) locations(rangeIDs []id) []Location {
+	var locations []Location
+	seen := map[id]bool{}
+	for _, rangeID := range rangeIDs {
+		if seen[rangeID] {
+			continue
+		}
+		seen[rangeID] = true
+		r, ok := d.ranges[rangeID]
+		if !ok {
+			continue
+		}
+		path, ok := d.documents[d.rangeDocs[rangeID]]
+		if !ok {
+			continue
+		}
+		locations = append(locations, Location{Path: path, Range: r})
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Path != locations[j].Path {
+			return locations[i].Path < locations[j].Path
+		}
+		return positionBefore(locations[i].Range.Start, locations[j].Range.Start)
+	})
+	return locations
+}
+
+// hoverContents converts the result of a hoverResult vertex (an LSP Hover) to Markdown.
+func hoverContents(result json.RawMessage) (string, error) {
+	if len(result) == 0 {
+		return "", nil
+	}
+	var hover struct {
+		Contents json.RawMessage `json:"contents"`
+	}
+	if err := json.Unmarshal(result, &hover); err != nil {
+		return "", err
+	}
+	if len(hover.Contents) == 0 {
+		return "", nil
+	}
+
+	// The contents may be a MarkupContent, a MarkedString or a list of MarkedStrings.
+	var list []json.RawMessage
+	if err := json.Unmarshal(hover.Contents, &list); err != nil {
+		list = []json.RawMessage{hover.Contents}
+	}
+	var parts []string
+	for _, raw := range list {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			parts = append(parts, s)
+			continue
+		}
+		var v struct {
+			Kind     string `json:"kind"`
+			Language string `json:"language"`
+			Value    string `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return "", err
+		}
+		if v.Language != "" {
+			parts = append(parts, "```"+v.Language+"\n"+v.Value+"\n```")
+		} else {
+			parts = append(parts, v.Value)
+		}
+	}
+	return strings.Join(parts, "\n\n---\n\n"), nil
+}
@@ -0,0 +1,84 @@
+package lsif
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// testDump is a minimal LSIF dump of two documents: a.go defines foo (line 0), and b.go calls it
+// (line 2).
+const testDump = `
+{"id":1,"type":"vertex","label":"metaData","version":"0.4.0","projectRoot":"file:///src"}
+{"id":2,"type":"vertex","label":"document","uri":"file:///src/a.go","languageId":"go"}
+{"id":3,"type":"vertex","label":"document","uri":"file:///src/b.go","languageId":"go"}
+{"id":4,"type":"vertex","label":"resultSet"}
+{"id":5,"type":"vertex","label":"range","start":{"line":0,"character":5},"end":{"line":0,"character":8}}
+{"id":6,"type":"vertex","label":"range","start":{"line":2,"character":1},"end":{"line":2,"character":4}}
+{"id":7,"type":"edge","label":"contains","outV":2,"inVs":[5]}
+{"id":8,"type":"edge","label":"contains","outV":3,"inVs":[6]}
+{"id":9,"type":"edge","label":"next","outV":5,"inV":4}
+{"id":10,"type":"edge","label":"next","outV":6,"inV":4}
+{"id":11,"type":"vertex","label":"hoverResult","result":{"contents":[{"language":"go","value":"func foo()"},"foo does things."]}}
+{"id":12,"type":"edge","label":"textDocument/hover","outV":4,"inV":11}
+{"id":13,"type":"vertex","label":"definitionResult"}
+{"id":14,"type":"edge","label":"textDocument/definition","outV":4,"inV":13}
+{"id":15,"type":"edge","label":"item","outV":13,"inVs":[5],"document":2}
+{"id":16,"type":"vertex","label":"referenceResult"}
+{"id":17,"type":"edge","label":"textDocument/references","outV":4,"inV":16}
+{"id":18,"type":"edge","label":"item","outV":16,"inVs":[5],"document":2,"property":"definitions"}
+{"id":19,"type":"edge","label":"item","outV":16,"inVs":[6],"document":3,"property":"references"}
+`
+
+func TestParse(t *testing.T) {
+	for name, input := range map[string]string{
+		"lines": testDump,
+		"array": "[" + strings.Join(strings.Split(strings.TrimSpace(testDump), "\n"), ",\n") + "]",
+	} {
+		t.Run(name, func(t *testing.T) {
+			docs, err := Parse(strings.NewReader(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(docs) != 2 {
+				t.Fatalf("got %d documents, want 2", len(docs))
+			}
+
+			b := docs["b.go"]
+			if b == nil {
+				t.Fatal("missing document b.go")
+			}
+			r := b.RangeAt(lsp.Position{Line: 2, Character: 2})
+			if r == nil {
+				t.Fatal("no range at b.go:2:2")
+			}
+			if want := "```go\nfunc foo()\n```\n\n---\n\nfoo does things."; b.Hover(r) != want {
+				t.Errorf("got hover %q, want %q", b.Hover(r), want)
+			}
+
+			def := Location{Path: "a.go", Range: lsp.Range{Start: lsp.Position{Line: 0, Character: 5}, End: lsp.Position{Line: 0, Character: 8}}}
+			ref := Location{Path: "b.go", Range: lsp.Range{Start: lsp.Position{Line: 2, Character: 1}, End: lsp.Position{Line: 2, Character: 4}}}
+			if got, want := b.Definitions(r), []Location{def}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got definitions %+v, want %+v", got, want)
+			}
+			if got, want := b.References(r), []Location{def, ref}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got references %+v, want %+v", got, want)
+			}
+
+			if r := b.RangeAt(lsp.Position{Line: 1, Character: 2}); r != nil {
+				t.Errorf("got range %+v at b.go:1:2, want none", r)
+			}
+		})
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader(`{"id":1,"type":"foo","label":"bar"}`)); err == nil {
+		t.Error("got nil error for element with invalid type")
+	}
+	if _, err := Parse(strings.NewReader(`{"id":1,`)); err == nil {
+		t.Error("got nil error for invalid JSON")
+	}
+}
@@ -3,21 +3,24 @@
 package query
 
 import (
+	"regexp"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/search/query/syntax"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/search/query/types"
 )
 
 // All field names.
 const (
-	FieldDefault   = ""
-	FieldCase      = "case"
-	FieldRepo      = "repo"
-	FieldRepoGroup = "repogroup"
-	FieldFile      = "file"
-	FieldFork      = "fork"
-	FieldArchived  = "archived"
-	FieldLang      = "lang"
-	FieldType      = "type"
+	FieldDefault    = ""
+	FieldCase       = "case"
+	FieldRepo       = "repo"
+	FieldRepoGroup  = "repogroup"
+	FieldFile       = "file"
+	FieldFork       = "fork"
+	FieldArchived   = "archived"
+	FieldVisibility = "visibility"
+	FieldLang       = "lang"
+	FieldType       = "type"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -33,21 +36,27 @@ const (
 	FieldTimeout = "timeout"
 )
 
+// repoHasTopicPattern matches repo: field values of the form has.topic(x), which match
+// repositories with the topic x on their code host. They are strings, not regexps, because topics
+// may contain regexp metacharacters (such as c++).
+var repoHasTopicPattern = regexp.MustCompile(`^has\.topic\(([^)]+)\)$`)
+
 var (
 	regexpNegatableFieldType = types.FieldType{Literal: types.RegexpType, Quoted: types.RegexpType, Negatable: true}
 	stringFieldType          = types.FieldType{Literal: types.StringType, Quoted: types.StringType}
 
 	conf = types.Config{
 		FieldTypes: map[string]types.FieldType{
-			FieldDefault:   {Literal: types.RegexpType, Quoted: types.StringType},
-			FieldCase:      {Literal: types.BoolType, Quoted: types.BoolType, Singular: true},
-			FieldRepo:      regexpNegatableFieldType,
-			FieldRepoGroup: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
-			FieldFile:      regexpNegatableFieldType,
-			FieldFork:      {Literal: types.StringType, Quoted: types.StringType, Singular: true},
-			FieldArchived:  {Literal: types.StringType, Quoted: types.StringType, Singular: true},
-			FieldLang:      {Literal: types.StringType, Quoted: types.StringType, Negatable: true},
-			FieldType:      stringFieldType,
+			FieldDefault:    {Literal: types.RegexpType, Quoted: types.StringType},
+			FieldCase:       {Literal: types.BoolType, Quoted: types.BoolType, Singular: true},
+			FieldRepo:       {Literal: types.RegexpType, Quoted: types.RegexpType, Negatable: true, StringValues: repoHasTopicPattern},
+			FieldRepoGroup:  {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldFile:       regexpNegatableFieldType,
+			FieldFork:       {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldArchived:   {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldVisibility: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldLang:       {Literal: types.StringType, Quoted: types.StringType, Negatable: true},
+			FieldType:       stringFieldType,
 
 			FieldBefore:    stringFieldType,
 			FieldAfter:     stringFieldType,
@@ -128,6 +137,7 @@ func (q *Query) Values(field string) []*types.Value {
 
 // RegexpPatterns returns the regexp pattern source strings for the given field.
 // If the field is not recognized or it is not always regexp-typed, it panics.
+// Values that are strings (see types.FieldType.StringValues) are omitted.
 func (q *Query) RegexpPatterns(field string) (values, negatedValues []string) {
 	fieldType, ok := q.conf.FieldTypes[field]
 	if !ok {
@@ -138,6 +148,9 @@ func (q *Query) RegexpPatterns(field string) (values, negatedValues []string) {
 	}
 
 	for _, v := range q.Fields[field] {
+		if v.Regexp == nil {
+			continue
+		}
 		s := v.Regexp.String()
 		if v.Not() {
 			negatedValues = append(negatedValues, s)
@@ -148,6 +161,25 @@ func (q *Query) RegexpPatterns(field string) (values, negatedValues []string) {
 	return
 }
 
+// RepoHasTopics returns the topics of the has.topic(x) values of the repo: field.
+func (q *Query) RepoHasTopics() (topics, notTopics []string) {
+	for _, v := range q.Fields[FieldRepo] {
+		if v.String == nil {
+			continue
+		}
+		m := repoHasTopicPattern.FindStringSubmatch(*v.String)
+		if m == nil {
+			continue
+		}
+		if v.Not() {
+			notTopics = append(notTopics, m[1])
+		} else {
+			topics = append(topics, m[1])
+		}
+	}
+	return topics, notTopics
+}
+
 // StringValues returns the string values for the given field. If the field is
 // not recognized or it is not always string-typed, it panics.
 func (q *Query) StringValues(field string) (values, negatedValues []string) {
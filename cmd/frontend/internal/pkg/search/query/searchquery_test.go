@@ -142,3 +142,28 @@ func checkPanic(t *testing.T, msg string, f func()) {
 	}()
 	f()
 }
+
+func TestQuery_RepoHasTopics(t *testing.T) {
+	// Topics may contain regexp metacharacters, so has.topic(x) values must not be checked as
+	// regexps.
+	query, err := ParseAndCheck("repo:github.com/foo repo:has.topic(c++) -repo:has.topic(go) r:has.topic(web) repo:has.topic()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topics, notTopics := query.RepoHasTopics()
+	if want := []string{"c++", "web"}; !reflect.DeepEqual(topics, want) {
+		t.Errorf("got topics %q, want %q", topics, want)
+	}
+	if want := []string{"go"}; !reflect.DeepEqual(notTopics, want) {
+		t.Errorf("got negated topics %q, want %q", notTopics, want)
+	}
+
+	// has.topic(x) values are not repo: patterns.
+	patterns, negatedPatterns := query.RegexpPatterns(FieldRepo)
+	if want := []string{"github.com/foo", "has.topic()"}; !reflect.DeepEqual(patterns, want) {
+		t.Errorf("got patterns %q, want %q", patterns, want)
+	}
+	if len(negatedPatterns) != 0 {
+		t.Errorf("got negated patterns %q, want none", negatedPatterns)
+	}
+}
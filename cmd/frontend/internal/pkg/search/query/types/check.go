@@ -33,6 +33,11 @@ type FieldType struct {
 	Singular  bool      // whether the field may only be used 0 or 1 times
 	Negatable bool      // whether the field can be matched negated (i.e., -field:value)
 
+	// StringValues, if set, matches the literal values of the field that are strings regardless
+	// of Literal (for example, has.topic(x) values of the regexp-typed repo: field). They are not
+	// validated as any other type.
+	StringValues *regexp.Regexp
+
 	// FeatureFlagEnabled returns true if this field is enabled.
 	// The field is always enabled if this is nil.
 	FeatureFlagEnabled func() bool
@@ -96,7 +101,11 @@ func (c *Config) checkExpr(expr *syntax.Expr) (field string, fieldType FieldType
 	value = &Value{syntax: expr}
 	switch expr.ValueType {
 	case syntax.TokenLiteral:
-		if err := setValue(value, expr.Value, fieldType.Literal); err != nil {
+		valueType := fieldType.Literal
+		if fieldType.StringValues != nil && fieldType.StringValues.MatchString(expr.Value) {
+			valueType = StringType
+		}
+		if err := setValue(value, expr.Value, valueType); err != nil {
 			return "", FieldType{}, nil, &TypeError{Pos: expr.Pos, Err: err}
 		}
 
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/pkg/actor"
 	"github.com/sourcegraph/sourcegraph/pkg/conf"
 	"github.com/sourcegraph/sourcegraph/pkg/env"
@@ -177,7 +179,27 @@ func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiry
 		}
 		value = &sessionInfo{Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: time.Now()}
 	}
-	return SetData(w, r, "actor", value)
+	if err := SetData(w, r, "actor", value); err != nil {
+		return err
+	}
+
+	// Sync the user's repository permissions when they sign in, so that changes on the code host
+	// take effect without waiting for the next periodic sync.
+	if _, authzProviders := authz.GetProviders(); len(authzProviders) > 0 && actor != nil && actor.IsAuthenticated() {
+		goroutine.Go(func() { syncUserPermissions(actor.UID) })
+	}
+	return nil
+}
+
+func syncUserPermissions(userID int32) {
+	ctx := context.Background()
+	user, err := db.Users.GetByID(ctx, userID)
+	if err == nil {
+		err = db.SyncUserPermissions(ctx, user)
+	}
+	if err != nil {
+		log15.Error("Syncing user permissions on sign-in failed.", "user", userID, "error", err)
+	}
 }
 
 func hasSessionCookie(r *http.Request) bool {
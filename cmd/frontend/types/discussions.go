@@ -17,6 +17,16 @@ type DiscussionThread struct {
 	ArchivedAt   *time.Time
 	UpdatedAt    time.Time
 	DeletedAt    *time.Time
+
+	// ResolvedAt is set when the thread has been resolved. Threads that are not
+	// resolved are open.
+	ResolvedAt *time.Time
+
+	// Labels are free-form labels attached to the thread, e.g. "security".
+	Labels []string
+
+	// AssigneeUserIDs are the users the thread is assigned to.
+	AssigneeUserIDs []int32
 }
 
 // DiscussionThreadTargetRepo mirrors the underlying discussion_threads_target_repo field types exactly.
@@ -29,6 +39,12 @@ type DiscussionThreadTargetRepo struct {
 	Branch   *string
 	Revision *string
 
+	// BaseBranch and BaseRevision are set if the thread is on the diff between
+	// the base and the head (Branch and Revision). The selection, if any, is
+	// then in the head version of the file.
+	BaseBranch   *string
+	BaseRevision *string
+
 	StartLine      *int32
 	EndLine        *int32
 	StartCharacter *int32
@@ -44,6 +60,12 @@ func (d *DiscussionThreadTargetRepo) HasSelection() bool {
 	return d.StartLine != nil || d.EndLine != nil || d.StartCharacter != nil || d.EndCharacter != nil || d.LinesBefore != nil || d.Lines != nil || d.LinesAfter != nil
 }
 
+// IsDiff tells if the thread is on a diff between two revisions, rather than on
+// a single revision.
+func (d *DiscussionThreadTargetRepo) IsDiff() bool {
+	return d.BaseRevision != nil
+}
+
 // DiscussionComment mirrors the underlying discussion_comments field types exactly.
 // It intentionally does not try to e.g. alleviate null fields.
 type DiscussionComment struct {
@@ -55,4 +77,62 @@ type DiscussionComment struct {
 	UpdatedAt    time.Time
 	DeletedAt    *time.Time
 	Reports      []string
+
+	// ParentCommentID is the comment (in the same thread) that this comment
+	// is a reply to, if any.
+	ParentCommentID *int64
+}
+
+// DiscussionCommentRevision mirrors the underlying discussion_comment_revisions field types
+// exactly. Each comment has one revision per version of its contents, the first of which is
+// created along with the comment.
+type DiscussionCommentRevision struct {
+	ID           int64
+	CommentID    int64
+	AuthorUserID int32 // the user who wrote this version of the contents
+	Contents     string
+	Reports      []string
+	CreatedAt    time.Time
+}
+
+// DiscussionCommentReaction mirrors the underlying discussion_comment_reactions field types
+// exactly.
+type DiscussionCommentReaction struct {
+	CommentID int64
+	UserID    int32
+	Reaction  string // an emoji, e.g. "👍"
+	CreatedAt time.Time
+}
+
+// DiscussionExternalComment mirrors the underlying discussion_external_comments field types
+// exactly. It records that a discussion comment was imported from (or posted to) a review comment
+// on a pull request of a code host.
+type DiscussionExternalComment struct {
+	CommentID        int64
+	ThreadID         int64
+	RepoID           api.RepoID
+	ServiceType      string
+	ServiceID        string
+	PullRequestID    string // the number (GitHub) or IID (GitLab) of the pull request
+	ExternalThreadID string // the ID of the first review comment (GitHub) or of the discussion (GitLab)
+	ExternalID       string // the ID of the review comment
+	CreatedAt        time.Time
+}
+
+// DiscussionSubscription mirrors the underlying discussion_subscriptions field types exactly. A
+// user is subscribed either to a single thread (ThreadID), or to all threads on a repository
+// (RepoID) whose path starts with PathPrefix (an empty PathPrefix matches all threads).
+type DiscussionSubscription struct {
+	ID         int64
+	UserID     int32
+	ThreadID   *int64
+	RepoID     *api.RepoID
+	PathPrefix string
+
+	// Muted is whether the user unwatched the thread, so that they are not
+	// notified about it even if they are otherwise subscribed. Only thread
+	// subscriptions may be muted.
+	Muted bool
+
+	CreatedAt time.Time
 }
@@ -29,6 +29,18 @@ type Repo struct {
 	Enabled bool
 	// Fork is whether this repository is a fork of another repository.
 	Fork bool
+	// Archived is whether this repository is archived on its code host.
+	Archived bool
+	// Topics are the topics (or labels or tags) of this repository on its code host.
+	Topics []string
+	// Stars is the number of stars of this repository on its code host.
+	Stars int
+	// DefaultBranch is the name of the default branch of this repository on its code host,
+	// or empty if unknown.
+	DefaultBranch string
+	// Visibility is the visibility of this repository on its code host (one of the
+	// api.RepoVisibility constants), or empty if unknown.
+	Visibility string
 	// CreatedAt is when this repository was created on Sourcegraph.
 	CreatedAt time.Time
 	// UpdatedAt is when this repository's metadata was last updated on Sourcegraph.
@@ -118,3 +130,11 @@ type SurveyResponse struct {
 	Better    *string
 	CreatedAt time.Time
 }
+
+// LSIFDump is an uploaded LSIF (Language Server Index Format) dump for a commit of a repository.
+type LSIFDump struct {
+	ID        int64
+	RepoID    api.RepoID
+	CommitID  api.CommitID
+	CreatedAt time.Time
+}
@@ -410,6 +410,12 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 		resp.Cloned = true
 		var statusErr, updateErr error
 
+		if req.FetchChangeRefs != nil {
+			if err := setFetchChangeRefs(dir, *req.FetchChangeRefs); err != nil {
+				log15.Warn("failed to set whether to fetch change refs", "repo", req.Repo, "error", err)
+			}
+		}
+
 		if debounce(req.Repo, req.Since) {
 			updateErr = s.doRepoUpdate(ctx, req.Repo, req.URL)
 		}
@@ -1197,7 +1203,11 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "git", "fetch", "--prune", url, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*", "+refs/pull/*:refs/pull/*")
+	args := []string{"fetch", "--prune", url, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*", "+refs/pull/*:refs/pull/*"}
+	if fetchChangeRefs(dir) {
+		args = append(args, "+refs/changes/*:refs/changes/*")
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = dir
 
 	// drop temporary pack files after a fetch. this function won't
@@ -1263,6 +1273,35 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 	return nil
 }
 
+// fetchChangeRefs reports whether updates of the repository in gitDir fetch Gerrit change refs
+// (refs/changes/*). This is stored in the repository's Git config, so that it also applies to
+// updates that are not requested by repo-updater (such as in ensureRevision).
+func fetchChangeRefs(gitDir string) bool {
+	cmd := exec.Command("git", "config", "--bool", "--get", "sourcegraph.fetchChangeRefs")
+	cmd.Dir = gitDir
+	out, err := cmd.Output()
+	// An error (exit code 1) means the key is not set.
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// setFetchChangeRefs sets whether updates of the repository in gitDir fetch Gerrit change refs.
+func setFetchChangeRefs(gitDir string, fetch bool) error {
+	if fetchChangeRefs(gitDir) == fetch {
+		return nil
+	}
+	var cmd *exec.Cmd
+	if fetch {
+		cmd = exec.Command("git", "config", "--bool", "sourcegraph.fetchChangeRefs", "true")
+	} else {
+		cmd = exec.Command("git", "config", "--unset", "sourcegraph.fetchChangeRefs")
+	}
+	cmd.Dir = gitDir
+	if _, err := cmd.Output(); err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "failed to update fetchChangeRefs")
+	}
+	return nil
+}
+
 func (s *Server) ensureRevision(ctx context.Context, repo api.RepoName, url, rev, repoDir string) (didUpdate bool) {
 	if rev == "" || rev == "HEAD" {
 		return false
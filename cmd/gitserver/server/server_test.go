@@ -388,3 +388,23 @@ func TestCloneRepo(t *testing.T) {
 		t.Fatal("failed to clone")
 	}
 }
+
+func TestFetchChangeRefs(t *testing.T) {
+	dir, cleanup := tmpDir(t)
+	defer cleanup()
+	if err := exec.Command("git", "--bare", "init", dir).Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fetchChangeRefs(dir) {
+		t.Fatal("expected change refs not to be fetched by default")
+	}
+	for _, want := range []bool{true, true, false, false} {
+		if err := setFetchChangeRefs(dir, want); err != nil {
+			t.Fatal(err)
+		}
+		if got := fetchChangeRefs(dir); got != want {
+			t.Fatalf("got fetchChangeRefs %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+// Code monitors are saved queries with actions, such as opening an issue or a
+// discussion thread for the new results. The actions are queued like
+// notifications (so they are retried when they fail), and run by the frontend,
+// which records the results each action handled so that repeated runs (and
+// retries) don't handle a result twice.
+
+// actionNotifications returns the notifications that run the actions of the
+// saved query on the new search results.
+func (n *notifier) actionNotifications() ([]*api.SavedQueryNotification, error) {
+	if len(n.query.Actions) == 0 {
+		return nil, nil
+	}
+
+	results, err := extractResults(n.results.Data.Search.Results.Results)
+	if err != nil {
+		return nil, err
+	}
+	if n.delta != nil {
+		// Only run the actions on the results that were added since the
+		// last run.
+		var added []*api.CodeMonitorResult
+		for _, r := range results {
+			if n.delta.addedIDs[r.Key] {
+				added = append(added, r)
+			}
+		}
+		results = added
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	notifications := make([]*api.SavedQueryNotification, len(n.query.Actions))
+	for i, action := range n.query.Actions {
+		// The payload only consists of strings, numbers, slices and
+		// structs, so encoding it can't fail.
+		payload, _ := json.Marshal(actionNotificationPayload{
+			Description: n.query.Description,
+			Query:       n.query.Query,
+			Action:      action,
+			Results:     results,
+		})
+		// Actions are run on behalf of the saved query, so they have no
+		// recipient.
+		notifications[i] = &api.SavedQueryNotification{
+			Spec:    n.spec,
+			Channel: api.SavedQueryNotificationChannelAction,
+			Payload: payload,
+		}
+	}
+	return notifications, nil
+}
@@ -105,6 +105,15 @@ func serveSavedQueryWasCreatedOrUpdated(w http.ResponseWriter, r *http.Request)
 			}()
 		}
 
+		if oldQuery := oldValue.Config.Query; oldQuery != "" && oldQuery != query.Query {
+			// The state of the old query is no longer used.
+			go func() {
+				if err := deleteSavedQueryState(context.Background(), spec, oldQuery); err != nil {
+					log15.Error("Failed to delete state of updated saved search.", "spec", spec, "error", err)
+				}
+			}()
+		}
+
 		allSavedQueries.allSavedQueries[key] = newValue
 	}
 	log15.Info("saved query created or updated", "total_saved_queries", len(allSavedQueries.allSavedQueries))
@@ -137,19 +146,9 @@ func serveSavedQueryWasDeleted(w http.ResponseWriter, r *http.Request) {
 		}()
 	}
 
-	// Delete from database, but only if another saved query is not the same.
-	anotherExists := false
-	for _, other := range allSavedQueries.allSavedQueries {
-		if other.Config.Query == query.Config.Query {
-			anotherExists = true
-			break
-		}
-	}
-	if !anotherExists {
-		if err := api.InternalClient.SavedQueriesDeleteInfo(r.Context(), query.Config.Query); err != nil {
-			log15.Error("Failed to delete saved query from DB: SavedQueriesDeleteInfo", "error", err)
-			return
-		}
+	if err := deleteSavedQueryState(r.Context(), query.Spec, query.Config.Query); err != nil {
+		log15.Error("Failed to delete state of deleted saved search.", "spec", query.Spec, "error", err)
+		return
 	}
 	log15.Info("saved query deleted", "total_saved_queries", len(allSavedQueries.allSavedQueries))
 }
@@ -228,6 +227,11 @@ func serveTestNotification(w http.ResponseWriter, r *http.Request) {
 			writeError(w, fmt.Errorf("error sending email notifications to %s: %s", recipient.spec, err))
 			return
 		}
+		payload := newWebhookPayload("test", query.Spec, query.Config, searchURL(query.Config.Query, utmSourceWebhook))
+		if err := webhookNotify(r.Context(), recipient, query.Config, payload); err != nil {
+			writeError(w, fmt.Errorf("error sending webhook notifications to %s: %s", recipient.spec, err))
+			return
+		}
 	}
 
 	log15.Info("saved query test notification sent", "spec", args.Spec, "key", key)
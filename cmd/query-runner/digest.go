@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log15 "gopkg.in/inconshreveable/log15.v2"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+// Saved queries with a digest run like other saved queries, and their actions
+// run immediately, but the notifications about their new (and removed)
+// results are batched into a pending digest that is sent once per digest
+// interval.
+
+var digestIntervals = map[string]time.Duration{
+	"daily":  24 * time.Hour,
+	"weekly": 7 * 24 * time.Hour,
+}
+
+// digestInterval returns how often the digest is sent.
+func digestInterval(digest string) (time.Duration, error) {
+	interval, ok := digestIntervals[digest]
+	if !ok {
+		return 0, fmt.Errorf("invalid digest %q (must be daily or weekly)", digest)
+	}
+	return interval, nil
+}
+
+// pendingDigest is the pending digest of a saved query: the changes in its
+// results since the last digest was sent.
+type pendingDigest struct {
+	Since   time.Time       // when the last digest was sent (or the first change was added)
+	Added   map[string]bool // labels of the added results
+	Removed map[string]bool // labels of the removed results
+}
+
+// add adds the changes to the digest. A result that was added and then removed
+// (or removed and then added) within the digest interval cancels out.
+func (d *pendingDigest) add(added, removed []string) {
+	if d.Added == nil {
+		d.Added = map[string]bool{}
+	}
+	if d.Removed == nil {
+		d.Removed = map[string]bool{}
+	}
+	for _, label := range added {
+		if d.Removed[label] {
+			delete(d.Removed, label)
+		} else {
+			d.Added[label] = true
+		}
+	}
+	for _, label := range removed {
+		if d.Added[label] {
+			delete(d.Added, label)
+		} else {
+			d.Removed[label] = true
+		}
+	}
+}
+
+// delta returns the changes in the digest.
+func (d *pendingDigest) delta() *resultDelta {
+	var delta resultDelta
+	for label := range d.Added {
+		delta.added = append(delta.added, label)
+	}
+	for label := range d.Removed {
+		delta.removed = append(delta.removed, label)
+	}
+	sort.Strings(delta.added)
+	sort.Strings(delta.removed)
+	return &delta
+}
+
+// addToDigest adds the new results (or the delta, if non-nil) to the pending
+// digest of the saved query.
+func addToDigest(spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, results *gqlSearchResponse, delta *resultDelta) error {
+	var added, removed []string
+	if delta != nil {
+		added, removed = delta.added, delta.removed
+	} else {
+		snapshot, err := extractSnapshot(results.Data.Search.Results.Results)
+		if err != nil {
+			return err
+		}
+		for _, label := range snapshot {
+			added = append(added, label)
+		}
+	}
+
+	var d pendingDigest
+	ok, err := digests.load(spec, query.Query, &d)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		d.Since = time.Now()
+	}
+	d.add(added, removed)
+	return digests.save(spec, query.Query, &d)
+}
+
+// sendDigest queues the notifications about the pending digest of the saved
+// query, if the digest interval has elapsed.
+func sendDigest(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) error {
+	interval, err := digestInterval(query.Digest)
+	if err != nil {
+		return err
+	}
+	var d pendingDigest
+	ok, err := digests.load(spec, query.Query, &d)
+	if err != nil || !ok {
+		return err
+	}
+	if time.Since(d.Since) < interval {
+		return nil
+	}
+
+	if delta := d.delta(); !delta.empty() {
+		log15.Info("queueing digest notifications", "new_results", len(delta.added), "removed_results", len(delta.removed), "description", query.Description)
+		recipients, err := getNotificationRecipients(ctx, spec, query)
+		if err != nil {
+			return err
+		}
+		n := &notifier{
+			spec:       spec,
+			query:      query,
+			newQuery:   query.Query,
+			delta:      delta,
+			recipients: recipients,
+		}
+		if notifications := n.notifications(); len(notifications) > 0 {
+			if err := api.InternalClient.SavedQueryNotificationsEnqueue(ctx, notifications); err != nil {
+				return err
+			}
+		}
+	}
+	return digests.save(spec, query.Query, &pendingDigest{Since: time.Now()})
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPendingDigest(t *testing.T) {
+	var d pendingDigest
+	d.add([]string{"a", "b"}, []string{"x"})
+	d.add([]string{"c", "x"}, []string{"b", "y"})
+
+	// b was added and then removed, and x was removed and then added again.
+	delta := d.delta()
+	if want := []string{"a", "c"}; !reflect.DeepEqual(delta.added, want) {
+		t.Errorf("got added %q, want %q", delta.added, want)
+	}
+	if want := []string{"y"}; !reflect.DeepEqual(delta.removed, want) {
+		t.Errorf("got removed %q, want %q", delta.removed, want)
+	}
+
+	if delta := (&pendingDigest{}).delta(); !delta.empty() {
+		t.Errorf("got delta %+v for empty digest, want empty", delta)
+	}
+}
+
+func TestDigestInterval(t *testing.T) {
+	if _, err := digestInterval("daily"); err != nil {
+		t.Error(err)
+	}
+	if _, err := digestInterval("monthly"); err == nil {
+		t.Error("got no error for invalid digest")
+	}
+}
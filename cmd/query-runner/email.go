@@ -3,13 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/txemail"
 	"github.com/sourcegraph/sourcegraph/pkg/txemail/txtypes"
-	log15 "gopkg.in/inconshreveable/log15.v2"
 )
 
 func canSendEmail(ctx context.Context) error {
@@ -23,53 +23,61 @@ func canSendEmail(ctx context.Context) error {
 	return nil
 }
 
-func (n *notifier) emailNotify(ctx context.Context) {
-	if err := canSendEmail(ctx); err != nil {
-		log15.Error("Failed to send email notification for saved search.", "error", err)
-		return
-	}
-
-	// Send tx emails asynchronously.
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-		defer cancel()
+// emailNotifications returns the email notifications to send to the
+// recipients about the new search results. Whether email can be sent is only
+// checked when delivering them, so that they are retried until SMTP is
+// configured.
+func (n *notifier) emailNotifications() []*api.SavedQueryNotification {
+	var notifications []*api.SavedQueryNotification
+	for _, recipient := range n.recipients {
+		if !recipient.email {
+			continue
+		}
 
-		for _, recipient := range n.recipients {
-			ownership := "the" // example: "new search results have been found for {{.Ownership}} saved search"
-			if n.spec.Subject.User != nil && *n.spec.Subject.User == recipient.spec.userID {
-				ownership = "your"
-			}
-			if n.spec.Subject.Org != nil {
-				ownership = "your organization's"
-			}
+		ownership := "the" // example: "new search results have been found for {{.Ownership}} saved search"
+		if n.spec.Subject.User != nil && *n.spec.Subject.User == recipient.spec.userID {
+			ownership = "your"
+		}
+		if n.spec.Subject.Org != nil {
+			ownership = "your organization's"
+		}
 
+		var payload emailNotificationPayload
+		if n.delta != nil {
+			payload = n.deltaEmail(ownership)
+		} else {
 			plural := ""
 			if n.results.Data.Search.Results.ApproximateResultCount != "1" {
 				plural = "s"
 			}
-			if err := sendEmail(ctx, recipient.spec.userID, "results", newSearchResultsEmailTemplates, struct {
-				URL                    string
-				Description            string
-				Query                  string
-				ApproximateResultCount string
-				Ownership              string
-				PluralResults          string
-			}{
-				URL:                    searchURL(n.newQuery, utmSourceEmail),
-				Description:            n.query.Description,
-				Query:                  n.query.Query,
-				ApproximateResultCount: n.results.Data.Search.Results.ApproximateResultCount,
-				Ownership:              ownership,
-				PluralResults:          plural,
-			}); err != nil {
-				log15.Error("Failed to send email notification for new saved search results.", "userID", recipient.spec.userID, "error", err)
+			payload = emailNotificationPayload{
+				Template: newSearchResultsEmailTemplates,
+				Data: struct {
+					URL                    string
+					Description            string
+					Query                  string
+					ApproximateResultCount string
+					Ownership              string
+					PluralResults          string
+					Digest                 string
+				}{
+					URL:                    searchURL(n.newQuery, utmSourceEmail),
+					Description:            n.query.Description,
+					Query:                  n.query.Query,
+					ApproximateResultCount: n.results.Data.Search.Results.ApproximateResultCount,
+					Ownership:              ownership,
+					PluralResults:          plural,
+					Digest:                 n.query.Digest,
+				},
 			}
 		}
-	}()
+		notifications = append(notifications, n.newNotification(api.SavedQueryNotificationChannelEmail, recipient, payload))
+	}
+	return notifications
 }
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `[{{.ApproximateResultCount}} new result{{.PluralResults}}] {{.Description}}`,
+	Subject: `{{with .Digest}}[{{.}} digest] {{end}}[{{.ApproximateResultCount}} new result{{.PluralResults}}] {{.Description}}`,
 	Text: `
 {{.ApproximateResultCount}} new search result{{.PluralResults}} found for {{.Ownership}} saved search:
 
@@ -86,6 +94,63 @@ View the new result{{.PluralResults}} on Sourcegraph: {{.URL}}
 `,
 })
 
+// deltaEmail returns the email notification about the added and removed
+// results of a saved query that is not a commit or diff query.
+func (n *notifier) deltaEmail(ownership string) emailNotificationPayload {
+	data := struct {
+		URL         string
+		Description string
+		Ownership   string
+		Summary     string
+		Added       []string
+		Removed     []string
+		Digest      string
+	}{
+		URL:         searchURL(n.newQuery, utmSourceEmail),
+		Description: n.query.Description,
+		Ownership:   ownership,
+		Summary:     n.delta.summary(strconv.Itoa),
+		Digest:      n.query.Digest,
+	}
+	if n.delta.listed() {
+		data.Added = n.delta.added
+		data.Removed = n.delta.removed
+	}
+	return emailNotificationPayload{Template: changedSearchResultsEmailTemplates, Data: data}
+}
+
+var changedSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `{{with .Digest}}[{{.}} digest] {{end}}[{{.Summary}}] {{.Description}}`,
+	Text: `
+{{.Summary}} found for {{.Ownership}} saved search:
+
+  "{{.Description}}"
+{{if .Added}}
+New results:
+{{range .Added}}
+  {{.}}{{end}}
+{{end}}{{if .Removed}}
+Removed results:
+{{range .Removed}}
+  {{.}}{{end}}
+{{end}}
+View the current results on Sourcegraph: {{.URL}}
+`,
+	HTML: `
+<strong>{{.Summary}}</strong> found for {{.Ownership}} saved search:
+
+<p style="padding-left: 16px">&quot;{{.Description}}&quot;</p>
+{{if .Added}}
+<p>New results:</p>
+<ul>{{range .Added}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{end}}{{if .Removed}}
+<p>Removed results:</p>
+<ul>{{range .Removed}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{end}}
+<p><a href="{{.URL}}">View the current results on Sourcegraph</a></p>
+`,
+})
+
 func emailNotifySubscribeUnsubscribe(ctx context.Context, recipient *recipient, query api.SavedQuerySpecAndConfig, template txtypes.Templates) error {
 	if !recipient.email {
 		return nil
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/pkg/api"
@@ -33,6 +35,9 @@ const gqlSearchQuery = `query Search(
 			timedout { name }
 			results {
 				__typename
+				... on Repository {
+					name
+				}
 				... on FileMatch {
 					resource
 					limitHit
@@ -113,6 +118,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
@@ -122,7 +128,8 @@ type gqlSearchResponse struct {
 	Errors []interface{}
 }
 
-func search(ctx context.Context, query string) (*gqlSearchResponse, error) {
+// search runs the search query on behalf of the owner of the saved query (see searchActorUserID).
+func search(ctx context.Context, subject api.SettingsSubject, query string) (*gqlSearchResponse, error) {
 	var buf bytes.Buffer
 	err := json.NewEncoder(&buf).Encode(graphQLQuery{
 		Query:     gqlSearchQuery,
@@ -137,7 +144,15 @@ func search(ctx context.Context, query string) (*gqlSearchResponse, error) {
 		return nil, errors.Wrap(err, "constructing frontend URL")
 	}
 
-	resp, err := ctxhttp.Post(ctx, nil, url, "application/json", &buf)
+	req, err := http.NewRequest("POST", url, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if uid, ok := searchActorUserID(subject); ok {
+		req.Header.Set(api.ActorUserIDHeader, strconv.FormatInt(int64(uid), 10))
+	}
+	resp, err := ctxhttp.Do(ctx, nil, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "Post")
 	}
@@ -153,6 +168,21 @@ func search(ctx context.Context, query string) (*gqlSearchResponse, error) {
 	return res, nil
 }
 
+// searchActorUserID returns the ID of the user whose repository permissions apply to the results
+// of a saved query in the settings of the subject: the user for user settings, and an anonymous
+// user (ID 0) for org settings, so that only results that all org members can see are returned.
+// Saved queries in global settings (which only site admins can edit) search all repositories.
+func searchActorUserID(subject api.SettingsSubject) (uid int32, ok bool) {
+	switch {
+	case subject.User != nil:
+		return *subject.User, true
+	case subject.Org != nil:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
 func gqlURL(queryName string) (string, error) {
 	u, err := url.Parse(api.InternalClient.URL)
 	if err != nil {
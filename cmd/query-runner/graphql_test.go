@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+)
+
+func TestSearchActorUserID(t *testing.T) {
+	userID, orgID := int32(1), int32(2)
+	tests := map[string]struct {
+		subject api.SettingsSubject
+		wantUID int32
+		wantOK  bool
+	}{
+		"user": {subject: api.SettingsSubject{User: &userID}, wantUID: 1, wantOK: true},
+		"org":  {subject: api.SettingsSubject{Org: &orgID}, wantUID: 0, wantOK: true},
+		"site": {subject: api.SettingsSubject{Site: true}, wantOK: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			uid, ok := searchActorUserID(test.subject)
+			if uid != test.wantUID || ok != test.wantOK {
+				t.Errorf("got (%d, %v), want (%d, %v)", uid, ok, test.wantUID, test.wantOK)
+			}
+		})
+	}
+}
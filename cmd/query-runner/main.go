@@ -10,7 +10,9 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -26,7 +28,8 @@ import (
 )
 
 var (
-	forceRunInterval = env.Get("FORCE_RUN_INTERVAL", "", "Force an interval to run saved queries at, instead of assuming query execution time * 30 (query that takes 2s to run, runs every 60s)")
+	forceRunInterval          = env.Get("FORCE_RUN_INTERVAL", "", "Force an interval to run saved queries at, instead of assuming query execution time * 30 (query that takes 2s to run, runs every 60s). Saved queries with a schedule are not affected.")
+	maxConcurrentSavedQueries = env.Get("MAX_CONCURRENT_SAVED_QUERIES", "1", "The maximum number of saved queries to run at once")
 )
 
 const port = "3183"
@@ -59,6 +62,8 @@ func main() {
 		}
 	}()
 
+	go deliverNotifications()
+
 	host := ""
 	if env.InsecureDev {
 		host = "127.0.0.1"
@@ -86,6 +91,7 @@ var executor = &executorT{}
 
 type executorT struct {
 	forceRunInterval *time.Duration
+	maxConcurrent    int // the maximum number of saved queries to run at once
 }
 
 func (e *executorT) run(ctx context.Context) error {
@@ -99,6 +105,14 @@ func (e *executorT) run(ctx context.Context) error {
 		e.forceRunInterval = &forceRunInterval
 	}
 
+	// Parse MAX_CONCURRENT_SAVED_QUERIES value.
+	maxConcurrent, err := strconv.Atoi(maxConcurrentSavedQueries)
+	if err != nil || maxConcurrent < 1 {
+		log15.Error("executor: failed to parse MAX_CONCURRENT_SAVED_QUERIES (must be a positive integer)", "value", maxConcurrentSavedQueries)
+		return nil
+	}
+	e.maxConcurrent = maxConcurrent
+
 	// Kick off fetching of the full list of saved queries from the frontend.
 	// Important to do this early on in case we get created/updated/deleted
 	// notifications for saved queries.
@@ -110,12 +124,26 @@ func (e *executorT) run(ctx context.Context) error {
 	for {
 		allSavedQueries := allSavedQueries.get()
 		start := time.Now()
+		// Run at most e.maxConcurrent queries at once, to avoid overloading
+		// searcher/gitserver.
+		sem := make(chan struct{}, e.maxConcurrent)
+		var wg sync.WaitGroup
 		for _, query := range allSavedQueries {
-			err := e.runQuery(ctx, query.Spec, query.Config)
-			if err != nil {
-				log15.Error("executor: failed to run query", "error", err, "query_description", query.Config.Description)
-			}
+			query := query
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				err := e.runQuery(ctx, query.Spec, query.Config)
+				if err != nil {
+					log15.Error("executor: failed to run query", "error", err, "query_description", query.Config.Description)
+				}
+			}()
 		}
+		wg.Wait()
 
 		// If running all the queries didn't take very long (due to them
 		// erroring out quickly, or if we had zero to run, or if they very
@@ -130,17 +158,13 @@ func (e *executorT) run(ctx context.Context) error {
 // runQuery runs the given query if an appropriate amount of time has elapsed
 // since it last ran.
 func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) error {
-	if !query.Notify && !query.NotifySlack {
-		// No need to run this query because there will be nobody to notify.
-		return nil
-	}
-	if !strings.Contains(query.Query, "type:diff") && !strings.Contains(query.Query, "type:commit") {
-		// TODO(slimsag): we temporarily do not support non-commit search
-		// queries, since those do not support the after:"time" operator.
+	if !query.Notify && !query.NotifySlack && !query.NotifyWebhook && query.Webhook == nil && len(query.Actions) == 0 {
+		// No need to run this query because there will be nobody to notify
+		// and no action to run.
 		return nil
 	}
 
-	info, err := api.InternalClient.SavedQueriesGetInfo(ctx, query.Query)
+	info, err := api.InternalClient.SavedQueriesGetInfo(ctx, spec, query.Query)
 	if err != nil {
 		return errors.Wrap(err, "SavedQueriesGetInfo")
 	}
@@ -148,25 +172,38 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	// If the saved query was executed recently in the past, then skip it to
 	// avoid putting too much pressure on searcher/gitserver.
 	if info != nil {
-		// We assume a run interval of 30x that which it takes to execute the
-		// query. For example, a query which takes 2s to execute will run (2s*30)
-		// every minute.
-		//
-		// Additionally, in case queries run very quickly (e.g. our after:
-		// queries with no results often return in ~15ms), we impose a minimum
-		// run interval of 10s.
-		runInterval := info.ExecDuration * 30
-		if runInterval < 10*time.Second {
-			runInterval = 10 * time.Second
-		}
-		if e.forceRunInterval != nil {
-			runInterval = *e.forceRunInterval
+		runInterval, err := runInterval(query, info.ExecDuration, e.forceRunInterval)
+		if err != nil {
+			return err
 		}
 		if time.Since(info.LastExecuted) < runInterval {
 			return nil // too early to run the query
 		}
 	}
 
+	// Don't run the query during its quiet hours. Results found in the
+	// meantime are notified when it runs after the quiet hours end.
+	quiet, err := inQuietHours(query.QuietHours, time.Now())
+	if err != nil {
+		return err
+	}
+	if quiet {
+		return nil
+	}
+
+	// Send the pending digest of the previous runs, if it is due.
+	if query.Digest != "" {
+		if err := sendDigest(ctx, spec, query); err != nil {
+			return errors.Wrap(err, "sending digest")
+		}
+	}
+
+	// Only commit and diff queries support the after:"time" operator. The
+	// results of other queries are compared against those of the last run.
+	if !isCommitQuery(query.Query) {
+		return e.runSnapshotQuery(ctx, spec, query, info)
+	}
+
 	// Construct a new query which finds search results introduced after the
 	// last time we queried.
 	var latestKnownResult time.Time
@@ -189,11 +226,24 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	// fails in order to avoid e.g. failed saved queries from executing
 	// constantly and potentially causing harm to the system. We'll retry at
 	// our normal interval, regardless of errors.
-	v, execDuration, searchErr := performSearch(ctx, newQuery)
+	v, execDuration, searchErr := performSearch(ctx, spec.Subject, newQuery)
+	recordExecution(ctx, spec, newQuery, v, execDuration, searchErr)
+
+	// Queue notifications for the new search results before recording them as
+	// known, so that they are found again next time if queueing fails.
+	var notifyErr error
+	if searchErr == nil {
+		notifyErr = notify(ctx, spec, query, newQuery, v, nil)
+	}
+	latestResult := latestResultTime(info, v, searchErr)
+	if notifyErr != nil {
+		latestResult = latestResultTime(info, v, notifyErr)
+	}
 	if err := api.InternalClient.SavedQueriesSetInfo(ctx, &api.SavedQueryInfo{
+		Spec:         spec,
 		Query:        query.Query,
 		LastExecuted: time.Now(),
-		LatestResult: latestResultTime(info, v, searchErr),
+		LatestResult: latestResult,
 		ExecDuration: execDuration,
 	}); err != nil {
 		return errors.Wrap(err, "SavedQueriesSetInfo")
@@ -202,24 +252,91 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	if searchErr != nil {
 		return searchErr
 	}
+	return errors.Wrap(notifyErr, "queueing notifications")
+}
 
-	// Send notifications for new search results in a separate goroutine, so
-	// that we don't block other search queries from running in sequence (which
-	// is done intentionally, to ensure no overloading of searcher/gitserver).
-	go func() {
-		if err := notify(context.Background(), spec, query, newQuery, v); err != nil {
-			log15.Error("executor: failed to send notifications", "error", err)
+// runSnapshotQuery runs a query that does not support the after:"time"
+// operator (such as a content query), and sends notifications for the results
+// that were added or removed since the last time it ran.
+func (e *executorT) runSnapshotQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, info *api.SavedQueryInfo) error {
+	v, execDuration, searchErr := performSearch(ctx, spec.Subject, query.Query)
+	recordExecution(ctx, spec, query.Query, v, execDuration, searchErr)
+	latestResult := time.Now()
+	if searchErr != nil && info != nil {
+		latestResult = info.LatestResult
+	}
+	if err := api.InternalClient.SavedQueriesSetInfo(ctx, &api.SavedQueryInfo{
+		Spec:         spec,
+		Query:        query.Query,
+		LastExecuted: time.Now(),
+		LatestResult: latestResult,
+		ExecDuration: execDuration,
+	}); err != nil {
+		return errors.Wrap(err, "SavedQueriesSetInfo")
+	}
+
+	if searchErr != nil {
+		return searchErr
+	}
+
+	cur, err := extractSnapshot(v.Data.Search.Results.Results)
+	if err != nil {
+		return errors.Wrap(err, "extractSnapshot")
+	}
+	var prev resultSnapshot
+	ok, err := snapshots.load(spec, query.Query, &prev)
+	if err != nil {
+		return errors.Wrap(err, "loading result snapshot")
+	}
+	results := v.Data.Search.Results
+	truncated, err := truncatedFiles(results.Results)
+	if err != nil {
+		return errors.Wrap(err, "truncatedFiles")
+	}
+	delta, next := diffSnapshots(prev, cur, incompleteResults{
+		all:   results.LimitHit || len(results.Cloning) > 0 || len(results.Timedout) > 0,
+		files: truncated,
+	})
+
+	// Queue notifications about the changes before saving the new snapshot, so
+	// that the changes are found again next time if queueing fails. If this
+	// is the first time the query ran, there is nothing to compare against.
+	if ok && !delta.empty() {
+		if err := notify(ctx, spec, query, query.Query, v, &delta); err != nil {
+			return errors.Wrap(err, "queueing notifications")
 		}
-	}()
+	}
+	if err := snapshots.save(spec, query.Query, next); err != nil {
+		return errors.Wrap(err, "saving result snapshot")
+	}
 	return nil
 }
 
-func performSearch(ctx context.Context, query string) (v *gqlSearchResponse, execDuration time.Duration, err error) {
+// recordExecution records a run of the saved query, so that users can see when
+// it ran and what it found (or why it failed).
+func recordExecution(ctx context.Context, spec api.SavedQueryIDSpec, query string, v *gqlSearchResponse, execDuration time.Duration, searchErr error) {
+	e := &api.SavedQueryExecution{
+		Spec:       spec,
+		Query:      query,
+		ExecutedAt: time.Now(),
+		Duration:   execDuration,
+	}
+	if searchErr != nil {
+		e.Error = searchErr.Error()
+	} else {
+		e.ResultCount = len(v.Data.Search.Results.Results)
+	}
+	if err := api.InternalClient.SavedQueriesRecordExecution(ctx, e); err != nil {
+		log15.Error("executor: failed to record saved query execution", "error", err, "query", query)
+	}
+}
+
+func performSearch(ctx context.Context, subject api.SettingsSubject, query string) (v *gqlSearchResponse, execDuration time.Duration, err error) {
 	attempts := 0
 	for {
 		// Query for search results.
 		start := time.Now()
-		v, err := search(ctx, query)
+		v, err := search(ctx, subject, query)
 		execDuration := time.Since(start)
 		if err != nil {
 			return nil, execDuration, errors.Wrap(err, "search")
@@ -267,12 +384,19 @@ func latestResultTime(prevInfo *api.SavedQueryInfo, v *gqlSearchResponse, search
 
 var externalURL *url.URL
 
-// notify handles sending notifications for new search results.
-func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse) error {
-	if len(results.Data.Search.Results.Results) == 0 {
-		return nil
+// notify queues notifications for new search results, to be delivered by
+// deliverNotifications. If delta is non-nil, the notifications are about the
+// delta instead of all results. If the saved query has a digest, the results
+// are instead added to its pending digest (but its actions are still queued).
+func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse, delta *resultDelta) error {
+	if delta != nil {
+		log15.Info("queueing notifications", "new_results", len(delta.added), "removed_results", len(delta.removed), "description", query.Description)
+	} else {
+		if len(results.Data.Search.Results.Results) == 0 {
+			return nil
+		}
+		log15.Info("queueing notifications", "new_results", len(results.Data.Search.Results.Results), "description", query.Description)
 	}
-	log15.Info("sending notifications", "new_results", len(results.Data.Search.Results.Results), "description", query.Description)
 
 	// Determine which users to notify.
 	recipients, err := getNotificationRecipients(ctx, spec, query)
@@ -280,29 +404,55 @@ func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSave
 		return err
 	}
 
-	// Send slack notifications.
 	n := &notifier{
 		spec:       spec,
 		query:      query,
 		newQuery:   newQuery,
 		results:    results,
+		delta:      delta,
 		recipients: recipients,
 	}
 
-	// Send Slack and email notifications.
-	n.slackNotify(ctx)
-	n.emailNotify(ctx)
-	return nil
+	// Queue Slack, email and webhook notifications (unless they are batched
+	// into a digest), and the actions of code monitors.
+	var notifications []*api.SavedQueryNotification
+	if query.Digest != "" {
+		if err := addToDigest(spec, query, results, delta); err != nil {
+			return errors.Wrap(err, "adding results to digest")
+		}
+	} else {
+		notifications = n.notifications()
+	}
+	actions, err := n.actionNotifications()
+	if err != nil {
+		return err
+	}
+	notifications = append(notifications, actions...)
+	if len(notifications) == 0 {
+		return nil
+	}
+	return api.InternalClient.SavedQueryNotificationsEnqueue(ctx, notifications)
 }
 
 type notifier struct {
 	spec       api.SavedQueryIDSpec
 	query      api.ConfigSavedQuery
 	newQuery   string
-	results    *gqlSearchResponse
+	results    *gqlSearchResponse // nil for digests
+	delta      *resultDelta       // nil for commit and diff queries (except in digests)
 	recipients recipients
 }
 
+// notifications returns the Slack, email and webhook notifications to send to
+// the recipients.
+func (n *notifier) notifications() []*api.SavedQueryNotification {
+	var notifications []*api.SavedQueryNotification
+	notifications = append(notifications, n.slackNotifications()...)
+	notifications = append(notifications, n.emailNotifications()...)
+	notifications = append(notifications, n.webhookNotifications()...)
+	return notifications
+}
+
 const (
 	utmSourceEmail = "saved-search-email"
 	utmSourceSlack = "saved-search-slack"
@@ -23,13 +23,14 @@ func (r recipientSpec) String() string {
 // recipient describes a recipient of a saved search notification and the type of notifications
 // they're configured to receive.
 type recipient struct {
-	spec  recipientSpec // the recipient's identity
-	email bool          // send an email to the recipient
-	slack bool          // post a Slack message to the recipient
+	spec    recipientSpec // the recipient's identity
+	email   bool          // send an email to the recipient
+	slack   bool          // post a Slack message to the recipient
+	webhook bool          // POST to the recipient's webhook (or the saved search's own webhook)
 }
 
 func (r *recipient) String() string {
-	return fmt.Sprintf("{%s email:%v slack:%v}", r.spec, r.email, r.slack)
+	return fmt.Sprintf("{%s email:%v slack:%v webhook:%v}", r.spec, r.email, r.slack, r.webhook)
 }
 
 func (r recipient) subject() api.SettingsSubject {
@@ -43,14 +44,16 @@ func (r recipient) subject() api.SettingsSubject {
 // events related to the saved search.
 func getNotificationRecipients(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) ([]*recipient, error) {
 	var recipients recipients
+	webhook := query.NotifyWebhook || query.Webhook != nil
 
 	// Notify the owner (user or org).
 	switch {
 	case spec.Subject.User != nil:
 		recipients.add(recipient{
-			spec:  recipientSpec{userID: *spec.Subject.User},
-			email: query.Notify,
-			slack: query.NotifySlack,
+			spec:    recipientSpec{userID: *spec.Subject.User},
+			email:   query.Notify,
+			slack:   query.NotifySlack,
+			webhook: webhook,
 		})
 
 	case spec.Subject.Org != nil:
@@ -69,8 +72,9 @@ func getNotificationRecipients(ctx context.Context, spec api.SavedQueryIDSpec, q
 		}
 
 		recipients.add(recipient{
-			spec:  recipientSpec{orgID: *spec.Subject.Org},
-			slack: query.NotifySlack,
+			spec:    recipientSpec{orgID: *spec.Subject.Org},
+			slack:   query.NotifySlack,
+			webhook: webhook,
 		})
 	}
 
@@ -87,6 +91,7 @@ func (rs *recipients) add(r recipient) {
 			// Merge into existing recipient.
 			r2.email = r2.email || r.email
 			r2.slack = r2.slack || r.slack
+			r2.webhook = r2.webhook || r.webhook
 			return
 		}
 	}
@@ -120,17 +125,19 @@ func diffNotificationRecipients(old, new recipients) (removed, added recipients)
 			return nil, nil
 		}
 		removed = &recipient{
-			spec:  spec,
-			email: old.email && !new.email,
-			slack: old.slack && !new.slack,
+			spec:    spec,
+			email:   old.email && !new.email,
+			slack:   old.slack && !new.slack,
+			webhook: old.webhook && !new.webhook,
 		}
 		if *removed == empty {
 			removed = nil
 		}
 		added = &recipient{
-			spec:  spec,
-			email: new.email && !old.email,
-			slack: new.slack && !old.slack,
+			spec:    spec,
+			email:   new.email && !old.email,
+			slack:   new.slack && !old.slack,
+			webhook: new.webhook && !old.webhook,
 		}
 		if *added == empty {
 			added = nil
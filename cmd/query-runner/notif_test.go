@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestGetNotificationRecipients(t *testing.T) {
@@ -32,6 +33,23 @@ func TestGetNotificationRecipients(t *testing.T) {
 		}
 	})
 
+	t.Run("saved query webhook", func(t *testing.T) {
+		recipients, err := getNotificationRecipients(ctx,
+			api.SavedQueryIDSpec{
+				Subject: api.SettingsSubject{User: &onetwothree},
+			},
+			api.ConfigSavedQuery{
+				Webhook: &schema.WebhookNotificationsConfig{Url: "https://example.com/hook"},
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []*recipient{{spec: recipientSpec{userID: 123}, webhook: true}}; !reflect.DeepEqual(recipients, want) {
+			t.Errorf("got %+v, want %+v", recipients, want)
+		}
+	})
+
 	t.Run("org", func(t *testing.T) {
 		api.MockOrgsListUsers = func(orgID int32) (users []int32, err error) {
 			if want := int32(123); orgID != want {
@@ -128,6 +146,12 @@ func TestDiffNotificationRecipients(t *testing.T) {
 			wantRemoved: recipients{{spec: recipientSpec{userID: 1}, email: true}},
 			wantAdded:   recipients{{spec: recipientSpec{orgID: 2}, slack: true}},
 		},
+		{
+			old:         recipients{{spec: recipientSpec{orgID: 2}, slack: true, webhook: true}},
+			new:         recipients{{spec: recipientSpec{orgID: 2}, slack: true}},
+			wantRemoved: recipients{{spec: recipientSpec{orgID: 2}, webhook: true}},
+			wantAdded:   nil,
+		},
 	}
 	for i, test := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log15 "gopkg.in/inconshreveable/log15.v2"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Notifications about new search results are not sent directly. They are
+// persisted in an outbox in the frontend's database (see
+// api.SavedQueryNotification), and delivered from there by
+// deliverNotifications. Failed deliveries are retried with backoff (and
+// eventually dead-lettered), and notifications are not lost if query-runner
+// restarts while sending them.
+
+// The payloads of the notifications of each channel.
+type (
+	emailNotificationPayload struct {
+		Template txtypes.Templates
+		Data     interface{}
+	}
+
+	slackNotificationPayload struct {
+		Text string
+	}
+
+	webhookNotificationPayload struct {
+		Payload *webhookPayload
+		Webhook *schema.WebhookNotificationsConfig // the saved query's own webhook, if any
+	}
+
+	actionNotificationPayload struct {
+		Description string
+		Query       string
+		Action      *schema.SavedQueryAction
+		Results     []*api.CodeMonitorResult
+	}
+)
+
+// newNotification returns a notification to the recipient with the given
+// channel-specific payload.
+func (n *notifier) newNotification(channel string, recipient *recipient, payload interface{}) *api.SavedQueryNotification {
+	// The payloads only consist of strings, slices and structs, so encoding
+	// them can't fail.
+	b, _ := json.Marshal(payload)
+	return &api.SavedQueryNotification{
+		Spec:      n.spec,
+		Channel:   channel,
+		Recipient: recipient.subject(),
+		Payload:   b,
+	}
+}
+
+// permanentError is a notification delivery error that won't go away by
+// retrying.
+type permanentError struct{ error }
+
+// notificationDeliveryBatchSize is the maximum number of notifications that
+// are claimed from the outbox at once.
+const notificationDeliveryBatchSize = 10
+
+// deliverNotifications delivers the notifications in the outbox forever.
+func deliverNotifications() {
+	for {
+		ctx := context.Background()
+		notifications, err := api.InternalClient.SavedQueryNotificationsDequeue(ctx, notificationDeliveryBatchSize)
+		if err != nil {
+			log15.Error("Failed to dequeue saved search notifications.", "error", err)
+		}
+
+		for _, n := range notifications {
+			req := api.SavedQueryNotificationsUpdateRequest{ID: n.ID}
+			if err := deliverNotification(ctx, n); err != nil {
+				log15.Warn("Failed to deliver saved search notification.", "id", n.ID, "channel", n.Channel, "recipient", n.Recipient, "attempts", n.Attempts, "error", err)
+				req.Error = err.Error()
+				_, req.Permanent = err.(permanentError)
+			}
+			if err := api.InternalClient.SavedQueryNotificationsUpdate(ctx, req); err != nil {
+				// The notification will be delivered again after its claim expires.
+				log15.Error("Failed to record saved search notification delivery.", "id", n.ID, "error", err)
+			}
+		}
+
+		if len(notifications) < notificationDeliveryBatchSize {
+			time.Sleep(5 * time.Second)
+		}
+	}
+}
+
+func deliverNotification(ctx context.Context, n *api.SavedQueryNotification) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	r := &recipient{}
+	switch {
+	case n.Recipient.User != nil:
+		r.spec.userID = *n.Recipient.User
+	case n.Recipient.Org != nil:
+		r.spec.orgID = *n.Recipient.Org
+	}
+
+	switch n.Channel {
+	case api.SavedQueryNotificationChannelEmail:
+		var payload emailNotificationPayload
+		if err := json.Unmarshal(n.Payload, &payload); err != nil {
+			return permanentError{err}
+		}
+		if r.spec.userID == 0 {
+			return permanentError{fmt.Errorf("unable to send email to %s", r.spec)}
+		}
+		if err := canSendEmail(ctx); err != nil {
+			return err
+		}
+		return sendEmail(ctx, r.spec.userID, "results", payload.Template, payload.Data)
+
+	case api.SavedQueryNotificationChannelSlack:
+		var payload slackNotificationPayload
+		if err := json.Unmarshal(n.Payload, &payload); err != nil {
+			return permanentError{err}
+		}
+		r.slack = true
+		if err := slackNotify(ctx, r, payload.Text); err != nil {
+			return err
+		}
+		logEvent("", "SavedSearchSlackNotificationSent", "results")
+		return nil
+
+	case api.SavedQueryNotificationChannelWebhook:
+		var payload webhookNotificationPayload
+		if err := json.Unmarshal(n.Payload, &payload); err != nil {
+			return permanentError{err}
+		}
+		r.webhook = true
+		if err := webhookNotify(ctx, r, api.ConfigSavedQuery{Webhook: payload.Webhook}, payload.Payload); err != nil {
+			return err
+		}
+		logEvent("", "SavedSearchWebhookNotificationSent", "results")
+		return nil
+
+	case api.SavedQueryNotificationChannelAction:
+		var payload actionNotificationPayload
+		if err := json.Unmarshal(n.Payload, &payload); err != nil {
+			return permanentError{err}
+		}
+		if payload.Action == nil {
+			return permanentError{fmt.Errorf("action notification has no action")}
+		}
+		return api.InternalClient.CodeMonitorsRunAction(ctx, &api.CodeMonitorRunActionRequest{
+			Spec:        n.Spec,
+			Description: payload.Description,
+			Query:       payload.Query,
+			Action:      *payload.Action,
+			Results:     payload.Results,
+		})
+
+	default:
+		return permanentError{fmt.Errorf("unknown notification channel %q", n.Channel)}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// minRunInterval is the minimum interval between runs of a saved query.
+const minRunInterval = 10 * time.Second
+
+var scheduleDescriptors = map[string]time.Duration{
+	"@hourly": time.Hour,
+	"@daily":  24 * time.Hour,
+	"@weekly": 7 * 24 * time.Hour,
+}
+
+// runInterval returns how long to wait after a run of the saved query before
+// running it again.
+//
+// Explicit schedules take precedence. Otherwise, we assume a run interval of
+// 30x that which it takes to execute the query (or forceRunInterval, if set).
+// For example, a query which takes 2s to execute will run (2s*30) every
+// minute. Digests don't affect the run interval (see sendDigest).
+func runInterval(query api.ConfigSavedQuery, execDuration time.Duration, forceRunInterval *time.Duration) (time.Duration, error) {
+	if query.Schedule != "" {
+		interval, ok := scheduleDescriptors[query.Schedule]
+		if !ok {
+			var err error
+			interval, err = time.ParseDuration(query.Schedule)
+			if err != nil {
+				return 0, fmt.Errorf("invalid schedule %q: %s", query.Schedule, err)
+			}
+		}
+		if interval < minRunInterval {
+			interval = minRunInterval
+		}
+		return interval, nil
+	}
+
+	if forceRunInterval != nil {
+		return *forceRunInterval, nil
+	}
+
+	// In case queries run very quickly (e.g. our after: queries with no
+	// results often return in ~15ms), we impose a minimum run interval.
+	interval := execDuration * 30
+	if interval < minRunInterval {
+		interval = minRunInterval
+	}
+	return interval, nil
+}
+
+// inQuietHours reports whether t is within the quiet hours.
+func inQuietHours(quietHours *schema.SavedQueryQuietHours, t time.Time) (bool, error) {
+	if quietHours == nil {
+		return false, nil
+	}
+
+	loc := time.UTC
+	if quietHours.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(quietHours.TimeZone)
+		if err != nil {
+			return false, fmt.Errorf("invalid quiet hours time zone %q: %s", quietHours.TimeZone, err)
+		}
+	}
+	start, err := minuteOfDay(quietHours.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := minuteOfDay(quietHours.End)
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(loc)
+	now := t.Hour()*60 + t.Minute()
+	if start <= end {
+		return start <= now && now < end, nil
+	}
+	// The quiet hours span midnight.
+	return now >= start || now < end, nil
+}
+
+// minuteOfDay parses a time of day in the "HH:MM" format and returns the
+// number of minutes since midnight.
+func minuteOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quiet hours time %q (must be HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
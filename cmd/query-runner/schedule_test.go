@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRunInterval(t *testing.T) {
+	force := 5 * time.Minute
+	tests := map[string]struct {
+		query        api.ConfigSavedQuery
+		execDuration time.Duration
+		force        *time.Duration
+		want         time.Duration
+		wantErr      bool
+	}{
+		"exec duration":     {execDuration: 2 * time.Second, want: time.Minute},
+		"minimum":           {execDuration: 15 * time.Millisecond, want: minRunInterval},
+		"forced":            {execDuration: 2 * time.Second, force: &force, want: force},
+		"schedule":          {query: api.ConfigSavedQuery{Schedule: "1h30m"}, force: &force, want: 90 * time.Minute},
+		"schedule minimum":  {query: api.ConfigSavedQuery{Schedule: "1s"}, want: minRunInterval},
+		"descriptor":        {query: api.ConfigSavedQuery{Schedule: "@daily"}, want: 24 * time.Hour},
+		"invalid schedule":  {query: api.ConfigSavedQuery{Schedule: "often"}, wantErr: true},
+		"digest":            {query: api.ConfigSavedQuery{Schedule: "1h", Digest: "weekly"}, want: time.Hour},
+		"digest and forced": {query: api.ConfigSavedQuery{Digest: "daily"}, force: &force, want: force},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := runInterval(test.query, test.execDuration, test.force)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2019, 5, 1, hour, min, 0, 0, time.UTC) }
+	tests := map[string]struct {
+		quietHours *schema.SavedQueryQuietHours
+		t          time.Time
+		want       bool
+		wantErr    bool
+	}{
+		"none":                  {t: at(3, 0), want: false},
+		"during":                {quietHours: &schema.SavedQueryQuietHours{Start: "12:00", End: "13:30"}, t: at(13, 0), want: true},
+		"at end":                {quietHours: &schema.SavedQueryQuietHours{Start: "12:00", End: "13:30"}, t: at(13, 30), want: false},
+		"before":                {quietHours: &schema.SavedQueryQuietHours{Start: "12:00", End: "13:30"}, t: at(11, 59), want: false},
+		"spanning midnight":     {quietHours: &schema.SavedQueryQuietHours{Start: "22:00", End: "07:00"}, t: at(3, 0), want: true},
+		"not spanning midnight": {quietHours: &schema.SavedQueryQuietHours{Start: "22:00", End: "07:00"}, t: at(12, 0), want: false},
+		"time zone at night":    {quietHours: &schema.SavedQueryQuietHours{Start: "22:00", End: "07:00", TimeZone: "America/Los_Angeles"}, t: at(12, 0), want: true},  // 05:00 PDT
+		"time zone by day":      {quietHours: &schema.SavedQueryQuietHours{Start: "22:00", End: "07:00", TimeZone: "America/Los_Angeles"}, t: at(18, 0), want: false}, // 11:00 PDT
+		"invalid time":          {quietHours: &schema.SavedQueryQuietHours{Start: "25:00", End: "07:00"}, t: at(3, 0), wantErr: true},
+		"invalid time zone":     {quietHours: &schema.SavedQueryQuietHours{Start: "22:00", End: "07:00", TimeZone: "Nowhere/Special"}, t: at(3, 0), wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := inQuietHours(test.quietHours, test.t)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
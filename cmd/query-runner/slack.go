@@ -4,30 +4,49 @@ import (
 	"context"
 	"fmt"
 
-	log15 "gopkg.in/inconshreveable/log15.v2"
-
 	"github.com/sourcegraph/sourcegraph/pkg/api"
 	"github.com/sourcegraph/sourcegraph/pkg/slack"
 )
 
-func (n *notifier) slackNotify(ctx context.Context) {
-	plural := ""
-	if n.results.Data.Search.Results.ApproximateResultCount != "1" {
-		plural = "s"
+// slackNotifications returns the Slack notifications to send to the recipients
+// about the new search results.
+func (n *notifier) slackNotifications() []*api.SavedQueryNotification {
+	var text string
+	if n.delta != nil {
+		text = fmt.Sprintf(`%s found for saved search <%s|"%s">`,
+			n.delta.summary(func(count int) string { return fmt.Sprintf("*%d*", count) }),
+			searchURL(n.newQuery, utmSourceSlack),
+			n.query.Description,
+		)
+		if n.delta.listed() {
+			for _, label := range n.delta.added {
+				text += fmt.Sprintf("\n+ `%s`", label)
+			}
+			for _, label := range n.delta.removed {
+				text += fmt.Sprintf("\n- `%s`", label)
+			}
+		}
+	} else {
+		plural := ""
+		if n.results.Data.Search.Results.ApproximateResultCount != "1" {
+			plural = "s"
+		}
+
+		text = fmt.Sprintf(`*%s* new result%s found for saved search <%s|"%s">`,
+			n.results.Data.Search.Results.ApproximateResultCount,
+			plural,
+			searchURL(n.newQuery, utmSourceSlack),
+			n.query.Description,
+		)
 	}
 
-	text := fmt.Sprintf(`*%s* new result%s found for saved search <%s|"%s">`,
-		n.results.Data.Search.Results.ApproximateResultCount,
-		plural,
-		searchURL(n.newQuery, utmSourceSlack),
-		n.query.Description,
-	)
+	var notifications []*api.SavedQueryNotification
 	for _, recipient := range n.recipients {
-		if err := slackNotify(ctx, recipient, text); err != nil {
-			log15.Error("Failed to post Slack notification message.", "recipient", recipient, "text", text, "error", err)
+		if recipient.slack {
+			notifications = append(notifications, n.newNotification(api.SavedQueryNotificationChannelSlack, recipient, slackNotificationPayload{Text: text}))
 		}
 	}
-	logEvent("", "SavedSearchSlackNotificationSent", "results")
+	return notifications
 }
 
 func slackNotifySubscribed(ctx context.Context, recipient *recipient, query api.SavedQuerySpecAndConfig) error {
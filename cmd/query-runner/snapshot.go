@@ -0,0 +1,273 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// isCommitQuery reports whether the query searches commits or diffs. Those queries can be
+// restricted to new results with the after: operator. The results of other queries (such as
+// content queries) are instead compared against a snapshot of the results of the previous run.
+func isCommitQuery(query string) bool {
+	return strings.Contains(query, "type:diff") || strings.Contains(query, "type:commit")
+}
+
+// resultSnapshot is the set of results of a saved query, as a map from the identity of each
+// result to its human-readable label.
+//
+// The identity of a line match is its repository, revision, path and a fingerprint of the
+// line's contents (not its line number, so that lines moving around in a file aren't reported as
+// new results).
+type resultSnapshot map[string]string
+
+// extractSnapshot returns the snapshot of the given search results. Results of types that can't
+// be identified are ignored.
+func extractSnapshot(results []interface{}) (resultSnapshot, error) {
+	extracted, err := extractResults(results)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(resultSnapshot, len(extracted))
+	for _, r := range extracted {
+		snapshot[r.Key] = r.Label
+	}
+	return snapshot, nil
+}
+
+// extractResults returns the identified search results, keyed like the results in a snapshot.
+// Results of types that can't be identified are ignored. Identical lines in a file are only
+// returned once (as the first of them).
+func extractResults(results []interface{}) ([]*api.CodeMonitorResult, error) {
+	// Round-trip through JSON to get typed results, instead of asserting the types of the
+	// decoded values.
+	b, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	var typed []struct {
+		Typename    string `json:"__typename"`
+		Name        string // Repository
+		Resource    string // FileMatch
+		LineMatches []struct {
+			Preview    string
+			LineNumber int
+		}
+		Commit struct { // CommitSearchResult
+			Repository struct {
+				Name string
+			}
+			OID            string
+			AbbreviatedOID string
+			Message        string
+		}
+	}
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return nil, err
+	}
+
+	var extracted []*api.CodeMonitorResult
+	seen := map[string]bool{}
+	add := func(r *api.CodeMonitorResult) {
+		if !seen[r.Key] {
+			seen[r.Key] = true
+			extracted = append(extracted, r)
+		}
+	}
+	for _, r := range typed {
+		switch r.Typename {
+		case "Repository":
+			add(&api.CodeMonitorResult{Key: "repo:" + r.Name, Label: r.Name, Repo: api.RepoName(r.Name)})
+		case "FileMatch":
+			repo, rev, path, err := parseResource(r.Resource)
+			if err != nil {
+				log15.Warn("Skipping search result with invalid resource", "error", err)
+				continue
+			}
+			if len(r.LineMatches) == 0 {
+				add(&api.CodeMonitorResult{Key: r.Resource, Label: repo + "/" + path, Repo: api.RepoName(repo), Rev: rev, Path: path})
+			}
+			for _, m := range r.LineMatches {
+				preview := strings.TrimSpace(m.Preview)
+				fingerprint := sha256.Sum256([]byte(preview))
+				add(&api.CodeMonitorResult{
+					Key:     r.Resource + "\x00" + hex.EncodeToString(fingerprint[:8]),
+					Label:   fmt.Sprintf("%s/%s:%d: %s", repo, path, m.LineNumber+1, truncate(preview, maxPreviewLength)),
+					Repo:    api.RepoName(repo),
+					Rev:     rev,
+					Path:    path,
+					Line:    m.LineNumber + 1,
+					Preview: m.Preview,
+				})
+			}
+		case "CommitSearchResult":
+			c := r.Commit
+			subject := strings.SplitN(c.Message, "\n", 2)[0]
+			add(&api.CodeMonitorResult{
+				Key:    "commit:" + c.Repository.Name + "@" + c.OID,
+				Label:  fmt.Sprintf("%s@%s: %s", c.Repository.Name, c.AbbreviatedOID, truncate(subject, maxPreviewLength)),
+				Repo:   api.RepoName(c.Repository.Name),
+				Commit: api.CommitID(c.OID),
+			})
+		}
+	}
+	return extracted, nil
+}
+
+// maxPreviewLength is the maximum length of the line preview in the label of a line match.
+const maxPreviewLength = 100
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
+
+// parseResource returns the repository name, revision and file path of a FileMatch resource URI,
+// such as "git://github.com/foo/bar?rev#dir/file.go". The revision is empty if the resource is
+// at the default branch.
+//
+// The URI isn't parsed with url.Parse because only the revision is escaped in it. The path may
+// contain any character, including "%", "?" and "#".
+func parseResource(resource string) (repo, rev, path string, err error) {
+	if !strings.HasPrefix(resource, "git://") {
+		return "", "", "", fmt.Errorf("invalid FileMatch resource %q", resource)
+	}
+	i := strings.Index(resource, "#")
+	if i == -1 {
+		return "", "", "", fmt.Errorf("invalid FileMatch resource %q", resource)
+	}
+	repo, path = resource[len("git://"):i], resource[i+1:]
+	if i := strings.Index(repo, "?"); i != -1 {
+		repo, rev = repo[:i], repo[i+1:]
+		if unescaped, err := url.QueryUnescape(rev); err == nil {
+			rev = unescaped
+		}
+	}
+	return repo, rev, path, nil
+}
+
+// resultDelta is the change in the results of a saved query between two runs.
+type resultDelta struct {
+	added, removed []string // labels of the added and removed results, sorted
+
+	addedIDs map[string]bool // identities of the added results
+}
+
+func (d *resultDelta) empty() bool { return len(d.added) == 0 && len(d.removed) == 0 }
+
+// maxListedResults is the maximum number of added and removed results that are listed in a
+// notification. Notifications about larger deltas only contain a summary.
+const maxListedResults = 10
+
+// listed reports whether the notification about the delta lists the added and removed results.
+func (d *resultDelta) listed() bool { return len(d.added)+len(d.removed) <= maxListedResults }
+
+// summary describes the delta, for example "3 new results and 1 removed result". The counts are
+// formatted with count.
+func (d *resultDelta) summary(count func(int) string) string {
+	describe := func(n int, adjective string) string {
+		plural := "s"
+		if n == 1 {
+			plural = ""
+		}
+		return fmt.Sprintf("%s %s result%s", count(n), adjective, plural)
+	}
+	var parts []string
+	if len(d.added) > 0 {
+		parts = append(parts, describe(len(d.added), "new"))
+	}
+	if len(d.removed) > 0 {
+		parts = append(parts, describe(len(d.removed), "removed"))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// incompleteResults describes which results may be missing from the current results of a saved
+// query even though they still exist.
+type incompleteResults struct {
+	// all is whether any result may be missing (because the search hit a limit or some
+	// repositories were cloning or timed out).
+	all bool
+
+	// files is the set of resources of the file matches whose line matches were truncated
+	// (because the search hit the limit of matches per file).
+	files map[string]bool
+}
+
+// contains reports whether the result with the given identity may be missing.
+func (i incompleteResults) contains(id string) bool {
+	if i.all {
+		return true
+	}
+	resource := strings.SplitN(id, "\x00", 2)[0] // see extractResults
+	return i.files[resource]
+}
+
+// truncatedFiles returns the resources of the file matches in the search results whose line
+// matches were truncated.
+func truncatedFiles(results []interface{}) (map[string]bool, error) {
+	b, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	var typed []struct {
+		Typename string `json:"__typename"`
+		Resource string // FileMatch
+		LimitHit bool   // FileMatch
+	}
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return nil, err
+	}
+
+	files := map[string]bool{}
+	for _, r := range typed {
+		if r.Typename == "FileMatch" && r.LimitHit {
+			files[r.Resource] = true
+		}
+	}
+	return files, nil
+}
+
+// diffSnapshots returns the delta between the previous and the current snapshot of the results
+// of a saved query, and the snapshot to compare the next run against.
+//
+// Results missing from the current snapshot that may still exist (see incompleteResults) aren't
+// reported as removed and are kept in the next snapshot. If any result may be missing, results
+// that are new in the current snapshot aren't reported as added either (but are kept in the next
+// snapshot), because a search that hits a limit may return a different subset of the results on
+// each run.
+func diffSnapshots(prev, cur resultSnapshot, incomplete incompleteResults) (delta resultDelta, next resultSnapshot) {
+	next = make(resultSnapshot, len(cur))
+	for id, label := range cur {
+		next[id] = label
+		if _, ok := prev[id]; !ok && !incomplete.all {
+			delta.added = append(delta.added, label)
+			if delta.addedIDs == nil {
+				delta.addedIDs = map[string]bool{}
+			}
+			delta.addedIDs[id] = true
+		}
+	}
+	for id, label := range prev {
+		if _, ok := cur[id]; ok {
+			continue
+		}
+		if incomplete.contains(id) {
+			next[id] = label
+		} else {
+			delta.removed = append(delta.removed, label)
+		}
+	}
+	sort.Strings(delta.added)
+	sort.Strings(delta.removed)
+	return delta, next
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestExtractSnapshot(t *testing.T) {
+	results := []interface{}{
+		map[string]interface{}{
+			"__typename": "FileMatch",
+			"resource":   "git://github.com/foo/bar#config/aws.go",
+			"lineMatches": []interface{}{
+				map[string]interface{}{"preview": "\tkey := \"AKIA123\"", "lineNumber": 9},
+				map[string]interface{}{"preview": "key := \"AKIA123\" ", "lineNumber": 20}, // same fingerprint
+			},
+		},
+		map[string]interface{}{
+			"__typename":  "FileMatch",
+			"resource":    "git://github.com/foo/bar?v1#README.md",
+			"lineMatches": []interface{}{},
+		},
+		map[string]interface{}{"__typename": "Repository", "name": "github.com/foo/baz"},
+		map[string]interface{}{
+			"__typename": "CommitSearchResult",
+			"commit": map[string]interface{}{
+				"repository":     map[string]interface{}{"name": "github.com/foo/bar"},
+				"oid":            "abc123def456",
+				"abbreviatedOID": "abc123d",
+				"message":        "Fix bug\n\nDetails",
+			},
+		},
+		map[string]interface{}{"__typename": "Unknown"},
+	}
+	snapshot, err := extractSnapshot(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var labels []string
+	for _, label := range snapshot {
+		labels = append(labels, label)
+	}
+	if len(labels) != 4 {
+		t.Fatalf("got %d results %q, want 4", len(labels), labels)
+	}
+	for _, want := range []string{
+		"github.com/foo/bar/config/aws.go:10: key := \"AKIA123\"",
+		"github.com/foo/bar/README.md",
+		"github.com/foo/baz",
+		"github.com/foo/bar@abc123d: Fix bug",
+	} {
+		found := false
+		for _, label := range labels {
+			found = found || label == want
+		}
+		if !found {
+			t.Errorf("missing result %q in %q", want, labels)
+		}
+	}
+}
+
+func TestExtractResults(t *testing.T) {
+	results, err := extractResults([]interface{}{
+		map[string]interface{}{
+			"__typename": "FileMatch",
+			"resource":   "git://github.com/foo/bar?v1#config/aws.go",
+			"lineMatches": []interface{}{
+				map[string]interface{}{"preview": "\tkey := \"AKIA123\"", "lineNumber": 9},
+				map[string]interface{}{"preview": "key := \"AKIA123\"", "lineNumber": 20}, // same fingerprint
+			},
+		},
+		map[string]interface{}{
+			"__typename": "CommitSearchResult",
+			"commit": map[string]interface{}{
+				"repository":     map[string]interface{}{"name": "github.com/foo/bar"},
+				"oid":            "abc123def456",
+				"abbreviatedOID": "abc123d",
+				"message":        "Fix bug",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if r := results[0]; r.Repo != "github.com/foo/bar" || r.Rev != "v1" || r.Path != "config/aws.go" || r.Line != 10 || r.Preview != "\tkey := \"AKIA123\"" {
+		t.Errorf("got line match %+v, want the first matching line at v1", r)
+	}
+	if r := results[1]; r.Key != "commit:github.com/foo/bar@abc123def456" || r.Commit != "abc123def456" {
+		t.Errorf("got commit %+v, want abc123def456", r)
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		resource        string
+		repo, rev, path string
+	}{
+		{resource: "git://github.com/foo/bar#dir/file.go", repo: "github.com/foo/bar", path: "dir/file.go"},
+		{resource: "git://github.com/foo/bar?release%2F1.0#dir/file.go", repo: "github.com/foo/bar", rev: "release/1.0", path: "dir/file.go"},
+		{resource: "git://github.com/foo/bar?v1#dir/100%.txt", repo: "github.com/foo/bar", rev: "v1", path: "dir/100%.txt"},
+		{resource: "git://github.com/foo/bar#a?b#c.go", repo: "github.com/foo/bar", path: "a?b#c.go"},
+	}
+	for _, test := range tests {
+		repo, rev, path, err := parseResource(test.resource)
+		if err != nil {
+			t.Errorf("%s: %s", test.resource, err)
+			continue
+		}
+		if repo != test.repo || rev != test.rev || path != test.path {
+			t.Errorf("%s: got (%q, %q, %q), want (%q, %q, %q)", test.resource, repo, rev, path, test.repo, test.rev, test.path)
+		}
+	}
+
+	if _, _, _, err := parseResource("github.com/foo/bar"); err == nil {
+		t.Error("got nil error for invalid resource")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	prev := resultSnapshot{"a": "A", "b": "B"}
+	cur := resultSnapshot{"b": "B", "c": "C"}
+
+	t.Run("complete", func(t *testing.T) {
+		delta, next := diffSnapshots(prev, cur, incompleteResults{})
+		if want := []string{"C"}; !reflect.DeepEqual(delta.added, want) {
+			t.Errorf("got added %q, want %q", delta.added, want)
+		}
+		if want := map[string]bool{"c": true}; !reflect.DeepEqual(delta.addedIDs, want) {
+			t.Errorf("got added IDs %v, want %v", delta.addedIDs, want)
+		}
+		if want := []string{"A"}; !reflect.DeepEqual(delta.removed, want) {
+			t.Errorf("got removed %q, want %q", delta.removed, want)
+		}
+		if !reflect.DeepEqual(next, cur) {
+			t.Errorf("got next snapshot %v, want %v", next, cur)
+		}
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		delta, next := diffSnapshots(prev, cur, incompleteResults{all: true})
+		if len(delta.added) != 0 || len(delta.addedIDs) != 0 {
+			t.Errorf("got added %q, want none", delta.added)
+		}
+		if len(delta.removed) != 0 {
+			t.Errorf("got removed %q, want none", delta.removed)
+		}
+		if want := (resultSnapshot{"a": "A", "b": "B", "c": "C"}); !reflect.DeepEqual(next, want) {
+			t.Errorf("got next snapshot %v, want %v", next, want)
+		}
+	})
+}
+
+func TestDiffSnapshots_truncatedFiles(t *testing.T) {
+	results := []interface{}{
+		map[string]interface{}{
+			"__typename":  "FileMatch",
+			"resource":    "git://github.com/foo/bar#a.go",
+			"limitHit":    true,
+			"lineMatches": []interface{}{map[string]interface{}{"preview": "x", "lineNumber": 0}},
+		},
+		map[string]interface{}{
+			"__typename":  "FileMatch",
+			"resource":    "git://github.com/foo/bar#b.go",
+			"lineMatches": []interface{}{map[string]interface{}{"preview": "x", "lineNumber": 0}},
+		},
+	}
+	truncated, err := truncatedFiles(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"git://github.com/foo/bar#a.go": true}; !reflect.DeepEqual(truncated, want) {
+		t.Fatalf("got truncated files %v, want %v", truncated, want)
+	}
+
+	// Line matches missing from the truncated file may still exist, so they aren't removed.
+	cur, err := extractSnapshot(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := resultSnapshot{}
+	for id, label := range cur {
+		prev[id] = label
+	}
+	prev["git://github.com/foo/bar#a.go\x00y"] = "a.go: y"
+	prev["git://github.com/foo/bar#b.go\x00y"] = "b.go: y"
+	delta, next := diffSnapshots(prev, cur, incompleteResults{files: truncated})
+	if len(delta.added) != 0 {
+		t.Errorf("got added %q, want none", delta.added)
+	}
+	if want := []string{"b.go: y"}; !reflect.DeepEqual(delta.removed, want) {
+		t.Errorf("got removed %q, want %q", delta.removed, want)
+	}
+	if _, ok := next["git://github.com/foo/bar#a.go\x00y"]; !ok {
+		t.Error("next snapshot is missing the line match of the truncated file")
+	}
+}
+
+func TestResultDelta_summary(t *testing.T) {
+	tests := []struct {
+		delta resultDelta
+		want  string
+	}{
+		{resultDelta{added: []string{"a"}}, "1 new result"},
+		{resultDelta{removed: []string{"a", "b"}}, "2 removed results"},
+		{resultDelta{added: []string{"a", "b"}, removed: []string{"c"}}, "2 new results and 1 removed result"},
+	}
+	for _, test := range tests {
+		if got := test.delta.summary(strconv.Itoa); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/redispool"
+)
+
+// The state of each saved query (its info stored by the frontend, the snapshot
+// of its results and its pending digest) is keyed by saved query and query, so
+// that saved queries with the same query don't share state, and the state is
+// reset when the query of a saved query changes.
+
+var (
+	// snapshots stores the snapshots of the results of saved queries.
+	snapshots = &redisStateStore{pool: redispool.Store, key: "query-runner:snapshots"}
+
+	// digests stores the pending digests of saved queries.
+	digests = &redisStateStore{pool: redispool.Store, key: "query-runner:digests"}
+)
+
+// redisStateStore stores a JSON-encoded value for each saved query in a single
+// redis hash.
+type redisStateStore struct {
+	pool *redis.Pool
+	key  string
+}
+
+func stateField(spec api.SavedQueryIDSpec, query string) string {
+	return savedQueryIDSpecKey(spec) + "\x00" + query
+}
+
+// load decodes the value of the saved query into v, or returns false if there
+// is none.
+func (s *redisStateStore) load(spec api.SavedQueryIDSpec, query string, v interface{}) (bool, error) {
+	c := s.pool.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("HGET", s.key, stateField(spec, query)))
+	if err == redis.ErrNil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *redisStateStore) save(spec api.SavedQueryIDSpec, query string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c := s.pool.Get()
+	defer c.Close()
+	_, err = c.Do("HSET", s.key, stateField(spec, query), b)
+	return err
+}
+
+func (s *redisStateStore) remove(spec api.SavedQueryIDSpec, query string) error {
+	c := s.pool.Get()
+	defer c.Close()
+	_, err := c.Do("HDEL", s.key, stateField(spec, query))
+	return err
+}
+
+// deleteSavedQueryState deletes the state of the saved query with the given
+// query, after the saved query was deleted or its query changed.
+func deleteSavedQueryState(ctx context.Context, spec api.SavedQueryIDSpec, query string) error {
+	if err := api.InternalClient.SavedQueriesDeleteInfo(ctx, spec, query); err != nil {
+		return err
+	}
+	if err := snapshots.remove(spec, query); err != nil {
+		return err
+	}
+	return digests.remove(spec, query)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"syscall"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+const utmSourceWebhook = "saved-search-webhook"
+
+// webhookPayload is the JSON payload POSTed to webhooks.
+type webhookPayload struct {
+	Event              string     `json:"event"` // "results" or "test"
+	SavedSearchID      graphql.ID `json:"savedSearchID"`
+	Description        string     `json:"description"`
+	Query              string     `json:"query"`
+	NewResultCount     int        `json:"newResultCount"`
+	RemovedResultCount int        `json:"removedResultCount,omitempty"`
+	Sample             []string   `json:"sample,omitempty"` // labels of some of the new results
+	URL                string     `json:"url"`
+}
+
+func newWebhookPayload(event string, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, url string) *webhookPayload {
+	return &webhookPayload{
+		Event:         event,
+		SavedSearchID: relay.MarshalID("SavedQuery", spec), // same as the GraphQL API's SavedQuery.id
+		Description:   query.Description,
+		Query:         query.Query,
+		URL:           url,
+	}
+}
+
+// webhookNotifications returns the webhook notifications to send to the
+// recipients about the new search results.
+func (n *notifier) webhookNotifications() []*api.SavedQueryNotification {
+	payload := newWebhookPayload("results", n.spec, n.query, searchURL(n.newQuery, utmSourceWebhook))
+	if n.delta != nil {
+		payload.NewResultCount = len(n.delta.added)
+		payload.RemovedResultCount = len(n.delta.removed)
+		payload.Sample = n.delta.added
+	} else {
+		payload.NewResultCount = len(n.results.Data.Search.Results.Results)
+		snapshot, err := extractSnapshot(n.results.Data.Search.Results.Results)
+		if err != nil {
+			log15.Warn("Failed to extract sample of search results for webhook notification.", "error", err)
+		}
+		for _, label := range snapshot {
+			payload.Sample = append(payload.Sample, label)
+		}
+		sort.Strings(payload.Sample)
+	}
+	if len(payload.Sample) > maxListedResults {
+		payload.Sample = payload.Sample[:maxListedResults]
+	}
+
+	var notifications []*api.SavedQueryNotification
+	for _, recipient := range n.recipients {
+		if recipient.webhook {
+			notifications = append(notifications, n.newNotification(api.SavedQueryNotificationChannelWebhook, recipient, webhookNotificationPayload{
+				Payload: payload,
+				Webhook: n.query.Webhook,
+			}))
+		}
+	}
+	return notifications
+}
+
+// webhookNotify POSTs the payload to the saved query's webhook, or else to the
+// webhook in the recipient's settings, signed with the recipient's webhook
+// secret (if any). The recipient is always the saved query's owner.
+func webhookNotify(ctx context.Context, recipient *recipient, query api.ConfigSavedQuery, payload *webhookPayload) error {
+	if !recipient.webhook {
+		return nil
+	}
+
+	config := query.Webhook
+	if config == nil {
+		settings, _, err := api.InternalClient.SettingsGetForSubject(ctx, recipient.subject())
+		if err != nil {
+			return err
+		}
+		config = settings.NotificationsWebhook
+	}
+	if config == nil || config.Url == "" {
+		return fmt.Errorf("unable to send webhook notification because recipient (%s) has no webhook URL configured", recipient.spec)
+	}
+
+	secret, err := api.InternalClient.WebhookSecretsGet(ctx, recipient.subject())
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, config, secret, body)
+}
+
+// postWebhook POSTs the body to the webhook, signed with the secret (if any).
+// Errors that won't go away by retrying are permanentErrors.
+func postWebhook(ctx context.Context, config *schema.WebhookNotificationsConfig, secret string, body []byte) error {
+	req, err := http.NewRequest("POST", config.Url, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set("X-Sourcegraph-Signature", "sha256="+webhookSignature(secret, body))
+	}
+
+	resp, err := ctxhttp.Do(ctx, webhookClient, req)
+	if err != nil {
+		if uerr, ok := err.(*url.Error); ok {
+			if oerr, ok := uerr.Err.(*net.OpError); ok {
+				if _, ok := oerr.Err.(disallowedAddrError); ok {
+					return permanentError{err}
+				}
+			}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("webhook responded with HTTP status %d", resp.StatusCode)
+		if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			// Client errors other than rate limiting won't go away by retrying.
+			return permanentError{err}
+		}
+		return err
+	}
+	return nil
+}
+
+// webhookSignature returns the hex-encoded HMAC-SHA256 of the body keyed by the secret.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookClient is the HTTP client used to POST to webhooks. Webhook URLs are
+// set by users, so it only connects to public addresses: otherwise anyone could
+// make query-runner send requests to services inside the cluster or to cloud
+// metadata endpoints. The address is checked when connecting (after DNS
+// resolution), so this also applies to redirects and to hostnames that resolve
+// to internal addresses. It doesn't use a proxy, which would hide the address.
+var webhookClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   checkWebhookAddr,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+	Timeout: time.Minute,
+}
+
+// disallowedAddrError is returned when a webhook resolves to an address that
+// is not publicly routable.
+type disallowedAddrError struct{ addr string }
+
+func (e disallowedAddrError) Error() string {
+	return fmt.Sprintf("webhooks may not be sent to non-public address %s", e.addr)
+}
+
+// nonPublicNets are the IP ranges that webhooks may not be sent to.
+var nonPublicNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",      // "this" network
+		"10.0.0.0/8",     // private
+		"100.64.0.0/10",  // carrier-grade NAT
+		"127.0.0.0/8",    // loopback
+		"169.254.0.0/16", // link-local (incl. cloud metadata endpoints)
+		"172.16.0.0/12",  // private
+		"192.0.0.0/24",   // IETF protocol assignments
+		"192.168.0.0/16", // private
+		"198.18.0.0/15",  // benchmarking
+		"224.0.0.0/4",    // multicast
+		"240.0.0.0/4",    // reserved (incl. broadcast)
+		"::/128",         // unspecified
+		"::1/128",        // loopback
+		"64:ff9b::/96",   // IPv4/IPv6 translation
+		"fc00::/7",       // unique local
+		"fe80::/10",      // link-local
+		"ff00::/8",       // multicast
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// isPublicIP reports whether ip is a publicly routable address.
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4 // also handles IPv4-mapped IPv6 addresses
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWebhookAddr is the net.Dialer Control func of webhookClient. It
+// rejects connections to addresses that are not publicly routable.
+func checkWebhookAddr(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return disallowedAddrError{addr: host}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	var requests int
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.Header.Get("X-Sourcegraph-Signature"), "sha256="+webhookSignature("s3cr3t", body); got != want {
+			t.Errorf("got signature %q, want %q", got, want)
+		}
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	// The test server listens on a loopback address.
+	defer func(c *http.Client) { webhookClient = c }(webhookClient)
+	webhookClient = http.DefaultClient
+
+	api.MockWebhookSecretsGet = func(subject api.SettingsSubject) (string, error) {
+		if subject.User == nil || *subject.User != 1 {
+			t.Errorf("got webhook secret of %+v, want the recipient's", subject)
+		}
+		return "s3cr3t", nil
+	}
+	defer func() { api.MockWebhookSecretsGet = nil }()
+
+	ctx := context.Background()
+	query := api.ConfigSavedQuery{
+		Query:   "AKIA",
+		Webhook: &schema.WebhookNotificationsConfig{Url: ts.URL},
+	}
+	payload := &webhookPayload{Event: "test", Query: query.Query}
+	owner := recipientSpec{userID: 1}
+
+	tests := map[string]struct {
+		recipient     *recipient
+		status        int
+		wantRequests  int
+		wantErr       bool
+		wantPermanent bool
+	}{
+		"ok":             {recipient: &recipient{spec: owner, webhook: true}, status: http.StatusOK, wantRequests: 1},
+		"server error":   {recipient: &recipient{spec: owner, webhook: true}, status: http.StatusServiceUnavailable, wantRequests: 1, wantErr: true},
+		"rate limited":   {recipient: &recipient{spec: owner, webhook: true}, status: http.StatusTooManyRequests, wantRequests: 1, wantErr: true},
+		"client error":   {recipient: &recipient{spec: owner, webhook: true}, status: http.StatusBadRequest, wantRequests: 1, wantErr: true, wantPermanent: true},
+		"not subscribed": {recipient: &recipient{spec: owner, email: true}, status: http.StatusOK},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests = 0
+			status = test.status
+			err := webhookNotify(ctx, test.recipient, query, payload)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, test.wantErr)
+			}
+			if _, permanent := err.(permanentError); permanent != test.wantPermanent {
+				t.Errorf("got permanent %v, want %v", permanent, test.wantPermanent)
+			}
+			if requests != test.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, test.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWebhookNotify_nonPublicAddress(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	api.MockWebhookSecretsGet = func(api.SettingsSubject) (string, error) { return "", nil }
+	defer func() { api.MockWebhookSecretsGet = nil }()
+
+	query := api.ConfigSavedQuery{Webhook: &schema.WebhookNotificationsConfig{Url: ts.URL}}
+	err := webhookNotify(context.Background(), &recipient{spec: recipientSpec{userID: 1}, webhook: true}, query, &webhookPayload{Event: "test"})
+	if _, permanent := err.(permanentError); !permanent {
+		t.Errorf("got error %v, want permanent error", err)
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests, want none", requests)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":              true,
+		"140.82.118.3":         true,
+		"2606:4700::6810:84e5": true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"172.32.0.1":           true,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.100.100.200":      false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"::":                   false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:10.0.0.1":      false,
+		"fd00:ec2::254":        false,
+		"fe80::1":              false,
+	}
+	for addr, want := range tests {
+		if got := isPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("%s: got public %v, want %v", addr, got, want)
+		}
+	}
+}
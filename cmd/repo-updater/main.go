@@ -107,6 +107,22 @@ func main() {
 	go repos.SyncBitbucketServerConnections(ctx)
 	go repos.RunBitbucketServerRepositorySyncWorker(ctx)
 
+	// Bitbucket Cloud connections and repos syncing threads
+	go repos.SyncBitbucketCloudConnections(ctx)
+	go repos.RunBitbucketCloudRepositorySyncWorker(ctx)
+
+	// Gerrit connections and repos syncing threads
+	go repos.SyncGerritConnections(ctx)
+	go repos.RunGerritRepositorySyncWorker(ctx)
+
+	// Gitea connections and repos syncing threads
+	go repos.SyncGiteaConnections(ctx)
+	go repos.RunGiteaRepositorySyncWorker(ctx)
+
+	// Azure DevOps connections and repos syncing threads
+	go repos.SyncAzureDevOpsConnections(ctx)
+	go repos.RunAzureDevOpsRepositorySyncWorker(ctx)
+
 	// Start other repos syncer syncing thread
 	go func() { log.Fatal(syncer.Run(ctx, repos.GetUpdateInterval())) }()
 
@@ -0,0 +1,316 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/atomicvalue"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var azureDevOpsConnections = func() *atomicvalue.Value {
+	c := atomicvalue.New()
+	c.Set(func() interface{} {
+		return []*azureDevOpsConnection{}
+	})
+	return c
+}()
+
+// SyncAzureDevOpsConnections periodically syncs connections from the Frontend API.
+func SyncAzureDevOpsConnections(ctx context.Context) {
+	t := time.NewTicker(configWatchInterval)
+	var lastConfig []*schema.AzureDevOpsConnection
+	for range t.C {
+		config, err := conf.AzureDevOpsConfigs(ctx)
+		if err != nil {
+			log15.Error("unable to fetch Azure DevOps configs", "err", err)
+			continue
+		}
+
+		if reflect.DeepEqual(config, lastConfig) {
+			continue
+		}
+		lastConfig = config
+
+		var conns []*azureDevOpsConnection
+		for _, c := range config {
+			conn, err := newAzureDevOpsConnection(c)
+			if err != nil {
+				log15.Error("Error processing configured Azure DevOps connection. Skipping it.", "url", c.Url, "error", err)
+				continue
+			}
+			conns = append(conns, conn)
+		}
+
+		azureDevOpsConnections.Set(func() interface{} {
+			return conns
+		})
+
+		azureDevOpsWorker.restart()
+	}
+}
+
+// getAzureDevOpsConnection returns the Azure DevOps connection (config + API client) that is
+// responsible for the repository specified by the args.
+func getAzureDevOpsConnection(args protocol.RepoLookupArgs) (*azureDevOpsConnection, error) {
+	conns := azureDevOpsConnections.Get().([]*azureDevOpsConnection)
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == azuredevops.ServiceType {
+		// Look up by external repository spec.
+		for _, conn := range conns {
+			if args.ExternalRepo.ServiceID == conn.baseURL.String() {
+				return conn, nil
+			}
+		}
+		return nil, errors.Errorf("no configured Azure DevOps connection with URL: %q", args.ExternalRepo.ServiceID)
+	}
+
+	if args.Repo != "" {
+		// Look up by repository name.
+		repo := strings.ToLower(string(args.Repo))
+		for _, conn := range conns {
+			if strings.HasPrefix(repo, conn.baseURL.Hostname()+"/") {
+				return conn, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func azureDevOpsRepoInfo(conn *azureDevOpsConnection, repo *azuredevops.Repo) *protocol.RepoInfo {
+	// Clone URL. The remote URL includes the organization as the username, and Azure DevOps
+	// accepts the personal access token as the password with any username.
+	var cloneURL string
+	if conn.config.GitURLType == "ssh" && repo.SSHURL != "" {
+		cloneURL = repo.SSHURL
+	} else {
+		cloneURL = setUserinfoBestEffort(repo.RemoteURL, repo.Org, conn.config.Token)
+	}
+
+	// Repo Links. Azure DevOps identifies commits and branches differently in file URLs, and the
+	// revision of file links is usually a commit ID, so {rev} is used as a commit.
+	var links *protocol.RepoLinks
+	if root := repo.WebURL; root != "" {
+		links = &protocol.RepoLinks{
+			Root:   root,
+			Tree:   root + "?path=/{path}&version=GC{rev}",
+			Blob:   root + "?path=/{path}&version=GC{rev}",
+			Commit: root + "/commit/{commit}",
+		}
+	}
+
+	return &protocol.RepoInfo{
+		Name: reposource.AzureDevOpsRepoName(conn.config.RepositoryPathPattern, conn.baseURL.Hostname(), repo.Org, repo.Project.Name, repo.Name),
+		ExternalRepo: &api.ExternalRepoSpec{
+			ID:          repo.ID,
+			ServiceType: azuredevops.ServiceType,
+			ServiceID:   conn.baseURL.String(),
+		},
+		Fork: repo.IsFork,
+		VCS: protocol.VCSInfo{
+			URL: cloneURL,
+		},
+		Links: links,
+	}
+}
+
+// GetAzureDevOpsRepository queries a configured Azure DevOps connection for information about the
+// specified repository.
+//
+// If args.Repo refers to a repository that is not known to be on a configured Azure DevOps
+// connection's host, it returns authoritative == false.
+func GetAzureDevOpsRepository(ctx context.Context, args protocol.RepoLookupArgs) (repo *protocol.RepoInfo, authoritative bool, err error) {
+	conn, err := getAzureDevOpsConnection(args)
+	if err != nil {
+		return nil, true, err // refers to an Azure DevOps repo but the host is not configured
+	}
+	if conn == nil {
+		return nil, false, nil // refers to a non-Azure DevOps repo
+	}
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == azuredevops.ServiceType {
+		// Look up by external repository spec, which is the ID of the repository. The ID doesn't
+		// identify the organization, so look for it in each configured organization.
+		for _, org := range conn.orgs() {
+			r, err := conn.client.Repo(ctx, org, "", args.ExternalRepo.ID)
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, true, err
+			}
+			return azureDevOpsRepoInfo(conn, r), true, nil
+		}
+		return nil, true, errors.Errorf("Azure DevOps repository with ID %q not found in configured organizations", args.ExternalRepo.ID)
+	}
+
+	if args.Repo != "" {
+		// Look up by repository name. Expect the repository name to be {host}/{org}/{project}/{repo}
+		// (the default repositoryPathPattern).
+		parts := strings.Split(string(args.Repo)[len(conn.baseURL.Hostname()+"/"):], "/")
+		if len(parts) != 3 {
+			return nil, true, errors.Errorf("malformed Azure DevOps repo name: %q", args.Repo)
+		}
+		r, err := conn.client.Repo(ctx, parts[0], parts[1], parts[2])
+		if err != nil {
+			return nil, true, err
+		}
+		return azureDevOpsRepoInfo(conn, r), true, nil
+	}
+
+	return nil, true, fmt.Errorf("unable to look up Azure DevOps repository (%+v)", args)
+}
+
+var azureDevOpsWorker = &worker{
+	work: func(ctx context.Context, shutdown chan struct{}) {
+		for _, c := range azureDevOpsConnections.Get().([]*azureDevOpsConnection) {
+			go func(c *azureDevOpsConnection) {
+				for {
+					updateAzureDevOpsRepos(ctx, c)
+					azureDevOpsUpdateTime.WithLabelValues(c.baseURL.String()).Set(float64(time.Now().Unix()))
+					select {
+					case <-shutdown:
+						return
+					case <-time.After(GetUpdateInterval()):
+					}
+				}
+			}(c)
+		}
+	},
+}
+
+// RunAzureDevOpsRepositorySyncWorker runs the worker that syncs repositories from configured Azure
+// DevOps connections to Sourcegraph.
+func RunAzureDevOpsRepositorySyncWorker(ctx context.Context) {
+	azureDevOpsWorker.start(ctx)
+}
+
+// updateAzureDevOpsRepos ensures that all provided repositories exist in the repository table.
+func updateAzureDevOpsRepos(ctx context.Context, conn *azureDevOpsConnection) {
+	repoChan := make(chan repoCreateOrUpdateRequest)
+	defer close(repoChan)
+	// The same token may be used by several connections (for different organizations or
+	// projects), so they are part of the source.
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("azuredevops:%s:%s:%s", conn.config.Token, strings.Join(conn.config.Orgs, ","), strings.Join(conn.config.Projects, ",")), nil, repoChan)
+	for r := range conn.listAllRepos(ctx) {
+		if r.DefaultBranch == "" {
+			continue // the repository has no commits, so there is nothing to clone
+		}
+
+		ri := azureDevOpsRepoInfo(conn, r)
+		repoChan <- repoCreateOrUpdateRequest{
+			RepoCreateOrUpdateRequest: api.RepoCreateOrUpdateRequest{
+				RepoName:     ri.Name,
+				ExternalRepo: ri.ExternalRepo,
+				Fork:         ri.Fork,
+				Enabled:      conn.config.InitialRepositoryEnablement,
+			},
+			URL: ri.VCS.URL,
+		}
+	}
+}
+
+// azureDevOpsBaseURL returns the URL of Azure DevOps from the config, defaulting to
+// https://dev.azure.com.
+func azureDevOpsBaseURL(config *schema.AzureDevOpsConnection) string {
+	if config.Url == "" {
+		return "https://dev.azure.com"
+	}
+	return config.Url
+}
+
+func newAzureDevOpsConnection(config *schema.AzureDevOpsConnection) (*azureDevOpsConnection, error) {
+	baseURL, err := url.Parse(azureDevOpsBaseURL(config))
+	if err != nil {
+		return nil, err
+	}
+	baseURL = NormalizeBaseURL(baseURL)
+
+	return &azureDevOpsConnection{
+		config:  config,
+		baseURL: baseURL,
+		client: &azuredevops.Client{
+			URL:   baseURL,
+			Token: config.Token,
+			HTTPClient: &http.Client{
+				Transport: azuredevops.WithRequestCounter(http.DefaultTransport),
+			},
+		},
+	}, nil
+}
+
+type azureDevOpsConnection struct {
+	config  *schema.AzureDevOpsConnection
+	baseURL *url.URL // the normalized URL of Azure DevOps
+	client  *azuredevops.Client
+}
+
+// orgs returns the configured organizations, including the organizations of the configured
+// projects.
+func (c *azureDevOpsConnection) orgs() []string {
+	orgs := append([]string(nil), c.config.Orgs...)
+	seen := map[string]bool{}
+	for _, org := range orgs {
+		seen[org] = true
+	}
+	for _, p := range c.config.Projects {
+		org := strings.SplitN(p, "/", 2)[0]
+		if !seen[org] {
+			seen[org] = true
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs
+}
+
+// listAllRepos lists the repositories of the configured organizations and projects. Each
+// repository is listed once.
+func (c *azureDevOpsConnection) listAllRepos(ctx context.Context) <-chan *azuredevops.Repo {
+	ch := make(chan *azuredevops.Repo)
+	go func() {
+		defer close(ch)
+
+		seen := map[string]bool{}
+		list := func(org, project string) {
+			repos, err := c.client.Repos(ctx, org, project)
+			if err != nil {
+				log15.Error("failed when listing Azure DevOps repos", "url", c.baseURL, "org", org, "project", project, "error", err)
+				return
+			}
+			for _, r := range repos {
+				if !seen[r.ID] {
+					seen[r.ID] = true
+					ch <- r
+				}
+			}
+		}
+
+		for _, org := range c.config.Orgs {
+			list(org, "")
+		}
+		for _, p := range c.config.Projects {
+			parts := strings.SplitN(p, "/", 2)
+			if len(parts) != 2 {
+				log15.Error("ignoring malformed Azure DevOps project (expected org/project)", "project", p)
+				continue
+			}
+			list(parts[0], parts[1])
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,82 @@
+package repos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsRepoInfo(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("test-fixtures", "azuredevops-repos.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repos []*azuredevops.Repo
+	if err := json.Unmarshal(b, &repos); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range repos {
+		r.Org = "sgtest" // set by the client
+	}
+
+	cases := map[string]*schema.AzureDevOpsConnection{
+		"simple": {
+			Token: "secret",
+			Orgs:  []string{"sgtest"},
+		},
+		"ssh": {
+			Token:                       "secret",
+			Projects:                    []string{"sgtest/Go Tools"},
+			GitURLType:                  "ssh",
+			InitialRepositoryEnablement: true,
+		},
+		"path-pattern": {
+			Url:                   "https://dev.azure.com",
+			Token:                 "secret",
+			Orgs:                  []string{"sgtest"},
+			RepositoryPathPattern: "azure/{project}/{repo}",
+		},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn, err := newAzureDevOpsConnection(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*protocol.RepoInfo
+			for _, r := range repos {
+				got = append(got, azureDevOpsRepoInfo(conn, r))
+			}
+			actual, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("test-fixtures", "azuredevops-repos-"+name+".golden")
+			if *update {
+				err := ioutil.WriteFile(golden, actual, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expect, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(actual, expect) {
+				d, err := diff(actual, expect)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Error(d)
+			}
+		})
+	}
+}
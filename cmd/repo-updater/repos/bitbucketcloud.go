@@ -0,0 +1,329 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/atomicvalue"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/pkg/ratelimit"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"golang.org/x/time/rate"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var bitbucketCloudConnections = func() *atomicvalue.Value {
+	c := atomicvalue.New()
+	c.Set(func() interface{} {
+		return []*bitbucketCloudConnection{}
+	})
+	return c
+}()
+
+// SyncBitbucketCloudConnections periodically syncs connections from
+// the Frontend API.
+func SyncBitbucketCloudConnections(ctx context.Context) {
+	t := time.NewTicker(configWatchInterval)
+	var lastConfig []*schema.BitbucketCloudConnection
+	for range t.C {
+		config, err := conf.BitbucketCloudConfigs(ctx)
+		if err != nil {
+			log15.Error("unable to fetch Bitbucket Cloud configs", "err", err)
+			continue
+		}
+
+		if reflect.DeepEqual(config, lastConfig) {
+			continue
+		}
+		lastConfig = config
+
+		var conns []*bitbucketCloudConnection
+		for _, c := range config {
+			conn, err := newBitbucketCloudConnection(c)
+			if err != nil {
+				log15.Error("Error processing configured Bitbucket Cloud connection. Skipping it.", "url", c.Url, "error", err)
+				continue
+			}
+			conns = append(conns, conn)
+		}
+
+		bitbucketCloudConnections.Set(func() interface{} {
+			return conns
+		})
+
+		bitbucketCloudWorker.restart()
+	}
+}
+
+// getBitbucketCloudConnection returns the Bitbucket Cloud connection (config + API client) that is
+// responsible for the repository specified by the args.
+func getBitbucketCloudConnection(args protocol.RepoLookupArgs) (*bitbucketCloudConnection, error) {
+	conns := bitbucketCloudConnections.Get().([]*bitbucketCloudConnection)
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == bitbucketcloud.ServiceType {
+		// Look up by external repository spec.
+		for _, conn := range conns {
+			if args.ExternalRepo.ServiceID == conn.baseURL.String() {
+				return conn, nil
+			}
+		}
+		return nil, errors.Errorf("no configured Bitbucket Cloud connection with URL: %q", args.ExternalRepo.ServiceID)
+	}
+
+	if args.Repo != "" {
+		// Look up by repository name.
+		repo := strings.ToLower(string(args.Repo))
+		for _, conn := range conns {
+			if strings.HasPrefix(repo, conn.baseURL.Hostname()+"/") {
+				return conn, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func bitbucketCloudRepoInfo(config *schema.BitbucketCloudConnection, repo *bitbucketcloud.Repo) *protocol.RepoInfo {
+	host, err := url.Parse(bitbucketCloudBaseURL(config))
+	if err != nil {
+		// This should never happen
+		log15.Error("malformed Bitbucket Cloud config, invalid URL", "url", config.Url, "error", err)
+		return nil
+	}
+	host = NormalizeBaseURL(host)
+
+	// Clone URL
+	var cloneURL string
+	for _, l := range repo.Links.Clone {
+		if l.Name == "ssh" && config.GitURLType == "ssh" {
+			cloneURL = l.Href
+			break
+		}
+		if l.Name == "https" {
+			cloneURL = setUserinfoBestEffort(l.Href, config.Username, config.AppPassword)
+			// No break, so that we fallback to https in case of ssh missing
+			// with GitURLType == "ssh"
+		}
+	}
+
+	// Repo Links
+	var links *protocol.RepoLinks
+	if root := repo.Links.HTML.Href; root != "" {
+		links = &protocol.RepoLinks{
+			Root:   root,
+			Tree:   root + "/src/{rev}/{path}",
+			Blob:   root + "/src/{rev}/{path}",
+			Commit: root + "/commits/{commit}",
+		}
+	}
+
+	return &protocol.RepoInfo{
+		Name: reposource.BitbucketCloudRepoName(config.RepositoryPathPattern, host.Hostname(), repo.FullName),
+		ExternalRepo: &api.ExternalRepoSpec{
+			ID:          repo.UUID,
+			ServiceType: bitbucketcloud.ServiceType,
+			ServiceID:   host.String(),
+		},
+		Description: repo.Description,
+		Fork:        repo.Parent != nil,
+		VCS: protocol.VCSInfo{
+			URL: cloneURL,
+		},
+		Links: links,
+	}
+}
+
+// bitbucketCloudRepoInfoSuffix matches out {owner}/{repoSlug} at the end of a string.
+var bitbucketCloudRepoInfoSuffix = regexp.MustCompile(`([^/]+)/([^/]+)/?$`)
+
+// GetBitbucketCloudRepository queries a configured Bitbucket Cloud connection for information
+// about the specified repository.
+//
+// If args.Repo refers to a repository that is not known to be on a configured Bitbucket Cloud
+// connection's host, it returns authoritative == false.
+func GetBitbucketCloudRepository(ctx context.Context, args protocol.RepoLookupArgs) (repo *protocol.RepoInfo, authoritative bool, err error) {
+	conn, err := getBitbucketCloudConnection(args)
+	if err != nil {
+		return nil, true, err // refers to a Bitbucket Cloud repo but the host is not configured
+	}
+	if conn == nil {
+		return nil, false, nil // refers to a non-Bitbucket Cloud repo
+	}
+
+	var fullName string
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == bitbucketcloud.ServiceType {
+		// Look up by external repository spec, which is the UUID of the repository. Bitbucket
+		// Cloud accepts "{}" in place of the owner when the repository is identified by UUID.
+		fullName = "{}/" + args.ExternalRepo.ID
+	} else if args.Repo != "" {
+		// Look up by repository name. Expect suffix {owner}/{repoSlug}
+		match := bitbucketCloudRepoInfoSuffix.FindStringSubmatch(string(args.Repo))
+		if len(match) == 0 {
+			return nil, true, errors.Errorf("malformed Bitbucket Cloud repo name: %q", args.Repo)
+		}
+		fullName = match[1] + "/" + match[2]
+	} else {
+		return nil, true, fmt.Errorf("unable to look up Bitbucket Cloud repository (%+v)", args)
+	}
+
+	r, err := conn.client.Repo(ctx, fullName)
+	if err != nil {
+		return nil, true, err
+	}
+	return bitbucketCloudRepoInfo(conn.config, r), true, nil
+}
+
+var bitbucketCloudWorker = &worker{
+	work: func(ctx context.Context, shutdown chan struct{}) {
+		for _, c := range bitbucketCloudConnections.Get().([]*bitbucketCloudConnection) {
+			go func(c *bitbucketCloudConnection) {
+				for {
+					updateBitbucketCloudRepos(ctx, c)
+					bitbucketCloudUpdateTime.WithLabelValues(c.baseURL.String()).Set(float64(time.Now().Unix()))
+					select {
+					case <-shutdown:
+						return
+					case <-time.After(GetUpdateInterval()):
+					}
+				}
+			}(c)
+		}
+	},
+}
+
+// RunBitbucketCloudRepositorySyncWorker runs the worker that syncs repositories from configured
+// Bitbucket Cloud connections to Sourcegraph.
+func RunBitbucketCloudRepositorySyncWorker(ctx context.Context) {
+	bitbucketCloudWorker.start(ctx)
+}
+
+// updateBitbucketCloudRepos ensures that all provided repositories exist in the repository table.
+func updateBitbucketCloudRepos(ctx context.Context, conn *bitbucketCloudConnection) {
+	repoChan := make(chan repoCreateOrUpdateRequest)
+	defer close(repoChan)
+	// The same user may be used by several connections (for different teams), so the teams are
+	// part of the source.
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("bitbucketcloud:%s:%s", conn.config.Username, strings.Join(conn.config.Teams, ",")), nil, repoChan)
+	for r := range conn.listAllRepos(ctx) {
+		if r.SCM != "git" {
+			continue // Mercurial repositories are not supported
+		}
+
+		ri := bitbucketCloudRepoInfo(conn.config, r)
+		if ri.VCS.URL == "" {
+			continue
+		}
+
+		repoChan <- repoCreateOrUpdateRequest{
+			RepoCreateOrUpdateRequest: api.RepoCreateOrUpdateRequest{
+				RepoName:     ri.Name,
+				ExternalRepo: ri.ExternalRepo,
+				Description:  ri.Description,
+				Fork:         ri.Fork,
+				Enabled:      conn.config.InitialRepositoryEnablement,
+			},
+			URL: ri.VCS.URL,
+		}
+	}
+}
+
+// Bitbucket Cloud allows each user 1,000 requests per hour to its repository APIs (see
+// https://confluence.atlassian.com/bitbucket/rate-limits-668173227.html). We list 100 repositories
+// per request, so this is enough to sync tens of thousands of repositories every hour and still
+// serve repository lookups.
+const (
+	bitbucketCloudRateLimit      = rate.Limit(1000.0 / 3600)
+	bitbucketCloudRateLimitBurst = 100
+)
+
+// bitbucketCloudBaseURL returns the URL of Bitbucket Cloud from the config, defaulting to
+// https://bitbucket.org.
+func bitbucketCloudBaseURL(config *schema.BitbucketCloudConnection) string {
+	if config.Url == "" {
+		return "https://bitbucket.org"
+	}
+	return config.Url
+}
+
+func newBitbucketCloudConnection(config *schema.BitbucketCloudConnection) (*bitbucketCloudConnection, error) {
+	baseURL, err := url.Parse(bitbucketCloudBaseURL(config))
+	if err != nil {
+		return nil, err
+	}
+	baseURL = NormalizeBaseURL(baseURL)
+
+	rawAPIURL := config.ApiURL
+	if rawAPIURL == "" {
+		rawAPIURL = "https://api.bitbucket.org"
+	}
+	apiURL, err := url.Parse(rawAPIURL)
+	if err != nil {
+		return nil, err
+	}
+	apiURL = NormalizeBaseURL(apiURL)
+
+	return &bitbucketCloudConnection{
+		config:  config,
+		baseURL: baseURL,
+		client: &bitbucketcloud.Client{
+			URL:         apiURL,
+			Username:    config.Username,
+			AppPassword: config.AppPassword,
+			HTTPClient: &http.Client{
+				Transport: bitbucketcloud.WithRequestCounter(http.DefaultTransport),
+			},
+			// The rate limit applies per user, so it is shared by all connections (and clients)
+			// with the same username.
+			RateLimit: ratelimit.DefaultRegistry.Limiter("bitbucketcloud:"+apiURL.String()+":"+config.Username, bitbucketCloudRateLimit, bitbucketCloudRateLimitBurst),
+		},
+	}, nil
+}
+
+type bitbucketCloudConnection struct {
+	config  *schema.BitbucketCloudConnection
+	baseURL *url.URL // the normalized URL of Bitbucket Cloud (not of its API)
+	client  *bitbucketcloud.Client
+}
+
+// listAllRepos lists the repositories of all configured teams, or the repositories that the user
+// is a member of if no teams are configured.
+func (c *bitbucketCloudConnection) listAllRepos(ctx context.Context) <-chan *bitbucketcloud.Repo {
+	const perPage = 100
+	ch := make(chan *bitbucketcloud.Repo, perPage)
+	go func() {
+		defer close(ch)
+
+		teams := c.config.Teams
+		if len(teams) == 0 {
+			teams = []string{""} // the repositories that the user is a member of
+		}
+		for _, team := range teams {
+			page := &bitbucketcloud.PageToken{Pagelen: perPage}
+			for page.HasMore() {
+				var repos []*bitbucketcloud.Repo
+				var err error
+				repos, page, err = c.client.Repos(ctx, page, team)
+				if err != nil {
+					log15.Error("failed when listing Bitbucket Cloud repos", "team", team, "error", err)
+					break
+				}
+				for _, r := range repos {
+					ch <- r
+				}
+			}
+		}
+	}()
+
+	return ch
+}
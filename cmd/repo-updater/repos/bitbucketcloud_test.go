@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketCloudRepoInfo(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("test-fixtures", "bitbucketcloud-repos.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repos []*bitbucketcloud.Repo
+	if err := json.Unmarshal(b, &repos); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*schema.BitbucketCloudConnection{
+		"simple": {
+			Username:    "sgtest",
+			AppPassword: "secret",
+		},
+		"ssh": {
+			Url:                         "https://bitbucket.org",
+			Username:                    "sgtest",
+			AppPassword:                 "secret",
+			InitialRepositoryEnablement: true,
+			GitURLType:                  "ssh",
+		},
+		"path-pattern": {
+			Username:              "sgtest",
+			AppPassword:           "secret",
+			RepositoryPathPattern: "bb/{nameWithOwner}",
+		},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []*protocol.RepoInfo
+			for _, r := range repos {
+				got = append(got, bitbucketCloudRepoInfo(config, r))
+			}
+			actual, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("test-fixtures", "bitbucketcloud-repos-"+name+".golden")
+			if *update {
+				err := ioutil.WriteFile(golden, actual, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expect, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(actual, expect) {
+				d, err := diff(actual, expect)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Error(d)
+			}
+		})
+	}
+}
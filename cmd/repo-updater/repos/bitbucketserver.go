@@ -16,10 +16,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/pkg/conf"
 	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/pkg/ratelimit"
 	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/pkg/vcs"
 	"github.com/sourcegraph/sourcegraph/schema"
-	"golang.org/x/time/rate"
 	log15 "gopkg.in/inconshreveable/log15.v2"
 )
 
@@ -144,6 +144,13 @@ func bitbucketServerRepoInfo(config *schema.BitbucketServerConnection, repo *bit
 		break
 	}
 
+	// Visibility. Bitbucket Server doesn't have stars, topics or archived repositories, and only
+	// returns the default branch from a separate endpoint.
+	visibility := api.RepoVisibilityPrivate
+	if repo.Public || (repo.Project != nil && repo.Project.Public) {
+		visibility = api.RepoVisibilityPublic
+	}
+
 	return &protocol.RepoInfo{
 		Name: repoName,
 		ExternalRepo: &api.ExternalRepoSpec{
@@ -153,6 +160,7 @@ func bitbucketServerRepoInfo(config *schema.BitbucketServerConnection, repo *bit
 		},
 		Description: repo.Name,
 		Fork:        repo.Origin != nil,
+		Visibility:  visibility,
 		VCS: protocol.VCSInfo{
 			URL: cloneURL,
 		},
@@ -262,7 +270,7 @@ func updateBitbucketServerRepos(ctx context.Context, conn *bitbucketServerConnec
 	if sourceID == "" {
 		sourceID = conn.config.Username
 	}
-	go createEnableUpdateRepos(ctx, fmt.Sprintf("bitbucket:%s", sourceID), repoChan)
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("bitbucket:%s", sourceID), nil, repoChan)
 	for r := range conn.listAllRepos(ctx) {
 		if r.State != "AVAILABLE" {
 			continue
@@ -281,7 +289,8 @@ func updateBitbucketServerRepos(ctx context.Context, conn *bitbucketServerConnec
 				Fork:         ri.Fork,
 				Enabled:      conn.config.InitialRepositoryEnablement,
 			},
-			URL: ri.VCS.URL,
+			URL:        ri.VCS.URL,
+			Visibility: ri.Visibility,
 		}
 	}
 }
@@ -334,7 +343,8 @@ func newBitbucketServerConnection(config *schema.BitbucketServerConnection) (*bi
 			HTTPClient: &http.Client{
 				Transport: bitbucketserver.WithRequestCounter(transport),
 			},
-			RateLimit: rate.NewLimiter(rateLimitRequestsPerSecond, rateLimitMaxBurstRequests),
+			RateLimit:        ratelimit.DefaultRegistry.Limiter("bitbucketserver:"+baseURL.String(), rateLimitRequestsPerSecond, rateLimitMaxBurstRequests),
+			RateLimitMonitor: ratelimit.DefaultRegistry.Monitor("bitbucketserver:"+baseURL.String(), ""),
 		},
 	}, nil
 }
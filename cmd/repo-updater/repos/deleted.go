@@ -0,0 +1,164 @@
+package repos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/pkg/redispool"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// droppedRepoLookup looks up a repository on its code host by its external repository spec, using
+// the connection whose sync listed the repository. It is used to find out what happened to
+// repositories that a sync no longer lists.
+type droppedRepoLookup func(ctx context.Context, spec *api.ExternalRepoSpec) (*protocol.RepoInfo, error)
+
+// listedRepoStore persists the repositories that the last sync of each source listed, so that the
+// repositories that a sync no longer lists are found even if repo-updater restarted since the
+// previous sync.
+type listedRepoStore interface {
+	// replace persists the repositories listed by the latest sync of the source and returns the
+	// repositories listed by the previous sync.
+	replace(source string, listed map[api.RepoName]*api.ExternalRepoSpec) (previous map[api.RepoName]*api.ExternalRepoSpec, err error)
+}
+
+var listedRepos listedRepoStore = newRedisListedRepoStore()
+
+// listDroppedRepos records the repositories listed by the latest sync of the source, and returns
+// the repositories that were listed by the previous sync of the source but not by the latest one.
+// Only repositories with an external repository spec are considered.
+//
+// If a sync lists no repositories (for example, because listing failed), the previous sync's
+// repositories are kept and none are considered dropped.
+func listDroppedRepos(source string, listed map[api.RepoName]*api.ExternalRepoSpec) (map[api.RepoName]*api.ExternalRepoSpec, error) {
+	withSpec := make(map[api.RepoName]*api.ExternalRepoSpec, len(listed))
+	for name, spec := range listed {
+		if spec != nil {
+			withSpec[name] = spec
+		}
+	}
+	if len(withSpec) == 0 {
+		return nil, nil
+	}
+
+	previous, err := listedRepos.replace(source, withSpec)
+	if err != nil {
+		return nil, err
+	}
+	dropped := map[api.RepoName]*api.ExternalRepoSpec{}
+	for name, spec := range previous {
+		if _, ok := withSpec[name]; !ok {
+			dropped[name] = spec
+		}
+	}
+	return dropped, nil
+}
+
+// handleDroppedRepos looks up repositories that a sync of the source no longer lists on their code
+// host by their external repository spec, to find out whether they were deleted or renamed.
+//
+// Deleted repositories are marked as deleted. Renamed repositories are renamed in place, so that
+// data attached to them (such as discussion threads) is kept. Repositories that still exist under
+// the same name (for example, because they were removed from the configuration) are left alone.
+func handleDroppedRepos(ctx context.Context, source string, lookup droppedRepoLookup, dropped map[api.RepoName]*api.ExternalRepoSpec) {
+	for name, spec := range dropped {
+		info, err := lookup(ctx, spec)
+		switch {
+		case errcode.IsNotFound(err) || github.IsNotFound(err) || gitlab.IsNotFound(err):
+			log15.Info("Repository was deleted on its code host", "source", source, "repo", name)
+			if err := api.InternalClient.ReposMarkDeleted(ctx, name); err != nil {
+				log15.Warn("Error marking repository as deleted", "repo", name, "error", err)
+			}
+
+		case err != nil:
+			log15.Warn("Error looking up repository that is no longer listed by its code host", "source", source, "repo", name, "error", err)
+
+		case info == nil || info.Name == name:
+			// The repository still exists.
+
+		default:
+			log15.Info("Repository was renamed on its code host", "source", source, "repo", name, "newName", info.Name)
+			_, err := api.InternalClient.ReposCreateIfNotExists(ctx, api.RepoCreateOrUpdateRequest{
+				RepoName:     info.Name,
+				ExternalRepo: info.ExternalRepo,
+				Description:  info.Description,
+				Fork:         info.Fork,
+				Archived:     info.Archived,
+			})
+			if err != nil {
+				log15.Warn("Error renaming repository", "repo", name, "newName", info.Name, "error", err)
+			}
+		}
+	}
+}
+
+// listedReposTTLSeconds is how long the repositories listed by a source are kept after its last
+// sync, so that the listings of sources that were removed from the configuration expire.
+const listedReposTTLSeconds = 30 * 24 * 60 * 60
+
+// redisListedRepoStore is a listedRepoStore that stores the repositories listed by each source in
+// a redis hash.
+type redisListedRepoStore struct {
+	pool      *redis.Pool
+	keyPrefix string
+}
+
+func newRedisListedRepoStore() *redisListedRepoStore {
+	return &redisListedRepoStore{pool: redispool.Store, keyPrefix: "repo-updater:listed:"}
+}
+
+func (s *redisListedRepoStore) replace(source string, listed map[api.RepoName]*api.ExternalRepoSpec) (map[api.RepoName]*api.ExternalRepoSpec, error) {
+	// Sources contain credentials, so they are hashed.
+	key := fmt.Sprintf("%s%x", s.keyPrefix, sha256.Sum256([]byte(source)))
+
+	c := s.pool.Get()
+	defer c.Close()
+
+	values, err := redis.StringMap(c.Do("HGETALL", key))
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[api.RepoName]*api.ExternalRepoSpec, len(values))
+	for name, value := range values {
+		var spec api.ExternalRepoSpec
+		if err := json.Unmarshal([]byte(value), &spec); err != nil {
+			return nil, err
+		}
+		previous[api.RepoName(name)] = &spec
+	}
+
+	args := redis.Args{}.Add(key)
+	for name, spec := range listed {
+		b, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		args = args.Add(string(name), b)
+	}
+	if err := c.Send("MULTI"); err != nil {
+		return nil, err
+	}
+	if err := c.Send("DEL", key); err != nil {
+		return nil, err
+	}
+	if len(listed) > 0 {
+		if err := c.Send("HMSET", args...); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.Send("EXPIRE", key, listedReposTTLSeconds); err != nil {
+		return nil, err
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,122 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+)
+
+// memListedRepoStore is a listedRepoStore that stores the listed repositories in memory.
+type memListedRepoStore map[string]map[api.RepoName]*api.ExternalRepoSpec
+
+func (s memListedRepoStore) replace(source string, listed map[api.RepoName]*api.ExternalRepoSpec) (map[api.RepoName]*api.ExternalRepoSpec, error) {
+	previous := s[source]
+	s[source] = listed
+	return previous, nil
+}
+
+func TestListDroppedRepos(t *testing.T) {
+	githubSpec := func(id string) *api.ExternalRepoSpec {
+		return &api.ExternalRepoSpec{ID: id, ServiceType: github.ServiceType, ServiceID: "https://github.com/"}
+	}
+	orig := listedRepos
+	listedRepos = memListedRepoStore{}
+	defer func() { listedRepos = orig }()
+
+	// The first sync of a source drops nothing.
+	dropped, err := listDroppedRepos("github:a", map[api.RepoName]*api.ExternalRepoSpec{
+		"github.com/a/b":       githubSpec("1"),
+		"github.com/a/dropped": githubSpec("2"),
+		"example.com/a/b":      nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dropped) != 0 {
+		t.Errorf("got dropped repos %+v, want none", dropped)
+	}
+
+	// Repositories that another source lists are not dropped from this source.
+	if _, err := listDroppedRepos("github:b", map[api.RepoName]*api.ExternalRepoSpec{
+		"github.com/a/other": githubSpec("3"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dropped, err = listDroppedRepos("github:a", map[api.RepoName]*api.ExternalRepoSpec{
+		"github.com/a/b": githubSpec("1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[api.RepoName]*api.ExternalRepoSpec{"github.com/a/dropped": githubSpec("2")}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("got dropped repos %+v, want %+v", dropped, want)
+	}
+
+	// A sync that lists no repositories drops none, and the next sync is compared to the last
+	// sync that listed repositories.
+	if dropped, err := listDroppedRepos("github:a", nil); err != nil {
+		t.Fatal(err)
+	} else if len(dropped) != 0 {
+		t.Errorf("got dropped repos %+v, want none", dropped)
+	}
+	if dropped, err := listDroppedRepos("github:a", map[api.RepoName]*api.ExternalRepoSpec{
+		"github.com/a/b": githubSpec("1"),
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(dropped) != 0 {
+		t.Errorf("got dropped repos %+v, want none", dropped)
+	}
+}
+
+func TestHandleDroppedRepos(t *testing.T) {
+	githubSpec := func(id string) *api.ExternalRepoSpec {
+		return &api.ExternalRepoSpec{ID: id, ServiceType: github.ServiceType, ServiceID: "https://github.com/"}
+	}
+	lookup := func(ctx context.Context, spec *api.ExternalRepoSpec) (*protocol.RepoInfo, error) {
+		switch spec.ID {
+		case "deleted":
+			return nil, github.ErrNotFound
+		case "renamed":
+			return &protocol.RepoInfo{Name: "github.com/a/new-name", ExternalRepo: spec}, nil
+		case "unchanged":
+			return &protocol.RepoInfo{Name: "github.com/a/unchanged", ExternalRepo: spec}, nil
+		case "error":
+			return nil, errors.New("rate limit exceeded")
+		}
+		t.Errorf("unexpected lookup of %+v", spec)
+		return nil, nil
+	}
+
+	var deleted []api.RepoName
+	api.MockReposMarkDeleted = func(repo api.RepoName) error {
+		deleted = append(deleted, repo)
+		return nil
+	}
+	defer func() { api.MockReposMarkDeleted = nil }()
+	var created []api.RepoCreateOrUpdateRequest
+	api.MockReposCreateIfNotExists = func(op api.RepoCreateOrUpdateRequest) (*api.Repo, error) {
+		created = append(created, op)
+		return &api.Repo{Name: op.RepoName, ExternalRepo: op.ExternalRepo}, nil
+	}
+	defer func() { api.MockReposCreateIfNotExists = nil }()
+
+	handleDroppedRepos(context.Background(), "github:t", lookup, map[api.RepoName]*api.ExternalRepoSpec{
+		"github.com/a/deleted":   githubSpec("deleted"),
+		"github.com/a/old-name":  githubSpec("renamed"),
+		"github.com/a/unchanged": githubSpec("unchanged"),
+		"github.com/a/error":     githubSpec("error"),
+	})
+
+	if want := []api.RepoName{"github.com/a/deleted"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deleted repos %q, want %q", deleted, want)
+	}
+	if want := []api.RepoCreateOrUpdateRequest{{RepoName: "github.com/a/new-name", ExternalRepo: githubSpec("renamed")}}; !reflect.DeepEqual(created, want) {
+		t.Errorf("got created repos %+v, want %+v", created, want)
+	}
+}
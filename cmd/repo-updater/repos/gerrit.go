@@ -0,0 +1,307 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/atomicvalue"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var gerritConnections = func() *atomicvalue.Value {
+	c := atomicvalue.New()
+	c.Set(func() interface{} {
+		return []*gerritConnection{}
+	})
+	return c
+}()
+
+// SyncGerritConnections periodically syncs connections from the Frontend API.
+func SyncGerritConnections(ctx context.Context) {
+	t := time.NewTicker(configWatchInterval)
+	var lastConfig []*schema.GerritConnection
+	for range t.C {
+		config, err := conf.GerritConfigs(ctx)
+		if err != nil {
+			log15.Error("unable to fetch Gerrit configs", "err", err)
+			continue
+		}
+
+		if reflect.DeepEqual(config, lastConfig) {
+			continue
+		}
+		lastConfig = config
+
+		var conns []*gerritConnection
+		for _, c := range config {
+			conn, err := newGerritConnection(c)
+			if err != nil {
+				log15.Error("Error processing configured Gerrit connection. Skipping it.", "url", c.Url, "error", err)
+				continue
+			}
+			conns = append(conns, conn)
+		}
+
+		gerritConnections.Set(func() interface{} {
+			return conns
+		})
+
+		gerritWorker.restart()
+	}
+}
+
+// getGerritConnection returns the Gerrit connection (config + API client) that is responsible for
+// the repository specified by the args.
+func getGerritConnection(args protocol.RepoLookupArgs) (*gerritConnection, error) {
+	conns := gerritConnections.Get().([]*gerritConnection)
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == gerrit.ServiceType {
+		// Look up by external repository spec.
+		for _, conn := range conns {
+			if args.ExternalRepo.ServiceID == conn.baseURL.String() {
+				return conn, nil
+			}
+		}
+		return nil, errors.Errorf("no configured Gerrit connection with URL: %q", args.ExternalRepo.ServiceID)
+	}
+
+	if args.Repo != "" {
+		// Look up by repository name.
+		repo := strings.ToLower(string(args.Repo))
+		for _, conn := range conns {
+			if strings.HasPrefix(repo, conn.baseURL.Hostname()+"/") {
+				return conn, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func gerritRepoInfo(conn *gerritConnection, project *gerrit.Project) *protocol.RepoInfo {
+	// Clone URL. Authenticated access to Git repositories is under the /a/ prefix (like the API).
+	var cloneURL string
+	if conn.config.Username != "" || conn.config.Password != "" {
+		u := conn.baseURL.ResolveReference(&url.URL{Path: "a/" + project.Name})
+		cloneURL = setUserinfoBestEffort(u.String(), conn.config.Username, conn.config.Password)
+	} else {
+		cloneURL = conn.baseURL.ResolveReference(&url.URL{Path: project.Name}).String()
+	}
+
+	return &protocol.RepoInfo{
+		Name: reposource.GerritRepoName(conn.config.RepositoryPathPattern, conn.baseURL.Hostname(), project.Name),
+		ExternalRepo: &api.ExternalRepoSpec{
+			ID:          project.Name,
+			ServiceType: gerrit.ServiceType,
+			ServiceID:   conn.baseURL.String(),
+		},
+		Description: project.Description,
+		VCS: protocol.VCSInfo{
+			URL: cloneURL,
+		},
+		Links: gerritRepoLinks(conn.baseURL, project),
+	}
+}
+
+// gerritRepoLinks returns links to the project in the gitiles repository browser, if Gerrit links
+// to it. Otherwise, it only links to the project's page in Gerrit (which has no views of files or
+// commits).
+func gerritRepoLinks(baseURL *url.URL, project *gerrit.Project) *protocol.RepoLinks {
+	for _, l := range project.WebLinks {
+		// The gitiles plugin names its links "browse" (or "gitiles" in older versions).
+		if l.Name != "browse" && l.Name != "gitiles" {
+			continue
+		}
+		u, err := url.Parse(l.URL)
+		if err != nil {
+			continue
+		}
+		if !u.IsAbs() {
+			// Relative links (such as /plugins/gitiles/myproject) are relative to the Gerrit URL,
+			// which may have a path.
+			u.Path = strings.TrimPrefix(u.Path, "/")
+		}
+		root := strings.TrimSuffix(baseURL.ResolveReference(u).String(), "/")
+		return &protocol.RepoLinks{
+			Root:   root,
+			Tree:   root + "/+/{rev}/{path}",
+			Blob:   root + "/+/{rev}/{path}",
+			Commit: root + "/+/{commit}",
+		}
+	}
+	return &protocol.RepoLinks{
+		Root: baseURL.ResolveReference(&url.URL{Path: "admin/repos/" + project.Name}).String(),
+	}
+}
+
+// GetGerritRepository queries a configured Gerrit connection for information about the specified
+// repository.
+//
+// If args.Repo refers to a repository that is not known to be on a configured Gerrit connection's
+// host, it returns authoritative == false.
+func GetGerritRepository(ctx context.Context, args protocol.RepoLookupArgs) (repo *protocol.RepoInfo, authoritative bool, err error) {
+	conn, err := getGerritConnection(args)
+	if err != nil {
+		return nil, true, err // refers to a Gerrit repo but the host is not configured
+	}
+	if conn == nil {
+		return nil, false, nil // refers to a non-Gerrit repo
+	}
+
+	var name string
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == gerrit.ServiceType {
+		// Look up by external repository spec, which is the project name.
+		name = args.ExternalRepo.ID
+	} else if args.Repo != "" {
+		// Look up by repository name. Expect the repository name to be {host}/{project} (the
+		// default repositoryPathPattern), because project names may contain slashes.
+		name = string(args.Repo)[len(conn.baseURL.Hostname()+"/"):]
+	} else {
+		return nil, true, fmt.Errorf("unable to look up Gerrit repository (%+v)", args)
+	}
+
+	p, err := conn.client.Project(ctx, name)
+	if err != nil {
+		return nil, true, err
+	}
+	return gerritRepoInfo(conn, p), true, nil
+}
+
+var gerritWorker = &worker{
+	work: func(ctx context.Context, shutdown chan struct{}) {
+		for _, c := range gerritConnections.Get().([]*gerritConnection) {
+			go func(c *gerritConnection) {
+				for {
+					updateGerritRepos(ctx, c)
+					gerritUpdateTime.WithLabelValues(c.baseURL.String()).Set(float64(time.Now().Unix()))
+					select {
+					case <-shutdown:
+						return
+					case <-time.After(GetUpdateInterval()):
+					}
+				}
+			}(c)
+		}
+	},
+}
+
+// RunGerritRepositorySyncWorker runs the worker that syncs repositories from configured Gerrit
+// connections to Sourcegraph.
+func RunGerritRepositorySyncWorker(ctx context.Context) {
+	gerritWorker.start(ctx)
+}
+
+// updateGerritRepos ensures that all provided repositories exist in the repository table.
+func updateGerritRepos(ctx context.Context, conn *gerritConnection) {
+	repoChan := make(chan repoCreateOrUpdateRequest)
+	defer close(repoChan)
+	// Several connections may list the projects of the same Gerrit server (as different users or
+	// with different repository names), so the source is specific to the connection.
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("gerrit:%s:%s:%s", conn.baseURL, conn.config.Username, conn.config.RepositoryPathPattern), nil, repoChan)
+	for p := range conn.listAllProjects(ctx) {
+		if p.State == "HIDDEN" {
+			continue
+		}
+
+		ri := gerritRepoInfo(conn, p)
+		setGerritFetchChangeRefs(ri.Name, conn.config.FetchChangeRefs)
+		repoChan <- repoCreateOrUpdateRequest{
+			RepoCreateOrUpdateRequest: api.RepoCreateOrUpdateRequest{
+				RepoName:     ri.Name,
+				ExternalRepo: ri.ExternalRepo,
+				Description:  ri.Description,
+				Enabled:      conn.config.InitialRepositoryEnablement,
+			},
+			URL: ri.VCS.URL,
+		}
+	}
+}
+
+// gerritFetchChangeRefs records whether the change refs of each repository synced from a Gerrit
+// connection are fetched, per the connection's fetchChangeRefs setting. It is sent to gitserver
+// with each update of these repositories (see requestGitserverRepoUpdate).
+var gerritFetchChangeRefs = struct {
+	sync.Mutex
+	m map[api.RepoName]bool
+}{m: map[api.RepoName]bool{}}
+
+func setGerritFetchChangeRefs(repo api.RepoName, fetch bool) {
+	gerritFetchChangeRefs.Lock()
+	gerritFetchChangeRefs.m[repo] = fetch
+	gerritFetchChangeRefs.Unlock()
+}
+
+// getGerritFetchChangeRefs reports whether the change refs of the repository are fetched. The
+// second return value is false if the repository has not been synced from a Gerrit connection.
+func getGerritFetchChangeRefs(repo api.RepoName) (fetch, ok bool) {
+	gerritFetchChangeRefs.Lock()
+	defer gerritFetchChangeRefs.Unlock()
+	fetch, ok = gerritFetchChangeRefs.m[repo]
+	return fetch, ok
+}
+
+func newGerritConnection(config *schema.GerritConnection) (*gerritConnection, error) {
+	baseURL, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = NormalizeBaseURL(baseURL)
+
+	return &gerritConnection{
+		config:  config,
+		baseURL: baseURL,
+		client: &gerrit.Client{
+			URL:      baseURL,
+			Username: config.Username,
+			Password: config.Password,
+			HTTPClient: &http.Client{
+				Transport: gerrit.WithRequestCounter(http.DefaultTransport),
+			},
+		},
+	}, nil
+}
+
+type gerritConnection struct {
+	config  *schema.GerritConnection
+	baseURL *url.URL // the normalized URL of the Gerrit server
+	client  *gerrit.Client
+}
+
+// listAllProjects lists all code projects that are visible to the configured user (or to anonymous
+// users).
+func (c *gerritConnection) listAllProjects(ctx context.Context) <-chan *gerrit.Project {
+	const perPage = 100
+	ch := make(chan *gerrit.Project, perPage)
+	go func() {
+		defer close(ch)
+
+		for skip, more := 0, true; more; {
+			var projects []*gerrit.Project
+			var err error
+			projects, more, err = c.client.Projects(ctx, skip, perPage)
+			if err != nil {
+				log15.Error("failed when listing Gerrit projects", "url", c.baseURL, "error", err)
+				break
+			}
+			for _, p := range projects {
+				ch <- p
+			}
+			skip += len(projects)
+		}
+	}()
+
+	return ch
+}
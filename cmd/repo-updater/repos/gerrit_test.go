@@ -0,0 +1,78 @@
+package repos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGerritRepoInfo(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("test-fixtures", "gerrit-projects.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var projects []*gerrit.Project
+	if err := json.Unmarshal(b, &projects); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*schema.GerritConnection{
+		"simple": {
+			Url:      "https://gerrit.example.com",
+			Username: "sgtest",
+			Password: "secret",
+		},
+		"anonymous": {
+			Url:                         "https://review.example.com/gerrit",
+			InitialRepositoryEnablement: true,
+		},
+		"path-pattern": {
+			Url:                   "https://gerrit.example.com",
+			Username:              "sgtest",
+			Password:              "secret",
+			RepositoryPathPattern: "gerrit/{project}",
+		},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn, err := newGerritConnection(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*protocol.RepoInfo
+			for _, p := range projects {
+				got = append(got, gerritRepoInfo(conn, p))
+			}
+			actual, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("test-fixtures", "gerrit-repos-"+name+".golden")
+			if *update {
+				err := ioutil.WriteFile(golden, actual, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expect, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(actual, expect) {
+				d, err := diff(actual, expect)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Error(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/atomicvalue"
+	"github.com/sourcegraph/sourcegraph/pkg/conf"
+	"github.com/sourcegraph/sourcegraph/pkg/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var giteaConnections = func() *atomicvalue.Value {
+	c := atomicvalue.New()
+	c.Set(func() interface{} {
+		return []*giteaConnection{}
+	})
+	return c
+}()
+
+// SyncGiteaConnections periodically syncs connections from the Frontend API.
+func SyncGiteaConnections(ctx context.Context) {
+	t := time.NewTicker(configWatchInterval)
+	var lastConfig []*schema.GiteaConnection
+	for range t.C {
+		config, err := conf.GiteaConfigs(ctx)
+		if err != nil {
+			log15.Error("unable to fetch Gitea configs", "err", err)
+			continue
+		}
+
+		if reflect.DeepEqual(config, lastConfig) {
+			continue
+		}
+		lastConfig = config
+
+		var conns []*giteaConnection
+		for _, c := range config {
+			conn, err := newGiteaConnection(c)
+			if err != nil {
+				log15.Error("Error processing configured Gitea connection. Skipping it.", "url", c.Url, "error", err)
+				continue
+			}
+			conns = append(conns, conn)
+		}
+
+		giteaConnections.Set(func() interface{} {
+			return conns
+		})
+
+		giteaWorker.restart()
+	}
+}
+
+// getGiteaConnection returns the Gitea connection (config + API client) that is responsible for
+// the repository specified by the args.
+func getGiteaConnection(args protocol.RepoLookupArgs) (*giteaConnection, error) {
+	conns := giteaConnections.Get().([]*giteaConnection)
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == gitea.ServiceType {
+		// Look up by external repository spec.
+		for _, conn := range conns {
+			if args.ExternalRepo.ServiceID == conn.baseURL.String() {
+				return conn, nil
+			}
+		}
+		return nil, errors.Errorf("no configured Gitea connection with URL: %q", args.ExternalRepo.ServiceID)
+	}
+
+	if args.Repo != "" {
+		// Look up by repository name.
+		repo := strings.ToLower(string(args.Repo))
+		for _, conn := range conns {
+			if strings.HasPrefix(repo, conn.baseURL.Hostname()+"/") {
+				return conn, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func giteaRepoInfo(conn *giteaConnection, repo *gitea.Repo) *protocol.RepoInfo {
+	// Clone URL. Gitea accepts an access token in place of the username.
+	var cloneURL string
+	if conn.config.GitURLType == "ssh" && repo.SSHURL != "" {
+		cloneURL = repo.SSHURL
+	} else {
+		cloneURL = setUserinfoBestEffort(repo.CloneURL, conn.config.Token, "")
+	}
+
+	// Repo Links. The /src/{rev} URLs of Gogs are also supported by Gitea (which redirects them to
+	// its /src/branch/{rev} and /src/commit/{rev} URLs).
+	var links *protocol.RepoLinks
+	if root := strings.TrimSuffix(repo.HTMLURL, "/"); root != "" {
+		links = &protocol.RepoLinks{
+			Root:   root,
+			Tree:   root + "/src/{rev}/{path}",
+			Blob:   root + "/src/{rev}/{path}",
+			Commit: root + "/commit/{commit}",
+		}
+	}
+
+	return &protocol.RepoInfo{
+		Name: reposource.GiteaRepoName(conn.config.RepositoryPathPattern, conn.baseURL.Hostname(), repo.FullName),
+		ExternalRepo: &api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(repo.ID, 10),
+			ServiceType: gitea.ServiceType,
+			ServiceID:   conn.baseURL.String(),
+		},
+		Description: repo.Description,
+		Fork:        repo.Fork,
+		Archived:    repo.Archived,
+		VCS: protocol.VCSInfo{
+			URL: cloneURL,
+		},
+		Links: links,
+	}
+}
+
+// giteaRepoInfoSuffix matches out {owner}/{name} at the end of a string.
+var giteaRepoInfoSuffix = regexp.MustCompile(`([^/]+)/([^/]+)/?$`)
+
+// GetGiteaRepository queries a configured Gitea connection for information about the specified
+// repository.
+//
+// If args.Repo refers to a repository that is not known to be on a configured Gitea connection's
+// host, it returns authoritative == false.
+func GetGiteaRepository(ctx context.Context, args protocol.RepoLookupArgs) (repo *protocol.RepoInfo, authoritative bool, err error) {
+	conn, err := getGiteaConnection(args)
+	if err != nil {
+		return nil, true, err // refers to a Gitea repo but the host is not configured
+	}
+	if conn == nil {
+		return nil, false, nil // refers to a non-Gitea repo
+	}
+
+	if args.ExternalRepo != nil && args.ExternalRepo.ServiceType == gitea.ServiceType {
+		// Look up by external repository spec, which is the ID of the repository.
+		id, err := strconv.ParseInt(args.ExternalRepo.ID, 10, 64)
+		if err != nil {
+			return nil, true, errors.Errorf("malformed Gitea repository ID: %q", args.ExternalRepo.ID)
+		}
+		r, err := conn.client.RepoByID(ctx, id)
+		if err == nil {
+			return giteaRepoInfo(conn, r), true, nil
+		}
+		// Gogs doesn't support looking up repositories by ID, so fall back to looking up the
+		// repository by name (if given).
+		if !errcode.IsNotFound(err) || args.Repo == "" {
+			return nil, true, err
+		}
+	}
+
+	if args.Repo == "" {
+		return nil, true, fmt.Errorf("unable to look up Gitea repository (%+v)", args)
+	}
+
+	// Look up by repository name. Expect suffix {owner}/{name}
+	match := giteaRepoInfoSuffix.FindStringSubmatch(string(args.Repo))
+	if len(match) == 0 {
+		return nil, true, errors.Errorf("malformed Gitea repo name: %q", args.Repo)
+	}
+	r, err := conn.client.Repo(ctx, match[1]+"/"+match[2])
+	if err != nil {
+		return nil, true, err
+	}
+	return giteaRepoInfo(conn, r), true, nil
+}
+
+var giteaWorker = &worker{
+	work: func(ctx context.Context, shutdown chan struct{}) {
+		for _, c := range giteaConnections.Get().([]*giteaConnection) {
+			go func(c *giteaConnection) {
+				for {
+					updateGiteaRepos(ctx, c)
+					giteaUpdateTime.WithLabelValues(c.baseURL.String()).Set(float64(time.Now().Unix()))
+					select {
+					case <-shutdown:
+						return
+					case <-time.After(GetUpdateInterval()):
+					}
+				}
+			}(c)
+		}
+	},
+}
+
+// RunGiteaRepositorySyncWorker runs the worker that syncs repositories from configured Gitea
+// connections to Sourcegraph.
+func RunGiteaRepositorySyncWorker(ctx context.Context) {
+	giteaWorker.start(ctx)
+}
+
+// updateGiteaRepos ensures that all provided repositories exist in the repository table.
+func updateGiteaRepos(ctx context.Context, conn *giteaConnection) {
+	repoChan := make(chan repoCreateOrUpdateRequest)
+	defer close(repoChan)
+	// Several connections may list different repositories of the same Gitea server, so the source
+	// is specific to the connection.
+	sourceID := strings.Join([]string{
+		conn.baseURL.String(),
+		conn.config.Token,
+		strings.Join(conn.config.Orgs, ","),
+		strings.Join(conn.config.Users, ","),
+		strings.Join(conn.config.RepositoryQuery, ","),
+	}, ":")
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("gitea:%s", sourceID), nil, repoChan)
+	for r := range conn.listAllRepos(ctx) {
+		if r.Empty {
+			continue // there is nothing to clone
+		}
+
+		ri := giteaRepoInfo(conn, r)
+		repoChan <- repoCreateOrUpdateRequest{
+			RepoCreateOrUpdateRequest: api.RepoCreateOrUpdateRequest{
+				RepoName:     ri.Name,
+				ExternalRepo: ri.ExternalRepo,
+				Description:  ri.Description,
+				Fork:         ri.Fork,
+				Archived:     ri.Archived,
+				Enabled:      conn.config.InitialRepositoryEnablement,
+			},
+			URL: ri.VCS.URL,
+		}
+	}
+}
+
+func newGiteaConnection(config *schema.GiteaConnection) (*giteaConnection, error) {
+	baseURL, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = NormalizeBaseURL(baseURL)
+
+	return &giteaConnection{
+		config:  config,
+		baseURL: baseURL,
+		client: &gitea.Client{
+			URL:   baseURL,
+			Token: config.Token,
+			HTTPClient: &http.Client{
+				Transport: gitea.WithRequestCounter(http.DefaultTransport),
+			},
+		},
+	}, nil
+}
+
+type giteaConnection struct {
+	config  *schema.GiteaConnection
+	baseURL *url.URL // the normalized URL of the Gitea server
+	client  *gitea.Client
+}
+
+// listAllRepos lists the repositories of the configured organizations and users, and the
+// repositories matching the configured repository queries. Each repository is listed once.
+func (c *giteaConnection) listAllRepos(ctx context.Context) <-chan *gitea.Repo {
+	// Gitea limits pages to 50 repositories by default.
+	const perPage = 50
+	ch := make(chan *gitea.Repo, perPage)
+	go func() {
+		defer close(ch)
+
+		seen := map[int64]bool{}
+		list := func(desc string, listPage func(page int) ([]*gitea.Repo, error)) {
+			for page := 1; ; page++ {
+				repos, err := listPage(page)
+				if err != nil {
+					log15.Error("failed when listing Gitea repos", "url", c.baseURL, "repos", desc, "error", err)
+					return
+				}
+				for _, r := range repos {
+					if !seen[r.ID] {
+						seen[r.ID] = true
+						ch <- r
+					}
+				}
+				if len(repos) < perPage {
+					return // last page
+				}
+			}
+		}
+
+		for _, org := range c.config.Orgs {
+			list("org "+org, func(page int) ([]*gitea.Repo, error) {
+				return c.client.OrgRepos(ctx, org, page, perPage)
+			})
+		}
+		for _, user := range c.config.Users {
+			list("user "+user, func(page int) ([]*gitea.Repo, error) {
+				return c.client.UserRepos(ctx, user, page, perPage)
+			})
+		}
+
+		repositoryQueries := c.config.RepositoryQuery
+		if len(repositoryQueries) == 0 {
+			// Users need to specify ["none"] to only sync the repositories of orgs and users.
+			if len(c.config.Orgs) == 0 && len(c.config.Users) == 0 {
+				repositoryQueries = []string{"all"}
+			} else {
+				repositoryQueries = []string{"none"}
+			}
+		}
+		for _, repositoryQuery := range repositoryQueries {
+			query := repositoryQuery
+			switch repositoryQuery {
+			case "none":
+				continue
+			case "all":
+				query = "" // matches all repositories
+			}
+			list("query "+repositoryQuery, func(page int) ([]*gitea.Repo, error) {
+				return c.client.SearchRepos(ctx, query, page, perPage)
+			})
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,78 @@
+package repos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/pkg/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaRepoInfo(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("test-fixtures", "gitea-repos.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repos []*gitea.Repo
+	if err := json.Unmarshal(b, &repos); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*schema.GiteaConnection{
+		"simple": {
+			Url:   "https://gitea.example.com",
+			Token: "secret-token",
+		},
+		"ssh": {
+			Url:                         "https://gitea.example.com",
+			Token:                       "secret-token",
+			GitURLType:                  "ssh",
+			InitialRepositoryEnablement: true,
+		},
+		"path-pattern": {
+			Url:                   "https://gitea.example.com",
+			Token:                 "secret-token",
+			RepositoryPathPattern: "gitea/{nameWithOwner}",
+		},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn, err := newGiteaConnection(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*protocol.RepoInfo
+			for _, r := range repos {
+				got = append(got, giteaRepoInfo(conn, r))
+			}
+			actual, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("test-fixtures", "gitea-repos-"+name+".golden")
+			if *update {
+				err := ioutil.WriteFile(golden, actual, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expect, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(actual, expect) {
+				d, err := diff(actual, expect)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Error(d)
+			}
+		})
+	}
+}
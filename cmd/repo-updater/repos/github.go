@@ -137,6 +137,40 @@ func init() {
 	}
 }
 
+// githubRepoToRepoInfo returns the repository info of the GitHub repository listed by conn.
+func githubRepoToRepoInfo(ghrepo *github.Repository, conn *githubConnection) *protocol.RepoInfo {
+	return &protocol.RepoInfo{
+		Name:          githubRepositoryToRepoPath(conn, ghrepo),
+		ExternalRepo:  github.ExternalRepoSpec(ghrepo, *conn.baseURL),
+		Description:   ghrepo.Description,
+		Fork:          ghrepo.IsFork,
+		Archived:      ghrepo.IsArchived,
+		Topics:        ghrepo.Topics,
+		Stars:         ghrepo.StargazerCount,
+		DefaultBranch: ghrepo.DefaultBranch,
+		Visibility:    githubVisibility(ghrepo),
+		Links: &protocol.RepoLinks{
+			Root:   ghrepo.URL,
+			Tree:   ghrepo.URL + "/tree/{rev}/{path}",
+			Blob:   ghrepo.URL + "/blob/{rev}/{path}",
+			Commit: ghrepo.URL + "/commit/{commit}",
+		},
+		VCS: protocol.VCSInfo{
+			URL: conn.authenticatedRemoteURL(ghrepo),
+		},
+	}
+}
+
+// lookupDroppedRepo looks up a repository that this connection listed by its external repository
+// spec (see droppedRepoLookup).
+func (c *githubConnection) lookupDroppedRepo(ctx context.Context, spec *api.ExternalRepoSpec) (*protocol.RepoInfo, error) {
+	ghrepo, err := c.client.GetRepositoryByNodeID(ctx, "", spec.ID)
+	if err != nil {
+		return nil, err
+	}
+	return githubRepoToRepoInfo(ghrepo, c), nil
+}
+
 // GetGitHubRepository queries a configured GitHub connection endpoint for information about the
 // specified repository.
 //
@@ -147,25 +181,6 @@ func GetGitHubRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 		return GetGitHubRepositoryMock(args)
 	}
 
-	ghrepoToRepoInfo := func(ghrepo *github.Repository, conn *githubConnection) *protocol.RepoInfo {
-		return &protocol.RepoInfo{
-			Name:         githubRepositoryToRepoPath(conn, ghrepo),
-			ExternalRepo: github.ExternalRepoSpec(ghrepo, *conn.baseURL),
-			Description:  ghrepo.Description,
-			Fork:         ghrepo.IsFork,
-			Archived:     ghrepo.IsArchived,
-			Links: &protocol.RepoLinks{
-				Root:   ghrepo.URL,
-				Tree:   ghrepo.URL + "/tree/{rev}/{path}",
-				Blob:   ghrepo.URL + "/blob/{rev}/{path}",
-				Commit: ghrepo.URL + "/commit/{commit}",
-			},
-			VCS: protocol.VCSInfo{
-				URL: conn.authenticatedRemoteURL(ghrepo),
-			},
-		}
-	}
-
 	conn, err := getGitHubConnection(args)
 	if err != nil {
 		return nil, true, err // refers to a GitHub repo but the host is not configured
@@ -239,7 +254,7 @@ func GetGitHubRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 		// Look up by external repository spec.
 		ghrepo, err := conn.client.GetRepositoryByNodeID(ctx, "", args.ExternalRepo.ID)
 		if ghrepo != nil {
-			repo = ghrepoToRepoInfo(ghrepo, conn)
+			repo = githubRepoToRepoInfo(ghrepo, conn)
 		}
 		return repo, true, err
 	}
@@ -254,7 +269,7 @@ func GetGitHubRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 
 		ghrepo, err := conn.client.GetRepository(ctx, owner, repoName)
 		if ghrepo != nil {
-			repo = ghrepoToRepoInfo(ghrepo, conn)
+			repo = githubRepoToRepoInfo(ghrepo, conn)
 		}
 		return repo, true, err
 	}
@@ -303,9 +318,14 @@ func githubRepositoryToRepoPath(conn *githubConnection, repo *github.Repository)
 func updateGitHubRepositories(ctx context.Context, conn *githubConnection) {
 	repos := conn.listAllRepositories(ctx)
 
+	var lookup droppedRepoLookup
+	if conn.config.Token != "" { // looking up repositories by their GraphQL node ID requires authentication
+		lookup = conn.lookupDroppedRepo
+	}
+
 	repoChan := make(chan repoCreateOrUpdateRequest)
 	defer close(repoChan)
-	go createEnableUpdateRepos(ctx, fmt.Sprintf("github:%s", conn.config.Token), repoChan)
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("github:%s", conn.config.Token), lookup, repoChan)
 	for repo := range repos {
 		// log15.Debug("github sync: create/enable/update repo", "repo", repo.NameWithOwner)
 		repoChan <- repoCreateOrUpdateRequest{
@@ -317,11 +337,24 @@ func updateGitHubRepositories(ctx context.Context, conn *githubConnection) {
 				Archived:     repo.IsArchived,
 				Enabled:      conn.config.InitialRepositoryEnablement,
 			},
-			URL: conn.authenticatedRemoteURL(repo),
+			URL:           conn.authenticatedRemoteURL(repo),
+			Topics:        repo.Topics,
+			Stars:         repo.StargazerCount,
+			DefaultBranch: repo.DefaultBranch,
+			Visibility:    githubVisibility(repo),
 		}
 	}
 }
 
+// githubVisibility returns the visibility of the GitHub repository. The GitHub API doesn't
+// distinguish internal repositories from private ones.
+func githubVisibility(repo *github.Repository) string {
+	if repo.IsPrivate {
+		return api.RepoVisibilityPrivate
+	}
+	return api.RepoVisibilityPublic
+}
+
 func newGitHubConnection(config *schema.GitHubConnection) (*githubConnection, error) {
 	baseURL, err := url.Parse(config.Url)
 	if err != nil {
@@ -342,7 +375,7 @@ func newGitHubConnection(config *schema.GitHubConnection) (*githubConnection, er
 		baseURL:          baseURL,
 		githubDotCom:     githubDotCom,
 		client:           github.NewClient(apiURL, config.Token, transport),
-		searchClient:     github.NewClient(apiURL, config.Token, transport),
+		searchClient:     github.NewSearchClient(apiURL, config.Token, transport),
 		originalHostname: originalHostname,
 	}, nil
 }
@@ -111,6 +111,44 @@ func getGitLabConnection(args protocol.RepoLookupArgs) (*gitlabConnection, error
 // GetGitLabRepositoryMock is set by tests that need to mock GetGitLabRepository.
 var GetGitLabRepositoryMock func(args protocol.RepoLookupArgs) (repo *protocol.RepoInfo, authoritative bool, err error)
 
+// gitlabProjectToRepoInfo returns the repository info of the GitLab project listed by conn.
+func gitlabProjectToRepoInfo(proj *gitlab.Project, conn *gitlabConnection) *protocol.RepoInfo {
+	return &protocol.RepoInfo{
+		Name:          gitlabProjectToRepoPath(conn, proj),
+		ExternalRepo:  gitlab.ExternalRepoSpec(proj, *conn.baseURL),
+		Description:   proj.Description,
+		Fork:          proj.ForkedFromProject != nil,
+		Archived:      proj.Archived,
+		Topics:        proj.TagList,
+		Stars:         proj.StarCount,
+		DefaultBranch: proj.DefaultBranch,
+		Visibility:    proj.Visibility, // same values as the api.RepoVisibility constants
+		VCS: protocol.VCSInfo{
+			URL: conn.authenticatedRemoteURL(proj),
+		},
+		Links: &protocol.RepoLinks{
+			Root:   proj.WebURL,
+			Tree:   proj.WebURL + "/tree/{rev}/{path}",
+			Blob:   proj.WebURL + "/blob/{rev}/{path}",
+			Commit: proj.WebURL + "/commit/{commit}",
+		},
+	}
+}
+
+// lookupDroppedRepo looks up a project that this connection listed by its external repository spec
+// (see droppedRepoLookup).
+func (c *gitlabConnection) lookupDroppedRepo(ctx context.Context, spec *api.ExternalRepoSpec) (*protocol.RepoInfo, error) {
+	id, err := strconv.Atoi(spec.ID)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := c.client.GetProject(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+	return gitlabProjectToRepoInfo(proj, c), nil
+}
+
 // GetGitLabRepository queries a configured GitLab connection endpoint for information about the
 // specified repository (a.k.a. project in GitLab's naming scheme).
 //
@@ -121,25 +159,6 @@ func GetGitLabRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 		return GetGitLabRepositoryMock(args)
 	}
 
-	ghrepoToRepoInfo := func(proj *gitlab.Project, conn *gitlabConnection) *protocol.RepoInfo {
-		return &protocol.RepoInfo{
-			Name:         gitlabProjectToRepoPath(conn, proj),
-			ExternalRepo: gitlab.ExternalRepoSpec(proj, *conn.baseURL),
-			Description:  proj.Description,
-			Fork:         proj.ForkedFromProject != nil,
-			Archived:     proj.Archived,
-			VCS: protocol.VCSInfo{
-				URL: conn.authenticatedRemoteURL(proj),
-			},
-			Links: &protocol.RepoLinks{
-				Root:   proj.WebURL,
-				Tree:   proj.WebURL + "/tree/{rev}/{path}",
-				Blob:   proj.WebURL + "/blob/{rev}/{path}",
-				Commit: proj.WebURL + "/commit/{commit}",
-			},
-		}
-	}
-
 	conn, err := getGitLabConnection(args)
 	if err != nil {
 		return nil, true, err // refers to a GitLab repo but the host is not configured
@@ -156,7 +175,7 @@ func GetGitLabRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 		}
 		proj, err := conn.client.GetProject(ctx, id, "")
 		if proj != nil {
-			repo = ghrepoToRepoInfo(proj, conn)
+			repo = gitlabProjectToRepoInfo(proj, conn)
 		}
 		return repo, true, err
 	}
@@ -166,7 +185,7 @@ func GetGitLabRepository(ctx context.Context, args protocol.RepoLookupArgs) (rep
 		pathWithNamespace := strings.TrimPrefix(strings.ToLower(string(args.Repo)), conn.baseURL.Hostname()+"/")
 		proj, err := conn.client.GetProject(ctx, 0, pathWithNamespace)
 		if proj != nil {
-			repo = ghrepoToRepoInfo(proj, conn)
+			repo = gitlabProjectToRepoInfo(proj, conn)
 		}
 		return repo, true, err
 	}
@@ -217,7 +236,7 @@ func updateGitLabProjects(ctx context.Context, conn *gitlabConnection) {
 
 	repoChan := make(chan repoCreateOrUpdateRequest)
 	defer close(repoChan)
-	go createEnableUpdateRepos(ctx, fmt.Sprintf("gitlab:%s", conn.config.Token), repoChan)
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("gitlab:%s", conn.config.Token), conn.lookupDroppedRepo, repoChan)
 	for proj := range projs {
 		repoChan <- repoCreateOrUpdateRequest{
 			RepoCreateOrUpdateRequest: api.RepoCreateOrUpdateRequest{
@@ -228,7 +247,11 @@ func updateGitLabProjects(ctx context.Context, conn *gitlabConnection) {
 				Archived:     proj.Archived,
 				Enabled:      conn.config.InitialRepositoryEnablement,
 			},
-			URL: conn.authenticatedRemoteURL(proj),
+			URL:           conn.authenticatedRemoteURL(proj),
+			Topics:        proj.TagList,
+			Stars:         proj.StarCount,
+			DefaultBranch: proj.DefaultBranch,
+			Visibility:    proj.Visibility,
 		}
 	}
 }
@@ -114,7 +114,7 @@ func gitoliteUpdateRepos(ctx context.Context, gconf *schema.GitoliteConnection,
 
 	repoChan := make(chan repoCreateOrUpdateRequest)
 	defer close(repoChan)
-	go createEnableUpdateRepos(ctx, fmt.Sprintf("gitolite:%s", gconf.Prefix), repoChan)
+	go createEnableUpdateRepos(ctx, fmt.Sprintf("gitolite:%s", gconf.Prefix), nil, repoChan)
 	if doPhabricator && gconf.Phabricator != nil {
 		go tryUpdateGitolitePhabricatorMetadata(ctx, gconf, rlist)
 	}
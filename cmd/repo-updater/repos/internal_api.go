@@ -14,7 +14,7 @@ import (
 type InternalAPI interface {
 	ExternalServicesList(context.Context, api.ExternalServicesListRequest) ([]*api.ExternalService, error)
 	ReposCreateIfNotExists(context.Context, api.RepoCreateOrUpdateRequest) (*api.Repo, error)
-	ReposUpdateMetadata(ctx context.Context, repo api.RepoName, description string, fork, archived bool) error
+	ReposUpdateMetadata(context.Context, api.ReposUpdateMetadataRequest) error
 }
 
 // NewInternalAPI returns a new internal API client with the given timeout for outgoing calls.
@@ -40,10 +40,10 @@ func (a *internalAPI) ReposCreateIfNotExists(ctx context.Context, req api.RepoCr
 }
 
 // ReposUpdateMetdata updates the metadata of repo with the given name.
-func (a *internalAPI) ReposUpdateMetadata(ctx context.Context, repoName api.RepoName, description string, fork, archived bool) error {
+func (a *internalAPI) ReposUpdateMetadata(ctx context.Context, req api.ReposUpdateMetadataRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, a.timeout)
 	defer cancel()
-	return api.InternalClient.ReposUpdateMetadata(ctx, repoName, description, fork, archived)
+	return api.InternalClient.ReposUpdateMetadata(ctx, req)
 }
 
 //
@@ -141,16 +141,14 @@ func (a *FakeInternalAPI) ReposCreateIfNotExists(
 // Non-existent repos return an error.
 func (a *FakeInternalAPI) ReposUpdateMetadata(
 	ctx context.Context,
-	repoName api.RepoName,
-	description string,
-	fork, archived bool,
+	req api.ReposUpdateMetadataRequest,
 ) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	_, ok := a.repos[repoName]
+	_, ok := a.repos[req.RepoName]
 	if !ok {
-		return fmt.Errorf("repo %q not found", repoName)
+		return fmt.Errorf("repo %q not found", req.RepoName)
 	}
 
 	// Fake success, no updates needed because the returned types (api.Repo)
@@ -30,12 +30,36 @@ var (
 		Name:      "time_last_phabricator_sync",
 		Help:      "The last time a comprehensive Phabricator sync finished",
 	}, []string{"id"})
+	azureDevOpsUpdateTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "src",
+		Subsystem: "repoupdater",
+		Name:      "time_last_azuredevops_sync",
+		Help:      "The last time a comprehensive Azure DevOps sync finished",
+	}, []string{"id"})
 	bitbucketServerUpdateTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "src",
 		Subsystem: "repoupdater",
 		Name:      "time_last_bitbucketserver_sync",
 		Help:      "The last time a comprehensive Bitbucket Server sync finished",
 	}, []string{"id"})
+	bitbucketCloudUpdateTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "src",
+		Subsystem: "repoupdater",
+		Name:      "time_last_bitbucketcloud_sync",
+		Help:      "The last time a comprehensive Bitbucket Cloud sync finished",
+	}, []string{"id"})
+	gerritUpdateTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "src",
+		Subsystem: "repoupdater",
+		Name:      "time_last_gerrit_sync",
+		Help:      "The last time a comprehensive Gerrit sync finished",
+	}, []string{"id"})
+	giteaUpdateTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "src",
+		Subsystem: "repoupdater",
+		Name:      "time_last_gitea_sync",
+		Help:      "The last time a comprehensive Gitea sync finished",
+	}, []string{"id"})
 
 	// gitoliteUpdateTime is an ugly duckling as repo-updater just
 	// hits a HTTP endpoint on the frontend that returns after
@@ -271,13 +271,12 @@ func (s *OtherReposSyncer) upsert(ctx context.Context, repo *protocol.RepoInfo)
 		return err
 	}
 
-	return s.api.ReposUpdateMetadata(
-		ctx,
-		repo.Name,
-		repo.Description,
-		repo.Fork,
-		repo.Archived,
-	)
+	return s.api.ReposUpdateMetadata(ctx, api.ReposUpdateMetadataRequest{
+		RepoName:    repo.Name,
+		Description: repo.Description,
+		Fork:        repo.Fork,
+		Archived:    repo.Archived,
+	})
 }
 
 var otherRepoNameReplacer = strings.NewReplacer(":", "-", "@", "-", "//", "")
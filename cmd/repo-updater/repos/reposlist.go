@@ -456,7 +456,7 @@ func (r *repoList) doUpdate(ctx context.Context, repo *repoData) {
 		if manual {
 			interval = 5 * time.Second
 		}
-		resp, err = gitserver.DefaultClient.RequestRepoUpdate(ctx, gitserver.Repo{Name: repoName, URL: url}, interval)
+		resp, err = requestGitserverRepoUpdate(ctx, gitserver.Repo{Name: repoName, URL: url}, interval)
 		if err != nil {
 			log15.Warn("error requesting repo update", "repo", repoName, "err", err)
 			return
@@ -719,8 +719,8 @@ func (r *repoList) updateSource(source string, newList sourceRepoList) (enqueued
 	return enqueued, dequeued
 }
 
-// Scheduler schedules repo updates.
-var Scheduler = newUpdateScheduler()
+// Scheduler schedules repo updates. Its schedule is persisted in redis.
+var Scheduler = newUpdateScheduler(newRedisScheduleStore())
 
 // schedulerConfig tracks the active scheduler configuration.
 type schedulerConfig struct {
@@ -3,7 +3,9 @@ package repos
 import (
 	"container/heap"
 	"context"
+	"net/url"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,7 +39,10 @@ const (
 // When it is time for a repo to update, the scheduler inserts the repo into a queue.
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
-// is limited by the gitMaxConcurrentClones site configuration.
+// is limited by the gitMaxConcurrentClones site configuration. The number of concurrent updates
+// of repos from a single code host is additionally limited by the
+// gitMaxConcurrentClonesPerCodeHost site configuration, so that one busy code host doesn't
+// starve the updates of repos from other code hosts.
 type updateScheduler struct {
 	mu sync.Mutex
 
@@ -47,6 +52,9 @@ type updateScheduler struct {
 
 	updateQueue *updateQueue
 	schedule    *schedule
+
+	// store persists the schedule across restarts. It is nil if the schedule isn't persisted.
+	store scheduleStore
 }
 
 // A configuredRepo2 represents the configuration data for a given repo from
@@ -66,18 +74,20 @@ type sourceRepoMap map[api.RepoName]*configuredRepo2
 // non-blocking sends.
 const notifyChanBuffer = 1
 
-// newUpdateScheduler returns a new scheduler.
-func newUpdateScheduler() *updateScheduler {
+// newUpdateScheduler returns a new scheduler that persists its schedule in store (if non-nil).
+func newUpdateScheduler(store scheduleStore) *updateScheduler {
 	return &updateScheduler{
 		sourceRepos: make(map[string]sourceRepoMap),
 		updateQueue: &updateQueue{
-			index:         make(map[api.RepoName]*repoUpdate),
-			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
+			index:           make(map[api.RepoName]*repoUpdate),
+			updatingPerHost: make(map[string]int),
+			notifyEnqueue:   make(chan struct{}, notifyChanBuffer),
 		},
 		schedule: &schedule{
 			index:  make(map[api.RepoName]*scheduledRepoUpdate),
 			wakeup: make(chan struct{}, notifyChanBuffer),
 		},
+		store: store,
 	}
 }
 
@@ -117,6 +127,7 @@ func (s *updateScheduler) runSchedule() {
 // runUpdateLoop sends repo update requests to gitserver.
 func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 	limiter := configuredLimiter()
+	watchMaxUpdatingPerHost(s.updateQueue.setMaxUpdatingPerHost)
 
 	for {
 		select {
@@ -154,6 +165,11 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
 					s.schedule.updateInterval(repo, interval)
 				}
+				if state := s.schedule.recordUpdate(repo, resp, err); state != nil && s.store != nil {
+					if err := s.store.save(repo.Name, state); err != nil {
+						log15.Warn("error persisting repo update schedule", "uri", repo.Name, "err", err)
+					}
+				}
 			}(ctx, repo, cancel)
 		}
 	}
@@ -161,7 +177,17 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 
 // requestRepoUpdate sends a request to gitserver to request an update.
 var requestRepoUpdate = func(ctx context.Context, repo *configuredRepo2, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
-	return gitserver.DefaultClient.RequestRepoUpdate(ctx, gitserver.Repo{Name: repo.Name, URL: repo.URL}, since)
+	return requestGitserverRepoUpdate(ctx, gitserver.Repo{Name: repo.Name, URL: repo.URL}, since)
+}
+
+// requestGitserverRepoUpdate requests an update of the repository from gitserver, along with the
+// fetch options of the repository's code host connection (such as whether to fetch Gerrit change
+// refs).
+func requestGitserverRepoUpdate(ctx context.Context, repo gitserver.Repo, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
+	if fetch, ok := getGerritFetchChangeRefs(repo.Name); ok {
+		return gitserver.DefaultClient.RequestRepoUpdateFetchChangeRefs(ctx, repo, since, fetch)
+	}
+	return gitserver.DefaultClient.RequestRepoUpdate(ctx, repo, since)
 }
 
 // configuredLimiter returns a mutable limiter that is
@@ -179,16 +205,62 @@ var configuredLimiter = func() *mutablelimiter.Limiter {
 	return limiter
 }
 
+// watchMaxUpdatingPerHost calls set with the configured maximum number of concurrent update
+// requests for repos from a single code host, and again whenever the configuration changes.
+var watchMaxUpdatingPerHost = func(set func(int)) {
+	conf.Watch(func() {
+		set(conf.Get().GitMaxConcurrentClonesPerCodeHost)
+	})
+}
+
 // updateSource updates the list of configured repos associated with the given source.
 // This is the source of truth for what repos exist in the schedule.
+//
+// The persisted schedule is loaded and removed outside of s.mu, because s.mu guards the
+// scheduler's hot paths and the store does network I/O.
 func (s *updateScheduler) updateSource(source string, newList sourceRepoMap) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
 	log15.Debug("updating configured repos", "source", source, "count", len(newList))
-	if s.sourceRepos[source] == nil {
-		s.sourceRepos[source] = sourceRepoMap{}
+
+	// Load the persisted schedule of newly enabled repos. The source's list is replaced (never
+	// mutated) below, so it is safe to read oldList after releasing s.mu. If the list changes in
+	// the meantime, repos whose state wasn't loaded are simply scheduled as new repos.
+	var states map[api.RepoName]*scheduleState
+	if s.store != nil {
+		s.mu.Lock()
+		oldList := s.sourceRepos[source]
+		s.mu.Unlock()
+
+		var names []api.RepoName
+		for key, updatedRepo := range newList {
+			if oldRepo := oldList[key]; updatedRepo.Enabled && (oldRepo == nil || !oldRepo.Enabled) {
+				names = append(names, updatedRepo.Name)
+			}
+		}
+		var err error
+		states, err = s.store.load(names)
+		if err != nil {
+			log15.Warn("error loading persisted repo update schedule", "source", source, "err", err)
+		}
 	}
 
+	removed := s.swapSource(source, newList, states)
+
+	if s.store != nil {
+		for _, name := range removed {
+			if err := s.store.remove(name); err != nil {
+				log15.Warn("error removing persisted repo update schedule", "uri", name, "err", err)
+			}
+		}
+	}
+}
+
+// swapSource replaces the list of configured repos associated with the given source, restoring
+// the schedule of newly enabled repos from states. It returns the names of the repos that were
+// removed from the schedule. It does no I/O.
+func (s *updateScheduler) swapSource(source string, newList sourceRepoMap, states map[api.RepoName]*scheduleState) (removed []api.RepoName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Remove repos that don't exist in the new list or are disabled in the new list.
 	oldList := s.sourceRepos[source]
 	for key, repo := range oldList {
@@ -196,6 +268,7 @@ func (s *updateScheduler) updateSource(source string, newList sourceRepoMap) {
 			s.schedule.remove(repo)
 			updating := false // don't immediately remove repos that are already updating; they will automatically get removed when the update finishes
 			s.updateQueue.remove(repo, updating)
+			removed = append(removed, repo.Name)
 		}
 	}
 
@@ -207,6 +280,12 @@ func (s *updateScheduler) updateSource(source string, newList sourceRepoMap) {
 
 		oldRepo := oldList[key]
 		if oldRepo == nil || !oldRepo.Enabled {
+			if state := states[updatedRepo.Name]; state != nil {
+				// The repo was updated before (e.g. before repo-updater restarted), so it doesn't
+				// need to be updated until its persisted schedule says so.
+				s.schedule.restore(updatedRepo, state)
+				continue
+			}
 			s.schedule.add(updatedRepo)
 			s.updateQueue.enqueue(updatedRepo, priorityLow)
 		} else {
@@ -218,6 +297,7 @@ func (s *updateScheduler) updateSource(source string, newList sourceRepoMap) {
 	s.sourceRepos[source] = newList
 
 	schedKnownRepos.Set(float64(len(newList)))
+	return removed
 }
 
 // UpdateOnce causes a single update of the given repository.
@@ -305,6 +385,15 @@ func (s *updateScheduler) ScheduleInfo(name api.RepoName) *protocol.RepoUpdateSc
 			Total:           len(s.schedule.index),
 			IntervalSeconds: int(update.Interval / time.Second),
 			Due:             update.Due,
+			Failures:        update.Failures,
+		}
+		if !update.LastFetched.IsZero() {
+			lastFetched := update.LastFetched
+			result.Schedule.LastFetched = &lastFetched
+		}
+		if !update.LastChanged.IsZero() {
+			lastChanged := update.LastChanged
+			result.Schedule.LastChanged = &lastChanged
 		}
 	}
 	s.schedule.mu.Unlock()
@@ -332,6 +421,13 @@ type updateQueue struct {
 
 	seq uint64
 
+	// updatingPerHost is the number of updating repos per code host (see codeHost).
+	updatingPerHost map[string]int
+
+	// maxUpdatingPerHost is the maximum number of repos from a single code host that may be
+	// updating at the same time. Zero means that there is no limit.
+	maxUpdatingPerHost int
+
 	// The queue performs a non-blocking send on this channel
 	// when a new value is enqueued so that the update loop
 	// can wake up if it is idle.
@@ -360,6 +456,7 @@ func (q *updateQueue) reset() {
 
 	q.heap = q.heap[:0]
 	q.index = map[api.RepoName]*repoUpdate{}
+	q.updatingPerHost = map[string]int{}
 	q.seq = 0
 	q.notifyEnqueue = make(chan struct{}, notifyChanBuffer)
 }
@@ -411,15 +508,65 @@ func (q *updateQueue) update(repo *configuredRepo2) {
 // remove removes the repo from the queue if the repo.Updating matches the updating argument.
 func (q *updateQueue) remove(repo *configuredRepo2, updating bool) {
 	q.mu.Lock()
-	if update := q.index[repo.Name]; update != nil && update.Updating == updating {
-		heap.Remove(q, update.Index)
+	defer q.mu.Unlock()
+	update := q.index[repo.Name]
+	if update == nil || update.Updating != updating {
+		return
+	}
+	heap.Remove(q, update.Index)
+	if update.Updating {
+		host := codeHost(update.Repo)
+		if q.updatingPerHost[host] > 1 {
+			q.updatingPerHost[host]--
+		} else {
+			delete(q.updatingPerHost, host)
+		}
+		if q.maxUpdatingPerHost > 0 {
+			// Repos from this code host may have been skipped by acquireNext.
+			notify(q.notifyEnqueue)
+		}
 	}
-	q.mu.Unlock()
+}
+
+// setMaxUpdatingPerHost sets the maximum number of repos from a single code host that may be
+// updating at the same time (zero means no limit).
+func (q *updateQueue) setMaxUpdatingPerHost(max int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if max == q.maxUpdatingPerHost {
+		return
+	}
+	q.maxUpdatingPerHost = max
+	notify(q.notifyEnqueue)
+}
+
+// hostAvailable reports whether another repo from host may start updating.
+// The caller must hold the lock on q.mu.
+func (q *updateQueue) hostAvailable(host string) bool {
+	return q.maxUpdatingPerHost <= 0 || q.updatingPerHost[host] < q.maxUpdatingPerHost
+}
+
+// codeHost returns the (lowercase) hostname of the code host that a repo is cloned from. It falls
+// back to the first component of the repo name, which is the hostname for most repos.
+func codeHost(repo *configuredRepo2) string {
+	if u, err := url.Parse(repo.URL); err == nil && u.Host != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	// Support SCP-style clone URLs (such as git@example.com:owner/repo).
+	if i := strings.Index(repo.URL, ":"); i > 0 && !strings.Contains(repo.URL[:i], "/") {
+		host := repo.URL[:i]
+		if j := strings.LastIndex(host, "@"); j >= 0 {
+			host = host[j+1:]
+		}
+		return strings.ToLower(host)
+	}
+	return strings.ToLower(strings.SplitN(string(repo.Name), "/", 2)[0])
 }
 
 // acquireNext acquires the next repo for update.
 // The acquired repo must be removed from the queue
 // when the update finishes (independent of success or failure).
+// Repos from code hosts that are at the per-host limit of updating repos are skipped.
 func (q *updateQueue) acquireNext() *configuredRepo2 {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -431,7 +578,25 @@ func (q *updateQueue) acquireNext() *configuredRepo2 {
 		// Everything in the queue is already updating.
 		return nil
 	}
+	host := codeHost(update.Repo)
+	if !q.hostAvailable(host) {
+		// Find the next repo in queue order whose code host is below the limit.
+		update = nil
+		for _, u := range q.heap {
+			if u.Updating || !q.hostAvailable(codeHost(u.Repo)) {
+				continue
+			}
+			if update == nil || q.Less(u.Index, update.Index) {
+				update = u
+			}
+		}
+		if update == nil {
+			return nil
+		}
+		host = codeHost(update.Repo)
+	}
 	update.Updating = true
+	q.updatingPerHost[host]++
 	heap.Fix(q, update.Index)
 	return update.Repo
 }
@@ -490,10 +655,13 @@ type schedule struct {
 
 // scheduledRepoUpdate is the update schedule for a single repo.
 type scheduledRepoUpdate struct {
-	Repo     *configuredRepo2 // the repo to update
-	Interval time.Duration    // how regularly the repo is updated
-	Due      time.Time        // the next time that the repo will be enqueued for a update
-	Index    int              `json:"-"` // the index in the heap
+	Repo        *configuredRepo2 // the repo to update
+	Interval    time.Duration    // how regularly the repo is updated
+	Due         time.Time        // the next time that the repo will be enqueued for a update
+	LastFetched time.Time        // the last time the repo was fetched (zero if unknown)
+	LastChanged time.Time        // the last time the repo changed (zero if unknown)
+	Failures    int              // the number of consecutive failed updates
+	Index       int              `json:"-"` // the index in the heap
 }
 
 // add adds a repo to the schedule.
@@ -511,6 +679,30 @@ func (s *schedule) add(repo *configuredRepo2) {
 	s.mu.Unlock()
 }
 
+// restore adds a repo to the schedule with its persisted state. The repo is due when its interval
+// has elapsed since it was last fetched.
+// It does nothing if the repo already exists in the schedule.
+func (s *schedule) restore(repo *configuredRepo2, state *scheduleState) {
+	s.mu.Lock()
+	if s.index[repo.Name] == nil {
+		interval := clampInterval(state.Interval)
+		due := timeNow().Add(minDelay)
+		if !state.LastFetched.IsZero() {
+			due = state.LastFetched.Add(interval)
+		}
+		heap.Push(s, &scheduledRepoUpdate{
+			Repo:        repo,
+			Interval:    interval,
+			Due:         due,
+			LastFetched: state.LastFetched,
+			LastChanged: state.LastChanged,
+			Failures:    state.Failures,
+		})
+		s.rescheduleTimer()
+	}
+	s.mu.Unlock()
+}
+
 // update updates the repo data in the schedule.
 // It does nothing if the repo is not in the schedule.
 func (s *schedule) update(repo *configuredRepo2) {
@@ -526,14 +718,7 @@ func (s *schedule) update(repo *configuredRepo2) {
 func (s *schedule) updateInterval(repo *configuredRepo2, interval time.Duration) {
 	s.mu.Lock()
 	if update := s.index[repo.Name]; update != nil {
-		switch {
-		case interval > maxDelay:
-			update.Interval = maxDelay
-		case interval < minDelay:
-			update.Interval = minDelay
-		default:
-			update.Interval = interval
-		}
+		update.Interval = clampInterval(interval)
 		update.Due = timeNow().Add(update.Interval)
 		log15.Debug("updated repo", "repo", repo.Name, "due", update.Due.Sub(timeNow()))
 		heap.Fix(s, update.Index)
@@ -542,6 +727,48 @@ func (s *schedule) updateInterval(repo *configuredRepo2, interval time.Duration)
 	s.mu.Unlock()
 }
 
+// recordUpdate records the result of an update of the repo in the schedule and returns the repo's
+// state to persist.
+// It does nothing and returns nil if the repo is not in the schedule.
+func (s *schedule) recordUpdate(repo *configuredRepo2, resp *gitserverprotocol.RepoUpdateResponse, err error) *scheduleState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update := s.index[repo.Name]
+	if update == nil {
+		return nil
+	}
+	if err != nil {
+		update.Failures++
+	} else {
+		update.Failures = 0
+	}
+	if resp != nil && resp.LastFetched != nil {
+		update.LastFetched = *resp.LastFetched
+	}
+	if resp != nil && resp.LastChanged != nil {
+		update.LastChanged = *resp.LastChanged
+	}
+	return &scheduleState{
+		LastFetched: update.LastFetched,
+		LastChanged: update.LastChanged,
+		Interval:    update.Interval,
+		Failures:    update.Failures,
+	}
+}
+
+// clampInterval returns the interval limited to the range [minDelay, maxDelay].
+func clampInterval(interval time.Duration) time.Duration {
+	switch {
+	case interval > maxDelay:
+		return maxDelay
+	case interval < minDelay:
+		return minDelay
+	default:
+		return interval
+	}
+}
+
 // remove removes a repo from the schedule.
 func (s *schedule) remove(repo *configuredRepo2) {
 	s.mu.Lock()
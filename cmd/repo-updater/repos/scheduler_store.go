@@ -0,0 +1,100 @@
+package repos
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/redispool"
+)
+
+// scheduleState is the state of a repo in the update schedule that is persisted across restarts of
+// repo-updater.
+type scheduleState struct {
+	LastFetched time.Time     // the last time the repo was fetched (zero if unknown)
+	LastChanged time.Time     // the last time the repo changed (zero if unknown)
+	Interval    time.Duration // how regularly the repo is updated
+	Failures    int           // the number of consecutive failed updates
+}
+
+// scheduleStore persists the update schedule, so that the update intervals the scheduler learned
+// aren't lost (and all repos fetched at once) when repo-updater restarts.
+type scheduleStore interface {
+	// load returns the persisted states of the given repos. Repos that have no persisted state
+	// are omitted.
+	load(names []api.RepoName) (map[api.RepoName]*scheduleState, error)
+
+	// save persists the state of a repo.
+	save(name api.RepoName, state *scheduleState) error
+
+	// remove removes the persisted state of a repo.
+	remove(name api.RepoName) error
+}
+
+// redisScheduleStore is a scheduleStore that stores the states of all repos in a single redis hash.
+type redisScheduleStore struct {
+	pool *redis.Pool
+	key  string
+}
+
+// scheduleStoreLoadBatchSize is the number of states that are loaded with a single HMGET.
+const scheduleStoreLoadBatchSize = 1000
+
+func newRedisScheduleStore() *redisScheduleStore {
+	return &redisScheduleStore{pool: redispool.Store, key: "repo-updater:schedule"}
+}
+
+func (s *redisScheduleStore) load(names []api.RepoName) (map[api.RepoName]*scheduleState, error) {
+	c := s.pool.Get()
+	defer c.Close()
+
+	states := make(map[api.RepoName]*scheduleState)
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > scheduleStoreLoadBatchSize {
+			batch = batch[:scheduleStoreLoadBatchSize]
+		}
+		names = names[len(batch):]
+
+		args := make([]interface{}, 0, len(batch)+1)
+		args = append(args, s.key)
+		for _, name := range batch {
+			args = append(args, string(name))
+		}
+		values, err := redis.ByteSlices(c.Do("HMGET", args...))
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			var state scheduleState
+			if err := json.Unmarshal(value, &state); err != nil {
+				return nil, err
+			}
+			states[batch[i]] = &state
+		}
+	}
+	return states, nil
+}
+
+func (s *redisScheduleStore) save(name api.RepoName, state *scheduleState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	c := s.pool.Get()
+	defer c.Close()
+	_, err = c.Do("HSET", s.key, string(name), b)
+	return err
+}
+
+func (s *redisScheduleStore) remove(name api.RepoName) error {
+	c := s.pool.Get()
+	defer c.Close()
+	_, err := c.Do("HDEL", s.key, string(name))
+	return err
+}
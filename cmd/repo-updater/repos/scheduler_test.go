@@ -3,6 +3,7 @@ package repos
 import (
 	"container/heap"
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -236,7 +237,7 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 
 			for _, call := range test.calls {
 				s.updateQueue.enqueue(&call.repo, call.priority)
@@ -397,7 +398,7 @@ func TestUpdateQueue_remove(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 			setupInitialQueue(s, test.initialQueue)
 
 			// Perform the removals.
@@ -419,12 +420,16 @@ func TestUpdateQueue_remove(t *testing.T) {
 func TestUpdateQueue_acquireNext(t *testing.T) {
 	a := &configuredRepo2{Name: "a", URL: "a.com"}
 	b := &configuredRepo2{Name: "b", URL: "b.com"}
+	x1 := &configuredRepo2{Name: "x.com/1", URL: "https://x.com/1"}
+	x2 := &configuredRepo2{Name: "x.com/2", URL: "https://x.com/2"}
+	y1 := &configuredRepo2{Name: "y.com/1", URL: "git@y.com:1"}
 
 	tests := []struct {
-		name           string
-		initialQueue   []*repoUpdate
-		acquireResults []*configuredRepo2
-		finalQueue     []*repoUpdate
+		name               string
+		maxUpdatingPerHost int
+		initialQueue       []*repoUpdate
+		acquireResults     []*configuredRepo2
+		finalQueue         []*repoUpdate
 	}{
 		{
 			name:           "acquire from empty queue returns nil",
@@ -462,6 +467,33 @@ func TestUpdateQueue_acquireNext(t *testing.T) {
 				{Repo: a, Updating: true, Seq: 1},
 			},
 		},
+		{
+			name:               "acquire skips repos from code hosts at the limit",
+			maxUpdatingPerHost: 1,
+			initialQueue: []*repoUpdate{
+				{Repo: x1, Seq: 1},
+				{Repo: x2, Seq: 2},
+				{Repo: y1, Seq: 3},
+			},
+			acquireResults: []*configuredRepo2{x1, y1, nil},
+			finalQueue: []*repoUpdate{
+				{Repo: x2, Seq: 2},
+				{Repo: x1, Updating: true, Seq: 1},
+				{Repo: y1, Updating: true, Seq: 3},
+			},
+		},
+		{
+			name: "acquire without a per-host limit",
+			initialQueue: []*repoUpdate{
+				{Repo: x1, Seq: 1},
+				{Repo: x2, Seq: 2},
+			},
+			acquireResults: []*configuredRepo2{x1, x2, nil},
+			finalQueue: []*repoUpdate{
+				{Repo: x1, Updating: true, Seq: 1},
+				{Repo: x2, Updating: true, Seq: 2},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -469,7 +501,8 @@ func TestUpdateQueue_acquireNext(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
+			s.updateQueue.maxUpdatingPerHost = test.maxUpdatingPerHost
 			setupInitialQueue(s, test.initialQueue)
 
 			// Test aquireNext.
@@ -624,7 +657,7 @@ func TestSchedule_add(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 			setupInitialSchedule(s, test.initialSchedule)
 
 			for _, call := range test.addCalls {
@@ -802,7 +835,7 @@ func TestSchedule_updateInterval(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 			setupInitialSchedule(s, test.initialSchedule)
 
 			for _, call := range test.updateCalls {
@@ -900,7 +933,7 @@ func TestSchedule_remove(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 			setupInitialSchedule(s, test.initialSchedule)
 
 			for _, call := range test.removeCalls {
@@ -1062,7 +1095,7 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 
 			setupInitialSchedule(s, test.initialSchedule)
 
@@ -1150,7 +1183,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 				},
 			},
 			finalSchedule: []*scheduledRepoUpdate{
-				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute)},
+				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute), LastFetched: defaultTime.Add(2 * time.Minute), LastChanged: defaultTime},
 			},
 			timeAfterFuncDelays: []time.Duration{time.Minute},
 			expectedNotifications: func(s *updateScheduler) []chan struct{} {
@@ -1170,6 +1203,8 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 			defer func() {
 				configuredLimiter = nil
 			}()
+			watchMaxUpdatingPerHost = func(func(int)) {}
+			defer func() { watchMaxUpdatingPerHost = nil }()
 
 			expectedRequestCount := len(test.mockRequestRepoUpdates)
 			mockRequestRepoUpdates := make(chan *mockRequestRepoUpdate, expectedRequestCount)
@@ -1193,7 +1228,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 			}
 			defer func() { requestRepoUpdate = nil }()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 
 			// unbuffer the channel
 			s.updateQueue.notifyEnqueue = make(chan struct{})
@@ -1547,7 +1582,7 @@ func TestUpdateScheduler_updateSource(t *testing.T) {
 			r, stop := startRecording()
 			defer stop()
 
-			s := newUpdateScheduler()
+			s := newUpdateScheduler(nil)
 			s.sourceRepos = test.initialSourceRepos
 			setupInitialSchedule(s, test.initialSchedule)
 			setupInitialQueue(s, test.initialQueue)
@@ -1568,3 +1603,172 @@ func TestUpdateScheduler_updateSource(t *testing.T) {
 }
 
 // TODO: update enabled state and url once in the queue?
+
+// memScheduleStore is a scheduleStore that stores states in memory.
+type memScheduleStore map[api.RepoName]*scheduleState
+
+func (s memScheduleStore) load(names []api.RepoName) (map[api.RepoName]*scheduleState, error) {
+	states := map[api.RepoName]*scheduleState{}
+	for _, name := range names {
+		if state, ok := s[name]; ok {
+			states[name] = state
+		}
+	}
+	return states, nil
+}
+
+func (s memScheduleStore) save(name api.RepoName, state *scheduleState) error {
+	s[name] = state
+	return nil
+}
+
+func (s memScheduleStore) remove(name api.RepoName) error {
+	delete(s, name)
+	return nil
+}
+
+func TestUpdateScheduler_persistedSchedule(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	a := &configuredRepo2{Name: "a", URL: "a.com", Enabled: true}
+	b := &configuredRepo2{Name: "b", URL: "b.com", Enabled: true}
+	store := memScheduleStore{
+		"a": {LastFetched: defaultTime.Add(-time.Hour), LastChanged: defaultTime.Add(-5 * time.Hour), Interval: 2 * time.Hour, Failures: 1},
+	}
+	s := newUpdateScheduler(store)
+
+	// a is restored from the store and not enqueued for an immediate update, b is new.
+	s.updateSource("s", sourceRepoMap{"a": a, "b": b})
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: b, Interval: minDelay, Due: defaultTime.Add(minDelay)},
+		{Repo: a, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour), LastFetched: defaultTime.Add(-time.Hour), LastChanged: defaultTime.Add(-5 * time.Hour), Failures: 1},
+	})
+	verifyQueue(t, s, []*repoUpdate{
+		{Repo: b, Seq: 1},
+	})
+
+	// Removing a repo from its source removes its persisted state.
+	s.updateSource("s", sourceRepoMap{"b": b})
+	if _, ok := store["a"]; ok {
+		t.Error("persisted state of removed repo a was not removed")
+	}
+}
+
+// unlockedScheduleStore is a scheduleStore that checks that the scheduler's mutex is not held
+// while the store is accessed.
+type unlockedScheduleStore struct {
+	memScheduleStore
+	t *testing.T
+	s *updateScheduler
+}
+
+func (s *unlockedScheduleStore) checkUnlocked(method string) {
+	locked := make(chan struct{})
+	go func() {
+		s.s.mu.Lock()
+		s.s.mu.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		s.t.Errorf("scheduler mutex held during %s", method)
+	}
+}
+
+func (s *unlockedScheduleStore) load(names []api.RepoName) (map[api.RepoName]*scheduleState, error) {
+	s.checkUnlocked("load")
+	return s.memScheduleStore.load(names)
+}
+
+func (s *unlockedScheduleStore) remove(name api.RepoName) error {
+	s.checkUnlocked("remove")
+	return s.memScheduleStore.remove(name)
+}
+
+func TestUpdateScheduler_updateSourceDoesNotHoldLockDuringStoreIO(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	a := &configuredRepo2{Name: "a", URL: "a.com", Enabled: true}
+	b := &configuredRepo2{Name: "b", URL: "b.com", Enabled: true}
+	store := &unlockedScheduleStore{
+		memScheduleStore: memScheduleStore{
+			"a": {LastFetched: defaultTime.Add(-time.Hour), LastChanged: defaultTime.Add(-5 * time.Hour), Interval: 2 * time.Hour},
+		},
+		t: t,
+	}
+	s := newUpdateScheduler(store)
+	store.s = s
+
+	s.updateSource("s", sourceRepoMap{"a": a, "b": b})
+	s.updateSource("s", sourceRepoMap{"b": b})
+	if _, ok := store.memScheduleStore["a"]; ok {
+		t.Error("persisted state of removed repo a was not removed")
+	}
+}
+
+func TestSchedule_recordUpdate(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	a := &configuredRepo2{Name: "a", URL: "a.com"}
+	s := newUpdateScheduler(nil)
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour), Failures: 2},
+	})
+
+	if state := s.schedule.recordUpdate(&configuredRepo2{Name: "b"}, nil, nil); state != nil {
+		t.Errorf("got state %+v for repo that is not in the schedule, want nil", state)
+	}
+
+	state := s.schedule.recordUpdate(a, nil, errors.New("x"))
+	if want := (&scheduleState{Interval: time.Hour, Failures: 3}); !reflect.DeepEqual(state, want) {
+		t.Errorf("got state %+v after failed update, want %+v", state, want)
+	}
+
+	resp := &gitserverprotocol.RepoUpdateResponse{LastFetched: timePtr(defaultTime), LastChanged: timePtr(defaultTime.Add(-time.Hour))}
+	state = s.schedule.recordUpdate(a, resp, nil)
+	if want := (&scheduleState{LastFetched: defaultTime, LastChanged: defaultTime.Add(-time.Hour), Interval: time.Hour}); !reflect.DeepEqual(state, want) {
+		t.Errorf("got state %+v after successful update, want %+v", state, want)
+	}
+}
+
+func TestUpdateQueue_removeUpdatingReleasesHost(t *testing.T) {
+	x1 := &configuredRepo2{Name: "x.com/1", URL: "https://x.com/1"}
+	x2 := &configuredRepo2{Name: "x.com/2", URL: "https://x.com/2"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := newUpdateScheduler(nil)
+	s.updateQueue.maxUpdatingPerHost = 1
+	s.updateQueue.enqueue(x1, priorityLow)
+	s.updateQueue.enqueue(x2, priorityLow)
+
+	if got := s.updateQueue.acquireNext(); got != x1 {
+		t.Fatalf("got %v, want %v", got, x1)
+	}
+	if got := s.updateQueue.acquireNext(); got != nil {
+		t.Fatalf("got %v, want nil (x.com is at the limit)", got)
+	}
+	s.updateQueue.remove(x1, true)
+	if got := s.updateQueue.acquireNext(); got != x2 {
+		t.Fatalf("got %v, want %v", got, x2)
+	}
+}
+
+func TestCodeHost(t *testing.T) {
+	tests := map[string]*configuredRepo2{
+		"github.com":      {Name: "github.com/a/b", URL: "https://token@GitHub.com/a/b"},
+		"gitolite.my.org": {Name: "gitolite.my.org/a", URL: "git@gitolite.my.org:a"},
+		"example.com":     {Name: "example.com/a", URL: "ssh://git@example.com:2222/a"},
+		"a":               {Name: "a", URL: "a.com"},
+	}
+	for want, repo := range tests {
+		if got := codeHost(repo); got != want {
+			t.Errorf("%s: got %q, want %q", repo.URL, got, want)
+		}
+	}
+}
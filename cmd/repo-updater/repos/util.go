@@ -62,17 +62,28 @@ func cachedTransportWithCertTrusted(cert string) (http.RoundTripper, error) {
 type repoCreateOrUpdateRequest struct {
 	api.RepoCreateOrUpdateRequest
 	URL string // the repository's Git remote URL
+
+	// Metadata from the code host that is only stored when updating the
+	// repository's metadata (see api.ReposUpdateMetadataRequest).
+	Topics        []string
+	Stars         int
+	DefaultBranch string
+	Visibility    string
 }
 
 // createEnableUpdateRepos receives requests on the provided channel. The
 // source argument should be a distinctive string identifying the configuration
 // being updated, so repo-updater can detect when repositories are dropped from
-// a given source.
-func createEnableUpdateRepos(ctx context.Context, source string, repoChan <-chan repoCreateOrUpdateRequest) {
+// a given source. If lookup is non-nil, repositories that the previous sync
+// of the source listed but this one doesn't are looked up with it, and those
+// that were deleted or renamed on their code host are marked as deleted or
+// renamed (see handleDroppedRepos).
+func createEnableUpdateRepos(ctx context.Context, source string, lookup droppedRepoLookup, repoChan <-chan repoCreateOrUpdateRequest) {
 	c := conf.Get()
 	newList := make(sourceRepoList)
 	newScheduler := newSchedulerEnabled(c)
 	newMap := make(sourceRepoMap)
+	listed := make(map[api.RepoName]*api.ExternalRepoSpec)
 
 	do := func(op repoCreateOrUpdateRequest) {
 		if op.RepoCreateOrUpdateRequest.RepoName == "" {
@@ -84,8 +95,18 @@ func createEnableUpdateRepos(ctx context.Context, source string, repoChan <-chan
 			log15.Warn("Error creating or updating repository", "repo", op.RepoName, "error", err)
 			return
 		}
-
-		err = api.InternalClient.ReposUpdateMetadata(ctx, op.RepoName, op.Description, op.Fork, op.Archived)
+		listed[createdRepo.Name] = op.ExternalRepo
+
+		err = api.InternalClient.ReposUpdateMetadata(ctx, api.ReposUpdateMetadataRequest{
+			RepoName:      op.RepoName,
+			Description:   op.Description,
+			Fork:          op.Fork,
+			Archived:      op.Archived,
+			Topics:        op.Topics,
+			Stars:         op.Stars,
+			DefaultBranch: op.DefaultBranch,
+			Visibility:    op.Visibility,
+		})
 		if err != nil {
 			log15.Warn("Error updating repository metadata", "repo", op.RepoName, "error", err)
 			return
@@ -106,6 +127,14 @@ func createEnableUpdateRepos(ctx context.Context, source string, repoChan <-chan
 		do(repo)
 	}
 
+	if lookup != nil {
+		if dropped, err := listDroppedRepos(source, listed); err != nil {
+			log15.Warn("Error listing repositories that are no longer listed by their code host", "source", source, "error", err)
+		} else {
+			handleDroppedRepos(ctx, source, lookup, dropped)
+		}
+	}
+
 	if !newScheduler {
 		repos.updateSource(source, newList)
 	} else if !c.DisableAutoGitUpdates {
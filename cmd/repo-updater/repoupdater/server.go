@@ -148,6 +148,10 @@ func (s *Server) repoLookup(ctx context.Context, args protocol.RepoLookupArgs) (
 		repos.GetGitHubRepository,
 		repos.GetGitLabRepository,
 		repos.GetBitbucketServerRepository,
+		repos.GetBitbucketCloudRepository,
+		repos.GetGerritRepository,
+		repos.GetGiteaRepository,
+		repos.GetAzureDevOpsRepository,
 		repos.GetAWSCodeCommitRepository,
 		repos.GetGitoliteRepository,
 	} {
@@ -172,7 +176,16 @@ func (s *Server) repoLookup(ctx context.Context, args protocol.RepoLookupArgs) (
 		}
 		if repo != nil {
 			go func() {
-				err := api.InternalClient.ReposUpdateMetadata(context.Background(), repo.Name, repo.Description, repo.Fork, repo.Archived)
+				err := api.InternalClient.ReposUpdateMetadata(context.Background(), api.ReposUpdateMetadataRequest{
+					RepoName:      repo.Name,
+					Description:   repo.Description,
+					Fork:          repo.Fork,
+					Archived:      repo.Archived,
+					Topics:        repo.Topics,
+					Stars:         repo.Stars,
+					DefaultBranch: repo.DefaultBranch,
+					Visibility:    repo.Visibility,
+				})
 				if err != nil {
 					log15.Warn("Error updating repo metadata", "repo", repo.Name, "err", err)
 				}
@@ -226,6 +226,9 @@ func TestRepoLookup(t *testing.T) {
 					Name:        "github.com/c/d",
 					Description: "b",
 					Fork:        true,
+					Topics:      []string{"t"},
+					Stars:       3,
+					Visibility:  api.RepoVisibilityPublic,
 				},
 			}
 
@@ -259,6 +262,9 @@ func TestRepoLookup(t *testing.T) {
 					Description: want.Repo.Description,
 					Fork:        want.Repo.Fork,
 					Archived:    want.Repo.Archived,
+					Topics:      want.Repo.Topics,
+					Stars:       want.Repo.Stars,
+					Visibility:  want.Repo.Visibility,
 				}
 				if !reflect.DeepEqual(got, want2) {
 					t.Errorf("got %+v, want %+v", got, want2)
@@ -53,7 +53,9 @@ func getOrCreateUser(ctx context.Context, p *provider, idToken *oidc.IDToken, us
 		IDToken    *oidc.IDToken  `json:"idToken"`
 		UserInfo   *oidc.UserInfo `json:"userInfo"`
 		UserClaims *userClaims    `json:"userClaims"`
-	}{IDToken: idToken, UserInfo: userInfo, UserClaims: claims})
+		// Groups is read by the groups authz provider to enforce repository permissions.
+		Groups []string `json:"groups,omitempty"`
+	}{IDToken: idToken, UserInfo: userInfo, UserClaims: claims, Groups: userGroups(p, idToken, userInfo)})
 
 	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, auth.GetAndSaveUserOp{
 		UserProps: db.NewUser{
@@ -77,3 +79,42 @@ func getOrCreateUser(ctx context.Context, p *provider, idToken *oidc.IDToken, us
 	}
 	return actor.FromUser(userID), "", nil
 }
+
+// userGroups returns the groups in the configured groups claim of the ID token or, if the ID token
+// has no such claim, of the user info.
+func userGroups(p *provider, idToken *oidc.IDToken, userInfo *oidc.UserInfo) []string {
+	name := p.config.GroupsClaim
+	if name == "" {
+		name = "groups"
+	}
+	for _, src := range []interface{ Claims(interface{}) error }{idToken, userInfo} {
+		var claims map[string]interface{}
+		if err := src.Claims(&claims); err != nil {
+			continue
+		}
+		if groups, ok := claimGroups(claims[name]); ok {
+			return groups
+		}
+	}
+	return nil
+}
+
+// claimGroups returns the groups in the value of a groups claim, which is either an array of
+// strings or a single string. It returns false if the value is not a valid groups claim.
+func claimGroups(v interface{}) (groups []string, ok bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, g := range v {
+			if g, ok := g.(string); ok && g != "" {
+				groups = append(groups, g)
+			}
+		}
+		return groups, true
+	case string:
+		if v != "" {
+			groups = []string{v}
+		}
+		return groups, true
+	}
+	return nil, false
+}
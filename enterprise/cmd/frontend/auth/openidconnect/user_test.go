@@ -0,0 +1,26 @@
+package openidconnect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClaimGroups(t *testing.T) {
+	tests := []struct {
+		claim      interface{}
+		wantGroups []string
+		wantOK     bool
+	}{
+		{claim: []interface{}{"a", "", 3, "b"}, wantGroups: []string{"a", "b"}, wantOK: true},
+		{claim: "a", wantGroups: []string{"a"}, wantOK: true},
+		{claim: "", wantOK: true},
+		{claim: nil},
+		{claim: map[string]interface{}{"a": true}},
+	}
+	for _, test := range tests {
+		groups, ok := claimGroups(test.claim)
+		if !reflect.DeepEqual(groups, test.wantGroups) || ok != test.wantOK {
+			t.Errorf("claim %v: got %q, %v, want %q, %v", test.claim, groups, ok, test.wantGroups, test.wantOK)
+		}
+	}
+}
@@ -18,6 +18,7 @@ type authnResponseInfo struct {
 	spec                 extsvc.ExternalAccountSpec
 	email, displayName   string
 	unnormalizedUsername string
+	groups               []string
 	accountData          interface{}
 }
 
@@ -57,6 +58,11 @@ func readAuthnResponse(p *provider, encodedResp string) (*authnResponseInfo, err
 	if pn := attr.Get("eduPersonPrincipalName"); email == "" && mightBeEmail(pn) {
 		email = pn
 	}
+	groupsAttributeName := p.config.GroupsAttributeName
+	if groupsAttributeName == "" {
+		groupsAttributeName = "groups"
+	}
+	groups := attr.GetAll(groupsAttributeName)
 	info := authnResponseInfo{
 		spec: extsvc.ExternalAccountSpec{
 			ServiceType: providerType,
@@ -67,7 +73,12 @@ func readAuthnResponse(p *provider, encodedResp string) (*authnResponseInfo, err
 		email:                email,
 		unnormalizedUsername: firstNonempty(attr.Get("login"), attr.Get("uid"), email),
 		displayName:          firstNonempty(attr.Get("displayName"), attr.Get("givenName")+" "+attr.Get("surname")),
-		accountData:          assertions,
+		groups:               groups,
+		accountData: struct {
+			*saml2.AssertionInfo
+			// Groups is read by the groups authz provider to enforce repository permissions.
+			Groups []string `json:"groups,omitempty"`
+		}{AssertionInfo: assertions, Groups: groups},
 	}
 	if assertions.NameID == "" {
 		return nil, errors.New("the SAML response did not contain a valid NameID")
@@ -125,3 +136,19 @@ func (v samlAssertionValues) Get(key string) string {
 	}
 	return ""
 }
+
+// GetAll returns all values of the attribute, which is how multi-valued attributes (such as group
+// memberships) are represented.
+func (v samlAssertionValues) GetAll(key string) []string {
+	var values []string
+	for _, a := range v {
+		if a.Name == key || a.FriendlyName == key {
+			for _, value := range a.Values {
+				if value := strings.TrimSpace(value.Value); value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	return values
+}
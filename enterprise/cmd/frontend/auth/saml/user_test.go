@@ -9,6 +9,7 @@ import (
 	"time"
 
 	saml2 "github.com/russellhaering/gosaml2"
+	"github.com/russellhaering/gosaml2/types"
 	dsig "github.com/russellhaering/goxmldsig"
 	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
 )
@@ -46,6 +47,19 @@ func TestReadAuthnResponse(t *testing.T) {
 	}
 }
 
+func TestSAMLAssertionValues_GetAll(t *testing.T) {
+	values := samlAssertionValues{
+		"urn:oid:1": {Name: "urn:oid:1", FriendlyName: "groups", Values: []types.AttributeValue{{Value: "a"}, {Value: " "}, {Value: "b "}}},
+		"email":     {Name: "email", Values: []types.AttributeValue{{Value: "alice@example.com"}}},
+	}
+	if got, want := values.GetAll("groups"), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := values.GetAll("memberOf"); got != nil {
+		t.Errorf("got %q, want nil", got)
+	}
+}
+
 var (
 	idpCert2 = func() *x509.Certificate {
 		b, _ := pem.Decode([]byte(`-----BEGIN CERTIFICATE-----
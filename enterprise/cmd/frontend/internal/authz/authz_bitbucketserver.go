@@ -0,0 +1,82 @@
+package authz
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	permbbs "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/authz/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/pkg/ratelimit"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func bitbucketServerProviders(ctx context.Context) (
+	authzProviders []authz.Provider,
+	seriousProblems []string,
+	warnings []string,
+) {
+	bitbucketServers, err := db.ExternalServices.ListBitbucketServerConnections(ctx)
+	if err != nil {
+		seriousProblems = append(seriousProblems, fmt.Sprintf("Could not load Bitbucket Server external service configs: %s", err))
+		return
+	}
+
+	for _, b := range bitbucketServers {
+		p, err := bitbucketServerProvider(b)
+		if err != nil {
+			seriousProblems = append(seriousProblems, err.Error())
+			continue
+		}
+		if p != nil {
+			authzProviders = append(authzProviders, p)
+		}
+	}
+	return authzProviders, seriousProblems, warnings
+}
+
+func bitbucketServerProvider(b *schema.BitbucketServerConnection) (authz.Provider, error) {
+	if b.Authorization == nil {
+		return nil, nil
+	}
+
+	baseURL, err := url.Parse(b.Url)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse URL for Bitbucket Server instance %q: %s", b.Url, err)
+	}
+
+	ttl, err := parseTTL(b.Authorization.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if b.Certificate != "" {
+		certPool := x509.NewCertPool()
+		if ok := certPool.AppendCertsFromPEM([]byte(b.Certificate)); !ok {
+			return nil, fmt.Errorf("Invalid certificate for Bitbucket Server instance %q", b.Url)
+		}
+		transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}
+	}
+
+	client := &bitbucketserver.Client{
+		URL:      baseURL,
+		Token:    b.Token,
+		Username: b.Username,
+		Password: b.Password,
+		HTTPClient: &http.Client{
+			Transport: bitbucketserver.WithRequestCounter(&nethttp.Transport{RoundTripper: transport}),
+		},
+		// Providers are recreated whenever the configuration is reloaded, so the rate limiter
+		// and monitor must be shared between them.
+		RateLimit:        ratelimit.DefaultRegistry.Limiter("bitbucketserver:"+baseURL.String(), 2, 500),
+		RateLimitMonitor: ratelimit.DefaultRegistry.Monitor("bitbucketserver:"+baseURL.String(), ""),
+	}
+	return permbbs.NewProvider(client, ttl, nil), nil
+}
@@ -0,0 +1,56 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/authz/groups"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// groupsProviders returns an authz provider for each SAML and OpenID Connect auth provider that
+// has group-based authorization configured.
+func groupsProviders(ctx context.Context) (
+	authzProviders []authz.Provider,
+	seriousProblems []string,
+	warnings []string,
+) {
+	for _, p := range auth.Providers() {
+		serviceType, authorization := groupsAuthorization(p.Config())
+		if authorization == nil {
+			continue
+		}
+
+		// The service ID of the auth provider (which is the service ID of the external accounts
+		// that hold the users' groups) is only known once the auth provider has been
+		// initialized. Until then, the repositories it would restrict access to must not be
+		// readable by default.
+		info := p.CachedInfo()
+		if info == nil || info.ServiceID == "" {
+			seriousProblems = append(seriousProblems, fmt.Sprintf("Could not enforce group-based repository permissions of %s auth provider (it is not yet initialized).", serviceType))
+			continue
+		}
+
+		gp, err := groups.NewProvider(serviceType, info.ServiceID, authorization.Groups)
+		if err != nil {
+			seriousProblems = append(seriousProblems, fmt.Sprintf("Could not create group-based authz provider for %s auth provider %q: %s", serviceType, info.ServiceID, err))
+			continue
+		}
+		authzProviders = append(authzProviders, gp)
+	}
+	return authzProviders, seriousProblems, warnings
+}
+
+// groupsAuthorization returns the service type of the auth provider and its group-based
+// authorization config, which is nil if the auth provider has none.
+func groupsAuthorization(c schema.AuthProviders) (serviceType string, authorization *schema.GroupsAuthorization) {
+	switch {
+	case c.Saml != nil:
+		return "saml", c.Saml.Authorization
+	case c.Openidconnect != nil:
+		return "openidconnect", c.Openidconnect.Authorization
+	}
+	return "", nil
+}
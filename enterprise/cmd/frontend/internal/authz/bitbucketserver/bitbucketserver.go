@@ -0,0 +1,360 @@
+// Package bitbucketserver contains an authorization provider for Bitbucket Server.
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/pkg/rcache"
+)
+
+// Provider implements authz.Provider for Bitbucket Server repository permissions.
+type Provider struct {
+	client   *bitbucketserver.Client
+	codeHost *bitbucketserver.CodeHost
+	cacheTTL time.Duration
+	cache    cache
+}
+
+// NewProvider returns a new Bitbucket Server authorization provider. The client must be
+// authenticated as a Bitbucket Server administrator, so that it can read the permissions of all
+// users, groups, projects and repositories.
+func NewProvider(client *bitbucketserver.Client, cacheTTL time.Duration, mockCache cache) *Provider {
+	p := &Provider{
+		client:   client,
+		codeHost: bitbucketserver.NewCodeHost(client.URL),
+		cacheTTL: cacheTTL,
+		cache:    mockCache,
+	}
+	// Note: this will use the same underlying Redis instance and key namespace for every instance
+	// of Provider.  This is by design, so that different instances, even in different processes,
+	// will share cache entries.
+	if p.cache == nil {
+		p.cache = rcache.NewWithTTL(fmt.Sprintf("bitbucketServerAuthz:%s", p.codeHost.ServiceID()), int(math.Ceil(cacheTTL.Seconds())))
+	}
+	return p
+}
+
+var _ authz.Provider = ((*Provider)(nil))
+
+// Repos implements the authz.Provider interface.
+func (p *Provider) Repos(ctx context.Context, repos map[authz.Repo]struct{}) (mine map[authz.Repo]struct{}, others map[authz.Repo]struct{}) {
+	return authz.GetCodeHostRepos(p.codeHost, repos)
+}
+
+// RepoPerms implements the authz.Provider interface.
+//
+// If the user's external account has an OAuth access token, the repositories that the user can
+// read are listed with that token. Otherwise, the user's permissions are computed from the
+// permissions that are granted on each repository and its project (to the user, to one of the
+// user's groups or to all users) and the user's global permissions, which are read with the
+// provider's administrator credentials. Both are cached for the provider's cache TTL.
+//
+// Users without an external account can only read public repositories.
+func (p *Provider) RepoPerms(ctx context.Context, account *extsvc.ExternalAccount, repos map[authz.Repo]struct{}) (map[api.RepoName]map[authz.Perm]bool, error) {
+	mine, _ := p.Repos(ctx, repos)
+	if len(mine) == 0 {
+		return nil, nil
+	}
+
+	var userSlug, token string
+	if account != nil && account.ServiceID == p.codeHost.ServiceID() && account.ServiceType == p.codeHost.ServiceType() {
+		_, tok, err := bitbucketserver.GetExternalAccountData(&account.ExternalAccountData)
+		if err != nil {
+			return nil, err
+		}
+		if tok != nil {
+			token = tok.AccessToken
+		}
+		userSlug = account.AccountID
+	}
+
+	perms := make(map[api.RepoName]map[authz.Perm]bool, len(mine))
+	if token != "" {
+		readable, err := p.tokenRepos(ctx, account.AccountID, token)
+		if err != nil {
+			return nil, err
+		}
+		for repo := range mine {
+			_, canRead := readable[repo.ExternalRepoSpec.ID]
+			perms[repo.RepoName] = map[authz.Perm]bool{authz.Read: canRead}
+		}
+		return perms, nil
+	}
+
+	repoPerms, err := p.repoPerms(ctx, mine)
+	if err != nil {
+		return nil, err
+	}
+	var user *userCacheVal
+	if userSlug != "" {
+		if user, err = p.userPerms(ctx, userSlug); err != nil {
+			return nil, err
+		}
+	}
+	for repo := range mine {
+		perms[repo.RepoName] = map[authz.Perm]bool{authz.Read: canRead(repoPerms[repo.ExternalRepoSpec.ID], userSlug, user)}
+	}
+	return perms, nil
+}
+
+// canRead reports whether the user (nil if the user has no Bitbucket Server account) can read the
+// repository with the given permissions.
+func canRead(repo *repoPermsCacheVal, userSlug string, user *userCacheVal) bool {
+	if repo == nil {
+		return false
+	}
+	if repo.Public {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	if user.Admin || repo.AllUsers {
+		return true
+	}
+	if _, ok := repo.Users[userSlug]; ok {
+		return true
+	}
+	for group := range user.Groups {
+		if _, ok := repo.Groups[group]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// repoPerms returns the permissions of the given repos, keyed by external repo ID. It consults and
+// updates the cache.
+func (p *Provider) repoPerms(ctx context.Context, repos map[authz.Repo]struct{}) (map[string]*repoPermsCacheVal, error) {
+	ids := make([]string, 0, len(repos))
+	keys := make([]string, 0, len(repos))
+	for repo := range repos {
+		ids = append(ids, repo.ExternalRepoSpec.ID)
+		keys = append(keys, repoPermsCacheKey(repo.ExternalRepoSpec.ID))
+	}
+
+	perms := make(map[string]*repoPermsCacheVal, len(repos))
+	var setArgs [][2]string
+	for i, v := range p.cache.GetMulti(keys...) {
+		if len(v) > 0 {
+			var val repoPermsCacheVal
+			if err := json.Unmarshal(v, &val); err != nil {
+				return nil, err
+			}
+			if p.cacheTTL >= val.TTL {
+				perms[ids[i]] = &val
+				continue
+			}
+			// if the cache TTL is now less than the cache entry TTL, invalidate that entry
+		}
+
+		val, err := p.fetchRepoPerms(ctx, ids[i])
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		perms[ids[i]] = val
+		setArgs = append(setArgs, [2]string{keys[i], string(b)})
+	}
+	if len(setArgs) > 0 {
+		p.cache.SetMulti(setArgs...)
+	}
+	return perms, nil
+}
+
+// fetchRepoPerms fetches the permissions of a repository from the Bitbucket Server API. The repo ID
+// is the external repo ID ("PROJECT/repo-slug").
+func (p *Provider) fetchRepoPerms(ctx context.Context, repoID string) (*repoPermsCacheVal, error) {
+	i := strings.Index(repoID, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid Bitbucket Server repository ID %q", repoID)
+	}
+	projectKey, repoSlug := repoID[:i], repoID[i+1:]
+
+	val := &repoPermsCacheVal{
+		Users:  map[string]struct{}{},
+		Groups: map[string]struct{}{},
+		TTL:    p.cacheTTL,
+	}
+	repo, err := p.client.Repo(ctx, projectKey, repoSlug)
+	if bitbucketserver.IsNotFound(err) {
+		return val, nil // nobody can read a repository that doesn't exist (anymore)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if repo.Public || (repo.Project != nil && repo.Project.Public) {
+		val.Public = true
+		return val, nil
+	}
+
+	// Every permission (read, write or admin) on a repository or its project includes read access.
+	add := func(perms []*bitbucketserver.Permission, err error) error {
+		if err != nil {
+			return err
+		}
+		for _, perm := range perms {
+			if perm.User != nil {
+				val.Users[perm.User.Slug] = struct{}{}
+			}
+			if perm.Group != nil {
+				val.Groups[perm.Group.Name] = struct{}{}
+			}
+		}
+		return nil
+	}
+	if err := add(p.client.RepoPermissions(ctx, projectKey, repoSlug, bitbucketserver.PermissionUsers)); err != nil {
+		return nil, err
+	}
+	if err := add(p.client.RepoPermissions(ctx, projectKey, repoSlug, bitbucketserver.PermissionGroups)); err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(projectKey, "~") {
+		// The owner of a personal project can read all of its repositories. Personal projects have
+		// no project permissions.
+		val.Users[strings.ToLower(projectKey[1:])] = struct{}{}
+		return val, nil
+	}
+	if err := add(p.client.ProjectPermissions(ctx, projectKey, bitbucketserver.PermissionUsers)); err != nil {
+		return nil, err
+	}
+	if err := add(p.client.ProjectPermissions(ctx, projectKey, bitbucketserver.PermissionGroups)); err != nil {
+		return nil, err
+	}
+	if val.AllUsers, err = p.client.ProjectDefaultPermission(ctx, projectKey, "PROJECT_READ"); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// userPerms returns the repository-independent permissions of a user. It consults and updates the
+// cache.
+func (p *Provider) userPerms(ctx context.Context, userSlug string) (*userCacheVal, error) {
+	key := userCacheKey(userSlug)
+	if b, ok := p.cache.Get(key); ok {
+		var val userCacheVal
+		if err := json.Unmarshal(b, &val); err != nil {
+			return nil, err
+		}
+		if p.cacheTTL >= val.TTL {
+			return &val, nil
+		}
+	}
+
+	val := &userCacheVal{Groups: map[string]struct{}{}, TTL: p.cacheTTL}
+	globalPerms, err := p.client.GlobalPermissions(ctx, bitbucketserver.PermissionUsers, userSlug)
+	if err != nil {
+		return nil, err
+	}
+	for _, perm := range globalPerms {
+		// The filter also matches other users whose name contains the user's slug.
+		if perm.User != nil && perm.User.Slug == userSlug && (perm.Permission == "ADMIN" || perm.Permission == "SYS_ADMIN") {
+			val.Admin = true
+		}
+	}
+	groups, err := p.client.UserGroups(ctx, userSlug)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		val.Groups[group] = struct{}{}
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.Set(key, b)
+	return val, nil
+}
+
+// tokenRepos returns the set of IDs of the repositories that the user with the given access token
+// can read. It consults and updates the cache.
+func (p *Provider) tokenRepos(ctx context.Context, accountID, token string) (map[string]struct{}, error) {
+	key := tokenReposCacheKey(accountID)
+	if b, ok := p.cache.Get(key); ok {
+		var val tokenReposCacheVal
+		if err := json.Unmarshal(b, &val); err != nil {
+			return nil, err
+		}
+		if p.cacheTTL >= val.TTL {
+			return val.Repos, nil
+		}
+	}
+
+	repos, err := p.client.WithToken(token).ReposWithPermission(ctx, "REPO_READ")
+	if err != nil {
+		return nil, err
+	}
+	val := tokenReposCacheVal{Repos: make(map[string]struct{}, len(repos)), TTL: p.cacheTTL}
+	for _, repo := range repos {
+		if repo.Project != nil {
+			val.Repos[repo.Project.Key+"/"+repo.Slug] = struct{}{}
+		}
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.Set(key, b)
+	return val.Repos, nil
+}
+
+// FetchAccount implements the authz.Provider interface. It returns an external account for the
+// Bitbucket Server user whose username equals the Sourcegraph user's username.
+//
+// 🚨 SECURITY: This assumes that Sourcegraph usernames and Bitbucket Server usernames identify the
+// same people (for example, because both are provisioned from the same directory). Site admins
+// must only configure authorization for Bitbucket Server if that is the case.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.ExternalAccount) (mine *extsvc.ExternalAccount, err error) {
+	if user == nil {
+		return nil, nil
+	}
+	users, err := p.client.Users(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if !strings.EqualFold(u.Name, user.Username) {
+			continue // the filter also matches users whose name or email address contains the username
+		}
+		acct := &extsvc.ExternalAccount{
+			UserID: user.ID,
+			ExternalAccountSpec: extsvc.ExternalAccountSpec{
+				ServiceType: p.codeHost.ServiceType(),
+				ServiceID:   p.codeHost.ServiceID(),
+				AccountID:   u.Slug,
+			},
+		}
+		acct.SetAccountData(u)
+		return acct, nil
+	}
+	return nil, nil
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID()
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType()
+}
+
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
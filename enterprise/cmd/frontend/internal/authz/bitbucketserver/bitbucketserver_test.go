@@ -0,0 +1,279 @@
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc/bitbucketserver"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// mockBitbucketServer is a fake of the parts of the Bitbucket Server REST API that the provider
+// uses.
+type mockBitbucketServer struct {
+	repos           map[string]*bitbucketserver.Repo         // "PROJECT/slug" -> repo
+	repoPerms       map[string][]*bitbucketserver.Permission // "PROJECT/slug" -> permissions
+	projectPerms    map[string][]*bitbucketserver.Permission // "PROJECT" -> permissions
+	projectDefaults map[string]bool                          // "PROJECT" -> PROJECT_READ for all users
+	globalPerms     []*bitbucketserver.Permission
+	groups          map[string][]string // user slug -> group names
+	users           []*bitbucketserver.User
+	tokenRepos      map[string][]string // access token -> "PROJECT/slug"
+
+	requests int
+}
+
+func (m *mockBitbucketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.requests++
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/1.0/")
+	q := r.URL.Query()
+	parts := strings.Split(path, "/")
+
+	var values interface{}
+	switch {
+	case path == "repos" && q.Get("permission") == "REPO_READ":
+		var repos []*bitbucketserver.Repo
+		for _, id := range m.tokenRepos[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+			repos = append(repos, m.repos[id])
+		}
+		values = repos
+	case path == "users":
+		var users []*bitbucketserver.User
+		for _, u := range m.users {
+			if strings.Contains(u.Name, q.Get("filter")) || strings.Contains(u.EmailAddress, q.Get("filter")) {
+				users = append(users, u)
+			}
+		}
+		values = users
+	case path == "admin/users/more-members":
+		var groups []*bitbucketserver.Group
+		for _, g := range m.groups[q.Get("context")] {
+			groups = append(groups, &bitbucketserver.Group{Name: g})
+		}
+		values = groups
+	case path == "admin/permissions/users":
+		var perms []*bitbucketserver.Permission
+		for _, p := range m.globalPerms {
+			if strings.Contains(p.User.Slug, q.Get("filter")) {
+				perms = append(perms, p)
+			}
+		}
+		values = perms
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "repos":
+		repo, ok := m.repos[parts[1]+"/"+parts[3]]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(repo)
+		return
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "repos" && parts[4] == "permissions":
+		values = filterPerms(m.repoPerms[parts[1]+"/"+parts[3]], parts[5])
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "permissions":
+		values = filterPerms(m.projectPerms[parts[1]], parts[3])
+	case len(parts) == 5 && parts[0] == "projects" && parts[2] == "permissions" && parts[3] == "PROJECT_READ" && parts[4] == "all":
+		_ = json.NewEncoder(w).Encode(map[string]bool{"permitted": m.projectDefaults[parts[1]]})
+		return
+	default:
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotFound)
+		return
+	}
+
+	// Return one value per page to exercise pagination.
+	v := reflect.ValueOf(values)
+	start, _ := strconv.Atoi(q.Get("start"))
+	page := reflect.MakeSlice(reflect.TypeOf([]interface{}{}), 0, 1)
+	if start < v.Len() {
+		page = reflect.Append(page, v.Index(start))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"values":        page.Interface(),
+		"isLastPage":    start+1 >= v.Len(),
+		"nextPageStart": start + 1,
+	})
+}
+
+func filterPerms(perms []*bitbucketserver.Permission, principal string) []*bitbucketserver.Permission {
+	var filtered []*bitbucketserver.Permission
+	for _, p := range perms {
+		if (principal == "users" && p.User != nil) || (principal == "groups" && p.Group != nil) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+func userPerm(slug, perm string) *bitbucketserver.Permission {
+	return &bitbucketserver.Permission{User: &bitbucketserver.User{Name: slug, Slug: slug}, Permission: perm}
+}
+
+func groupPerm(name, perm string) *bitbucketserver.Permission {
+	return &bitbucketserver.Permission{Group: &bitbucketserver.Group{Name: name}, Permission: perm}
+}
+
+func newRepo(projectKey, slug string, public bool) *bitbucketserver.Repo {
+	return &bitbucketserver.Repo{Slug: slug, Public: public, Project: &bitbucketserver.Project{Key: projectKey}}
+}
+
+func TestProvider_RepoPerms(t *testing.T) {
+	mock := &mockBitbucketServer{
+		repos: map[string]*bitbucketserver.Repo{
+			"PUB/public":    newRepo("PUB", "public", true),
+			"PRJ/direct":    newRepo("PRJ", "direct", false),
+			"PRJ/group":     newRepo("PRJ", "group", false),
+			"PRJ/secret":    newRepo("PRJ", "secret", false),
+			"OPEN/repo":     newRepo("OPEN", "repo", false),
+			"~BOB/personal": newRepo("~BOB", "personal", false),
+		},
+		repoPerms: map[string][]*bitbucketserver.Permission{
+			"PRJ/direct": {userPerm("alice", "REPO_READ")},
+			"PRJ/group":  {groupPerm("devs", "REPO_WRITE")},
+		},
+		projectPerms: map[string][]*bitbucketserver.Permission{
+			"PRJ": {userPerm("dave", "PROJECT_READ")},
+		},
+		projectDefaults: map[string]bool{"OPEN": true},
+		globalPerms: []*bitbucketserver.Permission{
+			userPerm("alice", "LICENSED_USER"),
+			userPerm("carol", "SYS_ADMIN"),
+		},
+		groups: map[string][]string{
+			"alice": {"other", "devs"},
+		},
+		tokenRepos: map[string][]string{
+			"t-erin": {"PRJ/secret"},
+		},
+	}
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+
+	provider := NewProvider(newClient(t, srv.URL), time.Hour, make(authz.MockCache))
+
+	serviceID := provider.ServiceID()
+	repos := map[authz.Repo]struct{}{}
+	for id := range mock.repos {
+		repos[authz.Repo{
+			RepoName:         api.RepoName("bitbucket.example.com/" + id),
+			ExternalRepoSpec: api.ExternalRepoSpec{ID: id, ServiceType: bitbucketserver.ServiceType, ServiceID: serviceID},
+		}] = struct{}{}
+	}
+	repos[authz.Repo{
+		RepoName:         "github.com/foo/bar",
+		ExternalRepoSpec: api.ExternalRepoSpec{ID: "PRJ/direct", ServiceType: "github", ServiceID: "https://github.com/"},
+	}] = struct{}{}
+
+	account := func(slug string, token string) *extsvc.ExternalAccount {
+		acct := &extsvc.ExternalAccount{
+			ExternalAccountSpec: extsvc.ExternalAccountSpec{ServiceType: bitbucketserver.ServiceType, ServiceID: serviceID, AccountID: slug},
+		}
+		var tok *oauth2.Token
+		if token != "" {
+			tok = &oauth2.Token{AccessToken: token}
+		}
+		bitbucketserver.SetExternalAccountData(&acct.ExternalAccountData, &bitbucketserver.User{Slug: slug}, tok)
+		return acct
+	}
+
+	tests := []struct {
+		description string
+		account     *extsvc.ExternalAccount
+		readable    []string
+	}{
+		{description: "anonymous", readable: []string{"PUB/public"}},
+		{description: "direct and group permissions", account: account("alice", ""), readable: []string{"PUB/public", "PRJ/direct", "PRJ/group", "OPEN/repo"}},
+		{description: "personal project owner", account: account("bob", ""), readable: []string{"PUB/public", "OPEN/repo", "~BOB/personal"}},
+		{description: "project permission", account: account("dave", ""), readable: []string{"PUB/public", "PRJ/direct", "PRJ/group", "PRJ/secret", "OPEN/repo"}},
+		{description: "admin", account: account("carol", ""), readable: []string{"PUB/public", "PRJ/direct", "PRJ/group", "PRJ/secret", "OPEN/repo", "~BOB/personal"}},
+		{description: "OAuth token", account: account("erin", "t-erin"), readable: []string{"PRJ/secret"}},
+	}
+	for run := 0; run < 2; run++ { // run twice for cache coherency
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%s: run %d", test.description, run), func(t *testing.T) {
+				mock.requests = 0
+
+				perms, err := provider.RepoPerms(context.Background(), test.account, repos)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := map[api.RepoName]map[authz.Perm]bool{}
+				for id := range mock.repos {
+					want[api.RepoName("bitbucket.example.com/"+id)] = map[authz.Perm]bool{authz.Read: false}
+				}
+				for _, id := range test.readable {
+					want[api.RepoName("bitbucket.example.com/"+id)][authz.Read] = true
+				}
+				if !reflect.DeepEqual(perms, want) {
+					t.Errorf("got perms\n%s\nwant\n%s", spew.Sdump(perms), spew.Sdump(want))
+				}
+
+				if run == 1 && mock.requests > 0 {
+					t.Errorf("expected permissions to be fully cached, but got %d requests", mock.requests)
+				}
+			})
+		}
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	alice := &bitbucketserver.User{Name: "alice", Slug: "alice", EmailAddress: "alice@example.com"}
+	srv := httptest.NewServer(&mockBitbucketServer{
+		users: []*bitbucketserver.User{
+			{Name: "malice", Slug: "malice", EmailAddress: "malice@example.com"},
+			alice,
+		},
+	})
+	defer srv.Close()
+
+	provider := NewProvider(newClient(t, srv.URL), time.Hour, make(authz.MockCache))
+
+	acct, err := provider.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct == nil {
+		t.Fatal("got nil account, want alice's account")
+	}
+	wantSpec := extsvc.ExternalAccountSpec{ServiceType: bitbucketserver.ServiceType, ServiceID: provider.ServiceID(), AccountID: "alice"}
+	if acct.UserID != 1 || acct.ExternalAccountSpec != wantSpec {
+		t.Errorf("got account %+v, want user 1 and %+v", acct, wantSpec)
+	}
+	if usr, _, err := bitbucketserver.GetExternalAccountData(&acct.ExternalAccountData); err != nil || !reflect.DeepEqual(usr, alice) {
+		t.Errorf("got account data %+v (error %v), want %+v", usr, err, alice)
+	}
+
+	acct, err = provider.FetchAccount(context.Background(), &types.User{ID: 2, Username: "nobody"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct != nil {
+		t.Errorf("got account %+v, want nil", acct)
+	}
+}
+
+func newClient(t *testing.T, rawurl string) *bitbucketserver.Client {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &bitbucketserver.Client{
+		URL:        u,
+		Token:      "admin-token",
+		HTTPClient: http.DefaultClient,
+		RateLimit:  rate.NewLimiter(rate.Inf, 0),
+	}
+}
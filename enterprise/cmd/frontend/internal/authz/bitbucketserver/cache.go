@@ -0,0 +1,57 @@
+package bitbucketserver
+
+import (
+	"fmt"
+	"time"
+)
+
+// cache describes the shape of the permissions cache that Provider uses internally.
+type cache interface {
+	GetMulti(keys ...string) [][]byte
+	SetMulti(keyvals ...[2]string)
+	Get(key string) ([]byte, bool)
+	Set(key string, b []byte)
+}
+
+func repoPermsCacheKey(repoID string) string {
+	return fmt.Sprintf("r:%s", repoID)
+}
+
+// repoPermsCacheVal describes who can read a repository. It is shared by all users.
+type repoPermsCacheVal struct {
+	// Public is whether the repository (or its project) is readable without authentication.
+	Public bool
+	// AllUsers is whether the repository's project grants read access to all licensed users.
+	AllUsers bool
+	// Users is the set of slugs of the users that were granted access to the repository or its
+	// project.
+	Users map[string]struct{}
+	// Groups is the set of names of the groups that were granted access to the repository or its
+	// project.
+	Groups map[string]struct{}
+	TTL    time.Duration
+}
+
+func userCacheKey(userSlug string) string {
+	return fmt.Sprintf("u:%s", userSlug)
+}
+
+// userCacheVal describes the permissions of a user that don't depend on a repository.
+type userCacheVal struct {
+	// Admin is whether the user has a global ADMIN or SYS_ADMIN permission, which grants access to
+	// all repositories.
+	Admin  bool
+	Groups map[string]struct{}
+	TTL    time.Duration
+}
+
+func tokenReposCacheKey(accountID string) string {
+	return fmt.Sprintf("t:%s", accountID)
+}
+
+// tokenReposCacheVal is the set of IDs of the repositories that a user who authenticated via OAuth
+// can read, as listed with their access token.
+type tokenReposCacheVal struct {
+	Repos map[string]struct{}
+	TTL   time.Duration
+}
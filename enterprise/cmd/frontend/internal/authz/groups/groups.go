@@ -0,0 +1,143 @@
+// Package groups contains an authorization provider that grants access to repositories based on
+// the groups that a SAML or OpenID Connect identity provider reports for a user.
+package groups
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+)
+
+// Provider implements authz.Provider for repository permissions derived from identity provider
+// groups. It is the source of permissions for every repository whose name matches the patterns of
+// any configured group, and grants read access on such a repository to the members of the groups
+// whose patterns match it.
+//
+// The groups of a user are read from the account data of the user's external account for the
+// identity provider, which is updated each time the user signs in.
+type Provider struct {
+	serviceType string
+	serviceID   string
+
+	// groupRepos maps each group name to the patterns of the repositories that its members can
+	// read.
+	groupRepos map[string][]*regexp.Regexp
+}
+
+// NewProvider returns a new groups authorization provider for the identity provider with the given
+// service type and ID (which must match those of the user external accounts created by the
+// identity provider). The groups map is keyed by group name, and each value is a list of regular
+// expressions that match the names of repositories that members of the group can read.
+func NewProvider(serviceType, serviceID string, groups map[string][]string) (*Provider, error) {
+	p := &Provider{
+		serviceType: serviceType,
+		serviceID:   serviceID,
+		groupRepos:  make(map[string][]*regexp.Regexp, len(groups)),
+	}
+	for group, patterns := range groups {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid repository pattern %q for group %q: %s", pattern, group, err)
+			}
+			p.groupRepos[group] = append(p.groupRepos[group], re)
+		}
+	}
+	return p, nil
+}
+
+var _ authz.Provider = ((*Provider)(nil))
+
+// Repos implements the authz.Provider interface. The provider is the source of permissions for the
+// repositories that match any group's patterns.
+func (p *Provider) Repos(ctx context.Context, repos map[authz.Repo]struct{}) (mine map[authz.Repo]struct{}, others map[authz.Repo]struct{}) {
+	mine, others = map[authz.Repo]struct{}{}, map[authz.Repo]struct{}{}
+	for repo := range repos {
+		if p.matchAny(p.allPatterns(), repo.RepoName) {
+			mine[repo] = struct{}{}
+		} else {
+			others[repo] = struct{}{}
+		}
+	}
+	return mine, others
+}
+
+// RepoPerms implements the authz.Provider interface. A user can read a repository if it matches
+// the patterns of any of the user's groups. Users without an account for the identity provider
+// (including anonymous users) are members of no groups.
+func (p *Provider) RepoPerms(ctx context.Context, userAccount *extsvc.ExternalAccount, repos map[authz.Repo]struct{}) (map[api.RepoName]map[authz.Perm]bool, error) {
+	var patterns []*regexp.Regexp
+	if userAccount != nil && userAccount.ServiceType == p.serviceType && userAccount.ServiceID == p.serviceID {
+		groups, err := accountGroups(userAccount)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			patterns = append(patterns, p.groupRepos[group]...)
+		}
+	}
+
+	perms := make(map[api.RepoName]map[authz.Perm]bool, len(repos))
+	for repo := range repos {
+		perms[repo.RepoName] = map[authz.Perm]bool{authz.Read: p.matchAny(patterns, repo.RepoName)}
+	}
+	return perms, nil
+}
+
+// accountGroups returns the groups stored in the account data of the external account by the SAML
+// and OpenID Connect auth providers.
+func accountGroups(acct *extsvc.ExternalAccount) ([]string, error) {
+	if acct.AccountData == nil {
+		return nil, nil
+	}
+	var data struct {
+		Groups []string `json:"groups"`
+	}
+	if err := acct.GetAccountData(&data); err != nil {
+		return nil, err
+	}
+	return data.Groups, nil
+}
+
+func (p *Provider) allPatterns() []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, groupPatterns := range p.groupRepos {
+		patterns = append(patterns, groupPatterns...)
+	}
+	return patterns
+}
+
+func (p *Provider) matchAny(patterns []*regexp.Regexp, name api.RepoName) bool {
+	for _, re := range patterns {
+		if re.MatchString(string(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchAccount implements the authz.Provider interface. The user's external account for the
+// identity provider can only be created by signing in with it, so this always returns nil.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.ExternalAccount) (mine *extsvc.ExternalAccount, err error) {
+	return nil, nil
+}
+
+// ServiceType implements the authz.Provider interface.
+func (p *Provider) ServiceType() string {
+	return p.serviceType
+}
+
+// ServiceID implements the authz.Provider interface.
+func (p *Provider) ServiceID() string {
+	return p.serviceID
+}
+
+// Validate implements the authz.Provider interface.
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
@@ -0,0 +1,84 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+)
+
+func TestProvider(t *testing.T) {
+	p, err := NewProvider("saml", "https://idp.example.com", map[string][]string{
+		"eng":   {"^github.com/org/"},
+		"sales": {"^github.com/org/crm$", "^gitlab.example.com/sales/"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos := map[authz.Repo]struct{}{}
+	for _, name := range []api.RepoName{"github.com/org/api", "github.com/org/crm", "gitlab.example.com/sales/leads", "github.com/other/public"} {
+		repos[authz.Repo{RepoName: name}] = struct{}{}
+	}
+	mine, others := p.Repos(context.Background(), repos)
+	if len(mine) != 3 || len(others) != 1 {
+		t.Fatalf("got %d repos claimed and %d others, want 3 and 1", len(mine), len(others))
+	}
+	if _, ok := others[authz.Repo{RepoName: "github.com/other/public"}]; !ok {
+		t.Errorf("got others %v, want github.com/other/public", others)
+	}
+
+	account := func(serviceID string, groups ...string) *extsvc.ExternalAccount {
+		data, err := json.Marshal(map[string][]string{"groups": groups})
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw := json.RawMessage(data)
+		return &extsvc.ExternalAccount{
+			ExternalAccountSpec: extsvc.ExternalAccountSpec{ServiceType: "saml", ServiceID: serviceID, AccountID: "u"},
+			ExternalAccountData: extsvc.ExternalAccountData{AccountData: &raw},
+		}
+	}
+	tests := map[string]struct {
+		account  *extsvc.ExternalAccount
+		wantRead []api.RepoName
+	}{
+		"anonymous":               {account: nil},
+		"no groups":               {account: account("https://idp.example.com")},
+		"unconfigured group":      {account: account("https://idp.example.com", "ops")},
+		"other identity provider": {account: account("https://other.example.com", "eng")},
+		"one group":               {account: account("https://idp.example.com", "sales"), wantRead: []api.RepoName{"github.com/org/crm", "gitlab.example.com/sales/leads"}},
+		"many groups": {
+			account:  account("https://idp.example.com", "eng", "sales"),
+			wantRead: []api.RepoName{"github.com/org/api", "github.com/org/crm", "gitlab.example.com/sales/leads"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			perms, err := p.RepoPerms(context.Background(), test.account, mine)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[api.RepoName]map[authz.Perm]bool{}
+			for repo := range mine {
+				want[repo.RepoName] = map[authz.Perm]bool{authz.Read: false}
+			}
+			for _, name := range test.wantRead {
+				want[name][authz.Read] = true
+			}
+			if !reflect.DeepEqual(perms, want) {
+				t.Errorf("got perms %v, want %v", perms, want)
+			}
+		})
+	}
+}
+
+func TestNewProvider_invalidPattern(t *testing.T) {
+	if _, err := NewProvider("saml", "https://idp.example.com", map[string][]string{"eng": {"("}}); err == nil {
+		t.Error("got nil error, want error for invalid pattern")
+	}
+}
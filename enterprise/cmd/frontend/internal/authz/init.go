@@ -59,10 +59,31 @@ func init() {
 			}
 		}
 
+		bitbucketServers, err := db.ExternalServices.ListBitbucketServerConnections(ctx)
+		if err != nil {
+			return []*graphqlbackend.Alert{{
+				TypeValue:    graphqlbackend.AlertTypeError,
+				MessageValue: fmt.Sprintf("Unable to fetch Bitbucket Server external services: %s", err),
+			}}
+		}
+		for _, b := range bitbucketServers {
+			if b.Authorization != nil {
+				authzTypes = append(authzTypes, "Bitbucket Server")
+				break
+			}
+		}
+
+		for _, p := range conf.Get().Critical.AuthProviders {
+			if _, authorization := groupsAuthorization(p); authorization != nil {
+				authzTypes = append(authzTypes, "SAML/OpenID Connect groups")
+				break
+			}
+		}
+
 		if len(authzTypes) > 0 {
 			return []*graphqlbackend.Alert{{
 				TypeValue:    graphqlbackend.AlertTypeError,
-				MessageValue: fmt.Sprintf("A Sourcegraph license is required to enable repository permissions for the following code hosts: %s. [**Get a license.**](/site-admin/license)", strings.Join(authzTypes, ", ")),
+				MessageValue: fmt.Sprintf("A Sourcegraph license is required to enable repository permissions for the following sources: %s. [**Get a license.**](/site-admin/license)", strings.Join(authzTypes, ", ")),
 			}}
 		}
 		return nil
@@ -114,5 +135,17 @@ func providersFromConfig(ctx context.Context, cfg *conf.Unified) (
 	seriousProblems = append(seriousProblems, ghproblems...)
 	warnings = append(warnings, ghwarnings...)
 
+	bbsp, bbsproblems, bbswarnings := bitbucketServerProviders(ctx)
+	authzProviders = append(authzProviders, bbsp...)
+	seriousProblems = append(seriousProblems, bbsproblems...)
+	warnings = append(warnings, bbswarnings...)
+
+	// Groups providers come last, so that they only apply to repositories that are not on a code
+	// host with repository permissions configured.
+	gp, gproblems, gwarnings := groupsProviders(ctx)
+	authzProviders = append(authzProviders, gp...)
+	seriousProblems = append(seriousProblems, gproblems...)
+	warnings = append(warnings, gwarnings...)
+
 	return allowAccessByDefault, authzProviders, seriousProblems, warnings
 }
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS lsif_documents;
+DROP TABLE IF EXISTS lsif_dumps;
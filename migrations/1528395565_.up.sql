@@ -0,0 +1,15 @@
+CREATE TABLE lsif_dumps (
+	id bigserial NOT NULL PRIMARY KEY,
+	repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+	commit_id text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT lsif_dumps_commit_id_valid CHECK (char_length(commit_id) = 40)
+);
+CREATE UNIQUE INDEX lsif_dumps_repo_id_commit_id ON lsif_dumps(repo_id, commit_id);
+
+CREATE TABLE lsif_documents (
+	dump_id bigint NOT NULL REFERENCES lsif_dumps(id) ON DELETE CASCADE,
+	path text NOT NULL,
+	data jsonb NOT NULL,
+	PRIMARY KEY (dump_id, path)
+);
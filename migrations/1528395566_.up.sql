@@ -0,0 +1,14 @@
+CREATE TABLE repo_permissions (
+	id serial NOT NULL PRIMARY KEY,
+	repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+	user_id integer REFERENCES users(id) ON DELETE CASCADE,
+	org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+	permission text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT repo_permissions_user_or_org CHECK ((user_id IS NULL) <> (org_id IS NULL)),
+	CONSTRAINT repo_permissions_permission_valid CHECK (permission = 'read')
+);
+CREATE UNIQUE INDEX repo_permissions_repo_id_user_id_permission ON repo_permissions(repo_id, user_id, permission) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX repo_permissions_repo_id_org_id_permission ON repo_permissions(repo_id, org_id, permission) WHERE org_id IS NOT NULL;
+CREATE INDEX repo_permissions_user_id ON repo_permissions(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX repo_permissions_org_id ON repo_permissions(org_id) WHERE org_id IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE repo DROP COLUMN permissions_synced_at;
+ALTER TABLE users DROP COLUMN permissions_synced_at;
+
+DROP TABLE IF EXISTS user_repo_permissions;
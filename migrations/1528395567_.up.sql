@@ -0,0 +1,11 @@
+CREATE TABLE user_repo_permissions (
+	user_id integer REFERENCES users(id) ON DELETE CASCADE,
+	repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+	permission text NOT NULL
+);
+CREATE UNIQUE INDEX user_repo_permissions_user_id_repo_id_permission ON user_repo_permissions(user_id, repo_id, permission) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX user_repo_permissions_anonymous_repo_id_permission ON user_repo_permissions(repo_id, permission) WHERE user_id IS NULL;
+CREATE INDEX user_repo_permissions_repo_id ON user_repo_permissions(repo_id);
+
+ALTER TABLE users ADD COLUMN permissions_synced_at timestamp with time zone;
+ALTER TABLE repo ADD COLUMN permissions_synced_at timestamp with time zone;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS repo_external_service_repo_idx;
+ALTER TABLE repo DROP COLUMN IF EXISTS deleted_at;
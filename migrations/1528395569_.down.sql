@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS repo_topics;
+ALTER TABLE repo DROP COLUMN IF EXISTS visibility;
+ALTER TABLE repo DROP COLUMN IF EXISTS default_branch;
+ALTER TABLE repo DROP COLUMN IF EXISTS stars;
+ALTER TABLE repo DROP COLUMN IF EXISTS topics;
@@ -0,0 +1,5 @@
+ALTER TABLE repo ADD COLUMN topics text[] NOT NULL DEFAULT '{}';
+ALTER TABLE repo ADD COLUMN stars integer NOT NULL DEFAULT 0;
+ALTER TABLE repo ADD COLUMN default_branch text;
+ALTER TABLE repo ADD COLUMN visibility text CHECK (visibility IN ('public', 'private', 'internal'));
+CREATE INDEX repo_topics ON repo USING gin (topics);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saved_query_notifications;
+DROP TABLE IF EXISTS saved_query_executions;
@@ -0,0 +1,32 @@
+CREATE TABLE saved_query_executions (
+    id bigserial PRIMARY KEY,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    saved_query_key text NOT NULL,
+    query text NOT NULL,
+    executed_at timestamp with time zone NOT NULL DEFAULT now(),
+    duration_ms integer NOT NULL,
+    result_count integer NOT NULL,
+    error text
+);
+CREATE INDEX saved_query_executions_saved_query_key ON saved_query_executions(saved_query_key, executed_at);
+
+CREATE TABLE saved_query_notifications (
+    id bigserial PRIMARY KEY,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    saved_query_key text NOT NULL,
+    channel text NOT NULL CHECK (channel IN ('email', 'slack', 'webhook')),
+    recipient_user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    recipient_org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    payload jsonb NOT NULL,
+    state text NOT NULL DEFAULT 'queued' CHECK (state IN ('queued', 'sent', 'dead')),
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    sent_at timestamp with time zone,
+    CONSTRAINT saved_query_notifications_has_recipient CHECK ((recipient_user_id IS NULL) <> (recipient_org_id IS NULL))
+);
+CREATE INDEX saved_query_notifications_saved_query_key ON saved_query_notifications(saved_query_key, created_at);
+CREATE INDEX saved_query_notifications_queued ON saved_query_notifications(next_attempt_at) WHERE state = 'queued';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS code_monitor_results;
+
+DELETE FROM saved_query_notifications WHERE channel = 'action';
+ALTER TABLE saved_query_notifications DROP CONSTRAINT saved_query_notifications_has_recipient;
+ALTER TABLE saved_query_notifications ADD CONSTRAINT saved_query_notifications_has_recipient CHECK ((recipient_user_id IS NULL) <> (recipient_org_id IS NULL));
+ALTER TABLE saved_query_notifications DROP CONSTRAINT saved_query_notifications_channel_check;
+ALTER TABLE saved_query_notifications ADD CONSTRAINT saved_query_notifications_channel_check CHECK (channel IN ('email', 'slack', 'webhook'));
@@ -0,0 +1,17 @@
+ALTER TABLE saved_query_notifications DROP CONSTRAINT saved_query_notifications_channel_check;
+ALTER TABLE saved_query_notifications ADD CONSTRAINT saved_query_notifications_channel_check CHECK (channel IN ('email', 'slack', 'webhook', 'action'));
+ALTER TABLE saved_query_notifications DROP CONSTRAINT saved_query_notifications_has_recipient;
+ALTER TABLE saved_query_notifications ADD CONSTRAINT saved_query_notifications_has_recipient CHECK (channel = 'action' OR (recipient_user_id IS NULL) <> (recipient_org_id IS NULL));
+
+CREATE TABLE code_monitor_results (
+    id bigserial PRIMARY KEY,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    saved_query_key text NOT NULL,
+    action_key text NOT NULL,
+    result_key text NOT NULL,
+    target_id text NOT NULL,
+    target_url text,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX code_monitor_results_unique ON code_monitor_results(saved_query_key, COALESCE(user_id, 0), COALESCE(org_id, 0), action_key, result_key);
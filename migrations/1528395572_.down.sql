@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS discussion_threads_target_repo_diff_idx;
+ALTER TABLE discussion_threads_target_repo DROP CONSTRAINT IF EXISTS discussion_threads_target_repo_diff_check;
+ALTER TABLE discussion_threads_target_repo DROP COLUMN IF EXISTS base_revision;
+ALTER TABLE discussion_threads_target_repo DROP COLUMN IF EXISTS base_branch;
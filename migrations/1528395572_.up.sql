@@ -0,0 +1,6 @@
+-- A thread target with a base revision is on the diff between the base and the head (the branch
+-- and revision columns). Its selection is in the head version of the file.
+ALTER TABLE discussion_threads_target_repo ADD COLUMN base_branch text;
+ALTER TABLE discussion_threads_target_repo ADD COLUMN base_revision text;
+ALTER TABLE discussion_threads_target_repo ADD CONSTRAINT discussion_threads_target_repo_diff_check CHECK (base_revision IS NULL AND base_branch IS NULL OR base_revision IS NOT NULL AND revision IS NOT NULL);
+CREATE INDEX discussion_threads_target_repo_diff_idx ON discussion_threads_target_repo(repo_id, base_revision, revision) WHERE base_revision IS NOT NULL;
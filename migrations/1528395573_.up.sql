@@ -0,0 +1,14 @@
+CREATE TABLE discussion_external_comments (
+    comment_id bigint PRIMARY KEY REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    service_type text NOT NULL,
+    service_id text NOT NULL,
+    pull_request_id text NOT NULL,
+    external_thread_id text NOT NULL,
+    external_id text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX discussion_external_comments_external_id_unique ON discussion_external_comments(service_type, service_id, external_id);
+CREATE INDEX discussion_external_comments_repo_id_idx ON discussion_external_comments(repo_id);
+CREATE INDEX discussion_external_comments_thread_id_idx ON discussion_external_comments(thread_id);
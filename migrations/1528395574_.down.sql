@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS discussion_threads_assignees;
+DROP INDEX IF EXISTS discussion_threads_labels_idx;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS labels;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS resolved_at;
@@ -0,0 +1,25 @@
+ALTER TABLE discussion_comments ADD COLUMN parent_comment_id bigint REFERENCES discussion_comments(id) ON DELETE SET NULL;
+CREATE INDEX discussion_comments_parent_comment_id_idx ON discussion_comments(parent_comment_id);
+
+CREATE TABLE discussion_comment_reactions (
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (comment_id, user_id, reaction)
+);
+CREATE INDEX discussion_comment_reactions_user_id_idx ON discussion_comment_reactions(user_id);
+
+CREATE TABLE discussion_comment_revisions (
+    id bigserial PRIMARY KEY,
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    author_user_id integer NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    contents text NOT NULL,
+    reports text[] NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX discussion_comment_revisions_comment_id_idx ON discussion_comment_revisions(comment_id);
+
+-- The current contents (and reports) of existing comments are their first revision.
+INSERT INTO discussion_comment_revisions(comment_id, author_user_id, contents, reports, created_at)
+    SELECT id, author_user_id, contents, reports, created_at FROM discussion_comments ORDER BY id;
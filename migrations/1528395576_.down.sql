@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS discussion_unsubscribe_tokens;
+DROP TABLE IF EXISTS discussion_subscriptions;
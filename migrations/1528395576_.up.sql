@@ -0,0 +1,24 @@
+CREATE TABLE discussion_subscriptions (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    thread_id bigint REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE,
+    path_prefix text NOT NULL DEFAULT '',
+    muted boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT discussion_subscriptions_has_one_target CHECK ((thread_id IS NULL) != (repo_id IS NULL)),
+    CONSTRAINT discussion_subscriptions_thread_has_no_path CHECK (thread_id IS NULL OR path_prefix = ''),
+    CONSTRAINT discussion_subscriptions_only_threads_muted CHECK (NOT muted OR thread_id IS NOT NULL)
+);
+CREATE UNIQUE INDEX discussion_subscriptions_user_id_thread_id_unique ON discussion_subscriptions(user_id, thread_id) WHERE thread_id IS NOT NULL;
+CREATE UNIQUE INDEX discussion_subscriptions_user_id_repo_id_path_prefix_unique ON discussion_subscriptions(user_id, repo_id, path_prefix) WHERE repo_id IS NOT NULL;
+CREATE INDEX discussion_subscriptions_thread_id_idx ON discussion_subscriptions(thread_id);
+CREATE INDEX discussion_subscriptions_repo_id_idx ON discussion_subscriptions(repo_id);
+
+CREATE TABLE discussion_unsubscribe_tokens (
+    token text PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX discussion_unsubscribe_tokens_user_id_thread_id_idx ON discussion_unsubscribe_tokens(user_id, thread_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS repo_permissions_repo_id_permission;
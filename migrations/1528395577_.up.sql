@@ -0,0 +1 @@
+CREATE INDEX repo_permissions_repo_id_permission ON repo_permissions(repo_id, permission);
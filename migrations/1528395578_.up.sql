@@ -0,0 +1,9 @@
+CREATE TABLE webhook_secrets (
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    secret text NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT webhook_secrets_has_one_subject CHECK ((user_id IS NULL) != (org_id IS NULL))
+);
+CREATE UNIQUE INDEX webhook_secrets_user_id_unique ON webhook_secrets(user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX webhook_secrets_org_id_unique ON webhook_secrets(org_id) WHERE org_id IS NOT NULL;
@@ -0,0 +1,6 @@
+DELETE FROM saved_queries;
+DROP INDEX IF EXISTS saved_queries_saved_query_key_query_unique;
+ALTER TABLE saved_queries DROP COLUMN IF EXISTS user_id;
+ALTER TABLE saved_queries DROP COLUMN IF EXISTS org_id;
+ALTER TABLE saved_queries DROP COLUMN IF EXISTS saved_query_key;
+CREATE UNIQUE INDEX saved_queries_query_unique ON saved_queries(query);
@@ -0,0 +1,9 @@
+-- The info of saved queries was keyed by query, so saved queries with the same query shared it.
+-- It is now keyed by saved query (and query). The existing info can't be attributed to a saved
+-- query, so it is removed.
+DELETE FROM saved_queries;
+DROP INDEX IF EXISTS saved_queries_query_unique;
+ALTER TABLE saved_queries ADD COLUMN user_id integer REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE saved_queries ADD COLUMN org_id integer REFERENCES orgs(id) ON DELETE CASCADE;
+ALTER TABLE saved_queries ADD COLUMN saved_query_key text NOT NULL;
+CREATE UNIQUE INDEX saved_queries_saved_query_key_query_unique ON saved_queries(saved_query_key, COALESCE(user_id, 0), COALESCE(org_id, 0), query);
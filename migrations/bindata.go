@@ -192,6 +192,36 @@
 // 1528395563_.up.sql (181B)
 // 1528395564_.down.sql (0)
 // 1528395564_.up.sql (0)
+// 1528395565_.down.sql (70B)
+// 1528395565_.up.sql (552B)
+// 1528395566_.down.sql (39B)
+// 1528395566_.up.sql (959B)
+// 1528395567_.down.sql (150B)
+// 1528395567_.up.sql (711B)
+// 1528395568_.down.sql (104B)
+// 1528395568_.up.sql (205B)
+// 1528395569_.down.sql (233B)
+// 1528395569_.up.sql (330B)
+// 1528395570_.down.sql (93B)
+// 1528395570_.up.sql (1.647kB)
+// 1528395571_.down.sql (601B)
+// 1528395571_.up.sql (1.078kB)
+// 1528395572_.down.sql (332B)
+// 1528395572_.up.sql (682B)
+// 1528395573_.down.sql (51B)
+// 1528395573_.up.sql (851B)
+// 1528395574_.down.sql (230B)
+// 1528395574_.up.sql (640B)
+// 1528395575_.down.sql (239B)
+// 1528395575_.up.sql (1.419kB)
+// 1528395576_.down.sql (99B)
+// 1528395576_.up.sql (1.64kB)
+// 1528395577_.down.sql (58B)
+// 1528395577_.up.sql (91B)
+// 1528395578_.down.sql (38B)
+// 1528395578_.up.sql (544B)
+// 1528395579_.down.sql (342B)
+// 1528395579_.up.sql (697B)
 
 package migrations
 
@@ -4100,6 +4130,606 @@ func _1528395564_UpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395565_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x73\x69\x66\x5f\x64\x6f\x63\x75\x6d\x65\x6e\x74\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x73\x69\x66\x5f\x64\x75\x6d\x70\x73\x3b\x0a\x03\x00\xdf\xcc\x0b\x11\x46\x00\x00\x00")
+
+func _1528395565_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395565_DownSql,
+		"1528395565_.down.sql",
+	)
+}
+
+func _1528395565_DownSql() (*asset, error) {
+	bytes, err := _1528395565_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395565_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5a, 0xac, 0x9e, 0xe2, 0x1e, 0x2d, 0xa9, 0x6c, 0x31, 0x29, 0xf1, 0x4d, 0x77, 0xd4, 0x60, 0x32, 0x4b, 0xcd, 0x8f, 0xca, 0x76, 0x7f, 0x3b, 0x35, 0xfa, 0xa, 0xdc, 0xa9, 0xaf, 0xbb, 0x24, 0xac}}
+	return a, nil
+}
+
+var __1528395565_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x91\xbd\x6e\xab\x30\x14\xc7\x67\xfc\x14\x67\x04\x89\xe1\x0e\x77\x8b\x3a\x50\x73\xa2\xa2\x50\xd3\x12\x23\x35\x13\x72\x62\x17\x5c\x81\x41\x70\xd2\x54\x7d\xfa\x8a\x08\x05\xa4\xa6\xa3\xfd\xff\x92\x7f\xe6\x39\x46\x12\x41\x46\x8f\x29\x42\x33\xda\xf7\x52\x9f\xdb\x7e\x04\x9f\x79\x56\xc3\xd1\x56\xa3\x19\xac\x6a\x40\x64\x12\x44\x91\xa6\xf0\x92\x27\xcf\x51\x7e\x80\x1d\x1e\x42\xe6\x0d\xa6\xef\x4a\xab\xc1\x3a\x32\x95\x19\x16\x5b\x8e\x5b\xcc\x51\x70\xdc\xc3\xe4\xf1\xad\x0e\x20\x13\x10\x63\x8a\x12\x81\x47\x7b\x1e\xc5\x18\x32\xef\xd4\xb5\xad\xa5\xa9\x82\xcc\x17\xdd\xf2\x93\x32\x18\x45\x46\x97\x8a\x80\x6c\x6b\x46\x52\x6d\x0f\x17\x4b\xf5\xf5\x08\xdf\x9d\x33\xcb\x5c\x8c\xdb\xa8\x48\x25\xb8\xee\xe2\x07\x21\xf3\x78\x26\xf6\x32\x8f\x12\x21\x57\x8f\x2a\x6f\x63\xe5\xa7\x6a\xac\x06\xfe\x84\x7c\x07\xfe\xa9\x56\x43\xd9\x18\x57\x51\xed\xdf\x2c\x01\x3c\xc0\xff\x7f\x01\x0b\x36\x6c\x66\x54\x88\xe4\xb5\x40\x48\x44\x8c\x6f\xeb\xd6\x99\xc1\xd2\x0e\x99\x58\xe9\xfe\xac\x87\xb0\x74\x6f\x18\xbb\x03\xbe\x3b\x9d\x5b\xe3\xe8\x0a\x7f\x8a\x4e\x54\x8e\xb6\xb2\x8e\xee\x72\x5d\x4d\xfc\x45\xb7\x57\x54\xff\x02\xab\x15\x29\xf8\x18\x3b\x77\x5c\xdf\xae\xfe\x15\xfc\x79\x3d\x84\x5e\x51\x1d\xb0\x60\xc3\x7e\x06\x00\xe5\x2c\x85\x92\x28\x02\x00\x00")
+
+func _1528395565_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395565_UpSql,
+		"1528395565_.up.sql",
+	)
+}
+
+func _1528395565_UpSql() (*asset, error) {
+	bytes, err := _1528395565_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395565_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x60, 0xa7, 0xd2, 0xab, 0x4c, 0xfa, 0x81, 0x75, 0x71, 0xf3, 0x2, 0x26, 0xcd, 0x83, 0x1e, 0xa7, 0x63, 0x76, 0xf7, 0x6, 0x84, 0x7, 0xfb, 0xda, 0x65, 0xb1, 0x83, 0xe2, 0xb9, 0xb0, 0x66, 0x9f}}
+	return a, nil
+}
+
+var __1528395566_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x27\x00\xd8\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x3b\x0a\x03\x00\x56\x4a\x30\xe6\x27\x00\x00\x00")
+
+func _1528395566_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395566_DownSql,
+		"1528395566_.down.sql",
+	)
+}
+
+func _1528395566_DownSql() (*asset, error) {
+	bytes, err := _1528395566_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395566_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0x42, 0xa4, 0x2b, 0x86, 0x6e, 0x4b, 0xb9, 0x4d, 0x11, 0xad, 0x99, 0xab, 0x75, 0xea, 0x2e, 0x61, 0xf8, 0x2c, 0xf8, 0x41, 0x8c, 0xf4, 0x52, 0x50, 0x9d, 0x14, 0x53, 0x55, 0x9b, 0x15, 0xc0}}
+	return a, nil
+}
+
+var __1528395566_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xcd\x6e\xab\x30\x14\x84\xd7\xf0\x14\xb3\x0b\x48\xbc\xc1\xfd\x91\xb8\x70\xa2\xa0\x70\x4d\xcb\x8f\xda\xac\x10\x2a\x16\xb5\x94\xe0\xc8\xb8\x4d\xd5\xa7\xaf\x42\x4c\x40\x4d\x68\xd3\x1d\x78\xce\x8c\xbf\x39\x0e\x52\xf2\x73\x42\xee\xff\x8b\x09\x8a\xef\x65\xb9\xe7\x6a\x27\xba\x4e\xc8\xb6\x83\x63\x5b\xa2\x46\xc7\x95\xa8\xb6\x60\x49\x0e\x56\xc4\x31\xee\xd2\xe8\xbf\x9f\x6e\xb0\xa6\x8d\x67\x5b\xbd\x49\xd4\x10\xad\xe6\x0d\x57\xe3\x58\x4a\x4b\x4a\x89\x05\x94\xf5\xc1\x8e\xa8\x5d\x24\x0c\x21\xc5\x94\x13\x02\x3f\x0b\xfc\x90\x3c\xdb\x7a\xe9\xb8\x9a\x06\x4c\x7c\x47\xa9\x9b\x35\x4a\xd5\xcc\xf8\xa4\x6a\xe6\x6d\x63\x41\x68\xfe\xa6\xcf\xc0\x9e\x6d\x3d\x29\x5e\x69\x5e\x97\x95\x86\x16\x3b\xde\xe9\x6a\xb7\xc7\x41\xe8\xe7\xfe\x17\xef\xb2\xe5\x63\xbf\x90\x96\x7e\x11\xe7\x68\xe5\xc1\x71\x3d\xdb\x0a\x12\x96\xe5\xa9\x1f\xb1\xfc\x62\x91\xe5\xb1\x48\x29\x55\x29\x55\x83\x60\x45\xc1\x1a\x8e\x33\xf4\x8e\xb2\x3e\xcf\xc5\xef\xbf\x70\x4c\xa7\xe1\xec\xbb\xdc\xf1\xbb\x7c\xad\xb6\xa2\x1e\xc2\xc7\x73\xfc\xc1\x42\xf1\xaa\x5e\xb8\xb6\xfb\xcb\x36\xcf\x5d\xb0\xe8\xbe\x20\x44\x2c\xa4\xc7\xcb\x50\xf3\xa2\xa5\x01\x9c\x68\x48\xd8\xc5\xb8\x63\xc6\x3d\x98\x79\x0f\xa3\xea\xe2\x61\x45\x29\x0d\x12\xa2\xec\xbc\xbf\x1f\xc2\x9c\x16\x73\x33\xcb\x69\xfc\x1a\xca\x64\xc3\x9f\x49\x66\x10\x06\xf8\x6b\x17\x1a\xed\x96\x9e\x33\xe9\x86\xe7\x5a\xf8\x49\xfa\x0a\xfc\x63\x00\xbd\x9c\x0f\x30\xbf\x03\x00\x00")
+
+func _1528395566_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395566_UpSql,
+		"1528395566_.up.sql",
+	)
+}
+
+func _1528395566_UpSql() (*asset, error) {
+	bytes, err := _1528395566_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395566_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0x38, 0x36, 0xac, 0xb1, 0x11, 0x72, 0xdb, 0xff, 0x38, 0x69, 0xb6, 0xf2, 0x3d, 0xa4, 0xee, 0x76, 0x42, 0x8f, 0xaf, 0xa8, 0xd1, 0xbd, 0x3b, 0x7c, 0x18, 0x69, 0x3c, 0x23, 0x8a, 0x67, 0x5f}}
+	return a, nil
+}
+
+var __1528395567_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xc8\x57\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x48\x2d\xca\xcd\x2c\x2e\xce\xcc\xcf\x2b\x8e\x2f\xae\xcc\x4b\x4e\x4d\x89\x4f\x2c\xb1\xe6\x42\xd6\x53\x5a\x9c\x5a\x54\x4c\x8c\x26\x2e\xb0\x1a\x88\x26\x4f\x37\x05\xd7\x08\xcf\xe0\x90\x60\xb0\xf6\x78\x90\xbd\xf1\x48\xda\xac\xb9\x00\x03\x00\x90\xfc\x72\x47\x96\x00\x00\x00")
+
+func _1528395567_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395567_DownSql,
+		"1528395567_.down.sql",
+	)
+}
+
+func _1528395567_DownSql() (*asset, error) {
+	bytes, err := _1528395567_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395567_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe7, 0x7d, 0x42, 0x84, 0xee, 0x42, 0xec, 0x89, 0x42, 0x61, 0xe4, 0x70, 0x5c, 0x72, 0x38, 0xab, 0x43, 0x96, 0xac, 0xf7, 0xbe, 0x4f, 0xb, 0x9e, 0x52, 0xe7, 0x19, 0x5, 0xab, 0xff, 0x58, 0x77}}
+	return a, nil
+}
+
+var __1528395567_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\xcb\x6a\x84\x30\x18\x85\xd7\x93\xa7\x38\xcb\x11\x7c\x03\x57\xa9\xfe\xa5\x42\x1a\xa9\x17\xda\x5d\x90\x1a\xda\x2c\x8c\x62\x32\xb4\xd3\xa7\x2f\x4a\x98\x49\x61\x7a\x19\xba\x94\xe3\xf9\xce\x97\x90\xbc\x26\xde\x12\x5a\x7e\x23\x08\x07\xa7\x17\xb5\xe8\x79\x52\xb3\x5e\x46\xe3\x9c\x99\xac\xc3\x9e\xed\xb6\xc0\x0c\x30\xd6\xeb\x17\xbd\xa0\xa6\x5b\xaa\x49\xe6\xd4\x6c\x1d\xb7\x37\x43\x82\x4a\xa2\x20\x41\x2d\x21\xe7\x4d\xce\x0b\x4a\xd9\x6e\x83\x45\x45\x59\xb5\x90\x9d\x10\x31\x61\xfd\xe7\x5b\xc0\x59\x04\x5e\xbf\xfb\x13\x80\x25\x19\x0b\xee\x9d\x2c\x1f\x3a\x42\x29\x0b\x7a\xba\x7c\x04\x15\xfc\x55\xd0\x89\xb2\x75\xf4\x62\x67\x1f\x3a\x29\x42\x29\xc5\x39\x4d\xf0\x78\x47\x75\xb8\x30\x33\xa0\x6c\x4e\x62\xd7\x68\xf5\x76\xb2\xc7\x71\x3a\xb8\xab\xc4\xfe\xe8\x13\xbb\xfc\x24\x11\x70\xbf\xee\x25\x19\x63\x5c\xb4\x54\x47\x8f\xc5\x81\x17\x05\xf2\x4a\x74\xf7\x32\xd2\x71\xca\x1d\xed\xb3\x1e\x54\xef\xe1\xcd\xa8\x9d\xef\xc7\x19\x6f\xc6\xbf\x6e\x9f\xf8\x98\xac\xce\xbe\xc0\xd6\x8d\xff\xb0\x3e\x07\x00\x37\x6b\x4b\xca\xc7\x02\x00\x00")
+
+func _1528395567_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395567_UpSql,
+		"1528395567_.up.sql",
+	)
+}
+
+func _1528395567_UpSql() (*asset, error) {
+	bytes, err := _1528395567_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395567_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x56, 0x43, 0xe3, 0xb3, 0x69, 0xb, 0x50, 0x46, 0x1a, 0x81, 0x98, 0x9f, 0x3c, 0x8c, 0xd4, 0x44, 0xeb, 0xa0, 0xe4, 0xa4, 0x92, 0xd0, 0xca, 0xfb, 0xdf, 0x63, 0xd8, 0xf5, 0xb5, 0x69, 0x54, 0xeb}}
+	return a, nil
+}
+
+var __1528395568_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x68\x00\x97\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x5f\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x73\x65\x72\x76\x69\x63\x65\x5f\x72\x65\x70\x6f\x5f\x69\x64\x78\x3b\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x72\x65\x70\x6f\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x65\x6c\x65\x74\x65\x64\x5f\x61\x74\x3b\x0a\x03\x00\x2b\x86\x39\xce\x68\x00\x00\x00")
+
+func _1528395568_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395568_DownSql,
+		"1528395568_.down.sql",
+	)
+}
+
+func _1528395568_DownSql() (*asset, error) {
+	bytes, err := _1528395568_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395568_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x21, 0x64, 0xd, 0xff, 0xc3, 0x66, 0xb2, 0xad, 0xfa, 0x66, 0xe2, 0xd1, 0xb7, 0x61, 0xba, 0x47, 0x7e, 0x86, 0x78, 0x83, 0x15, 0xc3, 0x67, 0xb1, 0x13, 0x41, 0x14, 0xde, 0x8, 0xdf, 0x2e, 0x79}}
+	return a, nil
+}
+
+var __1528395568_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\xbd\x0a\xc2\x30\x14\xc5\xf1\xbd\x4f\x71\x46\x05\xdf\xa0\x53\x6c\x2f\x58\x88\x29\xd4\x14\xdd\x42\x31\x17\x0c\xf4\x8b\xf4\xa2\xd5\xa7\x17\x32\x15\x3a\x9e\x1f\x7f\x8e\xd2\x96\x1a\x58\x75\xd6\x84\xc8\xf3\x04\x55\x96\x28\x6a\xdd\x5e\x0d\x3c\xf7\x2c\xec\x5d\x27\x90\x30\xf0\x22\xdd\x30\xe3\x13\xe4\x95\x26\x7e\xd3\xc8\x79\x56\x34\xa4\x2c\xa1\x32\x25\x3d\xd2\x83\xe3\x55\x38\x8e\x5d\xef\x16\x8e\xef\xf0\x64\x97\x34\xf8\x15\xb5\x49\xc5\x61\x57\xc8\x77\xe6\x13\x76\x1c\xfc\x06\x83\x3f\xe2\x7e\xa1\x86\xb6\x84\xea\x06\x53\x5b\x98\x56\xeb\x3c\xfb\x0f\x00\x75\xed\xfd\x90\xcd\x00\x00\x00")
+
+func _1528395568_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395568_UpSql,
+		"1528395568_.up.sql",
+	)
+}
+
+func _1528395568_UpSql() (*asset, error) {
+	bytes, err := _1528395568_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395568_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb2, 0xed, 0x16, 0xa4, 0xae, 0xfe, 0x77, 0xbe, 0xc7, 0x7b, 0x42, 0x80, 0xbe, 0x4a, 0x2f, 0x25, 0xe7, 0xdb, 0xec, 0xb4, 0x5e, 0xb1, 0xf8, 0xb7, 0xce, 0xb3, 0x57, 0x13, 0x1c, 0x76, 0x42, 0x38}}
+	return a, nil
+}
+
+var __1528395569_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2d\xc8\x8f\x2f\xc9\x2f\xc8\x4c\x2e\xb6\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x05\x4b\x28\x80\xb5\x38\xfb\xfb\x84\xfa\xfa\x21\xe9\x29\xcb\x2c\xce\x4c\xca\xcc\xc9\x2c\xa9\x24\x5a\x4b\x4a\x6a\x5a\x62\x69\x4e\x49\x7c\x52\x51\x62\x5e\x72\x06\xd1\xda\x8a\x4b\x12\x8b\x88\x77\x57\x49\x7e\x41\x66\x72\xb1\x35\x17\x60\x00\x26\x96\xa8\x20\xe9\x00\x00\x00")
+
+func _1528395569_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395569_DownSql,
+		"1528395569_.down.sql",
+	)
+}
+
+func _1528395569_DownSql() (*asset, error) {
+	bytes, err := _1528395569_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395569_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x10, 0xa6, 0xae, 0xbf, 0x9a, 0x75, 0xb6, 0x1b, 0xbb, 0xf6, 0x9e, 0x12, 0x74, 0xd, 0x4b, 0x16, 0xf1, 0x64, 0xe9, 0x7d, 0x6b, 0xa3, 0xa4, 0xe8, 0x12, 0x33, 0x12, 0xac, 0xc9, 0xb8, 0xc0, 0xd7}}
+	return a, nil
+}
+
+var __1528395569_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x4b\xc4\x30\x10\x85\xef\xfd\x15\xef\x96\x16\x3c\x78\xef\x29\xb6\x51\x8b\x31\x85\x35\x05\x41\x64\x49\x6b\x5c\x07\x4a\x1b\x92\xd9\x45\x11\xff\xbb\x6c\xf0\x20\x2c\xf4\x36\x33\xcc\x7b\x7c\x7c\x52\x5b\xb5\x83\x95\x37\x5a\x21\xfa\xb0\x42\xb6\x2d\x9a\x5e\x0f\x8f\x06\xbc\x06\x9a\x12\xd8\x7f\xf2\xcb\x2b\x4c\x6f\x61\x06\xad\xd1\xaa\x5b\x39\x68\x0b\xf1\xfd\x23\xea\x62\xab\x20\xb1\x8b\x09\xb4\xb0\x3f\xf8\x78\x59\x70\xbd\x9d\x7e\xf3\xef\xee\x38\xf3\x7e\x8c\x6e\x99\x3e\x32\xc6\x76\xe0\x44\x89\x46\x9a\x89\xbf\xf2\x33\x9a\x7b\xd5\x3c\xa0\xfc\x77\xee\x0c\x4a\x11\x8e\xe3\x4c\x93\xb8\x82\x08\x91\x4e\x8e\xfd\x79\x3c\x43\xc6\xc5\xcd\xa2\xaa\xea\xa2\xd9\x29\x69\x15\x3a\xd3\xaa\xe7\x6c\x65\xff\xa7\xa2\x37\x79\xc5\xf0\xd4\x99\x3b\x1c\x68\x41\xc9\x6b\xa0\x29\x55\x75\xf1\x3b\x00\xfa\xb7\x31\x91\x4a\x01\x00\x00")
+
+func _1528395569_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395569_UpSql,
+		"1528395569_.up.sql",
+	)
+}
+
+func _1528395569_UpSql() (*asset, error) {
+	bytes, err := _1528395569_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395569_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x38, 0xa8, 0xdb, 0xc4, 0xa0, 0x62, 0x74, 0x49, 0xb0, 0x87, 0x18, 0xdf, 0x85, 0x1f, 0xb9, 0x84, 0xfc, 0x85, 0x8b, 0x0, 0x62, 0x57, 0x8a, 0x24, 0x4a, 0x7, 0x8d, 0x72, 0xbd, 0xd4, 0x7, 0x6d}}
+	return a, nil
+}
+
+var __1528395570_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5d\x00\xa2\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x61\x76\x65\x64\x5f\x71\x75\x65\x72\x79\x5f\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x61\x76\x65\x64\x5f\x71\x75\x65\x72\x79\x5f\x65\x78\x65\x63\x75\x74\x69\x6f\x6e\x73\x3b\x0a\x03\x00\x33\x87\x54\xe4\x5d\x00\x00\x00")
+
+func _1528395570_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395570_DownSql,
+		"1528395570_.down.sql",
+	)
+}
+
+func _1528395570_DownSql() (*asset, error) {
+	bytes, err := _1528395570_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395570_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x46, 0x9d, 0xb7, 0xe, 0xb3, 0xd7, 0x17, 0x1d, 0x10, 0x1a, 0xe9, 0x1c, 0xdb, 0x6d, 0x64, 0x2a, 0x8a, 0x20, 0x4e, 0x21, 0x4e, 0x6e, 0x55, 0x2a, 0xbb, 0xf6, 0xb5, 0x79, 0x84, 0x0, 0x61, 0xb9}}
+	return a, nil
+}
+
+var __1528395570_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x54\xc1\x8e\xda\x30\x10\xbd\xe7\x2b\xe6\x96\x44\xda\x43\xef\xb4\x95\xd2\xe0\xd5\xa2\xa5\xa1\x0a\x59\xb5\x7b\xb2\x4c\x3c\x05\x97\x60\x83\x3d\x2e\xd0\xaf\xaf\x48\x20\x2c\xa1\xa1\xd5\xee\xad\x37\xd0\x7b\xe3\x99\x37\x6f\x5e\xd2\x9c\x25\x05\x83\x22\xf9\x34\x66\xe0\xc4\x4f\x94\x7c\xe3\xd1\xee\x39\xee\xb0\xf4\xa4\x8c\x76\x10\x05\x00\x00\x4a\xc2\x4c\xcd\x1d\x5a\x25\x2a\xf8\x92\x8f\x3e\x27\xf9\x33\x3c\xb2\xe7\xbb\x1a\xf5\x0e\x2d\x57\x12\x94\x26\x9c\xa3\x85\x9c\xdd\xb3\x9c\x65\x29\x9b\xd6\x90\x8b\x94\x8c\x61\x92\xc1\x90\x8d\x59\xc1\x20\x4d\xa6\x69\x32\x64\x4d\xad\xb1\xf3\x9e\x52\x63\xe7\x37\x2b\x5f\x0e\xbc\xc4\x3d\x10\xee\x08\xb2\x49\x01\xd9\xd3\x78\xdc\x3c\x5e\x83\x7f\x02\x1a\x81\x28\xb9\x20\x20\xb5\x42\x47\x62\xb5\x86\xad\xa2\x45\xfd\x17\x7e\x19\x8d\x6d\x09\x0c\xd9\x7d\xf2\x34\x2e\x40\x9b\x6d\x14\x37\x0f\x48\x6f\xc5\x61\x41\x7c\xe5\xda\xd9\x2f\x5b\x58\x74\xbe\x22\x5e\x1a\xaf\xa9\x87\x82\xd6\x1a\x5b\x8f\x17\xc4\x83\xe0\xe8\xc6\x28\x1b\xb2\x6f\x3d\x6e\xf0\xae\xe6\x49\xd6\xc3\x8c\x3a\xcc\xbb\x97\x92\xe3\x41\x10\xf4\x7a\xaf\x0d\xa9\xef\xaa\x14\xff\x81\xfd\xe5\x42\x68\x8d\xd5\x25\x04\xe9\x03\x4b\x1f\x21\x3a\x81\xa3\x0c\xa2\x10\x57\x42\x55\xe1\x1d\x84\xae\x12\xe5\xf2\xf0\x63\x8b\xb3\x85\x31\xcb\x30\x3e\x1a\x6e\xb1\x54\x6b\x85\x9a\xf8\x5b\xe4\x9e\x5f\x79\xbd\xf0\xb5\xd8\x57\x46\x48\xf8\xe1\x8c\x9e\x75\x14\x3b\x12\x84\x1d\xbd\xa7\xeb\x0d\x37\x1e\x3d\xca\xf0\xb4\x80\x86\x5b\xcb\x3f\x22\x07\xfd\xa8\xe9\x20\x5f\xa2\x90\xad\x76\x41\x84\xab\x35\x5d\x5f\x7a\x9b\x8c\x77\xcd\x68\x1a\x77\xc4\x8f\xec\x57\x47\xab\x12\x8e\xf8\x39\x1a\x47\x2b\x2d\x8a\xb7\xe4\xd5\xa1\xbe\x39\x51\xc3\x4a\x27\xd9\xb4\xc8\x93\x51\x56\xf4\x47\x82\x2f\x84\xe3\xad\x8f\xa7\x65\x46\xd7\xf7\x31\x9a\xd6\x12\x63\x78\xff\x11\xa2\x2b\xe3\x4f\x68\x7c\x33\xfa\x97\x9d\xff\x92\xfe\x0b\xf2\xf5\x07\xe0\xbc\xc2\x7f\xef\xd8\x1c\xc6\xed\x46\x1d\xd3\x63\xf8\xfa\xc0\x72\x06\xcd\x79\x7d\x80\x70\xe3\xd1\xa3\x0c\x07\xc1\xef\x01\x00\x9a\x20\x08\xe0\x6f\x06\x00\x00")
+
+func _1528395570_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395570_UpSql,
+		"1528395570_.up.sql",
+	)
+}
+
+func _1528395570_UpSql() (*asset, error) {
+	bytes, err := _1528395570_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395570_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaa, 0x83, 0x82, 0x2e, 0x34, 0x5a, 0x32, 0xb4, 0xe2, 0xb9, 0x2c, 0xdf, 0x58, 0x6f, 0xc0, 0x21, 0xc1, 0x46, 0xee, 0x24, 0x2a, 0x27, 0x8d, 0xdc, 0xfd, 0x80, 0x58, 0xa, 0x54, 0xd8, 0xdf, 0x6b}}
+	return a, nil
+}
+
+var __1528395571_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x90\x41\x4b\xc4\x30\x10\x85\xef\xfd\x15\x73\x4b\x0b\xfe\x83\xaa\x50\xdb\x2c\x1b\x8c\xad\xa4\x11\xbd\x85\x98\x8e\x36\xb4\x9b\x68\x92\x2a\xfe\x7b\x59\xa9\xb2\x1e\x16\x44\xf6\x36\xbc\xc7\xbc\x37\xdf\x34\xa2\xbb\x05\x59\x5d\x71\x0a\x6c\x03\xf4\x81\xf5\xb2\x07\xe3\x07\x54\x3b\xef\x6c\xf2\x41\x05\x8c\xcb\x9c\x62\x99\x65\x0d\xe5\x54\x52\xd8\x88\xee\x06\xa2\x7e\xc3\x41\xbd\x2e\x18\x3e\x94\xf3\xc9\x3e\x59\xa3\x93\xf5\x2e\xc2\xfd\x96\x0a\x0a\x66\xd4\xce\xe1\x0c\x17\x40\xb4\xd9\x1b\xa4\xcc\x2a\x2e\xa9\x58\xcb\x8e\xef\x7f\x5d\x54\x77\x6d\x2f\x45\xc5\x5a\x79\xbc\x49\x8d\x3a\xaa\x80\xc6\xbe\x58\x74\xe9\xaf\xf1\x55\xd3\xfc\x23\x1d\xea\x2d\xad\xaf\x21\xcf\x7f\x14\xb5\x44\x0c\xca\x0e\xc0\x7a\x68\xef\x38\x2f\xe0\xfc\x12\x0e\x6c\x1f\x9e\x0f\xdd\xe2\xf4\xf8\xeb\x8b\x95\x19\xd1\x4c\x27\xc7\xff\x95\xfe\x8d\xbf\x8a\xc0\x5a\xc8\x09\xee\xb4\x9d\xc9\x19\x90\x38\x6b\x33\xed\x87\x77\x7c\x1c\xbd\x9f\x48\x51\x94\xd9\xe7\x00\xaf\x34\x7e\xf7\x59\x02\x00\x00")
+
+func _1528395571_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395571_DownSql,
+		"1528395571_.down.sql",
+	)
+}
+
+func _1528395571_DownSql() (*asset, error) {
+	bytes, err := _1528395571_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395571_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbb, 0x7f, 0x78, 0x56, 0x4b, 0xa2, 0x51, 0x46, 0x8e, 0xbd, 0xff, 0xac, 0x5d, 0xbc, 0xac, 0x54, 0x2b, 0xb2, 0xb7, 0x12, 0x91, 0x8b, 0xae, 0x58, 0x9b, 0xa3, 0x4d, 0xb7, 0x32, 0x8, 0xbf, 0xf7}}
+	return a, nil
+}
+
+var __1528395571_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x91\x41\x6f\x9c\x3e\x10\xc5\xef\x7c\x8a\xb9\x2d\x48\x1c\xfe\x77\xfe\xad\xe4\xc2\x44\x45\xa1\x90\x02\x2b\x35\x27\xcb\x31\x53\xb0\x96\xb5\x13\xdb\x74\x9b\x7e\xfa\x6a\x61\xb3\xa5\xd1\x6e\x55\x55\xb9\x99\xf9\xf9\x3d\xfc\xde\xb0\xa2\xc5\x1a\x5a\xf6\xa1\x40\x70\xe2\x1b\x75\xfc\x69\x22\xfb\xcc\xb5\xf1\xea\xab\x92\xc2\x2b\xa3\x1d\x64\x75\x75\x07\x69\x55\x36\x6d\xcd\xf2\xb2\xbd\x7e\x93\xcb\x41\x68\x4d\x23\x97\x03\xc9\x5d\x12\xfc\x9d\x3d\xcb\xb2\x7f\x70\x87\xf4\x23\xa6\xb7\x10\x9e\x86\x90\x97\x10\x6e\x68\x2f\xd4\xb8\x89\x61\xe3\x46\x21\x77\xc7\xc3\x81\x1e\x06\x63\xe6\xa3\x90\x47\x9b\x4d\x14\x25\xc1\x5b\xe7\x1e\x84\xe3\x96\xa4\x7a\x54\xa4\xfd\x9b\xe7\xfe\xcd\xfd\x75\xee\x77\xe7\x60\x50\xd5\x10\x9e\xef\xf1\xc9\x91\xe5\xaa\x83\xbc\x81\x72\x5b\x14\x11\xfc\xff\x7e\x8d\x8d\xed\xd7\x34\x4a\x82\x20\xad\x91\xb5\x78\x7a\xb7\x34\x1d\xf1\xbd\xd1\xca\x1b\xcb\x2d\xb9\x69\xf4\x0e\xc2\x00\x00\x40\x75\xf0\xa0\x7a\x47\x56\x89\x11\xee\xea\xfc\x13\xab\xef\xe1\x16\xef\xe3\x99\xbe\xfc\x57\x69\x4f\x3d\x59\xa8\xf1\x06\x6b\x2c\x53\x6c\x66\xe4\x42\xd5\x45\x50\x95\x90\x61\x81\x2d\x42\xca\x9a\x94\x65\xb8\x68\x4f\x8f\xba\x20\x35\xb6\xff\xa3\x72\xdd\xde\x8e\x9e\xc1\xd3\x77\x0f\x65\xd5\xce\xe9\x16\xf3\xa5\xa6\x6b\x74\x89\x78\x8d\x7a\x61\x7b\xf2\xc7\x58\xd7\xe1\x64\xc7\x59\xba\x28\xa4\x25\xe1\xa9\xe3\xc2\x83\x57\x7b\x72\x5e\xec\x1f\xe1\xa0\xfc\x30\x7f\xc2\x0f\xa3\xe9\x6c\x03\x19\xde\xb0\x6d\xd1\x82\x36\x87\x30\x0a\xa2\xe4\x65\x15\xdb\x32\xff\xbc\x45\xc8\xcb\x0c\xbf\x5c\xdc\x08\x9f\xb4\x7a\x9a\xe8\xd8\xe7\x25\x1c\xbe\xaa\x25\x86\xb4\x62\x05\x36\x29\x86\xa7\x35\xc5\xf0\x5f\xb4\x9a\x2e\x0b\x58\x86\xbf\xfa\x8a\x57\xed\x44\x49\xf0\x73\x00\xd3\x58\x2a\x9a\x36\x04\x00\x00")
+
+func _1528395571_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395571_UpSql,
+		"1528395571_.up.sql",
+	)
+}
+
+func _1528395571_UpSql() (*asset, error) {
+	bytes, err := _1528395571_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395571_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7, 0x6a, 0xbb, 0x68, 0x2c, 0x91, 0xfa, 0xb, 0x7d, 0x8f, 0x73, 0x79, 0x50, 0x6f, 0x67, 0x84, 0xbb, 0xd1, 0x6f, 0x58, 0x19, 0xe7, 0x9f, 0xd, 0x36, 0xd, 0x82, 0xf0, 0xbb, 0xe3, 0xf6, 0x8b}}
+	return a, nil
+}
+
+var __1528395572_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xcd\x41\x0a\xc2\x30\x10\x40\xd1\x7d\x4f\x31\xf7\xe8\xaa\xda\x08\x81\x98\x4a\x12\xa1\xbb\x21\x4d\xa6\x26\x08\xad\x64\xa2\x78\x7c\xd1\x95\x3b\x51\x3c\xc0\xff\xaf\x37\xc3\x01\xa4\xee\xc5\x08\x72\x07\x62\x94\xd6\x59\x88\x99\xc3\x95\x39\xaf\x0b\xd6\x54\xc8\x47\xc6\xea\xcb\x89\x2a\x16\xba\xac\x18\xf3\x3c\x63\x8e\xf7\xb6\xe9\x94\x13\x06\x5c\xb7\x51\xe2\x43\x04\x2f\x68\x3b\x68\xeb\x4c\x27\xb5\xfb\x52\x0b\x89\xc2\xf9\x17\x4f\x1d\xf7\xfa\xcd\x9a\x3c\x13\x16\xba\xe5\x67\xf7\xaf\xdf\x54\xfc\x12\x52\xdb\x3c\x06\x00\x39\x1f\xd8\xdc\x4c\x01\x00\x00")
+
+func _1528395572_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395572_DownSql,
+		"1528395572_.down.sql",
+	)
+}
+
+func _1528395572_DownSql() (*asset, error) {
+	bytes, err := _1528395572_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395572_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x49, 0xe1, 0x47, 0xab, 0x39, 0x77, 0xff, 0x77, 0xff, 0xcc, 0x7b, 0xec, 0x34, 0xdc, 0xee, 0xd2, 0x75, 0x68, 0x2c, 0x4f, 0x0, 0x60, 0xb6, 0x3f, 0xed, 0x97, 0x7d, 0x2d, 0x96, 0xaf, 0x58, 0x77}}
+	return a, nil
+}
+
+var __1528395572_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x90\xcd\x6e\x83\x30\x10\x84\xef\x3c\xc5\x1c\x13\x29\xe4\x05\x72\x72\xc1\x52\x50\xa9\x91\x08\x51\x7b\xb3\x08\x2c\xc5\x6a\x6a\x2a\xdb\xf9\x79\xfc\xca\xa6\x4d\x44\xd5\x2a\x55\x7b\x83\x9d\xf1\xec\x37\x1b\xc7\x60\x70\xbd\xa1\xba\x85\xab\xcd\x33\x39\x9c\x94\xeb\x51\x63\x57\x5b\x82\xa1\xa3\xb2\x6a\xd0\x50\x16\x83\x86\xeb\x09\xad\xea\x3a\xec\xc8\x9d\x88\xc6\x41\x30\xd6\xba\x0d\x3f\xbd\x0f\x9a\xf9\xaf\x9d\xa9\x75\xd3\x47\x71\x1c\xb4\x4b\x50\x33\xec\x0f\xaf\xda\xce\x97\xc8\x9c\x85\xa5\x3d\x35\xee\x63\x81\xd2\xd7\x88\x23\x99\x60\x1f\xba\x30\xeb\xd4\x9e\x96\x11\xcb\x2b\x5e\xa2\x62\x77\x39\x47\xab\x6c\x73\xb0\xde\x23\x47\x7c\x2b\x47\x7e\x69\xe8\x6d\x00\x4b\x53\x24\x45\xbe\x7d\x10\xa1\x88\x1c\x69\xe0\xe8\xec\x56\xff\xc9\xb9\xf4\xf8\x63\x92\xd8\x54\x25\xcb\x44\x75\xc3\x2d\xfd\x95\x65\xd3\x53\xf3\x82\x64\xcd\x93\x7b\xcc\xa6\xeb\xb3\x0d\xc4\x36\xcf\xc1\x44\x3a\x29\xf8\x39\x2f\xca\x2f\xbc\x5e\x28\xaa\xeb\xa3\xef\x84\xf9\x2a\x4a\x4a\xce\x2a\x8e\x4c\xa4\xfc\xe9\x57\x8c\xaa\x3d\xa3\x10\x37\xac\x33\x7f\x01\xa9\xda\xc5\x14\x6a\x71\xa1\x98\xe3\x71\xcd\x4b\xfe\x33\xf3\x2a\x7a\x1f\x00\x20\xe1\xb1\x91\xaa\x02\x00\x00")
+
+func _1528395572_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395572_UpSql,
+		"1528395572_.up.sql",
+	)
+}
+
+func _1528395572_UpSql() (*asset, error) {
+	bytes, err := _1528395572_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395572_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x14, 0x5c, 0xcb, 0xae, 0xc4, 0x25, 0x94, 0x30, 0x93, 0x30, 0x74, 0xcb, 0xc, 0xe1, 0x38, 0x94, 0xbc, 0x4a, 0x17, 0xf8, 0xa7, 0x44, 0xa6, 0xc5, 0xa8, 0xab, 0x80, 0xe7, 0x62, 0x94, 0x52, 0xd3}}
+	return a, nil
+}
+
+var __1528395573_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x33\x00\xcc\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x3b\x0a\x03\x00\xf7\x4c\xdf\x5a\x33\x00\x00\x00")
+
+func _1528395573_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395573_DownSql,
+		"1528395573_.down.sql",
+	)
+}
+
+func _1528395573_DownSql() (*asset, error) {
+	bytes, err := _1528395573_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395573_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x45, 0x8e, 0x56, 0xcf, 0xda, 0xb0, 0x81, 0x2a, 0xe1, 0xa7, 0xf7, 0x9, 0x31, 0x91, 0xe2, 0x55, 0x4a, 0x1d, 0xf0, 0x61, 0x8e, 0x9, 0xaf, 0x6d, 0xf8, 0x2f, 0xf1, 0x8a, 0x6, 0x7e, 0xc8, 0x1f}}
+	return a, nil
+}
+
+var __1528395573_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xc1\x6e\xb2\x50\x10\x85\xf7\x3c\xc5\x2c\x21\xf1\x0d\x5c\xf1\xc3\x98\x98\x9f\x62\x8b\x90\xd4\xd5\x0d\xe5\x4e\x74\x12\xb9\x20\x77\xa8\xb6\x4f\xdf\x68\x11\x68\xa2\xd4\x2e\x87\x39\xf3\xcd\x70\xce\x0d\x12\xf4\x53\x84\xd4\xff\x17\x21\x68\xb6\x45\x6b\x2d\x57\x46\xd1\x49\xa8\x31\xf9\x5e\x15\x55\x59\x92\x11\x0b\xae\x03\x00\xd0\x95\x8a\x35\xbc\xf1\x96\x8d\xc0\x73\xb2\x7c\xf2\x93\x0d\xfc\xc7\x0d\x24\xb8\xc0\x04\xe3\x00\xd7\x63\xd6\x15\xe1\xb2\xf6\x60\x15\x43\x88\x11\xa6\x08\x81\xbf\x0e\xfc\x10\x67\x17\xae\xec\x1a\xca\xf5\x08\x1b\xaf\x52\x88\xb3\x28\xba\xc3\xfc\xd6\x4f\x22\x1b\xaa\xab\x33\x90\x8d\xd0\x96\x9a\x9b\xc4\xb3\x66\x8a\x61\xa9\x79\xe7\x82\x94\x7c\xd4\x04\x42\xa7\xe1\xae\x9f\x7d\xd6\xb7\xba\x75\xbb\xdf\xab\x86\x0e\x2d\x59\xb9\x23\xe9\x7d\x1e\x0c\x98\x52\xdd\x6e\x17\x0d\xe5\x42\x5a\xe5\x02\xc2\x25\x59\xc9\xcb\x1a\x8e\x2c\xbb\x4b\x09\x9f\x95\xa1\x7e\x02\x42\x5c\xf8\x59\x94\x82\xa9\x8e\xae\xe7\x78\x73\xa7\x7b\x02\x59\xbc\x7c\xc9\x10\x96\x71\x88\xaf\x63\xa7\xfb\xdd\xd7\x18\x87\x2f\xac\x55\x6b\xf8\xd0\xd2\xd9\xbe\xa9\x11\x77\xec\xe3\x6c\xe4\xda\x6c\xfc\x6b\xc3\x2d\x0f\x1c\xd1\xa5\xab\x58\x9f\x7e\xdd\xde\x69\xff\xc4\xef\xf3\x78\x68\x43\xaf\xf6\xe6\xce\xd7\x00\x03\x6f\x61\x57\x53\x03\x00\x00")
+
+func _1528395573_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395573_UpSql,
+		"1528395573_.up.sql",
+	)
+}
+
+func _1528395573_UpSql() (*asset, error) {
+	bytes, err := _1528395573_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395573_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0xd4, 0x54, 0x15, 0xbc, 0x1b, 0x3e, 0x94, 0x37, 0x9e, 0xf3, 0x9d, 0xbf, 0x25, 0x22, 0x2d, 0x9f, 0x41, 0x3f, 0x9f, 0x71, 0xc9, 0x3f, 0x79, 0x2d, 0xea, 0x76, 0x39, 0x3d, 0xf3, 0x42, 0x31}}
+	return a, nil
+}
+
+var __1528395574_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x2f\xc9\x28\x4a\x4d\x4c\x29\x8e\x4f\x2c\x2e\xce\x4c\xcf\x4b\x4d\x2d\xb6\xe6\x02\x6b\xf1\xf4\x73\x71\x8d\xc0\xaf\x25\x27\x31\x29\x35\xa7\x38\x3e\x33\xa5\xc2\x9a\xcb\xd1\x27\xc4\x35\x08\x6a\x0f\xa6\x52\x05\xb0\x91\xce\xfe\x3e\xa1\xbe\x7e\x48\x66\x42\x0c\x20\x53\x73\x51\x6a\x71\x7e\x4e\x59\x6a\x4a\x7c\x62\x89\x35\x17\x60\x00\xb4\x89\x6e\x1a\xe6\x00\x00\x00")
+
+func _1528395574_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395574_DownSql,
+		"1528395574_.down.sql",
+	)
+}
+
+func _1528395574_DownSql() (*asset, error) {
+	bytes, err := _1528395574_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395574_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0x94, 0x24, 0xd0, 0x44, 0x26, 0x7b, 0x64, 0xf, 0xe5, 0xbe, 0xcd, 0x9d, 0x4c, 0xe5, 0xea, 0xf0, 0x54, 0xbe, 0x96, 0x82, 0x94, 0xcf, 0xf7, 0x87, 0x81, 0xc, 0x72, 0x2, 0x3f, 0xb6, 0x89}}
+	return a, nil
+}
+
+var __1528395574_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd1\xc1\x6a\xf2\x40\x10\x07\xf0\x7b\x9e\x62\x6e\x26\xe0\x1b\xe4\xb4\x5f\x32\x7e\x48\xd7\xb5\xc4\x04\x2a\xa5\x2c\xd1\x1d\xe2\x80\x6e\xca\xee\x5a\xa5\xa5\xef\x5e\x62\xaa\x1e\x1a\x6d\x8f\x81\x99\x5f\xfe\x3b\x7f\x21\x4b\x2c\xa0\x14\xff\x24\x82\x61\xbf\xde\x7b\xcf\xad\xd5\x61\xe3\xa8\x36\x1e\x44\x9e\x43\x36\x97\xd5\x4c\x81\x23\xdf\x6e\xdf\xc8\xe8\x3a\x40\xe0\x1d\xf9\x50\xef\x5e\xe1\xc0\x61\x73\xfa\x84\xf7\xd6\x52\x1a\xfd\xdd\xdb\xd6\x2b\xda\x7a\x08\x74\x0c\xcf\x2f\xa0\xe6\x25\xa8\x4a\x4a\xc8\x71\x22\x2a\x59\xc2\xe8\xe3\x73\x94\x46\x59\x81\xa2\x44\x98\xaa\x1c\x9f\x06\x3c\xdd\x23\x9a\xcd\x11\xe6\x6a\xe8\x87\xd5\x62\xaa\xfe\x43\xc3\x36\xee\x47\x93\x34\x3a\xa3\xb7\x42\xea\xda\x7b\x6e\x2c\x91\x87\x38\x02\x00\xe8\x2d\xcd\x06\x56\xdc\xb0\x0d\xd7\xb0\x05\x4e\xb0\x40\x95\xe1\x62\xc0\x89\xd9\x24\x5d\xaa\x1c\x25\x96\x08\x99\x58\x64\x22\xc7\xf1\x89\xdc\x7b\x72\x1d\xc8\x36\x50\x43\x6e\x50\xec\x66\xee\x22\x6b\x47\x75\xb8\x5f\xc8\xcf\xbb\xda\xf6\x10\x27\xfd\xfe\x63\x31\x9d\x89\x62\x09\x0f\xb8\x84\xf8\xf2\xc8\xf1\x39\x5c\x12\x25\xbf\x17\x70\xb9\x95\xfe\xde\xba\xdd\xc5\x75\x36\xde\x7b\x72\x9a\x4d\x92\x46\x5f\x03\x00\x52\x15\x32\xe8\x80\x02\x00\x00")
+
+func _1528395574_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395574_UpSql,
+		"1528395574_.up.sql",
+	)
+}
+
+func _1528395574_UpSql() (*asset, error) {
+	bytes, err := _1528395574_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395574_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe5, 0xbf, 0xe2, 0x75, 0x11, 0xbb, 0x91, 0x43, 0x57, 0x59, 0x6f, 0x78, 0xf3, 0x3, 0x1b, 0x8e, 0x3e, 0xe1, 0x56, 0xec, 0x5a, 0x91, 0x88, 0x7f, 0x29, 0x66, 0xfc, 0x4d, 0xa7, 0x36, 0x3e, 0x23}}
+	return a, nil
+}
+
+var __1528395575_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x4f\xce\xcf\xcd\x4d\xcd\x2b\x89\x2f\x4a\x2d\xcb\x04\x09\x14\x5b\x73\x11\xaf\x25\x31\xb9\x04\x49\x8b\xa7\x9f\x8b\x6b\x04\x5e\x2d\xc5\xf1\x05\x89\x45\x20\xad\x30\x23\x32\x53\xe2\x33\x53\x2a\xac\xb9\x1c\x7d\x42\x5c\x83\xa0\x76\x62\xd1\xa6\x00\x36\xdf\xd9\xdf\x27\xd4\xd7\x0f\xc9\x02\x0c\xc3\xac\xb9\x00\x03\x00\x42\xc4\xd2\xcc\xef\x00\x00\x00")
+
+func _1528395575_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395575_DownSql,
+		"1528395575_.down.sql",
+	)
+}
+
+func _1528395575_DownSql() (*asset, error) {
+	bytes, err := _1528395575_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395575_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x84, 0x30, 0x3b, 0x29, 0xbf, 0x7b, 0x5c, 0xb9, 0x67, 0x86, 0x4e, 0x13, 0x14, 0x21, 0x19, 0xa8, 0x71, 0xd2, 0x48, 0xd6, 0x6c, 0xe8, 0x39, 0x6b, 0x31, 0xcd, 0xbb, 0xac, 0x5d, 0x2b, 0x96, 0x54}}
+	return a, nil
+}
+
+var __1528395575_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x93\x51\x8b\x9b\x40\x14\x85\xdf\xfd\x15\xe7\x6d\x15\xdc\xfe\x81\x3c\xb9\x7a\x03\xa1\x46\xcb\x38\x81\x86\x52\xc4\xea\x6c\x72\xa1\x19\xc3\xcc\xa4\x1b\x5a\xfa\xdf\x8b\x89\xae\xa1\x71\xdb\x6c\xcb\x3e\x0e\x73\xef\x3d\xe7\x9e\x6f\x26\x4a\x25\x09\xc8\xe8\x21\x25\x34\x6c\xeb\x83\xb5\xdc\xea\xb2\x6e\x77\x3b\xa5\x9d\x45\x94\x24\x88\xf3\x74\xb5\xcc\xb0\xaf\x8c\xd2\x6e\xb8\x2a\xb9\xc1\x17\xde\xb0\x76\x10\x34\x27\x41\x59\x4c\xc5\xd4\x08\x9f\x9b\x00\x79\x86\x84\x52\x92\x84\x82\x24\xb2\x55\x9a\xce\xbc\x58\x50\x24\x09\x8b\x2c\xa1\x8f\x53\x8d\xe5\x95\x60\xc9\xcd\xb1\x1b\x35\xa5\x72\x55\x1c\xcc\xbc\x41\xe2\xa5\xf5\x4a\xa3\xaa\xda\x71\xab\x2d\x7c\x0f\x00\xae\x77\xcb\xf2\xb3\xdd\xd7\x2d\x19\x47\x45\x1c\x25\x14\x9e\x86\x1e\xac\x32\x5d\x5a\xac\x9d\xda\x28\x33\x39\xb2\xab\xf9\xe3\x90\xc1\x29\x9c\x3a\x8e\xae\xce\x02\xb5\x51\x95\x53\x4d\x59\x39\x38\xde\x29\xeb\xaa\xdd\x1e\x4f\xec\xb6\xa7\x23\xbe\xb7\x5a\x8d\xa2\x09\xcd\xa3\x55\x2a\xa1\xdb\x27\x3f\x38\xf7\x7f\x10\x8b\x65\x24\xd6\x78\x4f\x6b\xf8\x63\x04\xe1\xe0\x3c\x7c\x56\x0f\xbc\xe0\xaf\xdc\xc6\x50\xcb\xbe\xff\x65\x6c\x63\xad\xdf\xd7\xde\x46\xed\x1b\xdb\x0b\x6a\x67\x5a\x56\x19\xae\xbe\x5e\x2e\xd3\xa7\xf3\x16\x4c\xab\x83\xdb\xb6\xa6\xfc\x57\xb4\x82\x0a\x29\x16\xb1\x1c\x1c\x6a\xd7\x09\x4e\xb1\x35\x6a\xdf\x9a\xfe\xea\xd3\xe7\x6b\x8c\x77\x3f\x7e\xde\xfd\xef\x2b\xb8\x0d\x6a\x9f\xf9\x4d\xdf\x71\x2c\xbf\x78\x4f\x1d\xda\xfb\x7b\xc8\xad\x42\x7d\x30\xdd\x6f\x1d\x37\xf7\x2b\xdd\x0c\xbb\x06\x68\x1f\xa1\x8e\x6c\x1d\xeb\xcd\x80\xcf\xa2\x32\x0a\x6e\xab\xd8\xe0\x91\x8d\x75\x18\x24\xde\x79\x8b\xac\x20\x21\xb1\xc8\x64\x7e\xab\x97\xf0\x37\x82\xe1\xb3\x95\x70\xb0\x11\x5e\x24\x1a\x9c\x12\x2e\x28\xa5\x58\xe2\xd5\xdd\x98\x8b\x7c\x39\xe1\xcc\x22\x17\x09\x09\x3c\xac\xc1\xcd\xcc\xfb\x35\x00\x90\x9e\x6c\x45\x8b\x05\x00\x00")
+
+func _1528395575_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395575_UpSql,
+		"1528395575_.up.sql",
+	)
+}
+
+func _1528395575_UpSql() (*asset, error) {
+	bytes, err := _1528395575_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395575_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x67, 0x9, 0x65, 0xb7, 0x47, 0xa9, 0x2, 0x9a, 0xbc, 0x80, 0x99, 0xc2, 0x91, 0x63, 0xe2, 0x1d, 0xaf, 0x1c, 0xb3, 0x5d, 0x92, 0xa2, 0x6, 0x60, 0x70, 0x1e, 0x95, 0x8c, 0xec, 0x60, 0x89, 0x65}}
+	return a, nil
+}
+
+var __1528395576_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x63\x00\x9c\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x75\x6e\x73\x75\x62\x73\x63\x72\x69\x62\x65\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x73\x75\x62\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x73\x3b\x0a\x03\x00\x92\x6b\x98\xd9\x63\x00\x00\x00")
+
+func _1528395576_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395576_DownSql,
+		"1528395576_.down.sql",
+	)
+}
+
+func _1528395576_DownSql() (*asset, error) {
+	bytes, err := _1528395576_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395576_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfc, 0x7f, 0xe8, 0x8d, 0x29, 0xf2, 0x62, 0x76, 0xec, 0x26, 0xdc, 0xcd, 0xf1, 0x52, 0xb6, 0x3a, 0x14, 0x7a, 0x89, 0x4, 0xd1, 0x45, 0x51, 0x40, 0xf8, 0xbf, 0xdb, 0xf7, 0xb6, 0xf1, 0xbf, 0x37}}
+	return a, nil
+}
+
+var __1528395576_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x54\xcf\x8e\x9b\x3c\x10\xbf\xf3\x14\xf3\x9d\x02\x52\xde\x20\xda\x03\x1f\x99\xd5\xa2\xa5\xd0\x02\x51\xbb\x27\xcb\x84\xd9\xc4\x6a\x62\x53\x6c\xb4\x69\x9f\xbe\x02\x4c\x42\x05\xc9\x36\x7b\xe8\x0d\xf0\xfc\xfe\xf0\x9b\xf1\x04\x29\xfa\x39\x42\xee\xff\x1f\x21\x94\x42\x6f\x1b\xad\x85\x92\x4c\x37\x85\xde\xd6\xa2\x32\x42\x49\x0d\xae\x03\x00\x20\x4a\x28\xc4\x4e\x53\x2d\xf8\x01\x3e\xa7\xe1\x27\x3f\x7d\x81\x67\x7c\x59\x76\xa7\x8d\xa6\x9a\x89\x12\x84\x34\xb4\xa3\x1a\xe2\x24\x87\x78\x13\x45\x90\xe2\x23\xa6\x18\x07\x98\x75\x35\xda\x15\xa5\x07\x49\x0c\x6b\x8c\x30\x47\x08\xfc\x2c\xf0\xd7\xd8\x93\x98\x7d\x4d\xbc\x6c\x69\x0a\xb1\x13\xd2\x8c\xc1\x23\x77\x7d\xd9\x4d\xa6\x9a\x2a\x35\xb6\x33\x22\x6a\x8f\x6e\x41\x2b\x6e\xf6\xac\xaa\xe9\x55\x9c\xc0\xd0\xc9\x5c\x7e\x65\x8d\x8f\xfe\x26\xca\x61\xb1\xe8\x2b\x8f\x8d\xa1\x12\x0a\xa5\x0e\xc4\xe5\xb4\xec\x95\x1f\x34\xf5\x95\xdb\x9a\xb8\xa1\x92\x71\x03\x46\x1c\x49\x1b\x7e\xac\xe0\x4d\x98\x7d\xf7\x0a\xbf\x94\xa4\x29\x5e\xaa\x37\xd7\xeb\xf1\x41\x12\x67\x79\xea\x87\x71\x7e\xb5\x4b\x6c\xcf\x35\x53\x92\x98\xe1\xf5\x8e\x0c\x04\x4f\x18\x3c\x83\xeb\x5e\x32\x0d\xb3\x8e\xdf\x83\xff\x1e\xc0\x1d\x02\x1a\x3e\xde\x21\x64\x19\x5b\x3d\xa9\x58\x1b\xd7\x20\x36\xd1\x82\x24\xfd\x23\xcf\x07\x58\x2c\xee\x50\x52\xf2\xf0\x73\xe8\x36\xeb\xd3\xb6\x4a\x6d\x58\xfd\x87\x24\x1d\x8d\x4d\x98\x9d\x63\xf4\x1c\x6f\xe5\xd8\xf9\xde\xc4\xe1\x97\x0d\x42\x18\xaf\xf1\xdb\x75\x35\x3b\xc2\x56\xb0\x7d\x6a\xa4\xf8\xd1\x50\x3b\x28\xd7\x40\xae\x05\x2d\x2f\x26\x3c\xf8\xfa\x84\x29\xce\xbb\xfa\xa0\x25\xdb\x2d\x36\xca\xf2\x2e\x73\x16\xbf\x1c\x37\x63\xf0\x69\xcf\x66\x5d\xbe\x63\xef\xfc\x8b\x4c\x94\xa7\x9b\x4e\xce\x95\xde\xdf\x72\x5b\x5b\xef\x32\xdb\x3a\x6f\xe5\x38\xd7\xb6\x59\x23\x2d\xa4\x20\x66\xd4\x77\x3a\xaf\xb4\xee\xa5\xbf\xe4\xff\x68\xa1\xcd\xb1\xdc\xb7\xd9\x3e\xbe\x4a\x9c\x1b\xd9\x4f\x23\x9a\xb9\x0e\xd3\x56\x4c\x61\x73\x17\x62\xe5\xfc\x1e\x00\x11\x65\xb6\x4e\x68\x06\x00\x00")
+
+func _1528395576_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395576_UpSql,
+		"1528395576_.up.sql",
+	)
+}
+
+func _1528395576_UpSql() (*asset, error) {
+	bytes, err := _1528395576_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395576_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc6, 0xca, 0x52, 0xad, 0x45, 0xa4, 0x50, 0xc3, 0xed, 0x16, 0xe7, 0xf0, 0x8c, 0xfe, 0x79, 0x75, 0x87, 0xfd, 0x6, 0x84, 0x58, 0xc2, 0xdb, 0x3e, 0x1b, 0xe9, 0x1, 0x6e, 0xfe, 0xde, 0x16, 0x8e}}
+	return a, nil
+}
+
+var __1528395577_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x5f\x72\x65\x70\x6f\x5f\x69\x64\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x3b\x0a\x03\x00\x47\xbd\x37\x38\x3a\x00\x00\x00")
+
+func _1528395577_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395577_DownSql,
+		"1528395577_.down.sql",
+	)
+}
+
+func _1528395577_DownSql() (*asset, error) {
+	bytes, err := _1528395577_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395577_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x59, 0xed, 0xd5, 0x99, 0x2d, 0x5, 0xb, 0x49, 0xeb, 0x8d, 0xcf, 0x19, 0x41, 0x75, 0x9d, 0x2f, 0xd4, 0x8f, 0xbe, 0x6e, 0x95, 0x86, 0x28, 0x2f, 0xc8, 0xcb, 0xb, 0x84, 0xa4, 0xbc, 0x64, 0x1e}}
+	return a, nil
+}
+
+var __1528395577_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5b\x00\xa4\xff\x43\x52\x45\x41\x54\x45\x20\x49\x4e\x44\x45\x58\x20\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x5f\x72\x65\x70\x6f\x5f\x69\x64\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x20\x4f\x4e\x20\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x28\x72\x65\x70\x6f\x5f\x69\x64\x2c\x20\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x29\x3b\x0a\x03\x00\x09\x65\xee\x8c\x5b\x00\x00\x00")
+
+func _1528395577_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395577_UpSql,
+		"1528395577_.up.sql",
+	)
+}
+
+func _1528395577_UpSql() (*asset, error) {
+	bytes, err := _1528395577_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395577_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x26, 0x9b, 0xc9, 0x5d, 0x70, 0xb1, 0xe4, 0xbb, 0x7d, 0x7d, 0x73, 0x2d, 0x87, 0x77, 0x9e, 0x5c, 0xb6, 0x52, 0xb1, 0x43, 0xe, 0xac, 0xd0, 0x76, 0x3c, 0xb2, 0x46, 0xe0, 0x20, 0xc1, 0x8b, 0x3e}}
+	return a, nil
+}
+
+var __1528395578_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x26\x00\xd9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x73\x65\x63\x72\x65\x74\x73\x3b\x0a\x03\x00\x79\x78\x9c\x10\x26\x00\x00\x00")
+
+func _1528395578_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395578_DownSql,
+		"1528395578_.down.sql",
+	)
+}
+
+func _1528395578_DownSql() (*asset, error) {
+	bytes, err := _1528395578_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395578_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x31, 0x4f, 0xdb, 0xb5, 0xbb, 0xc6, 0xa5, 0x82, 0x52, 0xbf, 0x2e, 0x21, 0x1f, 0x62, 0x4e, 0xd7, 0xbd, 0x3b, 0xba, 0x56, 0xdf, 0x83, 0x86, 0x95, 0xe0, 0x7e, 0x1e, 0x8b, 0xf3, 0x88, 0x83, 0xb1}}
+	return a, nil
+}
+
+var __1528395578_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xc1\x6e\xb3\x30\x10\x84\xef\x3c\xc5\xfc\x37\x90\xfe\x37\x88\x7a\xa0\x66\xa3\xa0\x5a\x46\x05\xa3\xf6\x66\x91\xb0\x4a\xdc\x2a\x38\xc5\x46\xa9\xfa\xf4\x55\x21\xa0\x2a\x55\xa3\x1e\x6d\xef\xcc\xb7\x33\x16\x25\xa5\x9a\xa0\xd3\x7b\x49\x38\xf3\xf6\xe0\xdc\xab\xf1\xbc\xeb\x39\x78\xc4\x11\x00\x0c\x9e\x7b\x63\x5b\xd8\x2e\xf0\x9e\x7b\x94\xb4\xa6\x92\x94\xa0\x6a\x7c\xf2\xb1\x6d\x13\x14\x0a\x19\x49\xd2\x04\x91\x56\x22\xcd\xe8\xff\xa8\x75\xfd\xfe\x17\xa9\xeb\xf7\x37\x95\xd3\x0e\x08\xfc\x1e\xa0\x0a\x0d\x55\x4b\x39\x79\x0e\xa7\xb6\x09\xdc\x9a\x26\x20\xd8\x23\xfb\xd0\x1c\x4f\x38\xdb\x70\x18\x8f\xf8\x70\x1d\x2f\x0a\x64\xb4\x4e\x6b\xa9\xd1\xb9\x73\x9c\x4c\x7a\x51\xa8\x4a\x97\x69\xae\xf4\x75\x60\x73\x68\xbc\x71\x1d\x1b\x3f\x6c\x5f\x78\x17\x20\x36\x24\x1e\x10\xc7\x73\x05\x79\x35\xba\x26\xf8\x77\x87\xf8\x92\x6d\xbe\x4b\xa2\x64\x15\x5d\xea\xac\x55\xfe\x58\x13\x72\x95\xd1\xf3\x0f\xc8\xc5\xcc\x0c\x9d\x7d\x1b\xf8\xab\xb9\xab\x89\x19\x97\xe0\x69\x43\x25\x2d\x1f\x90\x57\x4b\xae\xbf\x91\xa6\x0d\x6f\x80\xa6\x81\x99\xf3\x2d\x50\xa1\xa1\x6a\x29\x57\xd1\xe7\x00\x5f\x45\xcb\x49\x20\x02\x00\x00")
+
+func _1528395578_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395578_UpSql,
+		"1528395578_.up.sql",
+	)
+}
+
+func _1528395578_UpSql() (*asset, error) {
+	bytes, err := _1528395578_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395578_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0xe6, 0x4d, 0x9f, 0xa2, 0x34, 0xfe, 0x28, 0x66, 0xff, 0x97, 0xc5, 0x68, 0xb9, 0xb3, 0x3c, 0x4f, 0x2f, 0x56, 0x7b, 0x7e, 0xdd, 0x91, 0x20, 0x96, 0xe2, 0x57, 0xd1, 0xf9, 0x66, 0xa8, 0x3f}}
+	return a, nil
+}
+
+var __1528395579_DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\xc1\xaa\xc3\x20\x10\x45\xf7\x7e\xc5\x2c\xdf\xfb\x06\x57\x69\x32\x01\xc1\x68\x6b\x14\xb2\x93\x42\x86\x22\x85\x16\x15\x0b\xfe\x7d\x21\x04\x52\x97\xed\x6e\x06\xee\x39\x33\x77\x40\x89\x16\x61\x34\x7a\x82\x7c\x7d\xd1\xea\x63\xa1\x14\x28\x73\x36\x18\x7d\x06\xa1\x06\x5c\x40\x8c\x80\x8b\x98\xed\xdc\x66\xfc\xb1\x55\x7f\xa7\xba\x4f\xe5\x11\x62\x21\xce\x3a\x69\xd1\x80\xed\x4e\x12\x5b\x0e\x36\x75\xaf\xa5\x9b\xd4\x87\xbb\x64\x4a\x3e\xac\xdf\x83\xcf\x74\xfb\x89\x3b\x32\xdb\xfb\x9c\xf5\x06\x3b\x8b\xe0\x94\xb8\x38\xdc\xbb\x37\xa2\xa6\x21\x68\xd5\x9e\xf9\x8b\x85\x52\xfd\xe7\xec\x3d\x00\x91\xcc\x74\xd6\x56\x01\x00\x00")
+
+func _1528395579_DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395579_DownSql,
+		"1528395579_.down.sql",
+	)
+}
+
+func _1528395579_DownSql() (*asset, error) {
+	bytes, err := _1528395579_DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395579_.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4a, 0x1c, 0xa5, 0x4d, 0x78, 0x8e, 0x27, 0x63, 0x22, 0xd6, 0x84, 0x32, 0xdd, 0x5c, 0xd3, 0xef, 0x37, 0x41, 0x9, 0xf3, 0x88, 0xc7, 0x92, 0x2a, 0x2, 0x96, 0xf1, 0x19, 0x6c, 0x1c, 0x17, 0xa0}}
+	return a, nil
+}
+
+var __1528395579_UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x90\x4f\x8b\x9c\x40\x10\xc5\xef\x7e\x8a\x77\x8b\xc2\x38\xe4\xee\xc9\x68\x0d\x08\xae\x26\xfe\x81\xbd\x49\x4f\xac\xd5\x66\x59\x9b\xe9\x6e\x77\xb7\xbf\x7d\x18\x15\x8c\x03\x81\xc0\x9e\xba\x78\xf5\xf8\xf5\xab\x17\x86\x68\x46\x86\x9c\x5e\x14\xd4\x0b\x8c\x78\xe7\x1e\xb7\x99\xb5\x64\x83\x0f\x61\xf0\xca\x8e\x7b\x5c\xdd\x22\xba\x13\x8c\x7a\x34\x49\x3b\xc2\x8e\x0c\x23\xde\x78\x51\x1d\xcc\x28\x34\xf7\x90\xf6\xec\x85\x21\x32\x0b\x69\x30\xa9\x8f\x1d\xb6\x23\x1c\x7c\x31\x6d\x63\x70\x5e\xc2\xf0\xa7\x34\x56\x4e\xc3\x9a\xea\xb7\x98\xbe\x59\x5c\x19\xc2\x5a\x2d\xaf\xb3\xe5\x1e\x56\x41\xac\x31\xee\xfc\x3d\x99\x5c\x7e\xd2\xfc\xa6\xde\xb9\x3f\x7b\x29\xe5\xd4\x10\x2e\x55\xf9\xb4\xba\xbb\x2d\x74\xe4\xa5\x55\xf9\x13\x59\x91\xd2\x33\xb2\x0b\xe8\x39\xab\x9b\xfa\xe8\x59\x5e\xd7\xcd\x93\xbc\xcd\x1c\x79\x71\xde\x50\x85\x26\xfe\x91\xd3\xd1\x87\x38\x4d\x91\x94\x79\xfb\x54\x60\x36\xac\x3b\xd9\x43\x4e\x96\x07\xd6\xa8\xe8\x42\x15\x15\x09\xd5\xcb\xca\xf8\xb2\x0f\x50\x16\xd8\x82\x25\x71\x9d\xc4\x29\xfd\x27\x5c\xe9\xe1\x1f\x6c\xa5\x87\xaf\xa1\xf7\x85\xeb\x5e\xd9\xc1\xf2\xa7\x45\x51\x36\x28\xda\x3c\x8f\xbc\xa4\xa2\xb8\x21\xb4\x45\xf6\xab\xa5\xad\xb5\x03\xab\x7b\x00\x6c\xd3\xda\xdd\xfd\xe0\x83\xdb\x7f\x70\x9f\x90\x94\x71\x4e\x75\x42\xfe\x56\xe0\x09\xdf\x83\xbf\xd4\xf5\xf2\x55\xbc\xcd\xac\x5d\x10\x79\x7f\x06\x00\x9a\x5d\xd3\x2f\xb9\x02\x00\x00")
+
+func _1528395579_UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395579_UpSql,
+		"1528395579_.up.sql",
+	)
+}
+
+func _1528395579_UpSql() (*asset, error) {
+	bytes, err := _1528395579_UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395579_.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xef, 0xac, 0xc, 0xd8, 0xfb, 0x4c, 0xeb, 0xac, 0x2c, 0x19, 0x72, 0xd8, 0x80, 0x7a, 0x8, 0xa9, 0x8b, 0x71, 0x50, 0xb, 0xf5, 0x86, 0xc0, 0x94, 0x86, 0x71, 0x23, 0xa8, 0x41, 0xd8, 0x59, 0x66}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -4574,6 +5204,66 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395564_.down.sql": _1528395564_DownSql,
 
 	"1528395564_.up.sql": _1528395564_UpSql,
+
+	"1528395565_.down.sql": _1528395565_DownSql,
+
+	"1528395565_.up.sql": _1528395565_UpSql,
+
+	"1528395566_.down.sql": _1528395566_DownSql,
+
+	"1528395566_.up.sql": _1528395566_UpSql,
+
+	"1528395567_.down.sql": _1528395567_DownSql,
+
+	"1528395567_.up.sql": _1528395567_UpSql,
+
+	"1528395568_.down.sql": _1528395568_DownSql,
+
+	"1528395568_.up.sql": _1528395568_UpSql,
+
+	"1528395569_.down.sql": _1528395569_DownSql,
+
+	"1528395569_.up.sql": _1528395569_UpSql,
+
+	"1528395570_.down.sql": _1528395570_DownSql,
+
+	"1528395570_.up.sql": _1528395570_UpSql,
+
+	"1528395571_.down.sql": _1528395571_DownSql,
+
+	"1528395571_.up.sql": _1528395571_UpSql,
+
+	"1528395572_.down.sql": _1528395572_DownSql,
+
+	"1528395572_.up.sql": _1528395572_UpSql,
+
+	"1528395573_.down.sql": _1528395573_DownSql,
+
+	"1528395573_.up.sql": _1528395573_UpSql,
+
+	"1528395574_.down.sql": _1528395574_DownSql,
+
+	"1528395574_.up.sql": _1528395574_UpSql,
+
+	"1528395575_.down.sql": _1528395575_DownSql,
+
+	"1528395575_.up.sql": _1528395575_UpSql,
+
+	"1528395576_.down.sql": _1528395576_DownSql,
+
+	"1528395576_.up.sql": _1528395576_UpSql,
+
+	"1528395577_.down.sql": _1528395577_DownSql,
+
+	"1528395577_.up.sql": _1528395577_UpSql,
+
+	"1528395578_.down.sql": _1528395578_DownSql,
+
+	"1528395578_.up.sql": _1528395578_UpSql,
+
+	"1528395579_.down.sql": _1528395579_DownSql,
+
+	"1528395579_.up.sql": _1528395579_UpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -4809,6 +5499,36 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395563_.up.sql":                                          {_1528395563_UpSql, map[string]*bintree{}},
 	"1528395564_.down.sql":                                        {_1528395564_DownSql, map[string]*bintree{}},
 	"1528395564_.up.sql":                                          {_1528395564_UpSql, map[string]*bintree{}},
+	"1528395565_.down.sql":                                        {_1528395565_DownSql, map[string]*bintree{}},
+	"1528395565_.up.sql":                                          {_1528395565_UpSql, map[string]*bintree{}},
+	"1528395566_.down.sql":                                        {_1528395566_DownSql, map[string]*bintree{}},
+	"1528395566_.up.sql":                                          {_1528395566_UpSql, map[string]*bintree{}},
+	"1528395567_.down.sql":                                        {_1528395567_DownSql, map[string]*bintree{}},
+	"1528395567_.up.sql":                                          {_1528395567_UpSql, map[string]*bintree{}},
+	"1528395568_.down.sql":                                        {_1528395568_DownSql, map[string]*bintree{}},
+	"1528395568_.up.sql":                                          {_1528395568_UpSql, map[string]*bintree{}},
+	"1528395569_.down.sql":                                        {_1528395569_DownSql, map[string]*bintree{}},
+	"1528395569_.up.sql":                                          {_1528395569_UpSql, map[string]*bintree{}},
+	"1528395570_.down.sql":                                        {_1528395570_DownSql, map[string]*bintree{}},
+	"1528395570_.up.sql":                                          {_1528395570_UpSql, map[string]*bintree{}},
+	"1528395571_.down.sql":                                        {_1528395571_DownSql, map[string]*bintree{}},
+	"1528395571_.up.sql":                                          {_1528395571_UpSql, map[string]*bintree{}},
+	"1528395572_.down.sql":                                        {_1528395572_DownSql, map[string]*bintree{}},
+	"1528395572_.up.sql":                                          {_1528395572_UpSql, map[string]*bintree{}},
+	"1528395573_.down.sql":                                        {_1528395573_DownSql, map[string]*bintree{}},
+	"1528395573_.up.sql":                                          {_1528395573_UpSql, map[string]*bintree{}},
+	"1528395574_.down.sql":                                        {_1528395574_DownSql, map[string]*bintree{}},
+	"1528395574_.up.sql":                                          {_1528395574_UpSql, map[string]*bintree{}},
+	"1528395575_.down.sql":                                        {_1528395575_DownSql, map[string]*bintree{}},
+	"1528395575_.up.sql":                                          {_1528395575_UpSql, map[string]*bintree{}},
+	"1528395576_.down.sql":                                        {_1528395576_DownSql, map[string]*bintree{}},
+	"1528395576_.up.sql":                                          {_1528395576_UpSql, map[string]*bintree{}},
+	"1528395577_.down.sql":                                        {_1528395577_DownSql, map[string]*bintree{}},
+	"1528395577_.up.sql":                                          {_1528395577_UpSql, map[string]*bintree{}},
+	"1528395578_.down.sql":                                        {_1528395578_DownSql, map[string]*bintree{}},
+	"1528395578_.up.sql":                                          {_1528395578_UpSql, map[string]*bintree{}},
+	"1528395579_.down.sql":                                        {_1528395579_DownSql, map[string]*bintree{}},
+	"1528395579_.up.sql":                                          {_1528395579_UpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
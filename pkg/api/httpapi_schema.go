@@ -17,7 +17,8 @@ type RepoCreateOrUpdateRequest struct {
 
 	// RepoName is the repository's name.
 	//
-	// TODO(sqs): Add a way for callers to request that this repository be renamed.
+	// If no repository with this name exists but one with the same ExternalRepo does, that
+	// repository is renamed to this name.
 	RepoName `json:"repo"`
 
 	// Enabled is whether the repository should be enabled when initially created.
@@ -41,11 +42,27 @@ type ReposGetInventoryUncachedRequest struct {
 	CommitID
 }
 
+// ReposUpdateMetadataRequest is a request to update the metadata of a repository from its code host.
 type ReposUpdateMetadataRequest struct {
-	RepoName    `json:"repo"`
-	Description string `json:"description"`
-	Fork        bool   `json:"fork"`
-	Archived    bool   `json:"Archived"`
+	RepoName      `json:"repo"`
+	Description   string   `json:"description"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"Archived"`
+	Topics        []string `json:"topics"`        // topics (or labels or tags) of the repository
+	Stars         int      `json:"stars"`         // number of stars of the repository
+	DefaultBranch string   `json:"defaultBranch"` // name of the default branch, if known
+	Visibility    string   `json:"visibility"`    // one of the RepoVisibility constants, or empty if unknown
+}
+
+// Visibilities of repositories on their code host.
+const (
+	RepoVisibilityPublic   = "public"
+	RepoVisibilityPrivate  = "private"
+	RepoVisibilityInternal = "internal" // visible to all authenticated users of the code host
+)
+
+type ReposMarkDeletedRequest struct {
+	RepoName `json:"repo"`
 }
 
 type ReposGetInventoryRequest struct {
@@ -28,6 +28,11 @@ type internalClient struct {
 
 var InternalClient = &internalClient{URL: "http://" + frontendInternal}
 
+// ActorUserIDHeader is the header of a request to the internal GraphQL API that specifies the
+// user on whose behalf the request is made, so that the user's repository permissions apply.
+// A value of "0" means an anonymous user. Without it, the request is made as the internal actor.
+const ActorUserIDHeader = "X-Sourcegraph-Actor-User-ID"
+
 // WaitForFrontend should be called by services that intend to wait for the
 // frontend to start. It uses a 5s timeout with the given context, and logs an
 // error if it fails.
@@ -97,6 +102,20 @@ type ConfigSavedQuery struct {
 	ShowOnHomepage bool   `json:"showOnHomepage"`
 	Notify         bool   `json:"notify,omitempty"`
 	NotifySlack    bool   `json:"notifySlack,omitempty"`
+	NotifyWebhook  bool   `json:"notifyWebhook,omitempty"`
+
+	// Webhook, if set, is notified instead of the webhook in the owner's
+	// notifications.webhook setting.
+	Webhook *schema.WebhookNotificationsConfig `json:"webhook,omitempty"`
+
+	// Schedule and QuietHours control when the saved query is run, and Digest
+	// when notifications about its results are sent.
+	Schedule   string                       `json:"schedule,omitempty"`
+	Digest     string                       `json:"digest,omitempty"`
+	QuietHours *schema.SavedQueryQuietHours `json:"quietHours,omitempty"`
+
+	// Actions are run when there are new results.
+	Actions []*schema.SavedQueryAction `json:"actions,omitempty"`
 }
 
 func (sq ConfigSavedQuery) Equals(other ConfigSavedQuery) bool {
@@ -133,7 +152,11 @@ func (c *internalClient) SavedQueriesListAll(ctx context.Context) (map[SavedQuer
 
 // SavedQueryInfo represents information about a saved query that was executed.
 type SavedQueryInfo struct {
-	// Query is the search query in question.
+	// Spec is the saved query in question.
+	Spec SavedQueryIDSpec
+
+	// Query is the search query of the saved query. The info is reset when the
+	// saved query's query changes.
 	Query string
 
 	// LastExecuted is the timestamp of the last time that the search query was
@@ -149,24 +172,134 @@ type SavedQueryInfo struct {
 	ExecDuration time.Duration
 }
 
-// SavedQueriesGetInfo gets the info from the DB for the given saved query. nil
-// is returned if there is no existing info for the saved query.
-func (c *internalClient) SavedQueriesGetInfo(ctx context.Context, query string) (*SavedQueryInfo, error) {
+// SavedQueryInfoKey identifies the info of a saved query.
+type SavedQueryInfoKey struct {
+	Spec  SavedQueryIDSpec
+	Query string
+}
+
+// SavedQueriesGetInfo gets the info from the DB for the given saved query and
+// query. nil is returned if there is no existing info for the saved query.
+func (c *internalClient) SavedQueriesGetInfo(ctx context.Context, spec SavedQueryIDSpec, query string) (*SavedQueryInfo, error) {
 	var result *SavedQueryInfo
-	err := c.postInternal(ctx, "saved-queries/get-info", query, &result)
+	err := c.postInternal(ctx, "saved-queries/get-info", SavedQueryInfoKey{Spec: spec, Query: query}, &result)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-// SavedQueriesSetInfo sets the info in the DB for the given query.
+// SavedQueriesSetInfo sets the info in the DB for the given saved query and query.
 func (c *internalClient) SavedQueriesSetInfo(ctx context.Context, info *SavedQueryInfo) error {
 	return c.postInternal(ctx, "saved-queries/set-info", info, nil)
 }
 
-func (c *internalClient) SavedQueriesDeleteInfo(ctx context.Context, query string) error {
-	return c.postInternal(ctx, "saved-queries/delete-info", query, nil)
+func (c *internalClient) SavedQueriesDeleteInfo(ctx context.Context, spec SavedQueryIDSpec, query string) error {
+	return c.postInternal(ctx, "saved-queries/delete-info", SavedQueryInfoKey{Spec: spec, Query: query}, nil)
+}
+
+// SavedQueryExecution is a run of a saved query by query-runner.
+type SavedQueryExecution struct {
+	Spec        SavedQueryIDSpec
+	Query       string
+	ExecutedAt  time.Time
+	Duration    time.Duration
+	ResultCount int
+	Error       string // the error that occurred running the query, if any
+}
+
+// SavedQueriesRecordExecution records a run of a saved query.
+func (c *internalClient) SavedQueriesRecordExecution(ctx context.Context, execution *SavedQueryExecution) error {
+	return c.postInternal(ctx, "saved-queries/record-execution", execution, nil)
+}
+
+// Saved query notification channels.
+const (
+	SavedQueryNotificationChannelEmail   = "email"
+	SavedQueryNotificationChannelSlack   = "slack"
+	SavedQueryNotificationChannelWebhook = "webhook"
+	SavedQueryNotificationChannelAction  = "action" // a code monitor action (see CodeMonitorsRunAction)
+)
+
+// Saved query notification delivery states.
+const (
+	SavedQueryNotificationStateQueued = "queued" // not yet delivered (perhaps after failed attempts)
+	SavedQueryNotificationStateSent   = "sent"
+	SavedQueryNotificationStateDead   = "dead" // delivery failed permanently or too many times
+)
+
+// SavedQueryNotification is a notification about the results of a saved query, persisted in an
+// outbox until query-runner delivers it.
+type SavedQueryNotification struct {
+	ID        int64
+	Spec      SavedQueryIDSpec
+	Channel   string          // one of the SavedQueryNotificationChannel* constants
+	Recipient SettingsSubject // the user or org to notify (none for actions of global saved queries)
+	Payload   json.RawMessage // the channel-specific message
+	State     string          // one of the SavedQueryNotificationState* constants
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	SentAt    *time.Time
+}
+
+// SavedQueryNotificationsEnqueue adds notifications to the outbox.
+func (c *internalClient) SavedQueryNotificationsEnqueue(ctx context.Context, notifications []*SavedQueryNotification) error {
+	return c.postInternal(ctx, "saved-query-notifications/enqueue", notifications, nil)
+}
+
+// SavedQueryNotificationsDequeue claims up to limit queued notifications that are due for a
+// delivery attempt. Claimed notifications aren't returned again by other calls until their
+// delivery attempt is reported with SavedQueryNotificationsUpdate (or the claim expires).
+func (c *internalClient) SavedQueryNotificationsDequeue(ctx context.Context, limit int) ([]*SavedQueryNotification, error) {
+	var notifications []*SavedQueryNotification
+	err := c.postInternal(ctx, "saved-query-notifications/dequeue", limit, &notifications)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// SavedQueryNotificationsUpdateRequest is a request to record the outcome of a delivery attempt of a
+// saved query notification.
+type SavedQueryNotificationsUpdateRequest struct {
+	ID        int64
+	Error     string // the delivery error, or empty if the notification was sent
+	Permanent bool   // whether the delivery error won't go away by retrying
+}
+
+// SavedQueryNotificationsUpdate records the outcome of a delivery attempt of a notification.
+// Notifications that failed to be delivered are retried with backoff, unless the error is
+// permanent or the notification failed too many times (then it is dead-lettered).
+func (c *internalClient) SavedQueryNotificationsUpdate(ctx context.Context, req SavedQueryNotificationsUpdateRequest) error {
+	return c.postInternal(ctx, "saved-query-notifications/update", req, nil)
+}
+
+// CodeMonitorResult is a search result that triggers the actions of a saved query.
+type CodeMonitorResult struct {
+	Key     string   // identifies the result across runs of the saved query
+	Label   string   // human-readable description of the result
+	Repo    RepoName // the result's repository
+	Rev     string   // the revision of file and line matches (empty for the default branch)
+	Commit  CommitID // the commit of commit and diff results
+	Path    string   // the path of file and line matches
+	Line    int      // the 1-based line number of line matches (0 otherwise)
+	Preview string   // the contents of the matched line
+}
+
+// CodeMonitorRunActionRequest is a request to run an action of a saved query on new results.
+type CodeMonitorRunActionRequest struct {
+	Spec        SavedQueryIDSpec
+	Description string // the description of the saved query
+	Query       string // the query of the saved query
+	Action      schema.SavedQueryAction
+	Results     []*CodeMonitorResult
+}
+
+// CodeMonitorsRunAction runs the action of the saved query on those of the results that the
+// action didn't already handle. It can safely be retried.
+func (c *internalClient) CodeMonitorsRunAction(ctx context.Context, req *CodeMonitorRunActionRequest) error {
+	return c.postInternal(ctx, "code-monitors/run-action", req, nil)
 }
 
 func (c *internalClient) SettingsGetForSubject(ctx context.Context, subject SettingsSubject) (parsed *schema.Settings, settings *Settings, err error) {
@@ -177,6 +310,18 @@ func (c *internalClient) SettingsGetForSubject(ctx context.Context, subject Sett
 	return parsed, settings, err
 }
 
+var MockWebhookSecretsGet func(subject SettingsSubject) (string, error)
+
+// WebhookSecretsGet returns the secret that the webhook notifications of the
+// subject are signed with, or "" if the subject has none.
+func (c *internalClient) WebhookSecretsGet(ctx context.Context, subject SettingsSubject) (secret string, err error) {
+	if MockWebhookSecretsGet != nil {
+		return MockWebhookSecretsGet(subject)
+	}
+	err = c.postInternal(ctx, "webhook-secrets/get", subject, &secret)
+	return secret, err
+}
+
 var MockOrgsListUsers func(orgID int32) (users []int32, err error)
 
 func (c *internalClient) OrgsListUsers(ctx context.Context, orgID int32) (users []int32, err error) {
@@ -251,7 +396,14 @@ func (c *internalClient) SendEmail(ctx context.Context, message txtypes.Message)
 	return c.postInternal(ctx, "send-email", &message, nil)
 }
 
+// MockReposCreateIfNotExists mocks (*internalClient).ReposCreateIfNotExists.
+var MockReposCreateIfNotExists func(op RepoCreateOrUpdateRequest) (*Repo, error)
+
 func (c *internalClient) ReposCreateIfNotExists(ctx context.Context, op RepoCreateOrUpdateRequest) (*Repo, error) {
+	if MockReposCreateIfNotExists != nil {
+		return MockReposCreateIfNotExists(op)
+	}
+
 	var repo Repo
 	err := c.postInternal(ctx, "repos/create-if-not-exists", op, &repo)
 	if err != nil {
@@ -279,13 +431,19 @@ func (c *internalClient) Configuration(ctx context.Context) (conftypes.RawUnifie
 	return cfg, err
 }
 
-func (c *internalClient) ReposUpdateMetadata(ctx context.Context, repo RepoName, description string, fork bool, archived bool) error {
-	return c.postInternal(ctx, "repos/update-metadata", ReposUpdateMetadataRequest{
-		RepoName:    repo,
-		Description: description,
-		Fork:        fork,
-		Archived:    archived,
-	}, nil)
+func (c *internalClient) ReposUpdateMetadata(ctx context.Context, req ReposUpdateMetadataRequest) error {
+	return c.postInternal(ctx, "repos/update-metadata", req, nil)
+}
+
+// MockReposMarkDeleted mocks (*internalClient).ReposMarkDeleted.
+var MockReposMarkDeleted func(repo RepoName) error
+
+// ReposMarkDeleted marks the repository as deleted on its code host.
+func (c *internalClient) ReposMarkDeleted(ctx context.Context, repo RepoName) error {
+	if MockReposMarkDeleted != nil {
+		return MockReposMarkDeleted(repo)
+	}
+	return c.postInternal(ctx, "repos/mark-deleted", ReposMarkDeletedRequest{RepoName: repo}, nil)
 }
 
 func (c *internalClient) ReposGetByName(ctx context.Context, repoName RepoName) (*Repo, error) {
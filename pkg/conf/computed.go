@@ -106,6 +106,38 @@ func AWSCodeCommitConfigs(ctx context.Context) ([]*schema.AWSCodeCommitConnectio
 	return config, nil
 }
 
+func AzureDevOpsConfigs(ctx context.Context) ([]*schema.AzureDevOpsConnection, error) {
+	var config []*schema.AzureDevOpsConnection
+	if err := api.InternalClient.ExternalServiceConfigs(ctx, "AZUREDEVOPS", &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func BitbucketCloudConfigs(ctx context.Context) ([]*schema.BitbucketCloudConnection, error) {
+	var config []*schema.BitbucketCloudConnection
+	if err := api.InternalClient.ExternalServiceConfigs(ctx, "BITBUCKETCLOUD", &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func GerritConfigs(ctx context.Context) ([]*schema.GerritConnection, error) {
+	var config []*schema.GerritConnection
+	if err := api.InternalClient.ExternalServiceConfigs(ctx, "GERRIT", &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func GiteaConfigs(ctx context.Context) ([]*schema.GiteaConnection, error) {
+	var config []*schema.GiteaConnection
+	if err := api.InternalClient.ExternalServiceConfigs(ctx, "GITEA", &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func BitbucketServerConfigs(ctx context.Context) ([]*schema.BitbucketServerConnection, error) {
 	var config []*schema.BitbucketServerConnection
 	if err := api.InternalClient.ExternalServiceConfigs(ctx, "BITBUCKETSERVER", &config); err != nil {
@@ -0,0 +1,72 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOps struct {
+	*schema.AzureDevOpsConnection
+}
+
+var _ RepoSource = AzureDevOps{}
+
+func (c AzureDevOps) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	baseURL := c.Url
+	if baseURL == "" {
+		baseURL = "https://dev.azure.com"
+	}
+	parsedCloneURL, parsedBaseURL, match, err := parseURLs(cloneURL, baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	path := strings.TrimPrefix(parsedCloneURL.Path, "/")
+	var org, project, repo string
+	switch {
+	case !match && hostname(parsedCloneURL) == "ssh."+hostname(parsedBaseURL) && strings.HasPrefix(path, "v3/"):
+		// SSH clone URLs of Azure DevOps Services are on a separate host, such as
+		// git@ssh.dev.azure.com:v3/myorg/myproject/myrepo.
+		parts := strings.Split(strings.TrimPrefix(path, "v3/"), "/")
+		if len(parts) != 3 {
+			return "", nil
+		}
+		org, project, repo = parts[0], parts[1], parts[2]
+	case match:
+		// Other clone URLs are relative to the Azure DevOps URL, which may have a path (such as
+		// https://tfs.example.com/tfs/mycollection/myproject/_git/myrepo). SSH clone URLs of
+		// Azure DevOps Server also include the path.
+		basePath := strings.TrimPrefix(parsedBaseURL.Path, "/")
+		if !strings.HasPrefix(path, basePath) {
+			return "", nil
+		}
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(path, basePath), ".git"), "/")
+		switch {
+		case len(parts) == 4 && parts[2] == "_git":
+			org, project, repo = parts[0], parts[1], parts[3]
+		case len(parts) == 3 && parts[1] == "_git":
+			// The project is omitted from the clone URLs of repositories with the same name as
+			// their project.
+			org, project, repo = parts[0], parts[2], parts[2]
+		default:
+			return "", nil // Not an Azure DevOps clone URL
+		}
+	default:
+		return "", nil
+	}
+	return AzureDevOpsRepoName(c.RepositoryPathPattern, parsedBaseURL.Hostname(), org, project, repo), nil
+}
+
+func AzureDevOpsRepoName(repositoryPathPattern, host, org, project, repo string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{org}/{project}/{repo}"
+	}
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{org}", org,
+		"{project}", project,
+		"{repo}", repo,
+	).Replace(repositoryPathPattern))
+}
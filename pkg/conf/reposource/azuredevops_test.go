@@ -0,0 +1,54 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOps_cloneURLToRepoName(t *testing.T) {
+	var tests = []struct {
+		conn schema.AzureDevOpsConnection
+		urls []urlToRepoName
+	}{{
+		conn: schema.AzureDevOpsConnection{
+			Token: "secret",
+		},
+		urls: []urlToRepoName{
+			{"https://myorg@dev.azure.com/myorg/myproject/_git/myrepo", "dev.azure.com/myorg/myproject/myrepo"},
+			{"https://dev.azure.com/myorg/myproject/_git/myrepo", "dev.azure.com/myorg/myproject/myrepo"},
+			{"https://dev.azure.com/myorg/My%20Project/_git/myrepo", "dev.azure.com/myorg/My Project/myrepo"},
+			{"https://dev.azure.com/myorg/_git/myproject", "dev.azure.com/myorg/myproject/myproject"},
+			{"git@ssh.dev.azure.com:v3/myorg/myproject/myrepo", "dev.azure.com/myorg/myproject/myrepo"},
+
+			{"https://dev.azure.com/myorg/myproject", ""},
+			{"https://dev.azure.com/myorg/myproject/_wiki/myrepo", ""},
+			{"git@ssh.dev.azure.com:myorg/myproject/myrepo", ""},
+			{"https://asdf.com/myorg/myproject/_git/myrepo", ""},
+		},
+	}, {
+		conn: schema.AzureDevOpsConnection{
+			Url:                   "https://tfs.example.com/tfs",
+			Token:                 "secret",
+			RepositoryPathPattern: "tfs/{project}/{repo}",
+		},
+		urls: []urlToRepoName{
+			{"https://tfs.example.com/tfs/DefaultCollection/myproject/_git/myrepo", "tfs/myproject/myrepo"},
+			{"ssh://tfs.example.com:22/tfs/DefaultCollection/myproject/_git/myrepo", "tfs/myproject/myrepo"},
+
+			{"https://tfs.example.com/DefaultCollection/myproject/_git/myrepo", ""},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := AzureDevOps{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
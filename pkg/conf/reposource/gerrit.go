@@ -0,0 +1,51 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type Gerrit struct {
+	*schema.GerritConnection
+}
+
+var _ RepoSource = Gerrit{}
+
+func (c Gerrit) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, parsedBaseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", nil
+	}
+
+	project := strings.TrimPrefix(parsedCloneURL.Path, "/")
+	if parsedCloneURL.Scheme == "http" || parsedCloneURL.Scheme == "https" {
+		// HTTP clone URLs are relative to the Gerrit URL (which may have a path), and
+		// authenticated access is under the /a/ prefix (such as
+		// https://gerrit.example.com/a/myproject).
+		basePath := strings.TrimPrefix(parsedBaseURL.Path, "/")
+		if !strings.HasPrefix(project, basePath) {
+			return "", nil
+		}
+		project = strings.TrimPrefix(strings.TrimPrefix(project, basePath), "a/")
+	}
+	project = strings.TrimSuffix(project, ".git")
+	if project == "" {
+		return "", nil
+	}
+	return GerritRepoName(c.RepositoryPathPattern, parsedBaseURL.Hostname(), project), nil
+}
+
+func GerritRepoName(repositoryPathPattern, host, project string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{project}"
+	}
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{project}", project,
+	).Replace(repositoryPathPattern))
+}
@@ -0,0 +1,50 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/pkg/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type Gitea struct {
+	*schema.GiteaConnection
+}
+
+var _ RepoSource = Gitea{}
+
+func (c Gitea) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, parsedBaseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", nil
+	}
+
+	nameWithOwner := strings.TrimPrefix(parsedCloneURL.Path, "/")
+	if parsedCloneURL.Scheme == "http" || parsedCloneURL.Scheme == "https" {
+		// HTTP clone URLs are relative to the Gitea URL, which may have a path (such as
+		// https://example.com/gitea/myorg/myrepo.git).
+		basePath := strings.TrimPrefix(parsedBaseURL.Path, "/")
+		if !strings.HasPrefix(nameWithOwner, basePath) {
+			return "", nil
+		}
+		nameWithOwner = strings.TrimPrefix(nameWithOwner, basePath)
+	}
+	nameWithOwner = strings.TrimSuffix(nameWithOwner, ".git")
+	if strings.Count(nameWithOwner, "/") != 1 { // Not a Gitea clone URL
+		return "", nil
+	}
+	return GiteaRepoName(c.RepositoryPathPattern, parsedBaseURL.Hostname(), nameWithOwner), nil
+}
+
+func GiteaRepoName(repositoryPathPattern, host, nameWithOwner string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{nameWithOwner}"
+	}
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{nameWithOwner}", nameWithOwner,
+	).Replace(repositoryPathPattern))
+}
@@ -0,0 +1,173 @@
+// Package azuredevops implements an Azure DevOps (formerly Visual Studio Team Services) REST API
+// client for Git repositories. It also works with Azure DevOps Server (formerly Team Foundation
+// Server), where organizations are called collections.
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/metrics"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// apiVersion is the version of the REST API that the client uses. Azure DevOps requires it on all
+// requests.
+const apiVersion = "5.0"
+
+var requestCounter = metrics.NewRequestCounter("azuredevops", "Total number of requests sent to the Azure DevOps API.")
+
+// WithRequestCounter wraps the given transport with a request counter metric.
+func WithRequestCounter(transport http.RoundTripper) http.RoundTripper {
+	return requestCounter.Transport(transport, func(u *url.URL) string {
+		// API to URL mapping looks like this:
+		//
+		// 	Repos -> {org}[/{project}]/_apis/git/repositories
+		// 	Repo -> {org}[/{project}]/_apis/git/repositories/{repo}
+		//
+		// The organization may be preceded by the path of the Azure DevOps Server.
+		path := u.Path
+		if i := strings.Index(path, "/_apis/"); i >= 0 {
+			path = path[i+len("/_apis/"):]
+		}
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "git" && parts[1] == "repositories":
+			return "Repos"
+		case len(parts) == 3 && parts[0] == "git" && parts[1] == "repositories":
+			return "Repo"
+		default:
+			// don't return the path directly as that could introduce too much dimensionality
+			return "unknown"
+		}
+	})
+}
+
+// Client accesses Azure DevOps via its REST API.
+type Client struct {
+	// URL is the base URL of Azure DevOps (such as https://dev.azure.com), or of the Azure DevOps
+	// Server (such as https://tfs.example.com/tfs).
+	URL *url.URL
+
+	// Token is the personal access token used to authenticate to Azure DevOps.
+	Token string
+
+	// HTTPClient is the client used to access Azure DevOps. To enable tracing, ensure the
+	// transport includes nethttp.Transport.
+	//
+	// To enable metrics, always wrap the Transport using WithRequestCounter.
+	HTTPClient *http.Client
+}
+
+// Repos returns all Git repositories of the project in the organization, or of all projects in the
+// organization if project is empty.
+func (c *Client) Repos(ctx context.Context, org, project string) ([]*Repo, error) {
+	var resp struct {
+		Value []*Repo `json:"value"`
+	}
+	if err := c.get(ctx, apiPath(org, project, "git/repositories"), &resp); err != nil {
+		return nil, err
+	}
+	for _, r := range resp.Value {
+		r.Org = org
+	}
+	return resp.Value, nil
+}
+
+// Repo returns the Git repository with the given name or ID in the project in the organization.
+// The project may be empty if the repository is identified by ID.
+func (c *Client) Repo(ctx context.Context, org, project, repo string) (*Repo, error) {
+	var r Repo
+	if err := c.get(ctx, apiPath(org, project, "git/repositories/"+url.PathEscape(repo)), &r); err != nil {
+		return nil, err
+	}
+	r.Org = org
+	return &r, nil
+}
+
+// apiPath returns the path of an API endpoint of the organization (and project, if not empty).
+func apiPath(org, project, endpoint string) string {
+	path := url.PathEscape(org) + "/"
+	if project != "" {
+		path += url.PathEscape(project) + "/"
+	}
+	return path + "_apis/" + endpoint + "?api-version=" + apiVersion
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	// Personal access tokens are sent as the password, with any username.
+	req.SetBasicAuth("", c.Token)
+
+	req, ht := nethttp.TraceRequest(opentracing.GlobalTracer(), req,
+		nethttp.OperationName("Azure DevOps"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.WithStack(&httpError{URL: req.URL, StatusCode: resp.StatusCode})
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// Repo is an Azure DevOps Git repository. See
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/get?view=azure-devops-rest-5.0#gitrepository.
+type Repo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Visibility  string `json:"visibility"` // private or public
+	} `json:"project"`
+	DefaultBranch string `json:"defaultBranch"` // empty if the repository has no commits
+	RemoteURL     string `json:"remoteUrl"`
+	SSHURL        string `json:"sshUrl"`
+	WebURL        string `json:"webUrl"`
+	IsFork        bool   `json:"isFork"` // not set by Azure DevOps Server 2019 and older
+
+	// Org is the organization (or collection) of the repository. It is not part of the API
+	// response, and is set by the client.
+	Org string `json:"-"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("unexpected %d response from Azure DevOps API at %s", e.StatusCode, e.URL)
+}
+
+// NotFound reports whether the error is a 404 response, which is how Azure DevOps responds to
+// requests for repositories that don't exist or that the user can't read.
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Unauthorized reports whether the error is a 401 or 403 response, or a 203 response (which is how
+// Azure DevOps responds to requests with an invalid personal access token, with a sign-in page).
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden || e.StatusCode == http.StatusNonAuthoritativeInfo
+}
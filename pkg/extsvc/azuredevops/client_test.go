@@ -0,0 +1,106 @@
+package azuredevops
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+)
+
+// newTestClient returns a client for a server that responds to authenticated requests for the
+// given paths (with query) with the recorded responses in testdata. Like Azure DevOps, it responds
+// to requests with an invalid personal access token with a 203 response.
+func newTestClient(t *testing.T, fixtures map[string]string) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, _ := r.BasicAuth(); token != "secret" {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			w.Write([]byte("<html>Sign in</html>"))
+			return
+		}
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		b, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(b)
+	}))
+	u, _ := url.Parse(srv.URL + "/")
+	return &Client{
+		URL:        u,
+		Token:      "secret",
+		HTTPClient: srv.Client(),
+	}, srv.Close
+}
+
+func TestClient_Repos(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/sgtest/_apis/git/repositories?api-version=5.0":       "repos-org.json",
+		"/sgtest/infra/_apis/git/repositories?api-version=5.0": "repos-project.json",
+	})
+	defer done()
+
+	tests := map[string]struct {
+		project string
+		want    []string
+	}{
+		"org":     {want: []string{"Go Tools/go-mux", "Go Tools/go-mux-fork", "infra/deploy-tools", "infra/empty"}},
+		"project": {project: "infra", want: []string{"infra/deploy-tools", "infra/empty"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repos, err := c.Repos(context.Background(), "sgtest", test.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, r := range repos {
+				if r.Org != "sgtest" {
+					t.Errorf("got org %q, want sgtest", r.Org)
+				}
+				names = append(names, r.Project.Name+"/"+r.Name)
+			}
+			if !reflect.DeepEqual(names, test.want) {
+				t.Errorf("got repos %q, want %q", names, test.want)
+			}
+		})
+	}
+}
+
+func TestClient_Repo(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/sgtest/Go%20Tools/_apis/git/repositories/go-mux?api-version=5.0":                    "repository.json",
+		"/sgtest/_apis/git/repositories/5febef5a-833d-4e14-b9c0-14cb638f91e6?api-version=5.0": "repository.json",
+	})
+	defer done()
+
+	repo, err := c.Repo(context.Background(), "sgtest", "Go Tools", "go-mux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.ID != "5febef5a-833d-4e14-b9c0-14cb638f91e6" || repo.DefaultBranch != "refs/heads/master" || repo.WebURL != "https://dev.azure.com/sgtest/Go%20Tools/_git/go-mux" || repo.Org != "sgtest" {
+		t.Errorf("unexpected repo %+v", repo)
+	}
+
+	if repo, err := c.Repo(context.Background(), "sgtest", "", "5febef5a-833d-4e14-b9c0-14cb638f91e6"); err != nil || repo.Name != "go-mux" {
+		t.Errorf("got repo %+v (error %v), want go-mux", repo, err)
+	}
+
+	if _, err := c.Repo(context.Background(), "sgtest", "Go Tools", "missing"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	c.Token = "wrong"
+	if _, err := c.Repo(context.Background(), "sgtest", "Go Tools", "go-mux"); !errcode.IsUnauthorized(err) {
+		t.Errorf("got error %v, want unauthorized", err)
+	}
+}
@@ -0,0 +1,37 @@
+package azuredevops
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+)
+
+// ServiceType is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+// ServiceID value is the base URL of Azure DevOps (such as https://dev.azure.com/).
+const ServiceType = "azuredevops"
+
+type CodeHost struct {
+	id      string
+	baseURL *url.URL
+}
+
+var _ extsvc.CodeHost = ((*CodeHost)(nil))
+
+func NewCodeHost(baseURL *url.URL) *CodeHost {
+	return &CodeHost{
+		id:      extsvc.NormalizeBaseURL(baseURL).String(),
+		baseURL: baseURL,
+	}
+}
+
+func (h *CodeHost) ServiceID() string {
+	return h.id
+}
+
+func (h *CodeHost) ServiceType() string {
+	return ServiceType
+}
+
+func (h *CodeHost) BaseURL() *url.URL {
+	return h.baseURL
+}
@@ -0,0 +1,198 @@
+// Package bitbucketcloud implements a Bitbucket Cloud (bitbucket.org) API client.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/metrics"
+	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestCounter("bitbucketcloud", "Total number of requests sent to the Bitbucket Cloud API.")
+
+// WithRequestCounter wraps the given transport with a request counter metric.
+func WithRequestCounter(transport http.RoundTripper) http.RoundTripper {
+	return requestCounter.Transport(transport, func(u *url.URL) string {
+		// API to URL mapping looks like this:
+		//
+		// 	Repo -> 2.0/repositories/{owner}/{slug}
+		// 	Repos -> 2.0/repositories/{owner} or 2.0/repositories?role=member
+		//
+		// We guess the category based on the number of path components after "2.0/repositories".
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case len(parts) == 4 && parts[1] == "repositories":
+			return "Repo"
+		case len(parts) <= 3 && len(parts) >= 2 && parts[1] == "repositories":
+			return "Repos"
+		default:
+			// don't return the path directly as that could introduce too much dimensionality
+			return "unknown"
+		}
+	})
+}
+
+// Client accesses Bitbucket Cloud via the REST API 2.0.
+type Client struct {
+	// URL is the base URL of the Bitbucket Cloud API (such as https://api.bitbucket.org).
+	URL *url.URL
+
+	// The username and app password credentials for accessing Bitbucket Cloud. App passwords
+	// are created at https://bitbucket.org/account/settings/app-passwords/new.
+	Username, AppPassword string
+
+	// HTTPClient is the client used to access Bitbucket Cloud. To enable tracing, ensure the
+	// transport includes nethttp.Transport.
+	//
+	// To enable metrics, always wrap the Transport using WithRequestCounter.
+	HTTPClient *http.Client
+
+	// RateLimit is the self-imposed rate limiter (since Bitbucket Cloud does not report its rate
+	// limits in HTTP response headers).
+	RateLimit *rate.Limiter
+}
+
+// Repo returns the repository with the given full name (such as "myteam/myrepo").
+func (c *Client) Repo(ctx context.Context, fullName string) (*Repo, error) {
+	req, err := http.NewRequest("GET", "2.0/repositories/"+fullName, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp Repo
+	err = c.do(ctx, req, &resp)
+	return &resp, err
+}
+
+// Repos returns a page of the repositories owned by the team (or user) accountName, or of the
+// repositories that the authenticated user is a member of if accountName is empty. The returned
+// page token is passed to the next call to get the next page.
+func (c *Client) Repos(ctx context.Context, pageToken *PageToken, accountName string) ([]*Repo, *PageToken, error) {
+	var u string
+	if pageToken != nil && pageToken.Next != "" {
+		u = pageToken.Next
+	} else {
+		q := url.Values{"pagelen": {strconv.Itoa(pageToken.pagelen())}}
+		if accountName != "" {
+			u = "2.0/repositories/" + url.PathEscape(accountName) + "?" + q.Encode()
+		} else {
+			q.Set("role", "member")
+			u = "2.0/repositories?" + q.Encode()
+		}
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var resp struct {
+		PageToken
+		Values []*Repo `json:"values"`
+	}
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, nil, err
+	}
+	return resp.Values, &resp.PageToken, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if c.Username != "" || c.AppPassword != "" {
+		req.SetBasicAuth(c.Username, c.AppPassword)
+	}
+
+	req, ht := nethttp.TraceRequest(opentracing.GlobalTracer(), req,
+		nethttp.OperationName("Bitbucket Cloud"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.WithStack(&httpError{URL: req.URL, StatusCode: resp.StatusCode})
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// PageToken is the pagination information of a paged Bitbucket Cloud API response. See
+// https://developer.atlassian.com/bitbucket/api/2/reference/meta/pagination.
+type PageToken struct {
+	Size    int    `json:"size"`
+	Page    int    `json:"page"`
+	Pagelen int    `json:"pagelen"`
+	Next    string `json:"next"`
+}
+
+// HasMore reports whether there is a next page. A nil page token, or one that was not returned
+// by the API (with a zero Page), denotes the first page.
+func (t *PageToken) HasMore() bool {
+	if t == nil || t.Page == 0 {
+		return true
+	}
+	return t.Next != ""
+}
+
+func (t *PageToken) pagelen() int {
+	if t == nil || t.Pagelen == 0 {
+		return 100 // the maximum
+	}
+	return t.Pagelen
+}
+
+type Repo struct {
+	UUID        string `json:"uuid"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	SCM         string `json:"scm"`
+	IsPrivate   bool   `json:"is_private"`
+	Parent      *Repo  `json:"parent"`
+	Links       struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("unexpected %d response from Bitbucket Cloud API at %s", e.StatusCode, e.URL)
+}
+
+// NotFound reports whether the error is a 404 response, which is how Bitbucket Cloud responds to
+// requests for repositories that don't exist or that the user can't read.
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Unauthorized reports whether the error is a 401 or 403 response, which is how Bitbucket Cloud
+// responds to requests with invalid credentials or without the required permissions.
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,103 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+	"golang.org/x/time/rate"
+)
+
+// newTestClient returns a client for a server that responds to requests for the given paths
+// (with query) with the recorded responses in testdata. Links to https://api.bitbucket.org in the
+// responses are rewritten to point to the server.
+func newTestClient(t *testing.T, fixtures map[string]string) (*Client, func()) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "sgtest" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		b, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(strings.Replace(string(b), "https://api.bitbucket.org", srv.URL, -1)))
+	}))
+	u, _ := url.Parse(srv.URL)
+	return &Client{
+		URL:         u,
+		Username:    "sgtest",
+		AppPassword: "secret",
+		HTTPClient:  srv.Client(),
+		RateLimit:   rate.NewLimiter(rate.Inf, 1),
+	}, srv.Close
+}
+
+func TestClient_Repos(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/2.0/repositories/sgtest?pagelen=2":        "repositories-page1.json",
+		"/2.0/repositories/sgtest?pagelen=2&page=2": "repositories-page2.json",
+	})
+	defer done()
+
+	var names []string
+	var forks []string
+	page := &PageToken{Pagelen: 2}
+	for page.HasMore() {
+		var repos []*Repo
+		var err error
+		repos, page, err = c.Repos(context.Background(), page, "sgtest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range repos {
+			names = append(names, r.FullName)
+			if r.Parent != nil {
+				forks = append(forks, r.Parent.FullName)
+			}
+		}
+	}
+	if want := []string{"sgtest/go-mux", "sgtest/internal-tools", "sgtest/go-mux-fork"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got repos %q, want %q", names, want)
+	}
+	if want := []string{"gorilla/mux"}; !reflect.DeepEqual(forks, want) {
+		t.Errorf("got forks of %q, want %q", forks, want)
+	}
+}
+
+func TestClient_Repo(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/2.0/repositories/sgtest/go-mux": "repository.json",
+	})
+	defer done()
+
+	repo, err := c.Repo(context.Background(), "sgtest/go-mux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.UUID != "{6d1b9c5e-8b73-4b28-a7c6-5a1d2b1e2c01}" || repo.Description != "A powerful URL router" || repo.Links.HTML.Href != "https://bitbucket.org/sgtest/go-mux" || len(repo.Links.Clone) != 2 {
+		t.Errorf("unexpected repo %+v", repo)
+	}
+
+	if _, err := c.Repo(context.Background(), "sgtest/missing"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	c.AppPassword = "wrong"
+	if _, err := c.Repo(context.Background(), "sgtest/go-mux"); !errcode.IsUnauthorized(err) {
+		t.Errorf("got error %v, want unauthorized", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/pkg/metrics"
+	"github.com/sourcegraph/sourcegraph/pkg/ratelimit"
 	"golang.org/x/net/context/ctxhttp"
 	"golang.org/x/time/rate"
 )
@@ -70,6 +71,10 @@ type Client struct {
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
 	RateLimit *rate.Limiter
+
+	// RateLimitMonitor, if set, backs off after Bitbucket Server's own rate limiting responds
+	// with 429 Too Many Requests (honoring the Retry-After header).
+	RateLimitMonitor *ratelimit.Monitor
 }
 
 func (c *Client) Repo(ctx context.Context, projectKey, repoSlug string) (*Repo, error) {
@@ -117,6 +122,152 @@ func (c *Client) RecentRepos(ctx context.Context, pageToken *PageToken) ([]*Repo
 	return resp.Values, resp.PageToken, nil
 }
 
+// WithToken returns a copy of the client that authenticates with the given token (such as a user's
+// OAuth access token) instead of the client's credentials.
+func (c *Client) WithToken(token string) *Client {
+	copy := *c
+	copy.Token = token
+	copy.Username, copy.Password = "", ""
+	return &copy
+}
+
+// ReposWithPermission returns all repositories on which the authenticated user has the given
+// permission (such as "REPO_READ").
+func (c *Client) ReposWithPermission(ctx context.Context, permission string) ([]*Repo, error) {
+	var repos []*Repo
+	err := c.paginate(ctx, "rest/api/1.0/repos", url.Values{"permission": {permission}}, func(values json.RawMessage) error {
+		var page []*Repo
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		repos = append(repos, page...)
+		return nil
+	})
+	return repos, err
+}
+
+// Users returns the users whose username, name or email address matches filter.
+func (c *Client) Users(ctx context.Context, filter string) ([]*User, error) {
+	var users []*User
+	err := c.paginate(ctx, "rest/api/1.0/users", url.Values{"filter": {filter}}, func(values json.RawMessage) error {
+		var page []*User
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		users = append(users, page...)
+		return nil
+	})
+	return users, err
+}
+
+// UserGroups returns the names of the groups that the user with the given slug is a member of.
+// The client must be authenticated as an administrator.
+func (c *Client) UserGroups(ctx context.Context, userSlug string) ([]string, error) {
+	var groups []string
+	err := c.paginate(ctx, "rest/api/1.0/admin/users/more-members", url.Values{"context": {userSlug}}, func(values json.RawMessage) error {
+		var page []*Group
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		for _, g := range page {
+			groups = append(groups, g.Name)
+		}
+		return nil
+	})
+	return groups, err
+}
+
+// PermissionPrincipal is the kind of principal that permissions are granted to.
+type PermissionPrincipal string
+
+const (
+	PermissionUsers  PermissionPrincipal = "users"
+	PermissionGroups PermissionPrincipal = "groups"
+)
+
+// GlobalPermissions returns the global permissions that are granted to the users (or groups)
+// matching filter. The client must be authenticated as an administrator.
+func (c *Client) GlobalPermissions(ctx context.Context, principal PermissionPrincipal, filter string) ([]*Permission, error) {
+	return c.permissions(ctx, "rest/api/1.0/admin/permissions/"+string(principal), url.Values{"filter": {filter}})
+}
+
+// ProjectPermissions returns the permissions that are explicitly granted on a project. The client
+// must be authenticated as an administrator of the project.
+func (c *Client) ProjectPermissions(ctx context.Context, projectKey string, principal PermissionPrincipal) ([]*Permission, error) {
+	return c.permissions(ctx, fmt.Sprintf("rest/api/1.0/projects/%s/permissions/%s", projectKey, principal), nil)
+}
+
+// RepoPermissions returns the permissions that are explicitly granted on a repository. The client
+// must be authenticated as an administrator of the repository.
+func (c *Client) RepoPermissions(ctx context.Context, projectKey, repoSlug string, principal PermissionPrincipal) ([]*Permission, error) {
+	return c.permissions(ctx, fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/permissions/%s", projectKey, repoSlug, principal), nil)
+}
+
+// ProjectDefaultPermission reports whether the given permission (such as "PROJECT_READ") is granted
+// on a project to all licensed users.
+func (c *Client) ProjectDefaultPermission(ctx context.Context, projectKey, permission string) (bool, error) {
+	u := fmt.Sprintf("rest/api/1.0/projects/%s/permissions/%s/all", projectKey, permission)
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return false, err
+	}
+	var resp struct {
+		Permitted bool `json:"permitted"`
+	}
+	err = c.do(ctx, req, &resp)
+	return resp.Permitted, err
+}
+
+func (c *Client) permissions(ctx context.Context, path string, query url.Values) ([]*Permission, error) {
+	var perms []*Permission
+	err := c.paginate(ctx, path, query, func(values json.RawMessage) error {
+		var page []*Permission
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		perms = append(perms, page...)
+		return nil
+	})
+	return perms, err
+}
+
+// paginate requests all pages of a paged API resource and calls f with the values of each page.
+func (c *Client) paginate(ctx context.Context, path string, query url.Values, f func(values json.RawMessage) error) error {
+	var t *PageToken
+	for t.HasMore() {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		if t != nil {
+			q.Set("start", strconv.Itoa(t.NextPageStart))
+		}
+		u := path
+		if len(q) > 0 {
+			u += "?" + q.Encode()
+		}
+		req, err := http.NewRequest("GET", u, nil)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			*PageToken
+			Values json.RawMessage
+		}
+		if err := c.do(ctx, req, &resp); err != nil {
+			return err
+		}
+		if err := f(resp.Values); err != nil {
+			return err
+		}
+		if resp.PageToken == nil {
+			break
+		}
+		t = resp.PageToken
+	}
+	return nil
+}
+
 func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
 	req.URL = c.URL.ResolveReference(req.URL)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -133,6 +284,11 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 		nethttp.ClientTrace(false))
 	defer ht.Finish()
 
+	if c.RateLimitMonitor != nil {
+		if err := c.RateLimitMonitor.Wait(ctx); err != nil {
+			return err
+		}
+	}
 	if err := c.RateLimit.Wait(ctx); err != nil {
 		return err
 	}
@@ -142,6 +298,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests && c.RateLimitMonitor != nil {
+		c.RateLimitMonitor.BackOff(resp.Header)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return errors.WithStack(&httpError{URL: req.URL, StatusCode: resp.StatusCode})
 	}
@@ -221,6 +380,28 @@ type Project struct {
 	} `json:"links"`
 }
 
+type User struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	ID           int    `json:"id"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+	Slug         string `json:"slug"`
+	Type         string `json:"type"`
+}
+
+type Group struct {
+	Name string `json:"name"`
+}
+
+// Permission is a permission (such as "REPO_READ" or "PROJECT_ADMIN") that is granted to a user
+// or group.
+type Permission struct {
+	User       *User  `json:"user,omitempty"`
+	Group      *Group `json:"group,omitempty"`
+	Permission string `json:"permission"`
+}
+
 type httpError struct {
 	StatusCode int
 	URL        *url.URL
@@ -237,3 +418,9 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// IsNotFound reports whether err is a Bitbucket Server API not found error.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*httpError)
+	return ok && e.NotFound()
+}
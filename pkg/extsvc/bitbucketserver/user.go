@@ -0,0 +1,36 @@
+package bitbucketserver
+
+import (
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+	"golang.org/x/oauth2"
+)
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way. The token is nil if the account was not created by OAuth
+// authentication (for example, if it was matched by username).
+func GetExternalAccountData(data *extsvc.ExternalAccountData) (usr *User, tok *oauth2.Token, err error) {
+	var (
+		u User
+		t oauth2.Token
+	)
+
+	if data.AccountData != nil {
+		if err := data.GetAccountData(&u); err != nil {
+			return nil, nil, err
+		}
+		usr = &u
+	}
+	if data.AuthData != nil {
+		if err := data.GetAuthData(&t); err != nil {
+			return nil, nil, err
+		}
+		tok = &t
+	}
+	return usr, tok, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.ExternalAccountData, user *User, token *oauth2.Token) {
+	data.SetAccountData(user)
+	data.SetAuthData(token)
+}
@@ -0,0 +1,199 @@
+// Package gerrit implements a Gerrit REST API client.
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/metrics"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+var requestCounter = metrics.NewRequestCounter("gerrit", "Total number of requests sent to the Gerrit API.")
+
+// WithRequestCounter wraps the given transport with a request counter metric.
+func WithRequestCounter(transport http.RoundTripper) http.RoundTripper {
+	return requestCounter.Transport(transport, func(u *url.URL) string {
+		// API to URL mapping looks like this:
+		//
+		// 	Project -> [a/]projects/{name}
+		// 	Projects -> [a/]projects/
+		//
+		// The project name is URL-encoded, so it is always a single path component.
+		parts := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+		if len(parts) > 0 && parts[0] == "a" {
+			parts = parts[1:]
+		}
+		switch {
+		case len(parts) == 2 && parts[0] == "projects":
+			return "Project"
+		case len(parts) == 1 && parts[0] == "projects":
+			return "Projects"
+		default:
+			// don't return the path directly as that could introduce too much dimensionality
+			return "unknown"
+		}
+	})
+}
+
+// Client accesses a Gerrit server via its REST API.
+type Client struct {
+	// URL is the base URL of the Gerrit server (such as https://gerrit.example.com).
+	URL *url.URL
+
+	// The username and HTTP password credentials for accessing Gerrit. HTTP passwords are
+	// generated in Gerrit's user settings. If both are empty, the API is accessed anonymously.
+	Username, Password string
+
+	// HTTPClient is the client used to access Gerrit. To enable tracing, ensure the transport
+	// includes nethttp.Transport.
+	//
+	// To enable metrics, always wrap the Transport using WithRequestCounter.
+	HTTPClient *http.Client
+}
+
+// Project returns the project with the given name.
+func (c *Client) Project(ctx context.Context, name string) (*Project, error) {
+	req, err := c.newRequest("projects/" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	var resp Project
+	err = c.do(ctx, req, &resp)
+	return &resp, err
+}
+
+// Projects returns at most limit code projects (excluding projects that only hold permissions),
+// sorted by name, after skipping the first skip projects. It also reports whether there are more
+// projects.
+func (c *Client) Projects(ctx context.Context, skip, limit int) (projects []*Project, more bool, err error) {
+	q := url.Values{
+		"type": {"CODE"},
+		"n":    {strconv.Itoa(limit)},
+		"S":    {strconv.Itoa(skip)},
+	}
+	// The d (include descriptions) option must not have a value.
+	req, err := c.newRequest("projects/?d&" + q.Encode())
+	if err != nil {
+		return nil, false, err
+	}
+	var resp map[string]*Project
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, false, err
+	}
+	projects = make([]*Project, 0, len(resp))
+	for name, p := range resp {
+		// The project name is the key of the map (and is not set in the project itself).
+		p.Name = name
+		projects = append(projects, p)
+		more = more || p.MoreProjects
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, more, nil
+}
+
+func (c *Client) authenticated() bool {
+	return c.Username != "" || c.Password != ""
+}
+
+// newRequest returns a GET request for the given API path (with query), relative to the base URL.
+func (c *Client) newRequest(path string) (*http.Request, error) {
+	// Authenticated requests must be sent to the /a/ endpoints.
+	if c.authenticated() {
+		path = "a/" + path
+	}
+	return http.NewRequest("GET", path, nil)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	if c.authenticated() {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(opentracing.GlobalTracer(), req,
+		nethttp.OperationName("Gerrit"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.WithStack(&httpError{URL: req.URL, StatusCode: resp.StatusCode})
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(stripXSSIPrefix(body), result)
+}
+
+// stripXSSIPrefix strips the ")]}'" line that Gerrit prepends to all JSON responses to prevent
+// cross-site script inclusion.
+func stripXSSIPrefix(body []byte) []byte {
+	const prefix = ")]}'"
+	if !bytes.HasPrefix(body, []byte(prefix)) {
+		return body
+	}
+	if i := bytes.IndexByte(body, '\n'); i >= 0 {
+		return body[i+1:]
+	}
+	return nil
+}
+
+// Project is a Gerrit project (a Git repository). See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#project-info.
+type Project struct {
+	ID           string    `json:"id"` // the URL-encoded project name
+	Name         string    `json:"name"`
+	Parent       string    `json:"parent"`
+	Description  string    `json:"description"`
+	State        string    `json:"state"` // ACTIVE, READ_ONLY or HIDDEN
+	WebLinks     []WebLink `json:"web_links"`
+	MoreProjects bool      `json:"_more_projects"` // only set on the last project of a page
+}
+
+// WebLink is a link to a project in an external web browser, such as gitiles. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#web-link-info.
+type WebLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"` // may be relative to the Gerrit server URL
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("unexpected %d response from Gerrit API at %s", e.StatusCode, e.URL)
+}
+
+// NotFound reports whether the error is a 404 response, which is how Gerrit responds to requests
+// for projects that don't exist or that the user can't read.
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Unauthorized reports whether the error is a 401 or 403 response, which is how Gerrit responds to
+// requests with invalid credentials or without the required permissions.
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
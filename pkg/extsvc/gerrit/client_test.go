@@ -0,0 +1,99 @@
+package gerrit
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+)
+
+// newTestClient returns a client for a server that responds to authenticated requests for the
+// given paths (with query) with the recorded responses in testdata.
+func newTestClient(t *testing.T, fixtures map[string]string) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "sgtest" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		b, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(b)
+	}))
+	u, _ := url.Parse(srv.URL)
+	return &Client{
+		URL:        u,
+		Username:   "sgtest",
+		Password:   "secret",
+		HTTPClient: srv.Client(),
+	}, srv.Close
+}
+
+func TestClient_Projects(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/a/projects/?d&S=0&n=2&type=CODE": "projects-page1.json",
+		"/a/projects/?d&S=2&n=2&type=CODE": "projects-page2.json",
+	})
+	defer done()
+
+	var names []string
+	for skip, more := 0, true; more; {
+		var projects []*Project
+		var err error
+		projects, more, err = c.Projects(context.Background(), skip, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		skip += len(projects)
+	}
+	if want := []string{"go-mux", "infra/deploy-tools", "sandbox"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got projects %q, want %q", names, want)
+	}
+}
+
+func TestClient_Project(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/a/projects/infra%2Fdeploy-tools": "project.json",
+	})
+	defer done()
+
+	project, err := c.Project(context.Background(), "infra/deploy-tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Project{
+		ID:          "infra%2Fdeploy-tools",
+		Name:        "infra/deploy-tools",
+		Parent:      "All-Projects",
+		Description: "Deployment scripts",
+		State:       "ACTIVE",
+		WebLinks:    []WebLink{{Name: "gitiles", URL: "/plugins/gitiles/infra/deploy-tools"}},
+	}
+	if !reflect.DeepEqual(project, want) {
+		t.Errorf("got project %+v, want %+v", project, want)
+	}
+
+	if _, err := c.Project(context.Background(), "missing"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	c.Password = "wrong"
+	if _, err := c.Project(context.Background(), "infra/deploy-tools"); !errcode.IsUnauthorized(err) {
+		t.Errorf("got error %v, want unauthorized", err)
+	}
+}
@@ -0,0 +1,37 @@
+package gerrit
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+)
+
+// ServiceType is the (api.ExternalRepoSpec).ServiceType value for Gerrit repositories. The
+// ServiceID value is the base URL of the Gerrit server (such as https://gerrit.example.com/).
+const ServiceType = "gerrit"
+
+type CodeHost struct {
+	id      string
+	baseURL *url.URL
+}
+
+var _ extsvc.CodeHost = ((*CodeHost)(nil))
+
+func NewCodeHost(baseURL *url.URL) *CodeHost {
+	return &CodeHost{
+		id:      extsvc.NormalizeBaseURL(baseURL).String(),
+		baseURL: baseURL,
+	}
+}
+
+func (h *CodeHost) ServiceID() string {
+	return h.id
+}
+
+func (h *CodeHost) ServiceType() string {
+	return ServiceType
+}
+
+func (h *CodeHost) BaseURL() *url.URL {
+	return h.baseURL
+}
@@ -0,0 +1,191 @@
+// Package gitea implements a Gitea API client. Gitea's API is compatible with the API of Gogs (from
+// which Gitea was forked), so the client also works with Gogs, except where noted.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/pkg/metrics"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+var requestCounter = metrics.NewRequestCounter("gitea", "Total number of requests sent to the Gitea API.")
+
+// WithRequestCounter wraps the given transport with a request counter metric.
+func WithRequestCounter(transport http.RoundTripper) http.RoundTripper {
+	return requestCounter.Transport(transport, func(u *url.URL) string {
+		// API to URL mapping looks like this:
+		//
+		// 	Repo -> api/v1/repos/{owner}/{name}
+		// 	RepoByID -> api/v1/repositories/{id}
+		// 	OrgRepos -> api/v1/orgs/{org}/repos
+		// 	UserRepos -> api/v1/users/{user}/repos
+		// 	SearchRepos -> api/v1/repos/search
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 3 || parts[0] != "api" || parts[1] != "v1" {
+			return "unknown"
+		}
+		parts = parts[2:]
+		switch {
+		case len(parts) == 2 && parts[0] == "repos" && parts[1] == "search":
+			return "SearchRepos"
+		case len(parts) == 3 && parts[0] == "repos":
+			return "Repo"
+		case len(parts) == 2 && parts[0] == "repositories":
+			return "RepoByID"
+		case len(parts) == 3 && parts[0] == "orgs" && parts[2] == "repos":
+			return "OrgRepos"
+		case len(parts) == 3 && parts[0] == "users" && parts[2] == "repos":
+			return "UserRepos"
+		default:
+			// don't return the path directly as that could introduce too much dimensionality
+			return "unknown"
+		}
+	})
+}
+
+// Client accesses a Gitea (or Gogs) server via its REST API.
+type Client struct {
+	// URL is the base URL of the Gitea server (such as https://gitea.example.com).
+	URL *url.URL
+
+	// Token is the access token used to authenticate to Gitea. If empty, the API is accessed
+	// anonymously.
+	Token string
+
+	// HTTPClient is the client used to access Gitea. To enable tracing, ensure the transport
+	// includes nethttp.Transport.
+	//
+	// To enable metrics, always wrap the Transport using WithRequestCounter.
+	HTTPClient *http.Client
+}
+
+// Repo returns the repository with the given full name (such as "myorg/myrepo").
+func (c *Client) Repo(ctx context.Context, fullName string) (*Repo, error) {
+	var repo Repo
+	err := c.get(ctx, "api/v1/repos/"+fullName, &repo)
+	return &repo, err
+}
+
+// RepoByID returns the repository with the given ID. It is not supported by Gogs.
+func (c *Client) RepoByID(ctx context.Context, id int64) (*Repo, error) {
+	var repo Repo
+	err := c.get(ctx, "api/v1/repositories/"+strconv.FormatInt(id, 10), &repo)
+	return &repo, err
+}
+
+// OrgRepos returns a page (starting at 1) of at most limit repositories of the organization.
+func (c *Client) OrgRepos(ctx context.Context, org string, page, limit int) ([]*Repo, error) {
+	var repos []*Repo
+	err := c.get(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos?"+pageQuery(page, limit).Encode(), &repos)
+	return repos, err
+}
+
+// UserRepos returns a page (starting at 1) of at most limit repositories owned by the user.
+func (c *Client) UserRepos(ctx context.Context, user string, page, limit int) ([]*Repo, error) {
+	var repos []*Repo
+	err := c.get(ctx, "api/v1/users/"+url.PathEscape(user)+"/repos?"+pageQuery(page, limit).Encode(), &repos)
+	return repos, err
+}
+
+// SearchRepos returns a page (starting at 1) of at most limit repositories whose names match the
+// query, among all repositories that are visible to the authenticated user. An empty query matches
+// all repositories.
+func (c *Client) SearchRepos(ctx context.Context, query string, page, limit int) ([]*Repo, error) {
+	q := pageQuery(page, limit)
+	q.Set("q", query)
+	var resp struct {
+		OK   bool    `json:"ok"`
+		Data []*Repo `json:"data"`
+	}
+	if err := c.get(ctx, "api/v1/repos/search?"+q.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, errors.Errorf("Gitea repository search for %q failed", query)
+	}
+	return resp.Data, nil
+}
+
+func pageQuery(page, limit int) url.Values {
+	return url.Values{"page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(limit)}}
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	req, ht := nethttp.TraceRequest(opentracing.GlobalTracer(), req,
+		nethttp.OperationName("Gitea"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.WithStack(&httpError{URL: req.URL, StatusCode: resp.StatusCode})
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// Repo is a Gitea repository.
+type Repo struct {
+	ID    int64 `json:"id"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	Fork        bool   `json:"fork"`
+	Parent      *Repo  `json:"parent"`
+	Empty       bool   `json:"empty"`
+	Mirror      bool   `json:"mirror"`
+	Archived    bool   `json:"archived"` // not set by Gogs
+	HTMLURL     string `json:"html_url"`
+	SSHURL      string `json:"ssh_url"`
+	CloneURL    string `json:"clone_url"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("unexpected %d response from Gitea API at %s", e.StatusCode, e.URL)
+}
+
+// NotFound reports whether the error is a 404 response, which is how Gitea responds to requests
+// for repositories that don't exist or that the user can't read.
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Unauthorized reports whether the error is a 401 or 403 response, which is how Gitea responds to
+// requests with invalid credentials or without the required permissions.
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,123 @@
+package gitea
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/pkg/errcode"
+)
+
+// newTestClient returns a client for a server that responds to authenticated requests for the
+// given paths (with query) with the recorded responses in testdata.
+func newTestClient(t *testing.T, fixtures map[string]string) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		b, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(b)
+	}))
+	u, _ := url.Parse(srv.URL)
+	return &Client{
+		URL:        u,
+		Token:      "secret",
+		HTTPClient: srv.Client(),
+	}, srv.Close
+}
+
+func TestClient_OrgRepos(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/api/v1/orgs/sgtest/repos?limit=2&page=1": "org-repos-page1.json",
+		"/api/v1/orgs/sgtest/repos?limit=2&page=2": "org-repos-page2.json",
+	})
+	defer done()
+
+	var got []*Repo
+	for page := 1; ; page++ {
+		repos, err := c.OrgRepos(context.Background(), "sgtest", page, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, repos...)
+		if len(repos) < 2 {
+			break
+		}
+	}
+
+	var names []string
+	for _, r := range got {
+		names = append(names, r.FullName)
+	}
+	if want := []string{"sgtest/go-mux", "sgtest/internal-tools", "sgtest/go-mux-fork"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got repos %q, want %q", names, want)
+	}
+	if !got[1].Archived || !got[1].Private || got[0].Archived {
+		t.Errorf("got archived %v and private %v, want only sgtest/internal-tools to be archived and private", got[1].Archived, got[1].Private)
+	}
+	if !got[2].Fork || got[2].Parent == nil || got[2].Parent.FullName != "gorilla/mux" {
+		t.Errorf("got fork %v of %+v, want fork of gorilla/mux", got[2].Fork, got[2].Parent)
+	}
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/api/v1/repos/search?limit=50&page=1&q=mux": "search-repos.json",
+	})
+	defer done()
+
+	repos, err := c.SearchRepos(context.Background(), "mux", 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	if want := []string{"sgtest/go-mux", "sgtest/go-mux-fork"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got repos %q, want %q", names, want)
+	}
+}
+
+func TestClient_Repo(t *testing.T) {
+	c, done := newTestClient(t, map[string]string{
+		"/api/v1/repos/sgtest/go-mux": "repository.json",
+		"/api/v1/repositories/1":      "repository.json",
+	})
+	defer done()
+
+	repo, err := c.Repo(context.Background(), "sgtest/go-mux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.ID != 1 || repo.Description != "A powerful URL router" || repo.HTMLURL != "https://gitea.example.com/sgtest/go-mux" || repo.CloneURL != "https://gitea.example.com/sgtest/go-mux.git" {
+		t.Errorf("unexpected repo %+v", repo)
+	}
+
+	if repo, err := c.RepoByID(context.Background(), 1); err != nil || repo.FullName != "sgtest/go-mux" {
+		t.Errorf("got repo %+v (error %v), want sgtest/go-mux", repo, err)
+	}
+
+	if _, err := c.Repo(context.Background(), "sgtest/missing"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	c.Token = "wrong"
+	if _, err := c.Repo(context.Background(), "sgtest/go-mux"); !errcode.IsUnauthorized(err) {
+		t.Errorf("got error %v, want unauthorized", err)
+	}
+}
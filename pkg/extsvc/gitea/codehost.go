@@ -0,0 +1,37 @@
+package gitea
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/pkg/extsvc"
+)
+
+// ServiceType is the (api.ExternalRepoSpec).ServiceType value for Gitea (and Gogs) repositories.
+// The ServiceID value is the base URL of the Gitea server (such as https://gitea.example.com/).
+const ServiceType = "gitea"
+
+type CodeHost struct {
+	id      string
+	baseURL *url.URL
+}
+
+var _ extsvc.CodeHost = ((*CodeHost)(nil))
+
+func NewCodeHost(baseURL *url.URL) *CodeHost {
+	return &CodeHost{
+		id:      extsvc.NormalizeBaseURL(baseURL).String(),
+		baseURL: baseURL,
+	}
+}
+
+func (h *CodeHost) ServiceID() string {
+	return h.id
+}
+
+func (h *CodeHost) ServiceType() string {
+	return ServiceType
+}
+
+func (h *CodeHost) BaseURL() *url.URL {
+	return h.baseURL
+}
@@ -152,11 +152,26 @@ func NewClient(apiURL *url.URL, defaultToken string, transport http.RoundTripper
 		githubDotCom: urlIsGitHubDotCom(apiURL),
 		defaultToken: defaultToken,
 		httpClient:   &http.Client{Transport: transport},
-		RateLimit:    &ratelimit.Monitor{HeaderPrefix: "X-"},
+		RateLimit:    ratelimit.DefaultRegistry.Monitor(rateLimitKey(apiURL, defaultToken), "X-"),
 		repoCache:    map[string]*rcache.Cache{},
 	}
 }
 
+// NewSearchClient is like NewClient, but for clients that only use the GitHub search API. The search
+// API has an independent (and much lower) rate limit, which is monitored separately.
+func NewSearchClient(apiURL *url.URL, defaultToken string, transport http.RoundTripper) *Client {
+	c := NewClient(apiURL, defaultToken, transport)
+	c.RateLimit = ratelimit.DefaultRegistry.Monitor(rateLimitKey(c.apiURL, defaultToken)+":search", "X-")
+	return c
+}
+
+// rateLimitKey returns the key of the rate limit monitor in the rate limit registry. GitHub rate
+// limits are per user, so clients share a monitor only if they use the same API URL and token.
+func rateLimitKey(apiURL *url.URL, token string) string {
+	key := sha256.Sum256([]byte(token + ":" + apiURL.String()))
+	return "github:" + base64.URLEncoding.EncodeToString(key[:])
+}
+
 // cache returns the cache associated with the token (which can be empty, in which case the default
 // token will be used). Accessors of the caches should use this method rather than referencing
 // repoCache directly.
@@ -206,13 +221,16 @@ func (c *Client) do(ctx context.Context, token string, req *http.Request, result
 		span.Finish()
 	}()
 
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
 	resp, err = ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	c.RateLimit.Update(resp.Header)
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		var err githubAPIError
 		if decErr := json.NewDecoder(resp.Body).Decode(&err); decErr != nil {
 			log15.Warn("Failed to decode error response from github API", "error", decErr)
@@ -232,7 +250,10 @@ func (c *Client) requestGet(ctx context.Context, token, requestURI string, resul
 
 	// Include node_id (GraphQL ID) in response. See
 	// https://developer.github.com/changes/2017-12-19-graphql-node-id/.
-	req.Header.Add("Accept", "application/vnd.github.jean-grey-preview+json")
+	//
+	// Include topics in repository responses. See
+	// https://developer.github.com/v3/repos/#list-all-topics-for-a-repository.
+	req.Header.Add("Accept", "application/vnd.github.jean-grey-preview+json, application/vnd.github.mercy-preview+json")
 
 	return c.do(ctx, token, req, result)
 }
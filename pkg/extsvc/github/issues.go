@@ -0,0 +1,49 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Issue is a GitHub issue.
+type Issue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"` // the web URL of the issue
+}
+
+// CreateIssue opens an issue in the repository.
+func (c *Client) CreateIssue(ctx context.Context, owner, name, title, body string, labels []string) (*Issue, error) {
+	var issue Issue
+	err := c.requestPost(ctx, "", fmt.Sprintf("repos/%s/%s/issues", owner, name), map[string]interface{}{
+		"title":  title,
+		"body":   body,
+		"labels": labels,
+	}, &issue)
+	if err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment adds a comment to the issue (or pull request) with the given number.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, name string, number int, body string) error {
+	var comment struct{}
+	return c.requestPost(ctx, "", fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, name, number), map[string]interface{}{
+		"body": body,
+	}, &comment)
+}
+
+func (c *Client) requestPost(ctx context.Context, token, requestURI string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", requestURI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, token, req, result)
+}
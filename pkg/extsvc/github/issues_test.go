@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestClient_CreateIssue(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v3/repos/o/r/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "bearer t" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 7, "html_url": "https://ghe.example.com/o/r/issues/7"}`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL + "/api/v3")
+	c := NewClient(u, "t", nil)
+	issue, err := c.CreateIssue(context.Background(), "o", "r", "Title", "Body", []string{"security"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Issue{Number: 7, HTMLURL: "https://ghe.example.com/o/r/issues/7"}); !reflect.DeepEqual(issue, want) {
+		t.Errorf("got issue %+v, want %+v", issue, want)
+	}
+	if want := map[string]interface{}{"title": "Title", "body": "Body", "labels": []interface{}{"security"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got request %v, want %v", got, want)
+	}
+}
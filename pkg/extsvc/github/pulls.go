@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PullRequest is a GitHub pull request.
+type PullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"` // the web URL of the pull request
+	Base    struct {
+		Ref string `json:"ref"` // the branch that the pull request is merged into
+		SHA string `json:"sha"`
+	} `json:"base"`
+	Head struct {
+		Ref string `json:"ref"` // the branch of the pull request
+		SHA string `json:"sha"`
+	} `json:"head"`
+	UpdatedAt time.Time `json:"updated_at"` // when the pull request (including its comments) last changed
+}
+
+// PullRequestReviewComment is a comment on a line of the diff of a pull request. See
+// https://developer.github.com/v3/pulls/comments/.
+type PullRequestReviewComment struct {
+	ID               int64  `json:"id"`
+	InReplyToID      int64  `json:"in_reply_to_id"` // the ID of the comment this is a reply to, if any
+	Path             string `json:"path"`
+	Position         *int   `json:"position"`           // the line of the diff commented on (nil if the diff changed since)
+	CommitID         string `json:"commit_id"`          // the latest commit of the pull request
+	OriginalCommitID string `json:"original_commit_id"` // the commit that the comment was made on
+	DiffHunk         string `json:"diff_hunk"`          // the diff hunk of the original commit, up to and including the line commented on
+	Body             string `json:"body"`
+	User             struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	} `json:"user"`
+	HTMLURL   string    `json:"html_url"` // the web URL of the comment
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// pageSize is the number of items to request per page from list endpoints.
+const pageSize = 100
+
+// ListOpenPullRequests lists the open pull requests of the repository.
+func (c *Client) ListOpenPullRequests(ctx context.Context, owner, name string) ([]*PullRequest, error) {
+	var all []*PullRequest
+	for page := 1; ; page++ {
+		var pulls []*PullRequest
+		if err := c.requestGet(ctx, "", fmt.Sprintf("repos/%s/%s/pulls?state=open&per_page=%d&page=%d", owner, name, pageSize, page), &pulls); err != nil {
+			return nil, err
+		}
+		all = append(all, pulls...)
+		if len(pulls) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// ListPullRequestReviewComments lists the review comments on the pull request with the given
+// number, in ascending order of their IDs (so a comment is listed before its replies).
+func (c *Client) ListPullRequestReviewComments(ctx context.Context, owner, name string, number int) ([]*PullRequestReviewComment, error) {
+	var all []*PullRequestReviewComment
+	for page := 1; ; page++ {
+		var comments []*PullRequestReviewComment
+		if err := c.requestGet(ctx, "", fmt.Sprintf("repos/%s/%s/pulls/%d/comments?per_page=%d&page=%d", owner, name, number, pageSize, page), &comments); err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if len(comments) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// GetMergeBase returns the merge base of the two commits, which is the base commit that the diff
+// of a pull request from head into base is computed against.
+func (c *Client) GetMergeBase(ctx context.Context, owner, name, base, head string) (string, error) {
+	var comparison struct {
+		MergeBaseCommit struct {
+			SHA string `json:"sha"`
+		} `json:"merge_base_commit"`
+	}
+	if err := c.requestGet(ctx, "", fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, name, base, head), &comparison); err != nil {
+		return "", err
+	}
+	return comparison.MergeBaseCommit.SHA, nil
+}
+
+// CreatePullRequestReviewCommentReply replies to the review comment with the given ID on the pull
+// request with the given number.
+func (c *Client) CreatePullRequestReviewCommentReply(ctx context.Context, owner, name string, number int, inReplyTo int64, body string) (*PullRequestReviewComment, error) {
+	var comment PullRequestReviewComment
+	err := c.requestPost(ctx, "", fmt.Sprintf("repos/%s/%s/pulls/%d/comments", owner, name, number), map[string]interface{}{
+		"body":        body,
+		"in_reply_to": inReplyTo,
+	}, &comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
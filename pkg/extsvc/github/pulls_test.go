@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newFixtureServer returns a server that responds to requests for the given paths with the
+// recorded GitHub API responses in testdata, and records the decoded bodies of POST requests.
+func newFixtureServer(t *testing.T, fixtures map[string]string, posted map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := ioutil.ReadFile("testdata/" + fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Method == "POST" {
+			var body interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			posted[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write(data)
+	}))
+}
+
+func newFixtureClient(srv *httptest.Server) *Client {
+	u, _ := url.Parse(srv.URL + "/api/v3")
+	return NewClient(u, "t", nil)
+}
+
+func TestClient_ListOpenPullRequests(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{"GET /api/v3/repos/sourcegraph/example/pulls": "pulls.json"}, nil)
+	defer srv.Close()
+
+	pulls, err := newFixtureClient(srv).ListOpenPullRequests(context.Background(), "sourcegraph", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pulls) != 1 {
+		t.Fatalf("got %d pull requests, want 1", len(pulls))
+	}
+	pr := pulls[0]
+	if pr.Number != 12 || pr.HTMLURL != "https://github.com/sourcegraph/example/pull/12" {
+		t.Errorf("got pull request %d (%s), want 12", pr.Number, pr.HTMLURL)
+	}
+	if pr.Base.Ref != "master" || pr.Base.SHA != "e242ed3bffccdf271b7fbaf34ed72d089537b42f" {
+		t.Errorf("got base %+v", pr.Base)
+	}
+	if pr.Head.Ref != "retry-fetch" || pr.Head.SHA != "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15" {
+		t.Errorf("got head %+v", pr.Head)
+	}
+	if want := time.Date(2018, 11, 21, 16, 3, 10, 0, time.UTC); !pr.UpdatedAt.Equal(want) {
+		t.Errorf("got updated at %s, want %s", pr.UpdatedAt, want)
+	}
+}
+
+func TestClient_ListPullRequestReviewComments(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{"GET /api/v3/repos/sourcegraph/example/pulls/12/comments": "pull_comments.json"}, nil)
+	defer srv.Close()
+
+	comments, err := newFixtureClient(srv).ListPullRequestReviewComments(context.Background(), "sourcegraph", "example", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2", len(comments))
+	}
+	first, reply := comments[0], comments[1]
+	if first.ID != 235196003 || first.InReplyToID != 0 || first.Path != "fetch.go" || first.Position == nil || *first.Position != 6 {
+		t.Errorf("got first comment %+v", first)
+	}
+	if first.User.Login != "bob" || first.User.ID != 2084 {
+		t.Errorf("got first comment author %+v, want bob", first.User)
+	}
+	if reply.InReplyToID != first.ID {
+		t.Errorf("got reply to %d, want %d", reply.InReplyToID, first.ID)
+	}
+}
+
+func TestClient_CreatePullRequestReviewCommentReply(t *testing.T) {
+	posted := map[string]interface{}{}
+	srv := newFixtureServer(t, map[string]string{"POST /api/v3/repos/sourcegraph/example/pulls/12/comments": "pull_comment_reply.json"}, posted)
+	defer srv.Close()
+
+	comment, err := newFixtureClient(srv).CreatePullRequestReviewCommentReply(context.Background(), "sourcegraph", "example", 12, 235196003, "Thanks, makes sense.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.ID != 235254117 || comment.InReplyToID != 235196003 {
+		t.Errorf("got comment %d in reply to %d", comment.ID, comment.InReplyToID)
+	}
+	want := map[string]interface{}{"body": "Thanks, makes sense.", "in_reply_to": float64(235196003)}
+	if got := posted["/api/v3/repos/sourcegraph/example/pulls/12/comments"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got request %v, want %v", got, want)
+	}
+}
+
+func TestClient_GetMergeBase(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{"GET /api/v3/repos/sourcegraph/example/compare/e242ed3bffccdf271b7fbaf34ed72d089537b42f...0c1a96370c1a96370c1a96370c1a96370c1a9637": "compare.json"}, nil)
+	defer srv.Close()
+
+	mergeBase, err := newFixtureClient(srv).GetMergeBase(context.Background(), "sourcegraph", "example", "e242ed3bffccdf271b7fbaf34ed72d089537b42f", "0c1a96370c1a96370c1a96370c1a96370c1a9637")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "7d865e959b2466918c9863afca942d0fb89d7c9a"; mergeBase != want {
+		t.Errorf("got merge base %q, want %q", mergeBase, want)
+	}
+}
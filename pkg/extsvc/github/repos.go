@@ -33,6 +33,35 @@ type Repository struct {
 	IsFork           bool   // whether the repository is a fork of another repository
 	IsArchived       bool   // whether the repository is archived on the code host
 	ViewerPermission string // ADMIN, WRITE, READ, or empty if unknown. Only the graphql api populates this.
+
+	DefaultBranch  string   // name of the default branch, or empty if the repository is empty
+	StargazerCount int      // number of stars of the repository
+	Topics         []string // topics of the repository
+}
+
+// graphqlRepository is a repository as returned by the GraphQL API with the RepositoryFields
+// fragment. Its nested fields are flattened into a Repository by toRepository.
+type graphqlRepository struct {
+	Repository
+	DefaultBranchRef *struct{ Name string }
+	Stargazers       struct{ TotalCount int }
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct{ Name string }
+		}
+	}
+}
+
+func (r *graphqlRepository) toRepository() *Repository {
+	repo := r.Repository
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = r.DefaultBranchRef.Name
+	}
+	repo.StargazerCount = r.Stargazers.TotalCount
+	for _, n := range r.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, n.Topic.Name)
+	}
+	return &repo
 }
 
 // repositoryFieldsGraphQLFragment returns a GraphQL fragment that contains the fields needed to populate the
@@ -49,6 +78,9 @@ fragment RepositoryFields on Repository {
 	isFork
 	isArchived
 	viewerPermission
+	defaultBranchRef { name }
+	stargazers { totalCount }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 }
 	`
 	}
@@ -64,6 +96,9 @@ fragment RepositoryFields on Repository {
 	isPrivate
 	isFork
 	isArchived
+	defaultBranchRef { name }
+	stargazers { totalCount }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 }
 	`
 }
@@ -242,6 +277,10 @@ type restRepository struct {
 	Private     bool
 	Fork        bool
 	Archived    bool
+
+	DefaultBranch   string   `json:"default_branch"`
+	StargazersCount int      `json:"stargazers_count"`
+	Topics          []string `json:"topics"` // only included with the mercy preview (see requestGet)
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -261,14 +300,17 @@ func (c *Client) getRepositoryFromAPI(ctx context.Context, owner, name string) (
 // to a standard format.
 func convertRestRepo(restRepo restRepository) *Repository {
 	return &Repository{
-		ID:            restRepo.ID,
-		DatabaseID:    restRepo.DatabaseID,
-		NameWithOwner: restRepo.FullName,
-		Description:   restRepo.Description,
-		URL:           restRepo.HTMLURL,
-		IsPrivate:     restRepo.Private,
-		IsFork:        restRepo.Fork,
-		IsArchived:    restRepo.Archived,
+		ID:             restRepo.ID,
+		DatabaseID:     restRepo.DatabaseID,
+		NameWithOwner:  restRepo.FullName,
+		Description:    restRepo.Description,
+		URL:            restRepo.HTMLURL,
+		IsPrivate:      restRepo.Private,
+		IsFork:         restRepo.Fork,
+		IsArchived:     restRepo.Archived,
+		DefaultBranch:  restRepo.DefaultBranch,
+		StargazerCount: restRepo.StargazersCount,
+		Topics:         restRepo.Topics,
 	}
 }
 
@@ -297,7 +339,7 @@ func (c *Client) getPublicRepositories(ctx context.Context, sinceRepoID int64) (
 // API without use of the redis cache.
 func (c *Client) getRepositoryByNodeIDFromAPI(ctx context.Context, token, id string) (*Repository, error) {
 	var result struct {
-		Node *Repository `json:"node"`
+		Node *graphqlRepository `json:"node"`
 	}
 	if err := c.requestGraphQL(ctx, token, `
 query Repository($id: ID!) {
@@ -322,7 +364,7 @@ query Repository($id: ID!) {
 	if result.Node == nil {
 		return nil, ErrNotFound
 	}
-	return result.Node, nil
+	return result.Node.toRepository(), nil
 }
 
 func (c *Client) ListPublicRepositories(ctx context.Context, sinceRepoID int64) ([]*Repository, error) {
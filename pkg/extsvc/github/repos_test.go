@@ -86,18 +86,24 @@ func TestClient_GetRepository(t *testing.T) {
 	"full_name": "o/r",
 	"description": "d",
 	"html_url": "https://github.example.com/o/r",
-	"fork": true
+	"fork": true,
+	"default_branch": "master",
+	"stargazers_count": 3,
+	"topics": ["go", "cli"]
 }
 `}
 	c := newTestClient(t)
 	c.httpClient.Transport = &mock
 
 	want := Repository{
-		ID:            "i",
-		NameWithOwner: "o/r",
-		Description:   "d",
-		URL:           "https://github.example.com/o/r",
-		IsFork:        true,
+		ID:             "i",
+		NameWithOwner:  "o/r",
+		Description:    "d",
+		URL:            "https://github.example.com/o/r",
+		IsFork:         true,
+		DefaultBranch:  "master",
+		StargazerCount: 3,
+		Topics:         []string{"go", "cli"},
 	}
 
 	repo, err := c.GetRepository(context.Background(), "owner", "repo")
@@ -157,7 +163,10 @@ func TestClient_GetRepositoryByNodeID(t *testing.T) {
 			"nameWithOwner": "o/r",
 			"description": "d",
 			"url": "https://github.example.com/o/r",
-			"isFork": true
+			"isFork": true,
+			"defaultBranchRef": {"name": "master"},
+			"stargazers": {"totalCount": 3},
+			"repositoryTopics": {"nodes": [{"topic": {"name": "go"}}, {"topic": {"name": "cli"}}]}
 		}
 	}
 }
@@ -166,11 +175,14 @@ func TestClient_GetRepositoryByNodeID(t *testing.T) {
 	c.httpClient.Transport = &mock
 
 	want := Repository{
-		ID:            "i",
-		NameWithOwner: "o/r",
-		Description:   "d",
-		URL:           "https://github.example.com/o/r",
-		IsFork:        true,
+		ID:             "i",
+		NameWithOwner:  "o/r",
+		Description:    "d",
+		URL:            "https://github.example.com/o/r",
+		IsFork:         true,
+		DefaultBranch:  "master",
+		StargazerCount: 3,
+		Topics:         []string{"go", "cli"},
 	}
 
 	repo, err := c.GetRepositoryByNodeID(context.Background(), "", "i")
@@ -239,7 +251,7 @@ func repoListsAreEqual(a []*Repository, b []*Repository) bool {
 		return false
 	}
 	for i := 0; i < len(a); i++ {
-		if *a[i] != *b[i] {
+		if !reflect.DeepEqual(a[i], b[i]) {
 			return false
 		}
 	}
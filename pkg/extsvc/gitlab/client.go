@@ -57,7 +57,7 @@ func NewClientProvider(baseURL *url.URL, transport http.RoundTripper) *ClientPro
 		baseURL:       baseURL.ResolveReference(&url.URL{Path: path.Join(baseURL.Path, "api/v4") + "/"}),
 		httpClient:    &http.Client{Transport: transport},
 		gitlabClients: make(map[string]*Client),
-		RateLimit:     &ratelimit.Monitor{},
+		RateLimit:     ratelimit.DefaultRegistry.Monitor("gitlab:"+baseURL.String(), ""),
 	}
 }
 
@@ -152,13 +152,16 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 		span.Finish()
 	}()
 
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
 	resp, err = ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 	c.RateLimit.Update(resp.Header)
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return nil, errors.Wrap(httpError(resp.StatusCode), fmt.Sprintf("unexpected response from GitLab API (%s)", req.URL))
 	}
 
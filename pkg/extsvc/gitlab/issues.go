@@ -0,0 +1,51 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Issue is a GitLab issue.
+type Issue struct {
+	IID    int    `json:"iid"`     // the project-scoped ID of the issue
+	WebURL string `json:"web_url"` // the web URL of the issue
+}
+
+// CreateIssue opens an issue in the project with the given ID.
+func (c *Client) CreateIssue(ctx context.Context, projectID int, title, description string, labels []string) (*Issue, error) {
+	var issue Issue
+	err := c.post(ctx, fmt.Sprintf("projects/%d/issues", projectID), map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"labels":      strings.Join(labels, ","),
+	}, &issue)
+	if err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueNote adds a comment (called a note in GitLab) to the issue with the given project-scoped ID.
+func (c *Client) CreateIssueNote(ctx context.Context, projectID, issueIID int, body string) error {
+	var note struct{}
+	return c.post(ctx, fmt.Sprintf("projects/%d/issues/%d/notes", projectID, issueIID), map[string]interface{}{
+		"body": body,
+	}, &note)
+}
+
+func (c *Client) post(ctx context.Context, urlStr string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", urlStr, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, result)
+	return err
+}
@@ -0,0 +1,108 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MergeRequest is a GitLab merge request.
+type MergeRequest struct {
+	IID          int    `json:"iid"`           // the project-scoped ID of the merge request
+	WebURL       string `json:"web_url"`       // the web URL of the merge request
+	SourceBranch string `json:"source_branch"` // the branch of the merge request
+	TargetBranch string `json:"target_branch"` // the branch that the merge request is merged into
+	DiffRefs     struct {
+		BaseSHA string `json:"base_sha"`
+		HeadSHA string `json:"head_sha"`
+	} `json:"diff_refs"`
+	UpdatedAt time.Time `json:"updated_at"` // when the merge request (including its notes) last changed
+}
+
+// Discussion is a thread of notes (comments) on a merge request. See
+// https://docs.gitlab.com/ee/api/discussions.html.
+type Discussion struct {
+	ID             string  `json:"id"`
+	IndividualNote bool    `json:"individual_note"` // whether it's a single comment that can't be replied to
+	Notes          []*Note `json:"notes"`
+}
+
+// Note is a comment in a discussion.
+type Note struct {
+	ID     int    `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"` // whether the note was created by GitLab (e.g. "added 1 commit")
+	Author struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"author"`
+	Position  *NotePosition `json:"position"` // the line of the diff commented on (only for diff notes)
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// NotePosition is the line of a merge request diff that a note was made on.
+type NotePosition struct {
+	BaseSHA string `json:"base_sha"`
+	HeadSHA string `json:"head_sha"`
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+	OldLine *int   `json:"old_line"` // the line in the old file (nil for added lines)
+	NewLine *int   `json:"new_line"` // the line in the new file (nil for removed lines)
+}
+
+// perPage is the number of items to request per page from list endpoints.
+const perPage = 100
+
+// ListOpenMergeRequests lists the open merge requests of the project with the given ID.
+func (c *Client) ListOpenMergeRequests(ctx context.Context, projectID int) ([]*MergeRequest, error) {
+	var all []*MergeRequest
+	for page := 1; ; page++ {
+		var mrs []*MergeRequest
+		if err := c.get(ctx, fmt.Sprintf("projects/%d/merge_requests?state=opened&per_page=%d&page=%d", projectID, perPage, page), &mrs); err != nil {
+			return nil, err
+		}
+		all = append(all, mrs...)
+		if len(mrs) < perPage {
+			return all, nil
+		}
+	}
+}
+
+// ListMergeRequestDiscussions lists the discussions on the merge request with the given
+// project-scoped ID, in the order they were started.
+func (c *Client) ListMergeRequestDiscussions(ctx context.Context, projectID, mergeRequestIID int) ([]*Discussion, error) {
+	var all []*Discussion
+	for page := 1; ; page++ {
+		var discussions []*Discussion
+		if err := c.get(ctx, fmt.Sprintf("projects/%d/merge_requests/%d/discussions?per_page=%d&page=%d", projectID, mergeRequestIID, perPage, page), &discussions); err != nil {
+			return nil, err
+		}
+		all = append(all, discussions...)
+		if len(discussions) < perPage {
+			return all, nil
+		}
+	}
+}
+
+// CreateMergeRequestDiscussionNote adds a note to the discussion with the given ID on the merge
+// request with the given project-scoped ID.
+func (c *Client) CreateMergeRequestDiscussionNote(ctx context.Context, projectID, mergeRequestIID int, discussionID, body string) (*Note, error) {
+	var note Note
+	err := c.post(ctx, fmt.Sprintf("projects/%d/merge_requests/%d/discussions/%s/notes", projectID, mergeRequestIID, discussionID), map[string]interface{}{
+		"body": body,
+	}, &note)
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (c *Client) get(ctx context.Context, urlStr string, result interface{}) error {
+	req, err := http.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, result)
+	return err
+}
@@ -0,0 +1,127 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/pkg/ratelimit"
+)
+
+// newFixtureClient returns a client for a server that responds to requests for the given paths
+// with the recorded GitLab API responses in testdata, and records the decoded bodies of POST
+// requests.
+func newFixtureClient(t *testing.T, fixtures map[string]string, posted map[string]interface{}) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.Method+" "+r.URL.Path]
+		if !ok || r.Header.Get("Private-Token") != "t" {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := ioutil.ReadFile("testdata/" + fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Method == "POST" {
+			var body interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			posted[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write(data)
+	}))
+	u, _ := url.Parse(srv.URL + "/api/v4/")
+	return &Client{
+		baseURL:             u,
+		httpClient:          &http.Client{},
+		personalAccessToken: "t",
+		RateLimit:           &ratelimit.Monitor{},
+	}, srv.Close
+}
+
+func TestClient_ListOpenMergeRequests(t *testing.T) {
+	c, done := newFixtureClient(t, map[string]string{"GET /api/v4/projects/9162473/merge_requests": "merge_requests.json"}, nil)
+	defer done()
+
+	mrs, err := c.ListOpenMergeRequests(context.Background(), 9162473)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mrs) != 1 {
+		t.Fatalf("got %d merge requests, want 1", len(mrs))
+	}
+	mr := mrs[0]
+	if mr.IID != 4 || mr.WebURL != "https://gitlab.com/sourcegraph/example/merge_requests/4" {
+		t.Errorf("got merge request %d (%s), want 4", mr.IID, mr.WebURL)
+	}
+	if mr.SourceBranch != "retry-fetch" || mr.TargetBranch != "master" {
+		t.Errorf("got branches %q -> %q", mr.SourceBranch, mr.TargetBranch)
+	}
+	if want := time.Date(2018, 11, 21, 16, 3, 10, 302000000, time.UTC); !mr.UpdatedAt.Equal(want) {
+		t.Errorf("got updated at %s, want %s", mr.UpdatedAt, want)
+	}
+	if mr.DiffRefs.BaseSHA != "e242ed3bffccdf271b7fbaf34ed72d089537b42f" || mr.DiffRefs.HeadSHA != "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15" {
+		t.Errorf("got diff refs %+v", mr.DiffRefs)
+	}
+}
+
+func TestClient_ListMergeRequestDiscussions(t *testing.T) {
+	c, done := newFixtureClient(t, map[string]string{"GET /api/v4/projects/9162473/merge_requests/4/discussions": "merge_request_discussions.json"}, nil)
+	defer done()
+
+	discussions, err := c.ListMergeRequestDiscussions(context.Background(), 9162473, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discussions) != 2 {
+		t.Fatalf("got %d discussions, want 2", len(discussions))
+	}
+	if d := discussions[0]; !d.IndividualNote || len(d.Notes) != 1 || !d.Notes[0].System || d.Notes[0].Position != nil {
+		t.Errorf("got first discussion %+v, want a single system note", d)
+	}
+	d := discussions[1]
+	if d.ID != "87805b7c09016a7058e91bdbe7b29d1f284a39e6" || len(d.Notes) != 2 {
+		t.Fatalf("got discussion %q with %d notes", d.ID, len(d.Notes))
+	}
+	note := d.Notes[0]
+	if note.ID != 118371120 || note.Author.Username != "bob" || note.Author.ID != 2084 {
+		t.Errorf("got note %d by %+v", note.ID, note.Author)
+	}
+	newLine := 14
+	want := &NotePosition{
+		BaseSHA: "e242ed3bffccdf271b7fbaf34ed72d089537b42f",
+		HeadSHA: "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15",
+		OldPath: "fetch.go",
+		NewPath: "fetch.go",
+		NewLine: &newLine,
+	}
+	if !reflect.DeepEqual(note.Position, want) {
+		t.Errorf("got position %+v, want %+v", note.Position, want)
+	}
+}
+
+func TestClient_CreateMergeRequestDiscussionNote(t *testing.T) {
+	posted := map[string]interface{}{}
+	path := "/api/v4/projects/9162473/merge_requests/4/discussions/87805b7c09016a7058e91bdbe7b29d1f284a39e6/notes"
+	c, done := newFixtureClient(t, map[string]string{"POST " + path: "merge_request_discussion_note.json"}, posted)
+	defer done()
+
+	note, err := c.CreateMergeRequestDiscussionNote(context.Background(), 9162473, 4, "87805b7c09016a7058e91bdbe7b29d1f284a39e6", "Thanks, makes sense.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if note.ID != 118390547 {
+		t.Errorf("got note %d, want 118390547", note.ID)
+	}
+	if want := map[string]interface{}{"body": "Thanks, makes sense."}; !reflect.DeepEqual(posted[path], want) {
+		t.Errorf("got request %v, want %v", posted[path], want)
+	}
+}
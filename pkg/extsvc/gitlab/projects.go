@@ -19,6 +19,9 @@ type Project struct {
 	Visibility        string         `json:"visibility"`                    // "private", "internal", or "public"
 	ForkedFromProject *ProjectCommon `json:"forked_from_project,omitempty"` // If non-nil, the project from which this project was forked
 	Archived          bool           `json:"archived"`
+	DefaultBranch     string         `json:"default_branch"` // empty if the project's repository is empty
+	StarCount         int            `json:"star_count"`
+	TagList           []string       `json:"tag_list"` // the project's topics (called tags in older versions of GitLab)
 }
 
 type ProjectCommon struct {
@@ -435,12 +435,27 @@ func doListOne(ctx context.Context, urlSuffix string, addr string) ([]string, er
 // recently (within the Since duration specified in the request), the
 // update won't happen.
 func (c *Client) RequestRepoUpdate(ctx context.Context, repo Repo, since time.Duration) (*protocol.RepoUpdateResponse, error) {
-	req := &protocol.RepoUpdateRequest{
+	return c.requestRepoUpdate(ctx, &protocol.RepoUpdateRequest{
 		Repo:  repo.Name,
 		URL:   repo.URL,
 		Since: since,
-	}
-	resp, err := c.httpPost(ctx, repo.Name, "repo-update", req)
+	})
+}
+
+// RequestRepoUpdateFetchChangeRefs is like RequestRepoUpdate, but also sets whether this and all
+// later updates of the repository fetch Gerrit change refs (refs/changes/*). Do not use this if you
+// are not repo-updater.
+func (c *Client) RequestRepoUpdateFetchChangeRefs(ctx context.Context, repo Repo, since time.Duration, fetchChangeRefs bool) (*protocol.RepoUpdateResponse, error) {
+	return c.requestRepoUpdate(ctx, &protocol.RepoUpdateRequest{
+		Repo:            repo.Name,
+		URL:             repo.URL,
+		Since:           since,
+		FetchChangeRefs: &fetchChangeRefs,
+	})
+}
+
+func (c *Client) requestRepoUpdate(ctx context.Context, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.httpPost(ctx, req.Repo, "repo-update", req)
 	if err != nil {
 		return nil, err
 	}
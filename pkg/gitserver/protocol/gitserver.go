@@ -44,6 +44,10 @@ type RepoUpdateRequest struct {
 	Repo  api.RepoName  `json:"repo"`  // identifying URL for repo
 	URL   string        `json:"url"`   // repo's remote URL
 	Since time.Duration `json:"since"` // debounce interval for queries, used only with request-repo-update
+
+	// FetchChangeRefs, if non-nil, sets whether this and all later updates of the repo also fetch
+	// Gerrit change refs (refs/changes/*). If nil, the previous setting is kept.
+	FetchChangeRefs *bool `json:"fetchChangeRefs,omitempty"`
 }
 
 // RepoUpdateResponse returns meta information of the repo enqueued for
@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"sync"
@@ -19,6 +20,8 @@ type Monitor struct {
 	limit     int       // last RateLimit-Limit HTTP response header value
 	remaining int       // last RateLimit-Remaining HTTP response header value
 	reset     time.Time // last RateLimit-Remaining HTTP response header value
+
+	retryAfter time.Time // when requests may be retried after a 429 response (see BackOff)
 }
 
 // Get reports the client's rate limit status (as of the last API response it received).
@@ -31,6 +34,33 @@ func (c *Monitor) Get() (remaining int, reset time.Duration, known bool) {
 	return c.remaining, time.Until(c.reset), true
 }
 
+// Wait blocks until the rate limit has requests remaining again (as of the last API response) and
+// the wait requested by the last 429 response (see BackOff) is over, or until ctx is done. It
+// returns immediately if the rate limit status is unknown and there was no 429 response.
+func (c *Monitor) Wait(ctx context.Context) error {
+	c.mu.Lock()
+	var wait time.Duration
+	if c.known && c.remaining <= 0 {
+		wait = time.Until(c.reset)
+	}
+	if retryAfter := time.Until(c.retryAfter); retryAfter > wait {
+		wait = retryAfter
+	}
+	c.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // RecommendedWaitForBackgroundOp returns the recommended wait time before performing a periodic
 // background operation with the given rate limit cost. It takes the rate limit information from the last API
 // request into account.
@@ -117,3 +147,27 @@ func (c *Monitor) Update(h http.Header) {
 	c.remaining = remaining
 	c.reset = time.Unix(resetAtSeconds, 0)
 }
+
+// defaultRetryAfter is how long to back off after a 429 response without a valid Retry-After
+// header.
+const defaultRetryAfter = 30 * time.Second
+
+// BackOff records that the external service responded with 429 Too Many Requests, so that
+// subsequent calls to Wait block until the time given by the response's Retry-After header (in
+// seconds or as an HTTP date).
+func (c *Monitor) BackOff(h http.Header) {
+	retryAfter := time.Now().Add(defaultRetryAfter)
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			retryAfter = time.Now().Add(time.Duration(seconds) * time.Second)
+		} else if t, err := http.ParseTime(v); err == nil {
+			retryAfter = t
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if retryAfter.After(c.retryAfter) {
+		c.retryAfter = retryAfter
+	}
+}
@@ -1,6 +1,8 @@
 package ratelimit
 
 import (
+	"context"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -53,3 +55,57 @@ func TestMonitor_RecommendedWaitForBackgroundOp(t *testing.T) {
 		}
 	}
 }
+
+func TestMonitor_Wait(t *testing.T) {
+	ctx := context.Background()
+
+	// Unknown and remaining rate limits don't block.
+	if err := (&Monitor{}).Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Monitor{known: true, remaining: 1, reset: time.Now().Add(time.Hour)}).Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// An exhausted rate limit blocks until it is reset.
+	start := time.Now()
+	if err := (&Monitor{known: true, remaining: 0, reset: start.Add(50 * time.Millisecond)}).Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Wait returned after %s, want at least 50ms", elapsed)
+	}
+
+	// ... or until the context is done.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := (&Monitor{known: true, remaining: 0, reset: time.Now().Add(time.Hour)}).Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestMonitor_BackOff(t *testing.T) {
+	ctx := context.Background()
+
+	m := &Monitor{}
+	m.BackOff(http.Header{"Retry-After": []string{"3600"}})
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := m.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// A 429 response without a Retry-After header backs off for the default duration.
+	m = &Monitor{}
+	m.BackOff(http.Header{})
+	if wait := time.Until(m.retryAfter); wait <= 0 || wait > defaultRetryAfter {
+		t.Errorf("got retry after %s, want at most %s", wait, defaultRetryAfter)
+	}
+
+	// An HTTP date in the past doesn't block.
+	m = &Monitor{}
+	m.BackOff(http.Header{"Retry-After": []string{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}})
+	if err := m.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRegistry is the registry of rate limits that API clients for external services use.
+var DefaultRegistry = NewRegistry()
+
+// Registry holds the rate limit monitors and self-imposed rate limiters of external services, keyed
+// by an identifier of the external service (such as its base URL). All API clients for the same
+// external service share them, so that the rate limit is respected no matter how many clients
+// are created (for example, every time the external service configuration is reloaded).
+type Registry struct {
+	mu       sync.Mutex
+	monitors map[string]*Monitor
+	limiters map[string]*rate.Limiter
+}
+
+// NewRegistry returns a new, empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		monitors: map[string]*Monitor{},
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// Monitor returns the rate limit monitor of the external service identified by key. If there is
+// none yet, it creates one with the given HTTP header prefix (see Monitor.HeaderPrefix).
+func (r *Registry) Monitor(key, headerPrefix string) *Monitor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.monitors[key]
+	if !ok {
+		m = &Monitor{HeaderPrefix: headerPrefix}
+		r.monitors[key] = m
+	}
+	return m
+}
+
+// Limiter returns the self-imposed rate limiter of the external service identified by key. If
+// there is none yet, it creates one with the given limit and burst size.
+func (r *Registry) Limiter(key string, limit rate.Limit, burst int) *rate.Limiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.limiters[key]
+	if !ok {
+		l = rate.NewLimiter(limit, burst)
+		r.limiters[key] = l
+	}
+	return l
+}
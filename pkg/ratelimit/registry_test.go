@@ -0,0 +1,26 @@
+package ratelimit
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	m := r.Monitor("https://github.example.com", "X-")
+	if m.HeaderPrefix != "X-" {
+		t.Errorf("got header prefix %q, want %q", m.HeaderPrefix, "X-")
+	}
+	if r.Monitor("https://github.example.com", "X-") != m {
+		t.Error("got different monitors for the same key")
+	}
+	if r.Monitor("https://gitlab.example.com", "") == m {
+		t.Error("got the same monitor for different keys")
+	}
+
+	l := r.Limiter("https://bitbucket.example.com", 2, 500)
+	if l.Burst() != 500 {
+		t.Errorf("got burst %d, want 500", l.Burst())
+	}
+	if r.Limiter("https://bitbucket.example.com", 1, 1) != l {
+		t.Error("got different limiters for the same key")
+	}
+}
@@ -23,6 +23,11 @@ type RepoScheduleState struct {
 	Total           int
 	IntervalSeconds int
 	Due             time.Time
+
+	// The following fields are persisted across restarts of repo-updater.
+	LastFetched *time.Time `json:",omitempty"`
+	LastChanged *time.Time `json:",omitempty"`
+	Failures    int        // the number of consecutive failed updates
 }
 
 type RepoQueueState struct {
@@ -84,6 +89,11 @@ type RepoInfo struct {
 	Fork        bool   // whether this repository is a fork of another repository (from the external service)
 	Archived    bool   // whether this repository is archived (from the external service)
 
+	Topics        []string // topics (or labels or tags) of the repository (from the external service)
+	Stars         int      // number of stars of the repository (from the external service)
+	DefaultBranch string   // name of the default branch, if known (from the external service)
+	Visibility    string   // one of the api.RepoVisibility constants, or empty if unknown
+
 	VCS VCSInfo // VCS-related information (for cloning/updating)
 
 	Links *RepoLinks // link URLs related to this repository
@@ -17,11 +17,16 @@ import (
 
 // Message describes an email message to be sent.
 type Message struct {
-	FromName   string   // email "From" address proper name
-	To         []string // email "To" recipients
-	ReplyTo    *string  // optional "ReplyTo" address
-	MessageID  *string  // optional "Message-ID" header
-	References []string // optional "References" header list
+	FromName        string   // email "From" address proper name
+	To              []string // email "To" recipients
+	ReplyTo         *string  // optional "ReplyTo" address
+	MessageID       *string  // optional "Message-ID" header
+	References      []string // optional "References" header list
+	ListUnsubscribe *string  // optional "List-Unsubscribe" URL
+
+	// ListUnsubscribeOneClick is whether a POST request to the ListUnsubscribe URL unsubscribes
+	// without further confirmation (RFC 8058). If so, the "List-Unsubscribe-Post" header is set.
+	ListUnsubscribeOneClick bool
 
 	Template txtypes.Templates // unparsed subject/body templates
 	Data     interface{}       // template data
@@ -55,6 +60,12 @@ func Render(message Message) (*gophermail.Message, error) {
 		}
 		m.Headers["References"] = []string{refsList}
 	}
+	if message.ListUnsubscribe != nil {
+		m.Headers["List-Unsubscribe"] = []string{fmt.Sprintf("<%s>", *message.ListUnsubscribe)}
+		if message.ListUnsubscribeOneClick {
+			m.Headers["List-Unsubscribe-Post"] = []string{"List-Unsubscribe=One-Click"}
+		}
+	}
 
 	parsed, err := ParseTemplate(message.Template)
 	if err != nil {
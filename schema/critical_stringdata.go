@@ -183,6 +183,15 @@ const CriticalSchemaJSON = `{
             "Only allow users to authenticate if their email domain is equal to this value (example: mycompany.com). Do not include a leading \"@\". If not set, all users on this OpenID Connect provider can authenticate to Sourcegraph.",
           "type": "string",
           "pattern": "^[^<@]"
+        },
+        "groupsClaim": {
+          "description":
+            "The name of the claim in the ID token or user info that lists the groups that the user is a member of. The groups are used to enforce repository permissions if ` + "`" + `authorization` + "`" + ` is set.",
+          "type": "string",
+          "default": "groups"
+        },
+        "authorization": {
+          "$ref": "#/definitions/GroupsAuthorization"
         }
       }
     },
@@ -267,6 +276,34 @@ const CriticalSchemaJSON = `{
             "Whether the Service Provider should (insecurely) accept assertions from the Identity Provider without a valid signature.",
           "type": "boolean",
           "default": false
+        },
+        "groupsAttributeName": {
+          "description":
+            "The name (or friendly name) of the assertion attribute that lists the groups that the user is a member of. The groups are used to enforce repository permissions if ` + "`" + `authorization` + "`" + ` is set.",
+          "type": "string",
+          "default": "groups"
+        },
+        "authorization": {
+          "$ref": "#/definitions/GroupsAuthorization"
+        }
+      }
+    },
+    "GroupsAuthorization": {
+      "description":
+        "If non-null, enforces repository permissions from the groups that users are members of on this identity provider. A user's groups are updated each time they sign in.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["groups"],
+      "properties": {
+        "groups": {
+          "description":
+            "A map from group names to regular expressions that match repository names. Members of a group can read the repositories that match any of its patterns. A repository that matches the pattern of any group can only be read by members of the groups whose patterns match it (unless its permissions are enforced by its code host).",
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": { "type": "string", "format": "regex" }
+          },
+          "examples": [{ "backend-team": ["^github\\.example\\.com/backend/"], "everyone": ["^github\\.example\\.com/shared/"] }]
         }
       }
     },
@@ -79,16 +79,42 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+type AzureDevOpsConnection struct {
+	GitURLType                  string   `json:"gitURLType,omitempty"`
+	InitialRepositoryEnablement bool     `json:"initialRepositoryEnablement,omitempty"`
+	Orgs                        []string `json:"orgs,omitempty"`
+	Projects                    []string `json:"projects,omitempty"`
+	RepositoryPathPattern       string   `json:"repositoryPathPattern,omitempty"`
+	Token                       string   `json:"token"`
+	Url                         string   `json:"url,omitempty"`
+}
+type BitbucketCloudConnection struct {
+	ApiURL                      string   `json:"apiURL,omitempty"`
+	AppPassword                 string   `json:"appPassword"`
+	GitURLType                  string   `json:"gitURLType,omitempty"`
+	InitialRepositoryEnablement bool     `json:"initialRepositoryEnablement,omitempty"`
+	RepositoryPathPattern       string   `json:"repositoryPathPattern,omitempty"`
+	Teams                       []string `json:"teams,omitempty"`
+	Url                         string   `json:"url,omitempty"`
+	Username                    string   `json:"username"`
+}
+
+// BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions. The token (or username and password) of this `BitbucketServerConnection` must belong to a Bitbucket Server administrator, so that Sourcegraph can read the permissions of all users and repositories. Sourcegraph users are matched to the Bitbucket Server users with the same username, so this must only be enabled if Sourcegraph usernames and Bitbucket Server usernames identify the same people (for example, because both are provisioned from the same directory).
+type BitbucketServerAuthorization struct {
+	Ttl string `json:"ttl,omitempty"`
+}
 type BitbucketServerConnection struct {
-	Certificate                 string `json:"certificate,omitempty"`
-	ExcludePersonalRepositories bool   `json:"excludePersonalRepositories,omitempty"`
-	GitURLType                  string `json:"gitURLType,omitempty"`
-	InitialRepositoryEnablement bool   `json:"initialRepositoryEnablement,omitempty"`
-	Password                    string `json:"password,omitempty"`
-	RepositoryPathPattern       string `json:"repositoryPathPattern,omitempty"`
-	Token                       string `json:"token,omitempty"`
-	Url                         string `json:"url"`
-	Username                    string `json:"username,omitempty"`
+	Authorization               *BitbucketServerAuthorization `json:"authorization,omitempty"`
+	Certificate                 string                        `json:"certificate,omitempty"`
+	ExcludePersonalRepositories bool                          `json:"excludePersonalRepositories,omitempty"`
+	GitURLType                  string                        `json:"gitURLType,omitempty"`
+	InitialRepositoryEnablement bool                          `json:"initialRepositoryEnablement,omitempty"`
+	Password                    string                        `json:"password,omitempty"`
+	RepositoryPathPattern       string                        `json:"repositoryPathPattern,omitempty"`
+	Token                       string                        `json:"token,omitempty"`
+	Url                         string                        `json:"url"`
+	Username                    string                        `json:"username,omitempty"`
+	WebhookSecret               string                        `json:"webhookSecret,omitempty"`
 }
 
 // BuiltinAuthProvider description: Configures the builtin username-password authentication provider.
@@ -103,6 +129,13 @@ type CloneURLToRepositoryName struct {
 	To   string `json:"to"`
 }
 
+// CodeHostSync description: Syncs discussion threads with the review comments on open pull requests (GitHub) and merge requests (GitLab) of the given repositories. Review comments are imported as discussion threads anchored at the commented line, and replies made on Sourcegraph are posted back to the code host using the token of the repository's external service.
+type CodeHostSync struct {
+	IntervalSeconds int      `json:"intervalSeconds,omitempty"`
+	Repositories    []string `json:"repositories"`
+	Username        string   `json:"username"`
+}
+
 // CriticalConfiguration description: Critical configuration for a Sourcegraph site.
 type CriticalConfiguration struct {
 	AuthProviders        []AuthProviders     `json:"auth.providers,omitempty"`
@@ -124,8 +157,9 @@ type CriticalConfiguration struct {
 
 // Discussions description: Configures Sourcegraph code discussions.
 type Discussions struct {
-	AbuseEmails     []string `json:"abuseEmails,omitempty"`
-	AbuseProtection bool     `json:"abuseProtection,omitempty"`
+	AbuseEmails     []string      `json:"abuseEmails,omitempty"`
+	AbuseProtection bool          `json:"abuseProtection,omitempty"`
+	CodeHostSync    *CodeHostSync `json:"codeHostSync,omitempty"`
 }
 
 // ExperimentalFeatures description: Experimental features to enable or disable. Features that are now enabled by default are marked as deprecated.
@@ -141,6 +175,15 @@ type Extensions struct {
 	RemoteRegistry        interface{} `json:"remoteRegistry,omitempty"`
 }
 
+type GerritConnection struct {
+	FetchChangeRefs             bool   `json:"fetchChangeRefs,omitempty"`
+	InitialRepositoryEnablement bool   `json:"initialRepositoryEnablement,omitempty"`
+	Password                    string `json:"password,omitempty"`
+	RepositoryPathPattern       string `json:"repositoryPathPattern,omitempty"`
+	Url                         string `json:"url"`
+	Username                    string `json:"username,omitempty"`
+}
+
 // GitHubAuthProvider description: Configures the GitHub (or GitHub Enterprise) OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitHub instance: https://developer.github.com/apps/building-oauth-apps/creating-an-oauth-app/. When a user signs into Sourcegraph or links their GitHub account to their existing Sourcegraph account, GitHub will prompt the user for the repo scope.
 type GitHubAuthProvider struct {
 	AllowSignup  bool   `json:"allowSignup,omitempty"`
@@ -156,6 +199,7 @@ type GitHubAuthorization struct {
 	Ttl string `json:"ttl,omitempty"`
 }
 type GitHubConnection struct {
+	AllowCodeMonitorIssues      bool                 `json:"allowCodeMonitorIssues,omitempty"`
 	Authorization               *GitHubAuthorization `json:"authorization,omitempty"`
 	Certificate                 string               `json:"certificate,omitempty"`
 	GitURLType                  string               `json:"gitURLType,omitempty"`
@@ -165,6 +209,7 @@ type GitHubConnection struct {
 	RepositoryQuery             []string             `json:"repositoryQuery,omitempty"`
 	Token                       string               `json:"token"`
 	Url                         string               `json:"url"`
+	WebhookSecret               string               `json:"webhookSecret,omitempty"`
 }
 
 // GitLabAuthProvider description: Configures the GitLab OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitLab instance: https://docs.gitlab.com/ee/integration/oauth_provider.html. The application should have `api` and `read_user` scopes and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/gitlab/callback".
@@ -181,6 +226,7 @@ type GitLabAuthorization struct {
 	Ttl string `json:"ttl,omitempty"`
 }
 type GitLabConnection struct {
+	AllowCodeMonitorIssues      bool                 `json:"allowCodeMonitorIssues,omitempty"`
 	Authorization               *GitLabAuthorization `json:"authorization,omitempty"`
 	Certificate                 string               `json:"certificate,omitempty"`
 	GitURLType                  string               `json:"gitURLType,omitempty"`
@@ -189,6 +235,17 @@ type GitLabConnection struct {
 	RepositoryPathPattern       string               `json:"repositoryPathPattern,omitempty"`
 	Token                       string               `json:"token"`
 	Url                         string               `json:"url"`
+	WebhookSecret               string               `json:"webhookSecret,omitempty"`
+}
+type GiteaConnection struct {
+	GitURLType                  string   `json:"gitURLType,omitempty"`
+	InitialRepositoryEnablement bool     `json:"initialRepositoryEnablement,omitempty"`
+	Orgs                        []string `json:"orgs,omitempty"`
+	RepositoryPathPattern       string   `json:"repositoryPathPattern,omitempty"`
+	RepositoryQuery             []string `json:"repositoryQuery,omitempty"`
+	Token                       string   `json:"token,omitempty"`
+	Url                         string   `json:"url"`
+	Users                       []string `json:"users,omitempty"`
 }
 type GitoliteConnection struct {
 	Blacklist                  string       `json:"blacklist,omitempty"`
@@ -198,6 +255,11 @@ type GitoliteConnection struct {
 	Prefix                     string       `json:"prefix"`
 }
 
+// GroupsAuthorization description: If non-null, enforces repository permissions from the groups that users are members of on this identity provider. A user's groups are updated each time they sign in.
+type GroupsAuthorization struct {
+	Groups map[string][]string `json:"groups"`
+}
+
 // HTTPHeaderAuthProvider description: Configures the HTTP header authentication provider (which authenticates users by consulting an HTTP request header set by an authentication proxy such as https://github.com/bitly/oauth2_proxy).
 type HTTPHeaderAuthProvider struct {
 	StripUsernameHeaderPrefix string `json:"stripUsernameHeaderPrefix,omitempty"`
@@ -220,13 +282,15 @@ type Log struct {
 
 // OpenIDConnectAuthProvider description: Configures the OpenID Connect authentication provider for SSO.
 type OpenIDConnectAuthProvider struct {
-	ClientID           string `json:"clientID"`
-	ClientSecret       string `json:"clientSecret"`
-	ConfigID           string `json:"configID,omitempty"`
-	DisplayName        string `json:"displayName,omitempty"`
-	Issuer             string `json:"issuer"`
-	RequireEmailDomain string `json:"requireEmailDomain,omitempty"`
-	Type               string `json:"type"`
+	Authorization      *GroupsAuthorization `json:"authorization,omitempty"`
+	ClientID           string               `json:"clientID"`
+	ClientSecret       string               `json:"clientSecret"`
+	ConfigID           string               `json:"configID,omitempty"`
+	DisplayName        string               `json:"displayName,omitempty"`
+	GroupsClaim        string               `json:"groupsClaim,omitempty"`
+	Issuer             string               `json:"issuer"`
+	RequireEmailDomain string               `json:"requireEmailDomain,omitempty"`
+	Type               string               `json:"type"`
 }
 
 // OtherExternalServiceConnection description: Connection to Git repositories for which an external service integration isn't yet available.
@@ -262,17 +326,19 @@ type ReviewBoard struct {
 //
 // Note: if you are using IdP-initiated login, you must have *at most one* SAMLAuthProvider in the `auth.providers` array.
 type SAMLAuthProvider struct {
-	ConfigID                                 string `json:"configID,omitempty"`
-	DisplayName                              string `json:"displayName,omitempty"`
-	IdentityProviderMetadata                 string `json:"identityProviderMetadata,omitempty"`
-	IdentityProviderMetadataURL              string `json:"identityProviderMetadataURL,omitempty"`
-	InsecureSkipAssertionSignatureValidation bool   `json:"insecureSkipAssertionSignatureValidation,omitempty"`
-	NameIDFormat                             string `json:"nameIDFormat,omitempty"`
-	ServiceProviderCertificate               string `json:"serviceProviderCertificate,omitempty"`
-	ServiceProviderIssuer                    string `json:"serviceProviderIssuer,omitempty"`
-	ServiceProviderPrivateKey                string `json:"serviceProviderPrivateKey,omitempty"`
-	SignRequests                             *bool  `json:"signRequests,omitempty"`
-	Type                                     string `json:"type"`
+	Authorization                            *GroupsAuthorization `json:"authorization,omitempty"`
+	ConfigID                                 string               `json:"configID,omitempty"`
+	DisplayName                              string               `json:"displayName,omitempty"`
+	GroupsAttributeName                      string               `json:"groupsAttributeName,omitempty"`
+	IdentityProviderMetadata                 string               `json:"identityProviderMetadata,omitempty"`
+	IdentityProviderMetadataURL              string               `json:"identityProviderMetadataURL,omitempty"`
+	InsecureSkipAssertionSignatureValidation bool                 `json:"insecureSkipAssertionSignatureValidation,omitempty"`
+	NameIDFormat                             string               `json:"nameIDFormat,omitempty"`
+	ServiceProviderCertificate               string               `json:"serviceProviderCertificate,omitempty"`
+	ServiceProviderIssuer                    string               `json:"serviceProviderIssuer,omitempty"`
+	ServiceProviderPrivateKey                string               `json:"serviceProviderPrivateKey,omitempty"`
+	SignRequests                             *bool                `json:"signRequests,omitempty"`
+	Type                                     string               `json:"type"`
 }
 
 // SMTPServerConfig description: The SMTP server used to send transactional emails (such as email verifications, reset-password emails, and notifications).
@@ -284,13 +350,34 @@ type SMTPServerConfig struct {
 	Port           int    `json:"port"`
 	Username       string `json:"username,omitempty"`
 }
+
+// SavedQueryAction description: An action to run when new results are available for a saved query.
+type SavedQueryAction struct {
+	Labels     []string `json:"labels,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Type       string   `json:"type"`
+}
+
+// SavedQueryQuietHours description: A daily period during which a saved query is not run (and no notifications are sent for it). New results found after the quiet hours end are notified as usual.
+type SavedQueryQuietHours struct {
+	End      string `json:"end"`
+	Start    string `json:"start"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
 type SearchSavedQueries struct {
-	Description    string `json:"description"`
-	Key            string `json:"key"`
-	Notify         bool   `json:"notify,omitempty"`
-	NotifySlack    bool   `json:"notifySlack,omitempty"`
-	Query          string `json:"query"`
-	ShowOnHomepage bool   `json:"showOnHomepage,omitempty"`
+	Actions        []*SavedQueryAction         `json:"actions,omitempty"`
+	Description    string                      `json:"description"`
+	Digest         string                      `json:"digest,omitempty"`
+	Key            string                      `json:"key"`
+	Notify         bool                        `json:"notify,omitempty"`
+	NotifySlack    bool                        `json:"notifySlack,omitempty"`
+	NotifyWebhook  bool                        `json:"notifyWebhook,omitempty"`
+	Query          string                      `json:"query"`
+	QuietHours     *SavedQueryQuietHours       `json:"quietHours,omitempty"`
+	Schedule       string                      `json:"schedule,omitempty"`
+	ShowOnHomepage bool                        `json:"showOnHomepage,omitempty"`
+	Webhook        *WebhookNotificationsConfig `json:"webhook,omitempty"`
 }
 type SearchScope struct {
 	Description string `json:"description,omitempty"`
@@ -306,12 +393,13 @@ type Sentry struct {
 
 // Settings description: Configuration settings for users and organizations on Sourcegraph.
 type Settings struct {
-	Extensions             map[string]bool           `json:"extensions,omitempty"`
-	Motd                   []string                  `json:"motd,omitempty"`
-	NotificationsSlack     *SlackNotificationsConfig `json:"notifications.slack,omitempty"`
-	SearchRepositoryGroups map[string][]string       `json:"search.repositoryGroups,omitempty"`
-	SearchSavedQueries     []*SearchSavedQueries     `json:"search.savedQueries,omitempty"`
-	SearchScopes           []*SearchScope            `json:"search.scopes,omitempty"`
+	Extensions             map[string]bool             `json:"extensions,omitempty"`
+	Motd                   []string                    `json:"motd,omitempty"`
+	NotificationsSlack     *SlackNotificationsConfig   `json:"notifications.slack,omitempty"`
+	NotificationsWebhook   *WebhookNotificationsConfig `json:"notifications.webhook,omitempty"`
+	SearchRepositoryGroups map[string][]string         `json:"search.repositoryGroups,omitempty"`
+	SearchSavedQueries     []*SearchSavedQueries       `json:"search.savedQueries,omitempty"`
+	SearchScopes           []*SearchScope              `json:"search.scopes,omitempty"`
 }
 
 // SiteConfiguration description: Configuration for a Sourcegraph site.
@@ -332,8 +420,10 @@ type SiteConfiguration struct {
 	Extensions                        *Extensions                 `json:"extensions,omitempty"`
 	GitCloneURLToRepositoryName       []*CloneURLToRepositoryName `json:"git.cloneURLToRepositoryName,omitempty"`
 	GitMaxConcurrentClones            int                         `json:"gitMaxConcurrentClones,omitempty"`
+	GitMaxConcurrentClonesPerCodeHost int                         `json:"gitMaxConcurrentClonesPerCodeHost,omitempty"`
 	GithubClientID                    string                      `json:"githubClientID,omitempty"`
 	GithubClientSecret                string                      `json:"githubClientSecret,omitempty"`
+	LsifUploadMaxBytes                int                         `json:"lsif.uploadMaxBytes,omitempty"`
 	MaxReposToSearch                  int                         `json:"maxReposToSearch,omitempty"`
 	ParentSourcegraph                 *ParentSourcegraph          `json:"parentSourcegraph,omitempty"`
 	RepoListUpdateInterval            int                         `json:"repoListUpdateInterval,omitempty"`
@@ -345,3 +435,8 @@ type SiteConfiguration struct {
 type SlackNotificationsConfig struct {
 	WebhookURL string `json:"webhookURL"`
 }
+
+// WebhookNotificationsConfig description: Configuration for sending notifications to an HTTP webhook. Notifications are POSTed as JSON payloads.
+type WebhookNotificationsConfig struct {
+	Url string `json:"url"`
+}
@@ -39,6 +39,39 @@ const SettingsSchemaJSON = `{
           "notifySlack": {
             "type": "boolean",
             "description": "Notify Slack via the organization's Slack webhook URL when new results are available"
+          },
+          "notifyWebhook": {
+            "type": "boolean",
+            "description":
+              "Notify the webhook configured in the ` + "`" + `notifications.webhook` + "`" + ` setting of the owner of this configuration file when new results are available"
+          },
+          "webhook": {
+            "description":
+              "The webhook to notify when new results are available for this saved query (instead of the webhook configured in the ` + "`" + `notifications.webhook` + "`" + ` setting)",
+            "$ref": "#/definitions/WebhookNotificationsConfig"
+          },
+          "schedule": {
+            "type": "string",
+            "description":
+              "How often to run this saved query to check for new results: an interval such as \"30m\" or \"6h\" (the units are \"s\", \"m\" and \"h\"), or one of \"@hourly\", \"@daily\" and \"@weekly\". If not set, the interval depends on how long the query takes to run (at least 10 seconds).",
+            "pattern": "^(([0-9]+(\\.[0-9]+)?(s|m|h))+|@hourly|@daily|@weekly)$"
+          },
+          "digest": {
+            "type": "string",
+            "description":
+              "Batch the notifications about new results into one daily or weekly digest, instead of notifying every time the saved query finds new results. The saved query still runs according to ` + "`" + `schedule` + "`" + `, and actions still run immediately.",
+            "enum": ["daily", "weekly"]
+          },
+          "quietHours": {
+            "$ref": "#/definitions/SavedQueryQuietHours"
+          },
+          "actions": {
+            "description":
+              "Actions to run when new results are available for this saved query (in addition to the notifications). Each result is handled at most once by each action.",
+            "type": "array",
+            "items": {
+              "$ref": "#/definitions/SavedQueryAction"
+            }
           }
         },
         "additionalProperties": false,
@@ -64,6 +97,9 @@ const SettingsSchemaJSON = `{
     "notifications.slack": {
       "$ref": "#/definitions/SlackNotificationsConfig"
     },
+    "notifications.webhook": {
+      "$ref": "#/definitions/WebhookNotificationsConfig"
+    },
     "motd": {
       "description":
         "An array (often with just one element) of messages to display at the top of all pages, including for unauthenticated users. Users may dismiss a message (and any message with the same string value will remain dismissed for the user).\n\nMarkdown formatting is supported.\n\nUsually this setting is used in global and organization settings. If set in user settings, the message will only be displayed to that user. (This is useful for testing the correctness of the message's Markdown formatting.)\n\nMOTD stands for \"message of the day\" (which is the conventional Unix name for this type of message).",
@@ -110,6 +146,58 @@ const SettingsSchemaJSON = `{
         }
       }
     },
+    "SavedQueryAction": {
+      "type": "object",
+      "description": "An action to run when new results are available for a saved query.",
+      "additionalProperties": false,
+      "required": ["type"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "description":
+            "The type of the action. \"issue\" opens an issue listing the new results in a GitHub or GitLab repository (and comments on that issue about later new results). Issue actions are only supported for saved queries in global settings. \"discussionThread\" creates a Sourcegraph discussion thread for each new result (or a single thread listing all new results, if there are more than 10), anchored at the matched line. Discussion threads are created by the owner of the saved query, so they are only supported for saved queries in user settings.",
+          "enum": ["issue", "discussionThread"]
+        },
+        "repository": {
+          "type": "string",
+          "description":
+            "For issue actions, the name of the repository (on Sourcegraph, such as \"github.com/owner/name\") to open the issue in. It must be a repository on GitHub or GitLab that is added by an external service with a token that can create issues and with allowCodeMonitorIssues set."
+        },
+        "title": {
+          "type": "string",
+          "description": "For issue actions, the title of the issue. Defaults to the description of the saved query."
+        },
+        "labels": {
+          "type": "array",
+          "description": "For issue actions, the labels to add to the issue.",
+          "items": { "type": "string" }
+        }
+      }
+    },
+    "SavedQueryQuietHours": {
+      "type": "object",
+      "description":
+        "A daily period during which a saved query is not run (and no notifications are sent for it). New results found after the quiet hours end are notified as usual.",
+      "additionalProperties": false,
+      "required": ["start", "end"],
+      "properties": {
+        "start": {
+          "type": "string",
+          "description": "The start of the quiet hours, as HH:MM in 24-hour format (for example, \"22:00\").",
+          "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+        },
+        "end": {
+          "type": "string",
+          "description":
+            "The end of the quiet hours, as HH:MM in 24-hour format (for example, \"07:00\"). If it is earlier than the start, the quiet hours span midnight.",
+          "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+        },
+        "timeZone": {
+          "type": "string",
+          "description": "The IANA time zone of the start and end times (for example, \"Europe/Berlin\"). Defaults to UTC."
+        }
+      }
+    },
     "SlackNotificationsConfig": {
       "type": "object",
       "description": "Configuration for sending notifications to Slack.",
@@ -123,6 +211,21 @@ const SettingsSchemaJSON = `{
           "format": "uri"
         }
       }
+    },
+    "WebhookNotificationsConfig": {
+      "type": "object",
+      "description":
+        "Configuration for sending notifications to an HTTP webhook. Notifications are POSTed as JSON payloads.",
+      "additionalProperties": false,
+      "required": ["url"],
+      "properties": {
+        "url": {
+          "type": "string",
+          "description":
+            "The URL to POST notification payloads to. It must resolve to a public IP address. Payloads are signed with the user's or organization's webhook secret (which is not stored in settings; set it with the setWebhookSecret GraphQL mutation).",
+          "format": "uri"
+        }
+      }
     }
   }
 }
@@ -85,6 +85,12 @@ const SiteSchemaJSON = `{
       "type": "integer",
       "default": 5
     },
+    "gitMaxConcurrentClonesPerCodeHost": {
+      "description": "Maximum number of git clone processes that will be run concurrently to update repositories from a single code host (such as a GitHub Enterprise instance). This prevents a code host with many repositories to update from delaying the updates of repositories on other code hosts. The default of 0 means that only gitMaxConcurrentClones applies.",
+      "type": "integer",
+      "minimum": 0,
+      "default": 0
+    },
     "reviewBoard": {
       "description": "JSON array of configuration for Review Board.",
       "type": "array",
@@ -111,6 +117,12 @@ const SiteSchemaJSON = `{
       "type": "integer",
       "default": 500
     },
+    "lsif.uploadMaxBytes": {
+      "description":
+        "The maximum size (in bytes) of an uploaded LSIF dump, after decompression if it is gzip-encoded. Larger uploads are rejected.",
+      "type": "integer",
+      "default": 104857600
+    },
     "parentSourcegraph": {
       "description": "URL to fetch unreachable repository details from. Defaults to \"https://sourcegraph.com\"",
       "type": "object",
@@ -196,6 +208,32 @@ const SiteSchemaJSON = `{
           "type": "array",
           "items": { "type": "string" },
           "default": []
+        },
+        "codeHostSync": {
+          "description":
+            "Syncs discussion threads with the review comments on open pull requests (GitHub) and merge requests (GitLab) of the given repositories. Review comments are imported as discussion threads anchored at the commented line, and replies made on Sourcegraph are posted back to the code host using the token of the repository's external service.",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["username", "repositories"],
+          "properties": {
+            "username": {
+              "description":
+                "The username of the Sourcegraph user that imported review comments are authored by. Each imported comment names its author on the code host.",
+              "type": "string",
+              "minLength": 1
+            },
+            "repositories": {
+              "description": "The names of the repositories (as on Sourcegraph, e.g. github.com/foo/bar) to sync.",
+              "type": "array",
+              "items": { "type": "string" }
+            },
+            "intervalSeconds": {
+              "description": "The interval (in seconds) between syncs.",
+              "type": "integer",
+              "minimum": 10,
+              "default": 60
+            }
+          }
         }
       }
     }
@@ -299,6 +337,18 @@ const SiteSchemaJSON = `{
             "Defines whether repositories from this GitHub instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable GitHub repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by GitHub); site admins can still disable them explicitly, and they'll remain disabled.",
           "type": "boolean"
         },
+        "webhookSecret": {
+          "description":
+            "The secret of the GitHub webhooks that notify Sourcegraph of pushes to repositories on this GitHub instance. If set, Sourcegraph accepts ` + "`" + `push` + "`" + ` events at /.api/webhooks/github whose X-Hub-Signature header is signed with this secret, and updates the pushed repository immediately instead of waiting for the next periodic update. See https://docs.sourcegraph.com/admin/repo/webhooks.",
+          "type": "string",
+          "minLength": 1
+        },
+        "allowCodeMonitorIssues": {
+          "description":
+            "Allow code monitors (saved searches in global settings with an \"issue\" action) to open issues and comment on them in repositories on this GitHub instance, using this connection's token. The issues list the results of the saved search, which may include code from private repositories.",
+          "type": "boolean",
+          "default": false
+        },
         "authorization": { "$ref": "#/definitions/GitHubAuthorization" }
       }
     },
@@ -369,6 +419,18 @@ const SiteSchemaJSON = `{
             "Defines whether repositories from this GitLab instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable GitLab repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by GitLab); site admins can still disable them explicitly, and they'll remain disabled.",
           "type": "boolean"
         },
+        "webhookSecret": {
+          "description":
+            "The secret token of the GitLab webhooks that notify Sourcegraph of pushes to projects on this GitLab instance. If set, Sourcegraph accepts push events at /.api/webhooks/gitlab whose X-Gitlab-Token header equals this token, and updates the pushed project immediately instead of waiting for the next periodic update. See https://docs.sourcegraph.com/admin/repo/webhooks.",
+          "type": "string",
+          "minLength": 1
+        },
+        "allowCodeMonitorIssues": {
+          "description":
+            "Allow code monitors (saved searches in global settings with an \"issue\" action) to open issues and comment on them in repositories on this GitLab instance, using this connection's token. The issues list the results of the saved search, which may include code from private repositories.",
+          "type": "boolean",
+          "default": false
+        },
         "authorization": { "$ref": "#/definitions/GitLabAuthorization" }
       }
     },
@@ -451,6 +513,12 @@ const SiteSchemaJSON = `{
           "type": "string",
           "default": "{host}/{projectKey}/{repositorySlug}"
         },
+        "webhookSecret": {
+          "description":
+            "The secret of the Bitbucket Server webhooks that notify Sourcegraph of pushes to repositories on this Bitbucket Server instance (Bitbucket Server 5.14 and newer). If set, Sourcegraph accepts ` + "`" + `repo:refs_changed` + "`" + ` events at /.api/webhooks/bitbucket-server whose X-Hub-Signature header is signed with this secret, and updates the repository immediately instead of waiting for the next periodic update. See https://docs.sourcegraph.com/admin/repo/webhooks.",
+          "type": "string",
+          "minLength": 1
+        },
         "excludePersonalRepositories": {
           "description":
             "Whether or not personal repositories should be excluded or not. When true, Sourcegraph will ignore personal repositories it may have access to. See https://docs.sourcegraph.com/integration/bitbucket_server#excluding-personal-repositories for more information. Default: false.",
@@ -460,6 +528,221 @@ const SiteSchemaJSON = `{
           "description":
             "Defines whether repositories from this Bitbucket Server instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Bitbucket Server repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by Bitbucket Server); site admins can still disable them explicitly, and they'll remain disabled.",
           "type": "boolean"
+        },
+        "authorization": { "$ref": "#/definitions/BitbucketServerAuthorization" }
+      }
+    },
+    "BitbucketServerAuthorization": {
+      "description":
+        "If non-null, enforces Bitbucket Server repository permissions. The token (or username and password) of this ` + "`" + `BitbucketServerConnection` + "`" + ` must belong to a Bitbucket Server administrator, so that Sourcegraph can read the permissions of all users and repositories. Sourcegraph users are matched to the Bitbucket Server users with the same username, so this must only be enabled if Sourcegraph usernames and Bitbucket Server usernames identify the same people (for example, because both are provisioned from the same directory).",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "ttl": {
+          "description":
+            "The TTL of how long to cache permissions data. This is 3 hours by default.\n\nDecreasing the TTL will increase the load on the code host API. Computing the permissions of a repository requires ~6 API requests, and computing the groups and global permissions of a user requires ~2 API requests.",
+          "type": "string",
+          "default": "3h"
+        }
+      }
+    },
+    "BitbucketCloudConnection": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["username", "appPassword"],
+      "properties": {
+        "url": {
+          "description": "URL of Bitbucket Cloud, such as https://bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.",
+          "type": "string",
+          "pattern": "^https?://",
+          "format": "uri",
+          "default": "https://bitbucket.org",
+          "examples": ["https://bitbucket.org"]
+        },
+        "apiURL": {
+          "description": "The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.",
+          "type": "string",
+          "pattern": "^https?://",
+          "format": "uri",
+          "default": "https://api.bitbucket.org",
+          "examples": ["https://api.bitbucket.org"]
+        },
+        "username": {
+          "description": "The username to use when authenticating to Bitbucket Cloud. Also set the corresponding \"appPassword\" field.",
+          "type": "string",
+          "minLength": 1
+        },
+        "appPassword": {
+          "description": "The app password to use when authenticating to Bitbucket Cloud. Create one with the Repositories (Read) permission at https://bitbucket.org/account/settings/app-passwords/new. Also set the corresponding \"username\" field.",
+          "type": "string",
+          "minLength": 1
+        },
+        "teams": {
+          "description": "The teams (or users) whose repositories should be synced, such as \"myteam\". If empty, all repositories that the user of the app password is a member of are synced.",
+          "type": "array",
+          "items": { "type": "string", "pattern": "^[\\w-]+$" },
+          "examples": [["myteam"]]
+        },
+        "gitURLType": {
+          "description":
+            "The type of Git URLs to use for cloning and fetching Git repositories on Bitbucket Cloud.\n\nIf \"http\", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form https://bitbucket.org/myteam/myrepo.git (authenticating with the app password).\n\nIf \"ssh\", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.",
+          "type": "string",
+          "enum": ["http", "ssh"],
+          "default": "http"
+        },
+        "repositoryPathPattern": {
+          "description":
+            "The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.\n\n - \"{host}\" is replaced with the Bitbucket Cloud URL's host (such as bitbucket.org)\n - \"{nameWithOwner}\" is replaced with the Bitbucket Cloud repository's full name (such as \"myteam/myrepo\").\n\nFor example, if your Sourcegraph instance is at https://src.example.com, then a repositoryPathPattern of \"bb/{nameWithOwner}\" would mean that a Bitbucket Cloud repository at https://bitbucket.org/myteam/myrepo is available on Sourcegraph at https://src.example.com/bb/myteam/myrepo.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+          "type": "string",
+          "default": "{host}/{nameWithOwner}"
+        },
+        "initialRepositoryEnablement": {
+          "description":
+            "Defines whether repositories from Bitbucket Cloud should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Bitbucket Cloud repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by Bitbucket Cloud); site admins can still disable them explicitly, and they'll remain disabled.",
+          "type": "boolean"
+        }
+      }
+    },
+    "GiteaConnection": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["url"],
+      "properties": {
+        "url": {
+          "description": "URL of a Gitea (or Gogs) server, such as https://gitea.example.com.",
+          "type": "string",
+          "pattern": "^https?://",
+          "format": "uri",
+          "examples": ["https://gitea.example.com"]
+        },
+        "token": {
+          "description": "An access token for Gitea, which is generated in the Applications section of the user's settings on Gitea. If empty, Gitea is accessed anonymously, and only public repositories are synced.",
+          "type": "string"
+        },
+        "orgs": {
+          "description": "The organizations whose repositories should be synced, such as \"myorg\".",
+          "type": "array",
+          "items": { "type": "string", "minLength": 1 },
+          "examples": [["myorg"]]
+        },
+        "users": {
+          "description": "The users whose own repositories should be synced, such as \"alice\".",
+          "type": "array",
+          "items": { "type": "string", "minLength": 1 },
+          "examples": [["alice"]]
+        },
+        "repositoryQuery": {
+          "description":
+            "An array of strings specifying which Gitea repositories to sync, in addition to the repositories of the organizations and users listed in \"orgs\" and \"users\". The valid values are:\n\n- ` + "`" + `all` + "`" + ` syncs all repositories that are visible to the token's user (or all public repositories if no token is set)\n\n- ` + "`" + `none` + "`" + ` syncs no repositories (except those of \"orgs\" and \"users\")\n\n- All other values are searched for in the names of all repositories that are visible to the token's user, and the matching repositories are synced.\n\nIf multiple values are provided, their results are unioned. If not set, it defaults to [\"all\"] if neither \"orgs\" nor \"users\" is set, and to [\"none\"] otherwise.",
+          "type": "array",
+          "items": { "type": "string", "minLength": 1 },
+          "examples": [["all"], ["none"], ["mux"]]
+        },
+        "gitURLType": {
+          "description":
+            "The type of Git URLs to use for cloning and fetching Git repositories on Gitea.\n\nIf \"http\", Sourcegraph will access Gitea repositories using Git URLs of the form https://gitea.example.com/myorg/myrepo.git (authenticating with the token).\n\nIf \"ssh\", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.",
+          "type": "string",
+          "enum": ["http", "ssh"],
+          "default": "http"
+        },
+        "repositoryPathPattern": {
+          "description":
+            "The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository.\n\n - \"{host}\" is replaced with the Gitea URL's host (such as gitea.example.com)\n - \"{nameWithOwner}\" is replaced with the Gitea repository's full name (such as \"myorg/myrepo\").\n\nFor example, if your Sourcegraph instance is at https://src.example.com, then a repositoryPathPattern of \"gitea/{nameWithOwner}\" would mean that a Gitea repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea/myorg/myrepo.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+          "type": "string",
+          "default": "{host}/{nameWithOwner}"
+        },
+        "initialRepositoryEnablement": {
+          "description":
+            "Defines whether repositories from Gitea should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Gitea repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately; site admins can still disable them explicitly, and they'll remain disabled.",
+          "type": "boolean"
+        }
+      }
+    },
+    "GerritConnection": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["url"],
+      "properties": {
+        "url": {
+          "description": "URL of a Gerrit server, such as https://gerrit.example.com.",
+          "type": "string",
+          "pattern": "^https?://",
+          "format": "uri",
+          "examples": ["https://gerrit.example.com", "https://review.example.com/gerrit"]
+        },
+        "username": {
+          "description": "The username to use when authenticating to Gerrit. Also set the corresponding \"password\" field. If empty, Gerrit is accessed anonymously, and only projects that are visible to anonymous users are synced.",
+          "type": "string"
+        },
+        "password": {
+          "description": "The HTTP password to use when authenticating to Gerrit, which is generated in the HTTP Credentials section of the user's settings on Gerrit. Also set the corresponding \"username\" field.",
+          "type": "string"
+        },
+        "fetchChangeRefs": {
+          "description": "Whether to fetch the patch sets of Gerrit changes (the refs/changes/* refs) of each project, so that they can be searched and browsed as revisions (such as refs/changes/34/1234/2). Most projects have many more change refs than branches, so this increases the disk space used and the time taken to update repositories.",
+          "type": "boolean",
+          "default": false
+        },
+        "repositoryPathPattern": {
+          "description":
+            "The pattern used to generate the corresponding Sourcegraph repository name for a Gerrit project.\n\n - \"{host}\" is replaced with the Gerrit URL's host (such as gerrit.example.com)\n - \"{project}\" is replaced with the Gerrit project's name (such as \"infra/deploy-tools\").\n\nFor example, if your Sourcegraph instance is at https://src.example.com, then a repositoryPathPattern of \"gerrit/{project}\" would mean that a Gerrit project named infra/deploy-tools is available on Sourcegraph at https://src.example.com/gerrit/infra/deploy-tools.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+          "type": "string",
+          "default": "{host}/{project}"
+        },
+        "initialRepositoryEnablement": {
+          "description":
+            "Defines whether repositories from Gerrit should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Gerrit repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately; site admins can still disable them explicitly, and they'll remain disabled.",
+          "type": "boolean"
+        }
+      }
+    },
+    "AzureDevOpsConnection": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["token"],
+      "properties": {
+        "url": {
+          "description": "URL of Azure DevOps Services, or of an Azure DevOps Server (formerly Team Foundation Server) including its virtual directory, such as https://tfs.example.com/tfs.",
+          "type": "string",
+          "pattern": "^https?://",
+          "format": "uri",
+          "default": "https://dev.azure.com",
+          "examples": ["https://dev.azure.com", "https://tfs.example.com/tfs"]
+        },
+        "token": {
+          "description": "A personal access token with the Code (Read) scope, which is used to list repositories and to clone them (over HTTPS). Create one in the Personal access tokens section of the user's security settings on Azure DevOps.",
+          "type": "string",
+          "minLength": 1
+        },
+        "orgs": {
+          "description": "The organizations (or, on Azure DevOps Server, the collections) whose repositories should all be synced, such as \"myorg\".",
+          "type": "array",
+          "items": { "type": "string", "minLength": 1 },
+          "examples": [["myorg"]]
+        },
+        "projects": {
+          "description": "The projects whose repositories should be synced, as \"org/project\" (such as \"myorg/myproject\"). Use this instead of \"orgs\" to only sync some projects of an organization.",
+          "type": "array",
+          "items": { "type": "string", "pattern": "^[^/]+/[^/]+$" },
+          "examples": [["myorg/myproject"]]
+        },
+        "gitURLType": {
+          "description":
+            "The type of Git URLs to use for cloning and fetching Git repositories on Azure DevOps.\n\nIf \"http\", Sourcegraph will access Azure DevOps repositories using Git URLs of the form https://dev.azure.com/myorg/myproject/_git/myrepo (authenticating with the personal access token).\n\nIf \"ssh\", Sourcegraph will access Azure DevOps repositories using Git URLs of the form git@ssh.dev.azure.com:v3/myorg/myproject/myrepo. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.",
+          "type": "string",
+          "enum": ["http", "ssh"],
+          "default": "http"
+        },
+        "repositoryPathPattern": {
+          "description":
+            "The pattern used to generate the corresponding Sourcegraph repository name for an Azure DevOps repository.\n\n - \"{host}\" is replaced with the Azure DevOps URL's host (such as dev.azure.com)\n - \"{org}\" is replaced with the repository's organization (or collection)\n - \"{project}\" is replaced with the repository's project\n - \"{repo}\" is replaced with the repository's name\n\nFor example, if your Sourcegraph instance is at https://src.example.com, then a repositoryPathPattern of \"azure/{project}/{repo}\" would mean that an Azure DevOps repository at https://dev.azure.com/myorg/myproject/_git/myrepo is available on Sourcegraph at https://src.example.com/azure/myproject/myrepo.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+          "type": "string",
+          "default": "{host}/{org}/{project}/{repo}"
+        },
+        "initialRepositoryEnablement": {
+          "description":
+            "Defines whether repositories from Azure DevOps should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Azure DevOps repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately; site admins can still disable them explicitly, and they'll remain disabled.",
+          "type": "boolean"
         }
       }
     },
@@ -143,12 +143,20 @@ function serviceTypeDisplayNameAndIcon(
             return { displayName: 'GitHub', icon: GithubCircleIcon }
         case 'gitlab':
             return { displayName: 'GitLab' }
+        case 'bitbucketCloud':
+            return { displayName: 'Bitbucket' }
         case 'bitbucketserver':
             return { displayName: 'Bitbucket Server' }
         case 'phabricator':
             return { displayName: 'Phabricator', icon: PhabricatorIcon }
         case 'awscodecommit':
             return { displayName: 'AWS CodeCommit' }
+        case 'gerrit':
+            return { displayName: 'Gerrit' }
+        case 'gitea':
+            return { displayName: 'Gitea' }
+        case 'azuredevops':
+            return { displayName: 'Azure DevOps' }
     }
     return { displayName: serviceType ? upperFirst(serviceType) : 'code host' }
 }
@@ -42,6 +42,40 @@ export const ALL_EXTERNAL_SERVICES: ExternalServiceMetadata[] = [
   "region": "",
   "accessKeyID": "",
   "secretAccessKey": ""
+}`,
+    },
+    {
+        kind: GQL.ExternalServiceKind.AZUREDEVOPS,
+        displayName: 'Azure DevOps',
+        defaultConfig: `{
+  // Use Ctrl+Space for completion, and hover over JSON properties for documentation.
+  // Configuration options are documented here:
+  // https://docs.sourcegraph.com/admin/site_config/all#azuredevopsconnection-object
+
+  // Create a personal access token with the Code (Read) scope at
+  // https://dev.azure.com/[your-organization]/_usersSettings/tokens
+  "token": "",
+
+  // The organizations whose repositories to sync (or "projects": ["myorg/myproject"]).
+  "orgs": ["myorg"]
+}`,
+    },
+    {
+        kind: GQL.ExternalServiceKind.BITBUCKETCLOUD,
+        displayName: 'Bitbucket Cloud',
+        defaultConfig: `{
+  // Use Ctrl+Space for completion, and hover over JSON properties for documentation.
+  // Configuration options are documented here:
+  // https://docs.sourcegraph.com/admin/site_config/all#bitbucketcloudconnection-object
+
+  "username": "",
+
+  // Create an app password with the Repositories (Read) permission at
+  // https://bitbucket.org/account/settings/app-passwords/new
+  "appPassword": "",
+
+  // The teams whose repositories to sync (by default, all repositories that the user is a member of).
+  // "teams": ["myteam"]
 }`,
     },
     {
@@ -57,6 +91,43 @@ export const ALL_EXTERNAL_SERVICES: ExternalServiceMetadata[] = [
   // Create a personal access token with read scope at
   // https://[your-bitbucket-hostname]/plugins/servlet/access-tokens/add
   "token": ""
+}`,
+    },
+    {
+        kind: GQL.ExternalServiceKind.GERRIT,
+        displayName: 'Gerrit',
+        defaultConfig: `{
+  // Use Ctrl+Space for completion, and hover over JSON properties for documentation.
+  // Configuration options are documented here:
+  // https://docs.sourcegraph.com/admin/site_config/all#gerritconnection-object
+
+  "url": "https://gerrit.example.com",
+
+  // Generate an HTTP password in the HTTP Credentials section of your Gerrit user settings.
+  "username": "",
+  "password": ""
+
+  // Fetch the patch sets of changes (refs/changes/*) to make them searchable.
+  // "fetchChangeRefs": true
+}`,
+    },
+    {
+        kind: GQL.ExternalServiceKind.GITEA,
+        displayName: 'Gitea',
+        defaultConfig: `{
+  // Use Ctrl+Space for completion, and hover over JSON properties for documentation.
+  // Configuration options are documented here:
+  // https://docs.sourcegraph.com/admin/site_config/all#giteaconnection-object
+
+  "url": "https://gitea.example.com",
+
+  // Generate an access token in the Applications section of your Gitea user settings.
+  "token": "",
+
+  // The organizations and users whose repositories to sync (by default, all repositories that
+  // are visible to the token's user).
+  // "orgs": ["myorg"],
+  // "users": ["alice"]
 }`,
     },
     GITHUB_EXTERNAL_SERVICE,